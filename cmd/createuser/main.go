@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/prem0x01/hospital/internal/config"
+	"github.com/prem0x01/hospital/internal/database"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/repository"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+// createuser creates an account from the command line, reading the
+// password from standard input. It is how the first account is made;
+// after that accounts are created with POST /users.
+func main() {
+	email := flag.String("email", "", "login email")
+	role := flag.String("role", domain.RoleCompliance, "receptionist, doctor or compliance")
+	firstName := flag.String("first-name", "", "first name")
+	lastName := flag.String("last-name", "", "last name")
+	flag.Parse()
+
+	switch *role {
+	case domain.RoleReceptionist, domain.RoleDoctor, domain.RoleCompliance:
+	default:
+		log.Fatalf("Unknown role %q", *role)
+	}
+	if *email == "" || *firstName == "" || *lastName == "" {
+		fmt.Fprintln(os.Stderr, "usage: createuser -email <email> -first-name <name> -last-name <name> [-role compliance] < password")
+		os.Exit(2)
+	}
+
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		log.Fatal("Failed to read password from standard input:", err)
+	}
+	password = strings.TrimRight(password, "\r\n")
+	if len(password) < 6 {
+		log.Fatal("Password must be at least 6 characters")
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, reading configuration from the environment")
+	}
+	cfg := config.Load()
+	ctx := context.Background()
+
+	loc, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		log.Fatal("Invalid HOSPITAL_TIMEZONE:", err)
+	}
+
+	db, err := database.Initialize(cfg.DBUrl, loc)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db.Pool)
+	if _, err := userRepo.GetByEmail(ctx, *email); err == nil {
+		log.Fatalf("A user with email %s already exists", *email)
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		log.Fatal(err)
+	}
+	user := &domain.User{
+		Email:        *email,
+		PasswordHash: hash,
+		Role:         *role,
+		FirstName:    *firstName,
+		LastName:     *lastName,
+	}
+	if err := userRepo.Create(ctx, user); err != nil {
+		log.Fatal("Failed to create user:", err)
+	}
+
+	log.Printf("Created %s user %d (%s)", user.Role, user.ID, user.Email)
+}
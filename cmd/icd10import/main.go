@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/prem0x01/hospital/internal/config"
+	"github.com/prem0x01/hospital/internal/database"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/icd10"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+const usage = `usage: icd10import FILE
+
+Loads an ICD-10-CM release into the code catalog. FILE is the tabular list
+(icd10cm_tabular_YYYY.xml), the order file (icd10cm_order_YYYY.txt) or the
+codes file (icd10cm_codes_YYYY.txt). Codes missing from FILE are kept but
+deactivated.
+`
+
+// icd10import replaces the ICD-10 code catalog with the codes of a CMS
+// release file.
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, reading configuration from the environment")
+	}
+
+	cfg := config.Load()
+
+	loc, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		log.Fatal("Invalid HOSPITAL_TIMEZONE:", err)
+	}
+
+	codes, billable, err := readCodes(os.Args[1])
+	if err != nil {
+		log.Fatal("Failed to read ICD-10 codes:", err)
+	}
+	if len(codes) == 0 {
+		log.Fatalf("No ICD-10 codes found in %s", os.Args[1])
+	}
+
+	db, err := database.Initialize(cfg.DBUrl, loc)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close()
+
+	icd10Repo := repository.NewIcd10Repository(db.Queries, db.Pool)
+	if err := icd10Repo.Import(context.Background(), codes); err != nil {
+		log.Fatal("Failed to import ICD-10 codes:", err)
+	}
+
+	log.Printf("Imported %d ICD-10 codes, %d of them billable", len(codes), billable)
+}
+
+// readCodes parses the release file, keeping the last entry of any code
+// listed twice.
+func readCodes(path string) ([]domain.Icd10Code, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	var codes []domain.Icd10Code
+	seen := make(map[string]int)
+	billable := 0
+	err = icd10.Read(f, func(c icd10.Code) error {
+		code := domain.Icd10Code{Code: c.Code, Description: c.Description, Billable: c.Billable}
+		if i, ok := seen[c.Code]; ok {
+			if codes[i].Billable {
+				billable--
+			}
+			codes[i] = code
+		} else {
+			seen[c.Code] = len(codes)
+			codes = append(codes, code)
+		}
+		if c.Billable {
+			billable++
+		}
+		return nil
+	})
+	return codes, billable, err
+}
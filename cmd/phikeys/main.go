@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/prem0x01/hospital/internal/config"
+	"github.com/prem0x01/hospital/internal/database"
+	"github.com/prem0x01/hospital/internal/fieldcrypt"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+const usage = `usage: phikeys <command> [flags]
+
+commands:
+  generate                      print a new random master key
+  rotate                        create a new data key and re-encrypt every patient with it
+  rewrap -old-key-file <path>   re-wrap all data keys under the configured master key
+  decrypt-all                   write every patient back as plaintext (before rolling back migration 003)
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if os.Args[1] == "generate" {
+		key, err := fieldcrypt.GenerateKey()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(key)
+		return
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, reading configuration from the environment")
+	}
+	cfg := config.Load()
+	ctx := context.Background()
+
+	loc, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		log.Fatal("Invalid HOSPITAL_TIMEZONE:", err)
+	}
+
+	db, err := database.Initialize(cfg.DBUrl, loc)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close()
+
+	masterKey, err := fieldcrypt.LoadMasterKey(cfg.MasterKey, cfg.MasterKeyFile)
+	if err != nil {
+		log.Fatal("Failed to load master key:", err)
+	}
+	keyRepo := repository.NewEncryptionKeyRepository(db.Pool)
+
+	switch os.Args[1] {
+	case "rotate":
+		err = rotate(ctx, db, masterKey, keyRepo)
+	case "rewrap":
+		fs := flag.NewFlagSet("rewrap", flag.ExitOnError)
+		oldKeyFile := fs.String("old-key-file", "", "file holding the previous master key")
+		fs.Parse(os.Args[2:])
+		err = rewrap(ctx, db, masterKey, keyRepo, *oldKeyFile)
+	case "decrypt-all":
+		err = decryptAll(ctx, db, masterKey, keyRepo)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// rotate creates a fresh data key, re-encrypts every row with it and
+// retires the previous keys.
+func rotate(ctx context.Context, db *database.DB, masterKey *fieldcrypt.MasterKey, keyRepo *repository.EncryptionKeyRepository) error {
+	keyring, err := fieldcrypt.NewKeyring(ctx, masterKey, keyRepo)
+	if err != nil {
+		return err
+	}
+
+	id, err := keyring.Rotate(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("Created data key %d", id)
+
+	n, err := repository.NewPatientRepository(db.Queries, keyring).ReencryptAll(ctx)
+	if err != nil {
+		return fmt.Errorf("re-encryption stopped after %d patients: %w", n, err)
+	}
+	log.Printf("Re-encrypted %d patients", n)
+
+	if err := keyRepo.RetireAllExcept(ctx, id); err != nil {
+		return err
+	}
+	log.Printf("Retired all data keys except %d", id)
+	return nil
+}
+
+// rewrap moves the data keys from the old master key to the configured
+// one. Blind indexes are derived from the master key, so they are
+// recomputed afterwards.
+func rewrap(ctx context.Context, db *database.DB, masterKey *fieldcrypt.MasterKey, keyRepo *repository.EncryptionKeyRepository, oldKeyFile string) error {
+	if oldKeyFile == "" {
+		return fmt.Errorf("-old-key-file is required")
+	}
+	oldKey, err := fieldcrypt.LoadMasterKey("", oldKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load old master key: %w", err)
+	}
+
+	keys, err := keyRepo.ListKeys(ctx)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if k.MasterKeyID == masterKey.ID {
+			continue
+		}
+		if k.MasterKeyID != oldKey.ID {
+			return fmt.Errorf("data key %d is wrapped by unknown master key %s", k.ID, k.MasterKeyID)
+		}
+
+		dataKey, err := oldKey.Unwrap(k.Wrapped)
+		if err != nil {
+			return fmt.Errorf("data key %d: %w", k.ID, err)
+		}
+		wrapped, err := masterKey.Wrap(dataKey)
+		if err != nil {
+			return err
+		}
+		if err := keyRepo.Rewrap(ctx, k.ID, wrapped, masterKey.ID); err != nil {
+			return err
+		}
+		log.Printf("Re-wrapped data key %d", k.ID)
+	}
+
+	keyring, err := fieldcrypt.NewKeyring(ctx, masterKey, keyRepo)
+	if err != nil {
+		return err
+	}
+	n, err := repository.NewPatientRepository(db.Queries, keyring).ReencryptAll(ctx)
+	if err != nil {
+		return fmt.Errorf("re-indexing stopped after %d patients: %w", n, err)
+	}
+	log.Printf("Recomputed blind indexes for %d patients", n)
+	return nil
+}
+
+func decryptAll(ctx context.Context, db *database.DB, masterKey *fieldcrypt.MasterKey, keyRepo *repository.EncryptionKeyRepository) error {
+	keyring, err := fieldcrypt.NewKeyring(ctx, masterKey, keyRepo)
+	if err != nil {
+		return err
+	}
+
+	n, err := repository.NewPatientRepository(db.Queries, keyring).DecryptAll(ctx)
+	if err != nil {
+		return fmt.Errorf("decryption stopped after %d patients: %w", n, err)
+	}
+	log.Printf("Decrypted %d patients", n)
+	return nil
+}
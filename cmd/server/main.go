@@ -1,18 +1,28 @@
 package main
 
 import (
+	"context"
 	"log"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/gin-contrib/cors"
 	"github.com/joho/godotenv"
+	"github.com/prem0x01/hospital/internal/billing"
 	"github.com/prem0x01/hospital/internal/config"
 	"github.com/prem0x01/hospital/internal/database"
+	"github.com/prem0x01/hospital/internal/documents"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/fieldcrypt"
 	"github.com/prem0x01/hospital/internal/handlers"
 	"github.com/prem0x01/hospital/internal/middleware"
+	"github.com/prem0x01/hospital/internal/notify"
 	"github.com/prem0x01/hospital/internal/repository"
 	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/storage"
+	"github.com/prem0x01/hospital/internal/visibility"
 )
 
 func main() {
@@ -22,34 +32,206 @@ func main() {
 
 	cfg := config.Load()
 
-	db, err := database.Initialize(cfg.DBUrl)
+	loc, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		log.Fatal("Invalid HOSPITAL_TIMEZONE:", err)
+	}
+
+	db, err := database.Initialize(cfg.DBUrl, loc)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
-	if err := database.RunMigrations(cfg.DBUrl); err != nil {
+	if err := database.RunMigrations(cfg.DBUrl, loc); err != nil {
 		log.Fatal("Failed to run migrations:", err)
 	}
 
+	masterKey, err := fieldcrypt.LoadMasterKey(cfg.MasterKey, cfg.MasterKeyFile)
+	if err != nil {
+		log.Fatal("Failed to load master key (generate one with `go run ./cmd/phikeys generate`):", err)
+	}
+
+	keyring, err := fieldcrypt.NewKeyring(context.Background(), masterKey, repository.NewEncryptionKeyRepository(db.Pool))
+	if err != nil {
+		log.Fatal("Failed to load encryption keys:", err)
+	}
+
+	reminderOffsets, err := notify.ParseOffsets(cfg.ReminderOffsets)
+	if err != nil {
+		log.Fatal("Invalid REMINDER_OFFSETS:", err)
+	}
+
+	emailDriver, err := notify.NewDriver(cfg.EmailDriver, cfg.NotifyFileDir)
+	if err != nil {
+		log.Fatal("Failed to set up email driver:", err)
+	}
+
+	smsDriver, err := notify.NewDriver(cfg.SMSDriver, cfg.NotifyFileDir)
+	if err != nil {
+		log.Fatal("Failed to set up SMS driver:", err)
+	}
+
+	noShowGrace, err := time.ParseDuration(cfg.NoShowGracePeriod)
+	if err != nil || noShowGrace < 0 {
+		log.Fatal("Invalid NO_SHOW_GRACE_PERIOD:", cfg.NoShowGracePeriod)
+	}
+
+	documentTemplate, err := documents.LoadTemplate(cfg.DocumentTemplateFile)
+	if err != nil {
+		log.Fatal("Failed to load document template:", err)
+	}
+
+	s3PathStyle, err := strconv.ParseBool(cfg.S3PathStyle)
+	if err != nil {
+		log.Fatal("Invalid S3_PATH_STYLE:", cfg.S3PathStyle)
+	}
+	documentStore, err := storage.New(storage.Config{
+		Driver: cfg.StorageDriver,
+		Dir:    cfg.StorageDir,
+		S3: storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PathStyle: s3PathStyle,
+		},
+	})
+	if err != nil {
+		log.Fatal("Failed to set up document storage:", err)
+	}
+
+	documentMaxBytes, err := strconv.ParseInt(cfg.DocumentMaxBytes, 10, 64)
+	if err != nil || documentMaxBytes <= 0 {
+		log.Fatal("Invalid DOCUMENT_MAX_BYTES:", cfg.DocumentMaxBytes)
+	}
+
+	if err := billing.CheckCurrency(cfg.BillingCurrency); err != nil {
+		log.Fatal("Invalid BILLING_CURRENCY:", err)
+	}
+	paymentTerms, err := strconv.Atoi(cfg.BillingPaymentTermsDays)
+	if err != nil || paymentTerms < 0 {
+		log.Fatal("Invalid BILLING_PAYMENT_TERMS_DAYS:", cfg.BillingPaymentTermsDays)
+	}
+
 	userRepo := repository.NewUserRepository(db.Pool)
-	patientRepo := repository.NewPatientRepository(db.Queries)
-	appointmentRepo := repository.NewAppointmentRepository(db.Queries, db.Pool)
+	patientRepo := repository.NewPatientRepository(db.Queries, keyring)
+	appointmentRepo := repository.NewAppointmentRepository(db.Queries, db.Pool, reminderOffsets)
+	auditRepo := repository.NewAuditRepository(db.Pool)
+	emergencyAccessRepo := repository.NewEmergencyAccessRepository(db.Queries, db.Pool)
+	careTeamRepo := repository.NewCareTeamRepository(db.Queries)
+	availabilityRepo := repository.NewAvailabilityRepository(db.Pool)
+	waitlistRepo := repository.NewWaitlistRepository(db.Pool, appointmentRepo)
+	notificationRepo := repository.NewNotificationRepository(db.Pool)
+	calendarRepo := repository.NewCalendarRepository(db.Queries)
+	queueRepo := repository.NewQueueRepository(db.Pool)
+	jobRepo := repository.NewJobRepository(db.Pool)
+	departmentRepo := repository.NewDepartmentRepository(db.Queries)
+	resourceRepo := repository.NewResourceRepository(db.Queries)
+	noteRepo := repository.NewClinicalNoteRepository(db.Queries, db.Pool)
+	icd10Repo := repository.NewIcd10Repository(db.Queries, db.Pool)
+	diagnosisRepo := repository.NewDiagnosisRepository(db.Queries)
+	referralRepo := repository.NewReferralRepository(db.Queries, db.Pool)
+	patientDocumentRepo := repository.NewPatientDocumentRepository(db.Queries, db.Pool)
+	consentRepo := repository.NewConsentRepository(db.Queries, db.Pool)
+	billingRepo := repository.NewBillingRepository(db.Queries, db.Pool)
+	insuranceRepo := repository.NewInsuranceRepository(db.Queries, db.Pool)
+	inpatientRepo := repository.NewInpatientRepository(db.Queries, db.Pool)
+
+	// Patients written before field encryption, or before values were bound
+	// to their row, are sealed before anything is served.
+	sealed, err := patientRepo.EncryptLegacy(context.Background())
+	if err != nil {
+		log.Fatal("Failed to encrypt legacy patient rows:", err)
+	}
+	if sealed > 0 {
+		log.Printf("Encrypted %d legacy patient rows", sealed)
+	}
 
-	authService := services.NewAuthService(userRepo, cfg.JWTSecret)
-	patientService := services.NewPatientService(patientRepo)
-	appointmentService := services.NewAppointmentService(appointmentRepo, patientRepo)
+	auditService := services.NewAuditService(auditRepo)
+	authService := services.NewAuthService(userRepo, auditService, cfg.JWTSecret)
+	emergencyAccessService := services.NewEmergencyAccessService(emergencyAccessRepo, patientRepo, auditService)
+	careTeamService := services.NewCareTeamService(careTeamRepo, userRepo, patientRepo, emergencyAccessService, auditService)
+	patientService := services.NewPatientService(patientRepo, careTeamService, auditService)
+	availabilityService := services.NewAvailabilityService(availabilityRepo, userRepo, loc)
+	waitlistService := services.NewWaitlistService(waitlistRepo, appointmentRepo, patientRepo, careTeamService, auditService, loc)
+	departmentService := services.NewDepartmentService(departmentRepo, resourceRepo, availabilityRepo, userRepo)
+	resourceService := services.NewResourceService(resourceRepo, departmentRepo, appointmentRepo, loc)
+	noteService := services.NewClinicalNoteService(noteRepo, appointmentRepo, patientRepo, careTeamService, auditService)
+	diagnosisService := services.NewDiagnosisService(icd10Repo, diagnosisRepo, appointmentRepo, careTeamService, auditService)
+	appointmentService := services.NewAppointmentService(appointmentRepo, patientRepo, careTeamService, availabilityService, waitlistService, resourceService, noteService, auditService, loc)
+	referralService := services.NewReferralService(referralRepo, appointmentRepo, patientRepo, userRepo, departmentRepo, noteRepo, appointmentService, careTeamService, auditService)
+	documentService := services.NewDocumentService(documents.NewRenderer(documentTemplate), appointmentRepo, patientRepo, userRepo, departmentRepo, diagnosisRepo, careTeamService, auditService, loc)
+	patientDocumentService := services.NewPatientDocumentService(patientDocumentRepo, patientRepo, documentStore, careTeamService, auditService, documentMaxBytes)
+	calendarService := services.NewCalendarService(calendarRepo, appointmentRepo, appointmentService, auditService)
+	consentService := services.NewConsentService(consentRepo, patientRepo, careTeamService, auditService, loc)
+	notificationService := services.NewNotificationService(notificationRepo, appointmentRepo, patientRepo, careTeamService, consentService, emailDriver, smsDriver, auditService)
+	billingService := services.NewBillingService(billingRepo, appointmentRepo, patientRepo, auditService, cfg.BillingCurrency, paymentTerms, loc)
+	insuranceService := services.NewInsuranceService(insuranceRepo, billingRepo, appointmentRepo, patientRepo, diagnosisRepo, consentService, auditService, loc)
+	inpatientService := services.NewInpatientService(inpatientRepo, patientRepo, userRepo, appointmentRepo, careTeamService, auditService, loc)
+	queueService := services.NewQueueService(queueRepo, appointmentRepo, availabilityRepo, auditService)
+	housekeepingService := services.NewHousekeepingService(appointmentRepo, calendarRepo, auditService, noShowGrace)
+
+	jobScheduler := services.NewJobScheduler(jobRepo, loc)
+	for _, job := range []struct {
+		name, schedule string
+		run            services.JobFunc
+	}{
+		{"mark_no_shows", "*/5 * * * *", housekeepingService.MarkNoShows},
+		{"expire_waitlist_holds", "* * * * *", waitlistService.ExpireHolds},
+		{"deliver_notifications", "* * * * *", notificationService.DeliverDue},
+		{"purge_revoked_calendar_tokens", "0 3 * * *", housekeepingService.PurgeRevokedCalendarTokens},
+		{"refresh_statistics", "*/15 * * * *", housekeepingService.RefreshStatistics},
+	} {
+		if err := jobScheduler.Register(job.name, job.schedule, job.run); err != nil {
+			log.Fatal("Failed to register job:", err)
+		}
+	}
+
+	// These loops run on every replica rather than as jobs: each replica
+	// retries its own unwritten audit entries and feeds its own queue
+	// watchers.
+	go auditService.Run(context.Background(), time.Minute)
+	go queueService.Run(context.Background(), time.Minute)
+	go jobScheduler.Run(context.Background())
+
+	fieldPolicy, err := visibility.Load(cfg.FieldPolicyFile)
+	if err != nil {
+		log.Fatal("Failed to load field visibility policy:", err)
+	}
 
 	authHandler := handlers.NewAuthHandler(authService)
-	patientHandler := handlers.NewPatientHandler(patientService)
-	appointmentHandler := handlers.NewAppointmentHandler(appointmentService)
+	patientHandler := handlers.NewPatientHandler(patientService, fieldPolicy)
+	appointmentHandler := handlers.NewAppointmentHandler(appointmentService, fieldPolicy)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	emergencyAccessHandler := handlers.NewEmergencyAccessHandler(emergencyAccessService)
+	careTeamHandler := handlers.NewCareTeamHandler(careTeamService)
+	availabilityHandler := handlers.NewAvailabilityHandler(availabilityService, loc)
+	waitlistHandler := handlers.NewWaitlistHandler(waitlistService, fieldPolicy)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	calendarHandler := handlers.NewCalendarHandler(calendarService)
+	queueHandler := handlers.NewQueueHandler(queueService)
+	jobHandler := handlers.NewJobHandler(jobScheduler)
+	departmentHandler := handlers.NewDepartmentHandler(departmentService)
+	resourceHandler := handlers.NewResourceHandler(resourceService, loc)
+	noteHandler := handlers.NewClinicalNoteHandler(noteService)
+	diagnosisHandler := handlers.NewDiagnosisHandler(diagnosisService)
+	referralHandler := handlers.NewReferralHandler(referralService, fieldPolicy)
+	documentHandler := handlers.NewDocumentHandler(documentService)
+	patientDocumentHandler := handlers.NewPatientDocumentHandler(patientDocumentService)
+	consentHandler := handlers.NewConsentHandler(consentService)
+	billingHandler := handlers.NewBillingHandler(billingService)
+	insuranceHandler := handlers.NewInsuranceHandler(insuranceService)
+	inpatientHandler := handlers.NewInpatientHandler(inpatientService)
 
 	router := gin.Default()
+	router.Use(middleware.RequestID())
 
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type", middleware.RequestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", middleware.RequestIDHeader},
 		AllowCredentials: true,
 	}))
 
@@ -62,9 +244,11 @@ func main() {
 		auth := api.Group("/auth")
 		{
 			auth.POST("/login", authHandler.Login)
-			auth.POST("/register", authHandler.Register)
 		}
 
+		api.GET("/health", auditHandler.Health)
+		api.GET("/calendar/:file", calendarHandler.Feed)
+
 		protected := api.Group("/")
 		protected.Use(middleware.AuthMiddleware(cfg.JWTSecret))
 		{
@@ -75,15 +259,281 @@ func main() {
 				patients.GET("/:id", patientHandler.GetPatient)
 				patients.PUT("/:id", patientHandler.UpdatePatient)
 				patients.DELETE("/:id", patientHandler.DeletePatient)
+				patients.POST("/:id/emergency-access", middleware.RequireRole(domain.RoleDoctor), emergencyAccessHandler.RequestAccess)
+				patients.GET("/:id/care-team", careTeamHandler.GetCareTeam)
+				patients.POST("/:id/care-team", middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor), careTeamHandler.AddMember)
+				patients.DELETE("/:id/care-team/:doctorId", middleware.RequireRole(domain.RoleReceptionist), careTeamHandler.RemoveMember)
+				patients.GET("/:id/notification-preferences", notificationHandler.GetPreferences)
+				patients.PUT("/:id/notification-preferences", notificationHandler.SavePreferences)
+				patients.GET("/:id/notes", middleware.RequireRole(domain.RoleDoctor), noteHandler.ListNotes)
+				patients.POST("/:id/notes", middleware.RequireRole(domain.RoleDoctor), noteHandler.CreateNote)
+				patients.GET("/:id/problems", middleware.RequireRole(domain.RoleDoctor), diagnosisHandler.ListProblems)
+				patients.GET("/:id/card.pdf", middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor), documentHandler.PatientCard)
+
+				patientDocuments := patients.Group("/:id/documents")
+				patientDocuments.Use(middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor))
+				{
+					patientDocuments.GET("", patientDocumentHandler.ListDocuments)
+					patientDocuments.POST("", patientDocumentHandler.Upload)
+					patientDocuments.GET("/:documentId", patientDocumentHandler.GetDocument)
+					patientDocuments.GET("/:documentId/content", patientDocumentHandler.Download)
+					patientDocuments.DELETE("/:documentId", patientDocumentHandler.DeleteDocument)
+				}
+
+				consents := patients.Group("/:id/consents")
+				consents.Use(middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor))
+				{
+					consents.GET("", consentHandler.ListConsents)
+					consents.POST("", consentHandler.RecordConsent)
+					consents.GET("/check", consentHandler.CheckConsent)
+					consents.GET("/:consentId", consentHandler.GetConsent)
+					consents.POST("/:consentId/revoke", consentHandler.RevokeConsent)
+				}
+
+				patients.GET("/:id/balance", middleware.RequireRole(domain.RoleReceptionist, domain.RoleCompliance), billingHandler.PatientBalance)
+
+				coverages := patients.Group("/:id/coverages")
+				coverages.Use(middleware.RequireRole(domain.RoleReceptionist, domain.RoleCompliance))
+				{
+					coverages.GET("", insuranceHandler.ListCoverages)
+					coverages.POST("", middleware.RequireRole(domain.RoleReceptionist), insuranceHandler.CreateCoverage)
+					coverages.PUT("/:coverageId", middleware.RequireRole(domain.RoleReceptionist), insuranceHandler.UpdateCoverage)
+					coverages.DELETE("/:coverageId", middleware.RequireRole(domain.RoleReceptionist), insuranceHandler.DeleteCoverage)
+				}
 			}
 
 			appointments := protected.Group("/appointments")
 			{
 				appointments.GET("", appointmentHandler.GetAppointments)
 				appointments.POST("", appointmentHandler.CreateAppointment)
+				appointments.POST("/series", appointmentHandler.CreateSeries)
+				appointments.GET("/series/:id", appointmentHandler.GetSeries)
+				appointments.POST("/series/:id/cancel", appointmentHandler.CancelSeries)
 				appointments.GET("/:id", appointmentHandler.GetAppointment)
 				appointments.PUT("/:id", appointmentHandler.UpdateAppointment)
 				appointments.DELETE("/:id", appointmentHandler.DeleteAppointment)
+				appointments.POST("/:id/status", appointmentHandler.ChangeStatus)
+				appointments.POST("/:id/check-in", middleware.RequireRole(domain.RoleReceptionist), appointmentHandler.CheckIn)
+				appointments.GET("/:id/history", appointmentHandler.GetStatusHistory)
+				appointments.GET("/:id/calendar.ics", calendarHandler.DownloadAppointment)
+				appointments.GET("/:id/note", middleware.RequireRole(domain.RoleDoctor), noteHandler.GetAppointmentNote)
+				appointments.GET("/:id/diagnoses", middleware.RequireRole(domain.RoleDoctor), diagnosisHandler.ListDiagnoses)
+				appointments.POST("/:id/diagnoses", middleware.RequireRole(domain.RoleDoctor), diagnosisHandler.AddDiagnosis)
+				appointments.PUT("/:id/diagnoses/:diagnosisId", middleware.RequireRole(domain.RoleDoctor), diagnosisHandler.UpdateDiagnosis)
+				appointments.DELETE("/:id/diagnoses/:diagnosisId", middleware.RequireRole(domain.RoleDoctor), diagnosisHandler.DeleteDiagnosis)
+				appointments.GET("/:id/summary.pdf", middleware.RequireRole(domain.RoleDoctor), documentHandler.VisitSummary)
+				appointments.POST("/:id/prescription.pdf", middleware.RequireRole(domain.RoleDoctor), documentHandler.Prescription)
+				appointments.POST("/:id/invoice", middleware.RequireRole(domain.RoleReceptionist), billingHandler.CreateInvoice)
+			}
+
+			doctors := protected.Group("/doctors")
+			{
+				doctors.GET("", departmentHandler.ListDoctors)
+				doctors.GET("/:id/availability", availabilityHandler.GetAvailability)
+				doctors.PUT("/:id/availability", middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor), availabilityHandler.SetAvailability)
+				doctors.POST("/:id/availability/exceptions", middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor), availabilityHandler.AddException)
+				doctors.DELETE("/:id/availability/exceptions/:exceptionId", middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor), availabilityHandler.DeleteException)
+				doctors.GET("/:id/slots", availabilityHandler.GetDoctorSlots)
+				doctors.GET("/:id/specialties", departmentHandler.ListDoctorSpecialties)
+				doctors.PUT("/:id/specialties/:specialtyId", middleware.RequireRole(domain.RoleReceptionist), departmentHandler.AddDoctorSpecialty)
+				doctors.DELETE("/:id/specialties/:specialtyId", middleware.RequireRole(domain.RoleReceptionist), departmentHandler.RemoveDoctorSpecialty)
+			}
+			protected.GET("/slots", availabilityHandler.SearchSlots)
+
+			referrals := protected.Group("/referrals")
+			referrals.Use(middleware.RequireRole(domain.RoleDoctor, domain.RoleReceptionist))
+			{
+				referrals.GET("", referralHandler.ListReferrals)
+				referrals.POST("", middleware.RequireRole(domain.RoleDoctor), referralHandler.CreateReferral)
+				referrals.GET("/:id", referralHandler.GetReferral)
+				referrals.POST("/:id/accept", middleware.RequireRole(domain.RoleDoctor), referralHandler.AcceptReferral)
+				referrals.POST("/:id/decline", middleware.RequireRole(domain.RoleDoctor), referralHandler.DeclineReferral)
+				referrals.POST("/:id/appointments", referralHandler.BookAppointment)
+			}
+
+			notes := protected.Group("/notes")
+			notes.Use(middleware.RequireRole(domain.RoleDoctor))
+			{
+				notes.GET("/:id", noteHandler.GetNote)
+				notes.PUT("/:id", noteHandler.UpdateNote)
+				notes.GET("/:id/versions", noteHandler.ListVersions)
+				notes.POST("/:id/sign", noteHandler.SignNote)
+				notes.POST("/:id/addenda", noteHandler.AddAddendum)
+			}
+
+			protected.GET("/icd10/codes", diagnosisHandler.SearchCodes)
+			protected.GET("/icd10/codes/:code", diagnosisHandler.GetCode)
+
+			departments := protected.Group("/departments")
+			{
+				departments.GET("", departmentHandler.ListDepartments)
+				departments.POST("", middleware.RequireRole(domain.RoleReceptionist), departmentHandler.CreateDepartment)
+				departments.GET("/:id", departmentHandler.GetDepartment)
+				departments.PUT("/:id", middleware.RequireRole(domain.RoleReceptionist), departmentHandler.UpdateDepartment)
+				departments.DELETE("/:id", middleware.RequireRole(domain.RoleReceptionist), departmentHandler.DeleteDepartment)
+				departments.PUT("/:id/doctors/:doctorId", middleware.RequireRole(domain.RoleReceptionist), departmentHandler.AddDoctor)
+				departments.DELETE("/:id/doctors/:doctorId", middleware.RequireRole(domain.RoleReceptionist), departmentHandler.RemoveDoctor)
+			}
+
+			specialties := protected.Group("/specialties")
+			{
+				specialties.GET("", departmentHandler.ListSpecialties)
+				specialties.POST("", middleware.RequireRole(domain.RoleReceptionist), departmentHandler.CreateSpecialty)
+				specialties.DELETE("/:id", middleware.RequireRole(domain.RoleReceptionist), departmentHandler.DeleteSpecialty)
+			}
+
+			rooms := protected.Group("/rooms")
+			{
+				rooms.GET("", resourceHandler.ListRooms)
+				rooms.POST("", middleware.RequireRole(domain.RoleReceptionist), resourceHandler.CreateRoom)
+				rooms.GET("/:id", resourceHandler.GetRoom)
+				rooms.PUT("/:id", middleware.RequireRole(domain.RoleReceptionist), resourceHandler.UpdateRoom)
+				rooms.GET("/:id/bookings", resourceHandler.GetRoomSchedule)
+			}
+
+			equipment := protected.Group("/equipment")
+			{
+				equipment.GET("", resourceHandler.ListEquipment)
+				equipment.POST("", middleware.RequireRole(domain.RoleReceptionist), resourceHandler.CreateEquipment)
+				equipment.DELETE("/bookings/:id", middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor), resourceHandler.CancelEquipmentBooking)
+				equipment.GET("/:id", resourceHandler.GetEquipment)
+				equipment.PUT("/:id", middleware.RequireRole(domain.RoleReceptionist), resourceHandler.UpdateEquipment)
+				equipment.GET("/:id/bookings", resourceHandler.ListEquipmentBookings)
+				equipment.POST("/:id/bookings", middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor), resourceHandler.BookEquipment)
+			}
+
+			waitlist := protected.Group("/waitlist")
+			{
+				waitlist.POST("", middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor), waitlistHandler.CreateEntry)
+				waitlist.GET("", middleware.RequireRole(domain.RoleReceptionist), waitlistHandler.ListEntries)
+				waitlist.GET("/:id", middleware.RequireRole(domain.RoleReceptionist), waitlistHandler.GetEntry)
+				waitlist.DELETE("/:id", middleware.RequireRole(domain.RoleReceptionist), waitlistHandler.CancelEntry)
+				waitlist.POST("/holds/:id/confirm", middleware.RequireRole(domain.RoleReceptionist), waitlistHandler.ConfirmHold)
+				waitlist.POST("/holds/:id/decline", middleware.RequireRole(domain.RoleReceptionist), waitlistHandler.DeclineHold)
+			}
+
+			queue := protected.Group("/queue")
+			queue.Use(middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor))
+			{
+				queue.GET("", queueHandler.GetQueue)
+				queue.GET("/stream", queueHandler.Stream)
+			}
+
+			calendar := protected.Group("/calendar/tokens")
+			calendar.Use(middleware.RequireRole(domain.RoleDoctor))
+			{
+				calendar.POST("", calendarHandler.CreateToken)
+				calendar.GET("", calendarHandler.ListTokens)
+				calendar.DELETE("/:id", calendarHandler.RevokeToken)
+			}
+
+			notifications := protected.Group("/notifications")
+			notifications.Use(middleware.RequireRole(domain.RoleReceptionist))
+			{
+				notifications.GET("/templates", notificationHandler.ListTemplates)
+				notifications.PUT("/templates/:template/:channel/:language", notificationHandler.SaveTemplate)
+			}
+
+			consentTemplates := protected.Group("/consent-templates")
+			{
+				consentTemplates.GET("", consentHandler.ListTemplates)
+				consentTemplates.POST("", middleware.RequireRole(domain.RoleCompliance), consentHandler.CreateTemplate)
+				consentTemplates.GET("/:id", consentHandler.GetTemplate)
+			}
+
+			billingServices := protected.Group("/billing/services")
+			{
+				billingServices.GET("", billingHandler.ListServices)
+				billingServices.POST("", middleware.RequireRole(domain.RoleReceptionist), billingHandler.CreateService)
+				billingServices.GET("/:id", billingHandler.GetService)
+				billingServices.PUT("/:id", middleware.RequireRole(domain.RoleReceptionist), billingHandler.UpdateService)
+			}
+			protected.GET("/billing/outstanding", middleware.RequireRole(domain.RoleReceptionist, domain.RoleCompliance), billingHandler.OutstandingBalances)
+
+			invoices := protected.Group("/invoices")
+			invoices.Use(middleware.RequireRole(domain.RoleReceptionist, domain.RoleCompliance))
+			{
+				invoices.GET("", billingHandler.ListInvoices)
+				invoices.GET("/:id", billingHandler.GetInvoice)
+				invoices.POST("/:id/lines", middleware.RequireRole(domain.RoleReceptionist), billingHandler.AddLine)
+				invoices.DELETE("/:id/lines/:lineId", middleware.RequireRole(domain.RoleReceptionist), billingHandler.DeleteLine)
+				invoices.POST("/:id/issue", middleware.RequireRole(domain.RoleReceptionist), billingHandler.IssueInvoice)
+				invoices.POST("/:id/void", middleware.RequireRole(domain.RoleReceptionist), billingHandler.VoidInvoice)
+				invoices.POST("/:id/payments", middleware.RequireRole(domain.RoleReceptionist), billingHandler.RecordPayment)
+				invoices.POST("/:id/payments/:paymentId/refund", middleware.RequireRole(domain.RoleReceptionist), billingHandler.RefundPayment)
+				invoices.POST("/:id/claims", middleware.RequireRole(domain.RoleReceptionist), insuranceHandler.CreateClaim)
+			}
+
+			payers := protected.Group("/insurance/payers")
+			{
+				payers.GET("", insuranceHandler.ListPayers)
+				payers.POST("", middleware.RequireRole(domain.RoleReceptionist), insuranceHandler.CreatePayer)
+				payers.GET("/:id", insuranceHandler.GetPayer)
+				payers.PUT("/:id", middleware.RequireRole(domain.RoleReceptionist), insuranceHandler.UpdatePayer)
+			}
+
+			claims := protected.Group("/claims")
+			claims.Use(middleware.RequireRole(domain.RoleReceptionist, domain.RoleCompliance))
+			{
+				claims.GET("", insuranceHandler.ListClaims)
+				claims.GET("/:id", insuranceHandler.GetClaim)
+				claims.POST("/:id/adjudicate", middleware.RequireRole(domain.RoleReceptionist), insuranceHandler.AdjudicateClaim)
+				claims.POST("/:id/cancel", middleware.RequireRole(domain.RoleReceptionist), insuranceHandler.CancelClaim)
+				claims.POST("/:id/payment", middleware.RequireRole(domain.RoleReceptionist), insuranceHandler.RecordClaimPayment)
+			}
+
+			claimBatches := protected.Group("/claim-batches")
+			claimBatches.Use(middleware.RequireRole(domain.RoleReceptionist, domain.RoleCompliance))
+			{
+				claimBatches.GET("", insuranceHandler.ListBatches)
+				claimBatches.POST("", middleware.RequireRole(domain.RoleReceptionist), insuranceHandler.SubmitBatch)
+				claimBatches.GET("/:id", insuranceHandler.GetBatch)
+				claimBatches.GET("/:id/file", middleware.RequireRole(domain.RoleReceptionist), insuranceHandler.BatchFile)
+			}
+
+			wards := protected.Group("/wards")
+			{
+				wards.GET("", inpatientHandler.ListWards)
+				wards.POST("", middleware.RequireRole(domain.RoleReceptionist), inpatientHandler.CreateWard)
+				wards.GET("/board", middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor), inpatientHandler.Board)
+				wards.GET("/census", inpatientHandler.Census)
+				wards.PUT("/:id", middleware.RequireRole(domain.RoleReceptionist), inpatientHandler.UpdateWard)
+				wards.GET("/:id/beds", inpatientHandler.ListBeds)
+				wards.POST("/:id/beds", middleware.RequireRole(domain.RoleReceptionist), inpatientHandler.CreateBed)
+			}
+
+			beds := protected.Group("/beds")
+			{
+				beds.PUT("/:id", middleware.RequireRole(domain.RoleReceptionist), inpatientHandler.RenameBed)
+				beds.PUT("/:id/status", middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor), inpatientHandler.SetBedStatus)
+			}
+
+			admissions := protected.Group("/admissions")
+			admissions.Use(middleware.RequireRole(domain.RoleReceptionist, domain.RoleDoctor))
+			{
+				admissions.GET("", inpatientHandler.ListAdmissions)
+				admissions.POST("", inpatientHandler.Admit)
+				admissions.GET("/:id", inpatientHandler.GetAdmission)
+				admissions.POST("/:id/transfer", inpatientHandler.Transfer)
+				admissions.POST("/:id/discharge", inpatientHandler.Discharge)
+			}
+
+			protected.POST("/users", middleware.RequireRole(domain.RoleReceptionist, domain.RoleCompliance), authHandler.CreateUser)
+			protected.GET("/audit", middleware.RequireRole(domain.RoleCompliance), auditHandler.GetAuditLog)
+			protected.GET("/audit/status", middleware.RequireRole(domain.RoleCompliance), auditHandler.AuditStatus)
+
+			jobs := protected.Group("/jobs")
+			jobs.Use(middleware.RequireRole(domain.RoleCompliance))
+			{
+				jobs.GET("", jobHandler.ListJobs)
+				jobs.GET("/runs", jobHandler.ListRuns)
+			}
+
+			emergencyAccess := protected.Group("/emergency-access")
+			emergencyAccess.Use(middleware.RequireRole(domain.RoleCompliance))
+			{
+				emergencyAccess.GET("", emergencyAccessHandler.GetReviewQueue)
+				emergencyAccess.POST("/:id/review", emergencyAccessHandler.ReviewGrant)
 			}
 
 			protected.GET("/dashboard/stats", handlers.GetDashboardStats(patientRepo, appointmentRepo))
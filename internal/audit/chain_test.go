@@ -0,0 +1,100 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/audit"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildChain(t *testing.T, n int) []*domain.AuditEntry {
+	prev := audit.GenesisHash
+	entries := make([]*domain.AuditEntry, 0, n)
+	for i := 0; i < n; i++ {
+		id := int32(i + 1)
+		e := &domain.AuditEntry{
+			ID:           int64(i + 1),
+			OccurredAt:   time.Date(2025, 6, 17, 14, 0, i, 123456789, time.UTC),
+			ActorID:      &id,
+			ActorRole:    "doctor",
+			Action:       domain.AuditActionRead,
+			ResourceType: domain.ResourcePatient,
+			ResourceID:   &id,
+			PatientID:    &id,
+			RequestID:    "req",
+		}
+		assert.NoError(t, audit.Seal(e, prev))
+		prev = e.Hash
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func TestVerifierAcceptsIntactChain(t *testing.T) {
+	v := audit.NewVerifier()
+	for _, e := range buildChain(t, 5) {
+		assert.NoError(t, v.Check(e))
+	}
+	assert.Equal(t, int64(5), v.Checked())
+}
+
+func TestVerifierDetectsModifiedEntry(t *testing.T) {
+	entries := buildChain(t, 3)
+	entries[1].Action = domain.AuditActionDelete
+
+	v := audit.NewVerifier()
+	assert.NoError(t, v.Check(entries[0]))
+	assert.Error(t, v.Check(entries[1]))
+}
+
+func TestVerifierDetectsRemovedEntry(t *testing.T) {
+	entries := buildChain(t, 3)
+
+	v := audit.NewVerifier()
+	assert.NoError(t, v.Check(entries[0]))
+	assert.Error(t, v.Check(entries[2]))
+}
+
+func TestDiffReportsChangedFieldsOnly(t *testing.T) {
+	oldEmail := "old@example.com"
+	newEmail := "new@example.com"
+	before := domain.Patient{ID: 1, FirstName: "Asha", Email: &oldEmail}
+	after := domain.Patient{ID: 1, FirstName: "Asha", Email: &newEmail}
+
+	changes := audit.Diff(&before, &after)
+
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "old@example.com", changes["email"].Before)
+	assert.Equal(t, "new@example.com", changes["email"].After)
+}
+
+func TestDiffAgainstNilRecordsCreation(t *testing.T) {
+	changes := audit.Diff(nil, &domain.Patient{ID: 7, FirstName: "Ravi"})
+
+	assert.Nil(t, changes["first_name"].Before)
+	assert.Equal(t, "Ravi", changes["first_name"].After)
+	assert.NotContains(t, changes, "created_at")
+}
+
+func TestHashSurvivesStorageRoundTrip(t *testing.T) {
+	email := "a@example.com"
+	e := &domain.AuditEntry{
+		OccurredAt:   time.Now(),
+		ActorRole:    "receptionist",
+		Action:       domain.AuditActionCreate,
+		ResourceType: domain.ResourcePatient,
+		Changes:      audit.Diff(nil, &domain.Patient{ID: 3, FirstName: "Ravi", Email: &email}),
+	}
+	assert.NoError(t, audit.Seal(e, audit.GenesisHash))
+
+	raw, err := json.Marshal(e.Changes)
+	assert.NoError(t, err)
+	stored := *e
+	stored.Changes = nil
+	assert.NoError(t, json.Unmarshal(raw, &stored.Changes))
+
+	assert.NoError(t, audit.NewVerifier().Check(&stored))
+}
@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+// Diff compares two values of the same struct type field by field and
+// returns the fields whose JSON representation differs, keyed by JSON name.
+// Either side may be nil, which records a creation or a deletion.
+// Values are stored in their JSON-decoded form so that they hash the same
+// way before they are written and after they are read back.
+func Diff(before, after interface{}) map[string]domain.FieldChange {
+	b := flatten(before)
+	a := flatten(after)
+
+	changes := make(map[string]domain.FieldChange)
+	for name, av := range a {
+		bv, ok := b[name]
+		if !ok || !reflect.DeepEqual(av, bv) {
+			changes[name] = domain.FieldChange{Before: bv, After: av}
+		}
+	}
+	for name, bv := range b {
+		if _, ok := a[name]; !ok {
+			changes[name] = domain.FieldChange{Before: bv, After: nil}
+		}
+	}
+
+	for _, name := range ignoredFields {
+		delete(changes, name)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+// bookkeeping columns that change on every write and carry no information
+var ignoredFields = []string{"created_at", "updated_at"}
+
+func flatten(v interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+	if v == nil {
+		return out
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return out
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return out
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		out[name] = normalize(rv.Field(i).Interface())
+	}
+	return out
+}
+
+func normalize(v interface{}) interface{} {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var out interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+// Redacted replaces the value of a protected field in a change record.
+const Redacted = "[redacted]"
+
+// Redact hides the before and after values of the named fields, keeping
+// only the fact that they changed, and returns changes for chaining.
+func Redact(changes map[string]domain.FieldChange, fields ...string) map[string]domain.FieldChange {
+	for _, name := range fields {
+		c, ok := changes[name]
+		if !ok {
+			continue
+		}
+		if c.Before != nil {
+			c.Before = Redacted
+		}
+		if c.After != nil {
+			c.After = Redacted
+		}
+		changes[name] = c
+	}
+	return changes
+}
@@ -0,0 +1,105 @@
+// Package billing works out invoice amounts and insurance shares. Money
+// is an int64 count of minor units of the invoice currency, such as
+// cents, and rates are in basis points: 750 is 7.5%. No floating point is
+// used anywhere, so totals add up exactly; the only rounding is of
+// percentages, half up to the minor unit.
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const (
+	// MaxQuantity and MaxUnitPrice bound a line so that no intermediate
+	// product can overflow an int64.
+	MaxQuantity  = 10_000
+	MaxUnitPrice = 10_000_000_000
+	// MaxRate is 100% in basis points.
+	MaxRate = 10_000
+)
+
+// ErrAmount reports a quantity, price or rate out of range.
+var ErrAmount = errors.New("billing: amount out of range")
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// CheckCurrency reports whether code is an ISO 4217 style code such as
+// "USD".
+func CheckCurrency(code string) error {
+	if !currencyCode.MatchString(code) {
+		return fmt.Errorf("billing: invalid currency code %q", code)
+	}
+	return nil
+}
+
+// Amounts are the money columns of an invoice or one of its lines. Total
+// is Subtotal - Discount + Tax.
+type Amounts struct {
+	Subtotal int64
+	Discount int64
+	Tax      int64
+	Total    int64
+}
+
+// Plus returns the sum of a and b, as for adding a line to an invoice.
+func (a Amounts) Plus(b Amounts) Amounts {
+	return Amounts{
+		Subtotal: a.Subtotal + b.Subtotal,
+		Discount: a.Discount + b.Discount,
+		Tax:      a.Tax + b.Tax,
+		Total:    a.Total + b.Total,
+	}
+}
+
+// Line works out the amounts of quantity items at unitPrice each. The
+// discount is discount minor units plus discountRate of the subtotal, and
+// may not exceed it. Tax at taxRate is charged on what remains.
+func Line(quantity int32, unitPrice, discount int64, discountRate, taxRate int32) (Amounts, error) {
+	switch {
+	case quantity < 1 || quantity > MaxQuantity:
+		return Amounts{}, fmt.Errorf("%w: quantity must be between 1 and %d", ErrAmount, MaxQuantity)
+	case unitPrice < 0 || unitPrice > MaxUnitPrice:
+		return Amounts{}, fmt.Errorf("%w: unit price must be between 0 and %d", ErrAmount, int64(MaxUnitPrice))
+	case discount < 0:
+		return Amounts{}, fmt.Errorf("%w: discount cannot be negative", ErrAmount)
+	case discountRate < 0 || discountRate > MaxRate, taxRate < 0 || taxRate > MaxRate:
+		return Amounts{}, fmt.Errorf("%w: rates must be between 0 and %d basis points", ErrAmount, MaxRate)
+	}
+
+	a := Amounts{Subtotal: int64(quantity) * unitPrice}
+	if discount > a.Subtotal {
+		return Amounts{}, fmt.Errorf("%w: discount exceeds the line's subtotal", ErrAmount)
+	}
+	a.Discount = discount + Percent(a.Subtotal, discountRate)
+	if a.Discount > a.Subtotal {
+		return Amounts{}, fmt.Errorf("%w: discount exceeds the line's subtotal", ErrAmount)
+	}
+	a.Tax = Percent(a.Subtotal-a.Discount, taxRate)
+	a.Total = a.Subtotal - a.Discount + a.Tax
+	return a, nil
+}
+
+// Percent returns rate basis points of amount, rounded half up. amount
+// must not be negative.
+func Percent(amount int64, rate int32) int64 {
+	return (amount*int64(rate) + MaxRate/2) / MaxRate
+}
+
+// Share splits billed between an insurer and the patient under a policy
+// with a fixed copay and coinsurance at coinsuranceRate: the patient pays
+// the copay, or all of billed if it is less, then coinsuranceRate of the
+// rest. The insurer pays the remainder.
+func Share(billed, copay int64, coinsuranceRate int32) (payer, patient int64, err error) {
+	switch {
+	case billed < 0 || copay < 0:
+		return 0, 0, fmt.Errorf("%w: amounts cannot be negative", ErrAmount)
+	case coinsuranceRate < 0 || coinsuranceRate > MaxRate:
+		return 0, 0, fmt.Errorf("%w: rates must be between 0 and %d basis points", ErrAmount, MaxRate)
+	}
+
+	patient = min(copay, billed)
+	patient += Percent(billed-patient, coinsuranceRate)
+	return billed - patient, patient, nil
+}
@@ -0,0 +1,102 @@
+package billing_test
+
+import (
+	"testing"
+
+	"github.com/prem0x01/hospital/internal/billing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineWithoutDiscountOrTax(t *testing.T) {
+	a, err := billing.Line(3, 2500, 0, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, billing.Amounts{Subtotal: 7500, Total: 7500}, a)
+}
+
+func TestTaxIsChargedAfterDiscount(t *testing.T) {
+	// 2 x 49.99 less 10% and 5.00, plus 7.5% tax.
+	a, err := billing.Line(2, 4999, 500, 1000, 750)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9998), a.Subtotal)
+	assert.Equal(t, int64(1500), a.Discount) // 5.00 + 10.00 (9.998 rounded)
+	assert.Equal(t, int64(637), a.Tax)       // 7.5% of 84.98 = 6.3735
+	assert.Equal(t, int64(9135), a.Total)
+	assert.Equal(t, a.Subtotal-a.Discount+a.Tax, a.Total)
+}
+
+func TestPercentRoundsHalfUp(t *testing.T) {
+	assert.Equal(t, int64(1), billing.Percent(10, 500))  // 0.5
+	assert.Equal(t, int64(0), billing.Percent(9, 500))   // 0.45
+	assert.Equal(t, int64(3), billing.Percent(25, 1000)) // 2.5
+	assert.Equal(t, int64(0), billing.Percent(0, 750))
+	assert.Equal(t, int64(12345), billing.Percent(12345, billing.MaxRate))
+}
+
+func TestLargestLineDoesNotOverflow(t *testing.T) {
+	a, err := billing.Line(billing.MaxQuantity, billing.MaxUnitPrice, 0, 0, billing.MaxRate)
+	require.NoError(t, err)
+	assert.Equal(t, int64(billing.MaxQuantity)*billing.MaxUnitPrice, a.Subtotal)
+	assert.Equal(t, 2*a.Subtotal, a.Total)
+}
+
+func TestLineRejectsOutOfRange(t *testing.T) {
+	cases := []struct {
+		name                  string
+		quantity              int32
+		price, discount       int64
+		discountRate, taxRate int32
+	}{
+		{"zero quantity", 0, 100, 0, 0, 0},
+		{"huge quantity", billing.MaxQuantity + 1, 100, 0, 0, 0},
+		{"negative price", 1, -1, 0, 0, 0},
+		{"huge price", 1, billing.MaxUnitPrice + 1, 0, 0, 0},
+		{"negative discount", 1, 100, -1, 0, 0},
+		{"discount above subtotal", 1, 100, 101, 0, 0},
+		{"discounts together above subtotal", 1, 100, 60, 5000, 0},
+		{"rate above 100%", 1, 100, 0, 0, billing.MaxRate + 1},
+		{"negative rate", 1, 100, 0, -1, 0},
+	}
+	for _, c := range cases {
+		_, err := billing.Line(c.quantity, c.price, c.discount, c.discountRate, c.taxRate)
+		assert.ErrorIs(t, err, billing.ErrAmount, c.name)
+	}
+}
+
+func TestPlusAddsEveryColumn(t *testing.T) {
+	a := billing.Amounts{Subtotal: 100, Discount: 10, Tax: 9, Total: 99}
+	b := billing.Amounts{Subtotal: 50, Tax: 5, Total: 55}
+	assert.Equal(t, billing.Amounts{Subtotal: 150, Discount: 10, Tax: 14, Total: 154}, a.Plus(b))
+}
+
+func TestCheckCurrency(t *testing.T) {
+	assert.NoError(t, billing.CheckCurrency("USD"))
+	for _, bad := range []string{"", "usd", "US", "USDX", "U$D"} {
+		assert.Error(t, billing.CheckCurrency(bad), bad)
+	}
+}
+
+func TestShareAppliesCopayThenCoinsurance(t *testing.T) {
+	// 150.00 billed, 20.00 copay, 20% coinsurance of the remaining 130.00.
+	payer, patient, err := billing.Share(15000, 2000, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4600), patient)
+	assert.Equal(t, int64(10400), payer)
+}
+
+func TestShareCopayCannotExceedBill(t *testing.T) {
+	payer, patient, err := billing.Share(1500, 2000, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), patient)
+	assert.Zero(t, payer)
+}
+
+func TestShareWithoutCostSharing(t *testing.T) {
+	payer, patient, err := billing.Share(9999, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9999), payer)
+	assert.Zero(t, patient)
+
+	_, _, err = billing.Share(100, 0, billing.MaxRate+1)
+	assert.ErrorIs(t, err, billing.ErrAmount)
+}
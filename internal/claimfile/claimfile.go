@@ -0,0 +1,176 @@
+// Package claimfile writes batches of insurance claims in the CSV format
+// the hospital hands to its clearinghouse.
+//
+// A file is RFC 4180 CSV in UTF-8 with CRLF line endings. The first
+// record is Header. Each record after it is one service line of a claim;
+// the lines of a claim are consecutive and repeat the claim's columns.
+// Money is an integer count of minor units of the currency, dates are
+// YYYY-MM-DD, and diagnosis codes are ICD-10-CM without the decimal
+// point, primary first, separated by "|". Every record starts with
+// Version so that later revisions of the format can be told apart.
+//
+// The line totals are those of the invoice. claim_billed_minor is what
+// the claim asks for, which on a secondary claim is less than their sum.
+package claimfile
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is the revision of the format written.
+const Version = "1"
+
+const dateFormat = "2006-01-02"
+
+// Header names the columns, in order.
+var Header = []string{
+	"format_version",
+	"batch_id",
+	"claim_id",
+	"invoice_id",
+	"service_date",
+	"currency",
+	"payer_code",
+	"payer_name",
+	"policy_number",
+	"member_number",
+	"group_number",
+	"subscriber_name",
+	"subscriber_relationship",
+	"patient_mrn",
+	"patient_last_name",
+	"patient_first_name",
+	"patient_birth_date",
+	"patient_gender",
+	"diagnosis_codes",
+	"claim_billed_minor",
+	"claim_payer_share_minor",
+	"claim_patient_share_minor",
+	"line_number",
+	"service_code",
+	"service_description",
+	"quantity",
+	"unit_price_minor",
+	"line_total_minor",
+}
+
+// Batch is the claims sent to one payer together.
+type Batch struct {
+	ID        int32
+	PayerCode string
+	PayerName string
+	Claims    []Claim
+}
+
+type Claim struct {
+	ID          int32
+	InvoiceID   int32
+	ServiceDate time.Time
+	Currency    string
+	Policy      Policy
+	Patient     Patient
+	// Diagnoses are ICD-10-CM codes, primary first.
+	Diagnoses         []string
+	BilledMinor       int64
+	PayerShareMinor   int64
+	PatientShareMinor int64
+	Lines             []Line
+}
+
+// Policy identifies the coverage claimed against. Relationship is the
+// patient's relationship to the subscriber: self, spouse, child or other.
+type Policy struct {
+	PolicyNumber   string
+	MemberNumber   string
+	GroupNumber    string
+	SubscriberName string
+	Relationship   string
+}
+
+// Patient is who was treated. A zero BirthDate is written empty.
+type Patient struct {
+	MRN       string
+	LastName  string
+	FirstName string
+	BirthDate time.Time
+	Gender    string
+}
+
+type Line struct {
+	ServiceCode    string
+	Description    string
+	Quantity       int32
+	UnitPriceMinor int64
+	TotalMinor     int64
+}
+
+// Write writes b to w. Every claim must have at least one line.
+func (b *Batch) Write(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+	if err := cw.Write(Header); err != nil {
+		return err
+	}
+
+	for _, c := range b.Claims {
+		if len(c.Lines) == 0 {
+			return fmt.Errorf("claimfile: claim %d has no lines", c.ID)
+		}
+		diagnoses := make([]string, len(c.Diagnoses))
+		for i, code := range c.Diagnoses {
+			diagnoses[i] = strings.ReplaceAll(code, ".", "")
+		}
+		var birthDate string
+		if !c.Patient.BirthDate.IsZero() {
+			birthDate = c.Patient.BirthDate.Format(dateFormat)
+		}
+
+		for i, l := range c.Lines {
+			err := cw.Write([]string{
+				Version,
+				itoa(int64(b.ID)),
+				itoa(int64(c.ID)),
+				itoa(int64(c.InvoiceID)),
+				c.ServiceDate.Format(dateFormat),
+				c.Currency,
+				b.PayerCode,
+				b.PayerName,
+				c.Policy.PolicyNumber,
+				c.Policy.MemberNumber,
+				c.Policy.GroupNumber,
+				c.Policy.SubscriberName,
+				c.Policy.Relationship,
+				c.Patient.MRN,
+				c.Patient.LastName,
+				c.Patient.FirstName,
+				birthDate,
+				c.Patient.Gender,
+				strings.Join(diagnoses, "|"),
+				itoa(c.BilledMinor),
+				itoa(c.PayerShareMinor),
+				itoa(c.PatientShareMinor),
+				itoa(int64(i + 1)),
+				l.ServiceCode,
+				l.Description,
+				itoa(int64(l.Quantity)),
+				itoa(l.UnitPriceMinor),
+				itoa(l.TotalMinor),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
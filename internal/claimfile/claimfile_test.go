@@ -0,0 +1,83 @@
+package claimfile_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prem0x01/hospital/internal/claimfile"
+)
+
+func batch() *claimfile.Batch {
+	return &claimfile.Batch{
+		ID:        12,
+		PayerCode: "60054",
+		PayerName: "Acme Health",
+		Claims: []claimfile.Claim{{
+			ID:          301,
+			InvoiceID:   88,
+			ServiceDate: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+			Currency:    "USD",
+			Policy: claimfile.Policy{
+				PolicyNumber:   "POL-1",
+				MemberNumber:   "W123456789",
+				SubscriberName: "Maria Lopez",
+				Relationship:   "child",
+			},
+			Patient: claimfile.Patient{
+				MRN:       "MRN-000042",
+				LastName:  "Lopez",
+				FirstName: "Ana",
+				BirthDate: time.Date(2015, 7, 9, 0, 0, 0, 0, time.UTC),
+				Gender:    "female",
+			},
+			Diagnoses:         []string{"J02.9", "R50.9"},
+			BilledMinor:       17850,
+			PayerShareMinor:   14280,
+			PatientShareMinor: 3570,
+			Lines: []claimfile.Line{
+				{ServiceCode: "CONS-PED", Description: "Paediatric consultation", Quantity: 1, UnitPriceMinor: 15000, TotalMinor: 15000},
+				{Description: "Strep test, rapid", Quantity: 2, UnitPriceMinor: 1425, TotalMinor: 2850},
+			},
+		}},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, batch().Write(&buf))
+
+	want := strings.Join([]string{
+		strings.Join(claimfile.Header, ","),
+		"1,12,301,88,2025-03-03,USD,60054,Acme Health,POL-1,W123456789,,Maria Lopez,child,MRN-000042,Lopez,Ana,2015-07-09,female,J029|R509,17850,14280,3570,1,CONS-PED,Paediatric consultation,1,15000,15000",
+		`1,12,301,88,2025-03-03,USD,60054,Acme Health,POL-1,W123456789,,Maria Lopez,child,MRN-000042,Lopez,Ana,2015-07-09,female,J029|R509,17850,14280,3570,2,,"Strep test, rapid",2,1425,2850`,
+		"",
+	}, "\r\n")
+	assert.Equal(t, want, buf.String())
+}
+
+func TestEveryRecordHasEveryColumn(t *testing.T) {
+	b := batch()
+	b.Claims[0].Patient.BirthDate = time.Time{}
+	var buf bytes.Buffer
+	require.NoError(t, b.Write(&buf))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	for _, r := range records {
+		assert.Len(t, r, len(claimfile.Header))
+	}
+	assert.Empty(t, records[1][16], "missing birth date is written empty")
+}
+
+func TestClaimWithoutLinesIsRejected(t *testing.T) {
+	b := batch()
+	b.Claims[0].Lines = nil
+	assert.Error(t, b.Write(&bytes.Buffer{}))
+}
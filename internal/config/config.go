@@ -1,17 +1,81 @@
 package config
 
-import "os"
+import (
+	"os"
+	// Embeds the time zone database so HOSPITAL_TIMEZONE resolves on hosts
+	// without one.
+	_ "time/tzdata"
+)
 
 type Config struct {
-	DBUrl     string
-	JWTSecret string
-	Port      string
+	DBUrl             string
+	JWTSecret         string
+	Port              string
+	MasterKey         string
+	MasterKeyFile     string
+	FieldPolicyFile   string
+	ReminderOffsets   string
+	EmailDriver       string
+	SMSDriver         string
+	NotifyFileDir     string
+	NoShowGracePeriod string
+	// TimeZone is the IANA name of the hospital's time zone. Times given
+	// without a UTC offset are read in it.
+	TimeZone string
+	// DocumentTemplateFile is a JSON file with the hospital name, header,
+	// footer and logo printed on generated PDFs.
+	DocumentTemplateFile string
+	// StorageDriver is where uploaded patient documents are kept: "local"
+	// under StorageDir, or "s3" in an S3-compatible bucket.
+	StorageDriver    string
+	StorageDir       string
+	S3Endpoint       string
+	S3Region         string
+	S3Bucket         string
+	S3AccessKey      string
+	S3SecretKey      string
+	S3PathStyle      string
+	DocumentMaxBytes string
+	// BillingCurrency is the ISO 4217 code invoices are raised in, and
+	// BillingPaymentTermsDays how long after issue they fall due.
+	BillingCurrency         string
+	BillingPaymentTermsDays string
 }
 
 func Load() *Config {
 	return &Config{
-		DBUrl:     os.Getenv("DBURL"),
-		JWTSecret: os.Getenv("JWT_SECRET"),
-		Port:      os.Getenv("PORT"),
+		DBUrl:             os.Getenv("DBURL"),
+		JWTSecret:         os.Getenv("JWT_SECRET"),
+		Port:              os.Getenv("PORT"),
+		MasterKey:         os.Getenv("PHI_MASTER_KEY"),
+		MasterKeyFile:     getEnv("PHI_MASTER_KEY_FILE", "master.key"),
+		FieldPolicyFile:   os.Getenv("FIELD_POLICY_FILE"),
+		ReminderOffsets:   getEnv("REMINDER_OFFSETS", "24h,2h"),
+		EmailDriver:       getEnv("NOTIFY_EMAIL_DRIVER", "log"),
+		SMSDriver:         getEnv("NOTIFY_SMS_DRIVER", "log"),
+		NotifyFileDir:     getEnv("NOTIFY_FILE_DIR", "notifications"),
+		NoShowGracePeriod: getEnv("NO_SHOW_GRACE_PERIOD", "1h"),
+		TimeZone:          getEnv("HOSPITAL_TIMEZONE", "UTC"),
+
+		DocumentTemplateFile: os.Getenv("DOCUMENT_TEMPLATE_FILE"),
+		StorageDriver:        getEnv("STORAGE_DRIVER", "local"),
+		StorageDir:           getEnv("STORAGE_DIR", "uploads"),
+		S3Endpoint:           os.Getenv("S3_ENDPOINT"),
+		S3Region:             os.Getenv("S3_REGION"),
+		S3Bucket:             os.Getenv("S3_BUCKET"),
+		S3AccessKey:          os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:          os.Getenv("S3_SECRET_KEY"),
+		S3PathStyle:          getEnv("S3_PATH_STYLE", "true"),
+		DocumentMaxBytes:     getEnv("DOCUMENT_MAX_BYTES", "20971520"),
+
+		BillingCurrency:         getEnv("BILLING_CURRENCY", "USD"),
+		BillingPaymentTermsDays: getEnv("BILLING_PAYMENT_TERMS_DAYS", "30"),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
 	}
+	return fallback
 }
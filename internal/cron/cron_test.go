@@ -0,0 +1,103 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/cron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func next(t *testing.T, spec, from string, loc *time.Location, n int) []string {
+	t.Helper()
+	s, err := cron.Parse(spec)
+	require.NoError(t, err)
+	at, err := time.ParseInLocation("2006-01-02 15:04", from, loc)
+	require.NoError(t, err)
+
+	var out []string
+	for i := 0; i < n; i++ {
+		at = s.Next(at)
+		out = append(out, at.Format("Mon 2006-01-02 15:04 MST"))
+	}
+	return out
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		spec string
+		from string
+		want []string
+	}{
+		{"*/15 * * * *", "2026-03-02 09:07", []string{
+			"Mon 2026-03-02 09:15 UTC", "Mon 2026-03-02 09:30 UTC", "Mon 2026-03-02 09:45 UTC",
+		}},
+		{"0 9-17/4 * * mon-fri", "2026-03-06 14:00", []string{
+			"Fri 2026-03-06 17:00 UTC", "Mon 2026-03-09 09:00 UTC", "Mon 2026-03-09 13:00 UTC",
+		}},
+		{"@daily", "2026-12-31 00:00", []string{
+			"Fri 2027-01-01 00:00 UTC", "Sat 2027-01-02 00:00 UTC",
+		}},
+		{"30 2 29 feb *", "2026-01-01 00:00", []string{
+			"Tue 2028-02-29 02:30 UTC",
+		}},
+		// Either day field matches when both are restricted.
+		{"0 0 1 * 7", "2026-03-01 00:00", []string{
+			"Sun 2026-03-08 00:00 UTC", "Sun 2026-03-15 00:00 UTC", "Sun 2026-03-22 00:00 UTC",
+			"Sun 2026-03-29 00:00 UTC", "Wed 2026-04-01 00:00 UTC",
+		}},
+		{"5/20 0 * * *", "2026-03-02 00:00", []string{
+			"Mon 2026-03-02 00:05 UTC", "Mon 2026-03-02 00:25 UTC", "Mon 2026-03-02 00:45 UTC", "Tue 2026-03-03 00:05 UTC",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			assert.Equal(t, tt.want, next(t, tt.spec, tt.from, time.UTC, len(tt.want)))
+		})
+	}
+}
+
+func TestNextNever(t *testing.T) {
+	s, err := cron.Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
+
+func TestNextDaylightSaving(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 02:30 does not exist on 8 March 2026, so that day is skipped.
+	assert.Equal(t, []string{
+		"Sat 2026-03-07 02:30 EST", "Mon 2026-03-09 02:30 EDT",
+	}, next(t, "30 2 * * *", "2026-03-07 00:00", ny, 2))
+
+	// Hourly jobs keep running through the change.
+	assert.Equal(t, []string{
+		"Sun 2026-03-08 01:00 EST", "Sun 2026-03-08 03:00 EDT", "Sun 2026-03-08 04:00 EDT",
+	}, next(t, "0 * * * *", "2026-03-08 00:30", ny, 3))
+
+	// 01:30 happens twice on 1 November 2026.
+	assert.Equal(t, []string{
+		"Sun 2026-11-01 01:30 EDT", "Sun 2026-11-01 01:30 EST", "Mon 2026-11-02 01:30 EST",
+	}, next(t, "30 1 * * *", "2026-11-01 00:00", ny, 3))
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+	} {
+		_, err := cron.Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
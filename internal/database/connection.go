@@ -3,9 +3,15 @@ package database
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prem0x01/hospital/internal/database/queries"
 )
@@ -15,8 +21,28 @@ type DB struct {
 	Pool *pgxpool.Pool
 }
 
-func Initialize(databaseURL string) (*DB, error) {
-	pool, err := pgxpool.New(context.Background(), databaseURL)
+// Initialize connects to the database. Sessions run in loc, and
+// timestamps are returned in loc, so dates computed in SQL and times
+// written to JSON are the hospital's. As in migrations, the zone is also
+// available as current_setting('hospital.timezone'), which views such as
+// appointment_daily_stats read when they are refreshed.
+func Initialize(databaseURL string, loc *time.Location) (*DB, error) {
+	config, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database url: %w", err)
+	}
+	config.ConnConfig.RuntimeParams["timezone"] = loc.String()
+	config.ConnConfig.RuntimeParams["hospital.timezone"] = loc.String()
+	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		conn.TypeMap().RegisterType(&pgtype.Type{
+			Name:  "timestamptz",
+			OID:   pgtype.TimestamptzOID,
+			Codec: &pgtype.TimestamptzCodec{ScanLocation: loc},
+		})
+		return nil
+	}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
@@ -35,10 +61,20 @@ func (db *DB) Close() {
 	db.Pool.Close()
 }
 
-func RunMigrations(databaseURL string) error {
+// RunMigrations applies pending migrations. Migrations can read the
+// hospital's time zone with current_setting('hospital.timezone').
+func RunMigrations(databaseURL string, loc *time.Location) error {
+	u, err := url.Parse(databaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse database url: %w", err)
+	}
+	q := u.Query()
+	q.Set("options", strings.TrimSpace(q.Get("options")+" -c hospital.timezone="+loc.String()))
+	u.RawQuery = q.Encode()
+
 	m, err := migrate.New(
 		"file://internal/database/migrations",
-		databaseURL,
+		u.String(),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create migrate instance: %w", err)
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS audit_log_no_update ON audit_log;
+DROP FUNCTION IF EXISTS audit_log_immutable();
+
+DROP INDEX IF EXISTS idx_audit_log_occurred_at;
+DROP INDEX IF EXISTS idx_audit_log_actor_id;
+DROP INDEX IF EXISTS idx_audit_log_patient_id;
+
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    actor_id INTEGER REFERENCES users(id),
+    actor_role VARCHAR(50) NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('read', 'list', 'search', 'create', 'update', 'delete')),
+    resource_type VARCHAR(50) NOT NULL,
+    resource_id INTEGER,
+    patient_id INTEGER,
+    changes JSONB,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    prev_hash CHAR(64) NOT NULL,
+    hash CHAR(64) NOT NULL UNIQUE
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_patient_id ON audit_log(patient_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_id ON audit_log(actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_occurred_at ON audit_log(occurred_at);
+
+-- the log is append-only: rows may never be changed or removed once written
+CREATE OR REPLACE FUNCTION audit_log_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_no_update
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_immutable();
@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS idx_patients_phone_bidx;
+DROP INDEX IF EXISTS idx_patients_email_bidx;
+
+ALTER TABLE patients
+    DROP COLUMN IF EXISTS phone_bidx,
+    DROP COLUMN IF EXISTS email_bidx;
+
+-- values written while encryption was enabled must be decrypted with
+-- `phikeys decrypt-all` before rolling back, or they will not fit
+ALTER TABLE patients
+    ALTER COLUMN email TYPE VARCHAR(255),
+    ALTER COLUMN phone TYPE VARCHAR(20),
+    ALTER COLUMN emergency_contact_name TYPE VARCHAR(100),
+    ALTER COLUMN emergency_contact_phone TYPE VARCHAR(20);
+
+DROP TABLE IF EXISTS encryption_keys;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS encryption_keys (
+    id SERIAL PRIMARY KEY,
+    wrapped_key BYTEA NOT NULL,
+    master_key_id VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    retired_at TIMESTAMP
+);
+
+-- ciphertext does not fit the original column widths
+ALTER TABLE patients
+    ALTER COLUMN email TYPE TEXT,
+    ALTER COLUMN phone TYPE TEXT,
+    ALTER COLUMN emergency_contact_name TYPE TEXT,
+    ALTER COLUMN emergency_contact_phone TYPE TEXT;
+
+ALTER TABLE patients
+    ADD COLUMN IF NOT EXISTS email_bidx VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS phone_bidx VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_patients_email_bidx ON patients(email_bidx);
+CREATE INDEX IF NOT EXISTS idx_patients_phone_bidx ON patients(phone_bidx);
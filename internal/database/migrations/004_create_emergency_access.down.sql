@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_emergency_access_review_status;
+DROP INDEX IF EXISTS idx_emergency_access_doctor_patient;
+
+DROP TABLE IF EXISTS emergency_access_grants;
+
+ALTER TABLE audit_log DROP CONSTRAINT IF EXISTS audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check
+    CHECK (action IN ('read', 'list', 'search', 'create', 'update', 'delete'));
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('receptionist', 'doctor'));
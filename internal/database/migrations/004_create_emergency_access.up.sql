@@ -0,0 +1,23 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('receptionist', 'doctor', 'compliance'));
+
+ALTER TABLE audit_log DROP CONSTRAINT IF EXISTS audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check
+    CHECK (action IN ('read', 'list', 'search', 'create', 'update', 'delete', 'emergency_access'));
+
+CREATE TABLE IF NOT EXISTS emergency_access_grants (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    doctor_id INTEGER NOT NULL REFERENCES users(id),
+    justification TEXT NOT NULL CHECK (length(trim(justification)) > 0),
+    granted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    review_status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (review_status IN ('pending', 'acknowledged', 'flagged')),
+    reviewed_by INTEGER REFERENCES users(id),
+    reviewed_at TIMESTAMPTZ,
+    review_notes TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_emergency_access_doctor_patient ON emergency_access_grants(doctor_id, patient_id, expires_at);
+CREATE INDEX IF NOT EXISTS idx_emergency_access_review_status ON emergency_access_grants(review_status);
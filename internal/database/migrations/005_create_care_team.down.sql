@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_appointments_doctor_patient;
+DROP INDEX IF EXISTS idx_care_team_members_doctor_id;
+
+DROP TABLE IF EXISTS care_team_members;
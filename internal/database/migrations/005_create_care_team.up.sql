@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS care_team_members (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    doctor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('assignment', 'referral')),
+    added_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (patient_id, doctor_id, source)
+);
+
+CREATE INDEX IF NOT EXISTS idx_care_team_members_doctor_id ON care_team_members(doctor_id);
+CREATE INDEX IF NOT EXISTS idx_appointments_doctor_patient ON appointments(doctor_id, patient_id);
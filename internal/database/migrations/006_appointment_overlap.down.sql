@@ -0,0 +1,4 @@
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_patient_no_overlap;
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_doctor_no_overlap;
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_end_after_start;
+ALTER TABLE appointments DROP COLUMN IF EXISTS end_time;
@@ -0,0 +1,52 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS end_time TIMESTAMP;
+UPDATE appointments SET end_time = appointment_date + INTERVAL '30 minutes' WHERE end_time IS NULL;
+ALTER TABLE appointments ALTER COLUMN end_time SET NOT NULL;
+ALTER TABLE appointments ADD CONSTRAINT appointments_end_after_start CHECK (end_time > appointment_date);
+
+-- Double bookings made before these constraints existed would stop them
+-- from being added. Of each overlapping pair, keep the appointment that
+-- starts first (lower id on a tie) and cancel the other, saying why in its
+-- notes. Pairs are resolved earliest first, so an appointment that only
+-- overlapped one that has been cancelled is kept.
+DO $$
+DECLARE
+    kept_id INTEGER;
+    cancelled_id INTEGER;
+BEGIN
+    LOOP
+        SELECT earlier.id, later.id INTO kept_id, cancelled_id
+        FROM appointments earlier
+        JOIN appointments later
+          ON (earlier.doctor_id = later.doctor_id OR earlier.patient_id = later.patient_id)
+         AND (earlier.appointment_date, earlier.id) < (later.appointment_date, later.id)
+         AND later.appointment_date < earlier.end_time
+        WHERE COALESCE(earlier.status, 'scheduled') NOT IN ('cancelled', 'no_show')
+          AND COALESCE(later.status, 'scheduled') NOT IN ('cancelled', 'no_show')
+        ORDER BY later.appointment_date, later.id, earlier.appointment_date, earlier.id
+        LIMIT 1;
+        EXIT WHEN NOT FOUND;
+
+        UPDATE appointments
+        SET status = 'cancelled',
+            notes = concat_ws(E'\n', notes, format('Cancelled when double bookings were disallowed: overlapped appointment %s.', kept_id)),
+            updated_at = NOW()
+        WHERE id = cancelled_id;
+        RAISE NOTICE 'cancelled appointment % (overlapped appointment %)', cancelled_id, kept_id;
+    END LOOP;
+END $$;
+
+-- Ranges are half-open, so back-to-back appointments do not overlap.
+-- Cancelled appointments and no-shows free their slot.
+ALTER TABLE appointments ADD CONSTRAINT appointments_doctor_no_overlap
+    EXCLUDE USING gist (
+        doctor_id WITH =,
+        tstzrange(appointment_date AT TIME ZONE 'UTC', end_time AT TIME ZONE 'UTC', '[)') WITH &&
+    ) WHERE (doctor_id IS NOT NULL AND COALESCE(status, 'scheduled') NOT IN ('cancelled', 'no_show'));
+
+ALTER TABLE appointments ADD CONSTRAINT appointments_patient_no_overlap
+    EXCLUDE USING gist (
+        patient_id WITH =,
+        tstzrange(appointment_date AT TIME ZONE 'UTC', end_time AT TIME ZONE 'UTC', '[)') WITH &&
+    ) WHERE (patient_id IS NOT NULL AND COALESCE(status, 'scheduled') NOT IN ('cancelled', 'no_show'));
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS availability_exceptions;
+DROP TABLE IF EXISTS availability_breaks;
+DROP TABLE IF EXISTS availability_templates;
+DROP TABLE IF EXISTS doctor_profiles;
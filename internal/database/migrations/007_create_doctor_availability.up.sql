@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS doctor_profiles (
+    doctor_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    specialty VARCHAR(100),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_doctor_profiles_specialty ON doctor_profiles(LOWER(specialty));
+
+-- Weekly working hours. Times are wall-clock times at the hospital; a
+-- doctor may have several windows on the same weekday (split shifts).
+CREATE TABLE IF NOT EXISTS availability_templates (
+    id SERIAL PRIMARY KEY,
+    doctor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    slot_minutes INTEGER NOT NULL DEFAULT 30 CHECK (slot_minutes BETWEEN 5 AND 480),
+    CHECK (end_time > start_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_availability_templates_doctor_id ON availability_templates(doctor_id);
+
+CREATE TABLE IF NOT EXISTS availability_breaks (
+    id SERIAL PRIMARY KEY,
+    template_id INTEGER NOT NULL REFERENCES availability_templates(id) ON DELETE CASCADE,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    CHECK (end_time > start_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_availability_breaks_template_id ON availability_breaks(template_id);
+
+-- Leave, holidays and other one-off absences that override the template.
+CREATE TABLE IF NOT EXISTS availability_exceptions (
+    id SERIAL PRIMARY KEY,
+    doctor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('leave', 'holiday', 'other')),
+    reason TEXT,
+    created_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_availability_exceptions_doctor_range ON availability_exceptions(doctor_id, starts_at, ends_at);
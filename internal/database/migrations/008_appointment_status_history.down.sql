@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS appointment_status_history;
+
+UPDATE appointments SET status = 'scheduled' WHERE status IN ('checked_in', 'in_progress');
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_status_check;
+ALTER TABLE appointments ADD CONSTRAINT appointments_status_check
+    CHECK (status IN ('scheduled', 'completed', 'cancelled', 'no_show'));
@@ -0,0 +1,20 @@
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_status_check;
+ALTER TABLE appointments ADD CONSTRAINT appointments_status_check
+    CHECK (status IN ('scheduled', 'checked_in', 'in_progress', 'completed', 'cancelled', 'no_show'));
+
+CREATE TABLE IF NOT EXISTS appointment_status_history (
+    id SERIAL PRIMARY KEY,
+    appointment_id INTEGER NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT,
+    changed_by INTEGER REFERENCES users(id),
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_appointment_status_history_appointment_id ON appointment_status_history(appointment_id, changed_at);
+
+-- Seed the history with each existing appointment's current status.
+INSERT INTO appointment_status_history (appointment_id, from_status, to_status, changed_by, changed_at)
+SELECT id, NULL, COALESCE(status, 'scheduled'), created_by, COALESCE(created_at, NOW())
+FROM appointments;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS appointment_series (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    doctor_id INTEGER REFERENCES users(id),
+    rrule TEXT NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    duration_minutes INTEGER NOT NULL CHECK (duration_minutes > 0),
+    notes TEXT,
+    created_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    cancelled_at TIMESTAMPTZ
+);
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS series_id INTEGER REFERENCES appointment_series(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_appointments_series_id ON appointments(series_id, appointment_date);
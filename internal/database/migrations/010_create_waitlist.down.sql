@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS waitlist_holds;
+DROP TABLE IF EXISTS waitlist_entries;
@@ -0,0 +1,40 @@
+-- Patients waiting for an earlier appointment with a doctor, or with any
+-- doctor of a specialty, somewhere in [earliest, latest].
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    doctor_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    specialty VARCHAR(100),
+    earliest TIMESTAMP NOT NULL,
+    latest TIMESTAMP NOT NULL,
+    duration_minutes INTEGER NOT NULL DEFAULT 30 CHECK (duration_minutes > 0),
+    notes TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'offered', 'booked', 'cancelled')),
+    created_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (doctor_id IS NOT NULL OR specialty IS NOT NULL),
+    CHECK (latest > earliest)
+);
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_waiting ON waitlist_entries(created_at) WHERE status = 'waiting';
+
+-- A freed slot tentatively held for one waitlist entry until expires_at.
+-- slot_ends_at is the end of the freed time, which may be longer than the
+-- held appointment, so the slot can be offered on if the hold lapses.
+CREATE TABLE IF NOT EXISTS waitlist_holds (
+    id SERIAL PRIMARY KEY,
+    entry_id INTEGER NOT NULL REFERENCES waitlist_entries(id) ON DELETE CASCADE,
+    doctor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    slot_ends_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'declined', 'expired')),
+    expires_at TIMESTAMPTZ NOT NULL,
+    appointment_id INTEGER REFERENCES appointments(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+    CHECK (ends_at > starts_at AND slot_ends_at >= ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_holds_entry_id ON waitlist_holds(entry_id);
+CREATE INDEX IF NOT EXISTS idx_waitlist_holds_pending ON waitlist_holds(doctor_id, starts_at) WHERE status = 'pending';
@@ -0,0 +1,61 @@
+-- Message texts per template, channel and language, as Go text/template
+-- sources. subject is ignored for SMS.
+CREATE TABLE IF NOT EXISTS notification_templates (
+    template VARCHAR(50) NOT NULL,
+    channel VARCHAR(10) NOT NULL CHECK (channel IN ('email', 'sms')),
+    language VARCHAR(10) NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (template, channel, language)
+);
+
+INSERT INTO notification_templates (template, channel, language, subject, body) VALUES
+('appointment_reminder', 'email', 'en',
+ 'Reminder: your appointment on {{.Date}}',
+ 'Dear {{.FirstName}},
+
+This is a reminder of your appointment{{if .DoctorName}} with {{.DoctorName}}{{end}} on {{.Date}} at {{.Time}}.
+
+If you cannot attend, please call us to reschedule.'),
+('appointment_reminder', 'sms', 'en', '',
+ 'Reminder: appointment{{if .DoctorName}} with {{.DoctorName}}{{end}} on {{.Date}} at {{.Time}}. Call us if you cannot attend.'),
+('appointment_reminder', 'email', 'es',
+ 'Recordatorio: su cita el {{.Date}}',
+ 'Estimado/a {{.FirstName}}:
+
+Le recordamos su cita{{if .DoctorName}} con {{.DoctorName}}{{end}} el {{.Date}} a las {{.Time}}.
+
+Si no puede asistir, llámenos para cambiarla.'),
+('appointment_reminder', 'sms', 'es', '',
+ 'Recordatorio: cita{{if .DoctorName}} con {{.DoctorName}}{{end}} el {{.Date}} a las {{.Time}}. Llámenos si no puede asistir.')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    patient_id INTEGER PRIMARY KEY REFERENCES patients(id) ON DELETE CASCADE,
+    language VARCHAR(10) NOT NULL DEFAULT 'en',
+    email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    sms_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Transactional outbox: rows are written in the same transaction as the
+-- appointment change that causes them and delivered by a background
+-- worker. locked_until leases a row to one worker while it is sent.
+CREATE TABLE IF NOT EXISTS notification_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    appointment_id INTEGER NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    template VARCHAR(50) NOT NULL,
+    channel VARCHAR(10) NOT NULL CHECK (channel IN ('email', 'sms')),
+    send_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed', 'skipped', 'cancelled')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    locked_until TIMESTAMPTZ,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_outbox_pending
+    ON notification_outbox(appointment_id, template, channel, send_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_notification_outbox_due ON notification_outbox(send_at) WHERE status = 'pending';
@@ -0,0 +1,12 @@
+-- Only a hash of each token is stored, so the feed URLs cannot be
+-- recovered from a copy of the database.
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+    id SERIAL PRIMARY KEY,
+    doctor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash BYTEA NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_calendar_tokens_doctor_id ON calendar_tokens(doctor_id);
@@ -0,0 +1,51 @@
+ALTER TABLE encryption_keys
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN retired_at TYPE TIMESTAMP USING retired_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE patients
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE notification_outbox
+    ALTER COLUMN send_at TYPE TIMESTAMP USING send_at AT TIME ZONE current_setting('hospital.timezone');
+
+ALTER TABLE waitlist_holds
+    ALTER COLUMN starts_at TYPE TIMESTAMP USING starts_at AT TIME ZONE current_setting('hospital.timezone'),
+    ALTER COLUMN ends_at TYPE TIMESTAMP USING ends_at AT TIME ZONE current_setting('hospital.timezone'),
+    ALTER COLUMN slot_ends_at TYPE TIMESTAMP USING slot_ends_at AT TIME ZONE current_setting('hospital.timezone');
+
+ALTER TABLE waitlist_entries
+    ALTER COLUMN earliest TYPE TIMESTAMP USING earliest AT TIME ZONE current_setting('hospital.timezone'),
+    ALTER COLUMN latest TYPE TIMESTAMP USING latest AT TIME ZONE current_setting('hospital.timezone');
+
+ALTER TABLE appointment_series
+    ALTER COLUMN starts_at TYPE TIMESTAMP USING starts_at AT TIME ZONE current_setting('hospital.timezone');
+
+ALTER TABLE availability_exceptions
+    ALTER COLUMN starts_at TYPE TIMESTAMP USING starts_at AT TIME ZONE current_setting('hospital.timezone'),
+    ALTER COLUMN ends_at TYPE TIMESTAMP USING ends_at AT TIME ZONE current_setting('hospital.timezone');
+
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_patient_no_overlap;
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_doctor_no_overlap;
+
+ALTER TABLE appointments
+    ALTER COLUMN appointment_date TYPE TIMESTAMP USING appointment_date AT TIME ZONE current_setting('hospital.timezone'),
+    ALTER COLUMN end_time TYPE TIMESTAMP USING end_time AT TIME ZONE current_setting('hospital.timezone'),
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE appointments ADD CONSTRAINT appointments_doctor_no_overlap
+    EXCLUDE USING gist (
+        doctor_id WITH =,
+        tstzrange(appointment_date AT TIME ZONE 'UTC', end_time AT TIME ZONE 'UTC', '[)') WITH &&
+    ) WHERE (doctor_id IS NOT NULL AND COALESCE(status, 'scheduled') NOT IN ('cancelled', 'no_show'));
+
+ALTER TABLE appointments ADD CONSTRAINT appointments_patient_no_overlap
+    EXCLUDE USING gist (
+        patient_id WITH =,
+        tstzrange(appointment_date AT TIME ZONE 'UTC', end_time AT TIME ZONE 'UTC', '[)') WITH &&
+    ) WHERE (patient_id IS NOT NULL AND COALESCE(status, 'scheduled') NOT IN ('cancelled', 'no_show'));
@@ -0,0 +1,62 @@
+-- Appointment times were stored as naive wall-clock times, entered in the
+-- hospital's time zone. RunMigrations sets hospital.timezone to
+-- HOSPITAL_TIMEZONE so they can be read as such. PostgreSQL reads a time
+-- that falls in a spring-forward gap with the offset from before the
+-- change, and a repeated fall-back time with the offset from after it.
+--
+-- created_at and updated_at were filled by NOW(), which wrote the time in
+-- the database server's own zone, so they are read in that zone instead.
+
+-- The overlap constraints index an expression on these columns and must
+-- be rebuilt for the new type.
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_doctor_no_overlap;
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_patient_no_overlap;
+
+ALTER TABLE appointments
+    ALTER COLUMN appointment_date TYPE TIMESTAMPTZ USING appointment_date AT TIME ZONE current_setting('hospital.timezone'),
+    ALTER COLUMN end_time TYPE TIMESTAMPTZ USING end_time AT TIME ZONE current_setting('hospital.timezone'),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE appointments ADD CONSTRAINT appointments_doctor_no_overlap
+    EXCLUDE USING gist (
+        doctor_id WITH =,
+        tstzrange(appointment_date, end_time, '[)') WITH &&
+    ) WHERE (doctor_id IS NOT NULL AND COALESCE(status, 'scheduled') NOT IN ('cancelled', 'no_show'));
+
+ALTER TABLE appointments ADD CONSTRAINT appointments_patient_no_overlap
+    EXCLUDE USING gist (
+        patient_id WITH =,
+        tstzrange(appointment_date, end_time, '[)') WITH &&
+    ) WHERE (patient_id IS NOT NULL AND COALESCE(status, 'scheduled') NOT IN ('cancelled', 'no_show'));
+
+ALTER TABLE availability_exceptions
+    ALTER COLUMN starts_at TYPE TIMESTAMPTZ USING starts_at AT TIME ZONE current_setting('hospital.timezone'),
+    ALTER COLUMN ends_at TYPE TIMESTAMPTZ USING ends_at AT TIME ZONE current_setting('hospital.timezone');
+
+ALTER TABLE appointment_series
+    ALTER COLUMN starts_at TYPE TIMESTAMPTZ USING starts_at AT TIME ZONE current_setting('hospital.timezone');
+
+ALTER TABLE waitlist_entries
+    ALTER COLUMN earliest TYPE TIMESTAMPTZ USING earliest AT TIME ZONE current_setting('hospital.timezone'),
+    ALTER COLUMN latest TYPE TIMESTAMPTZ USING latest AT TIME ZONE current_setting('hospital.timezone');
+
+ALTER TABLE waitlist_holds
+    ALTER COLUMN starts_at TYPE TIMESTAMPTZ USING starts_at AT TIME ZONE current_setting('hospital.timezone'),
+    ALTER COLUMN ends_at TYPE TIMESTAMPTZ USING ends_at AT TIME ZONE current_setting('hospital.timezone'),
+    ALTER COLUMN slot_ends_at TYPE TIMESTAMPTZ USING slot_ends_at AT TIME ZONE current_setting('hospital.timezone');
+
+ALTER TABLE notification_outbox
+    ALTER COLUMN send_at TYPE TIMESTAMPTZ USING send_at AT TIME ZONE current_setting('hospital.timezone');
+
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE patients
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE encryption_keys
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN retired_at TYPE TIMESTAMPTZ USING retired_at AT TIME ZONE current_setting('TimeZone');
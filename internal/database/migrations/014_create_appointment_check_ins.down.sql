@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS appointments_queue_changed ON appointments;
+DROP TABLE IF EXISTS appointment_check_ins;
+DROP FUNCTION IF EXISTS notify_queue_changed();
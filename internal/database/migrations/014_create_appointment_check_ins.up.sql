@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS appointment_check_ins (
+    appointment_id INTEGER PRIMARY KEY REFERENCES appointments(id) ON DELETE CASCADE,
+    arrived_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    priority VARCHAR(20) NOT NULL DEFAULT 'routine'
+        CHECK (priority IN ('routine', 'urgent', 'emergency')),
+    checked_in_by INTEGER REFERENCES users(id)
+);
+
+-- Listeners on queue_changed (the live check-in queue) are told whenever
+-- an appointment or check-in changes, whichever replica made the change.
+CREATE OR REPLACE FUNCTION notify_queue_changed() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('queue_changed', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER appointments_queue_changed
+    AFTER INSERT OR UPDATE OR DELETE ON appointments
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_queue_changed();
+
+CREATE TRIGGER appointment_check_ins_queue_changed
+    AFTER INSERT OR UPDATE OR DELETE ON appointment_check_ins
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_queue_changed();
@@ -0,0 +1,2 @@
+DROP MATERIALIZED VIEW IF EXISTS appointment_daily_stats;
+DROP TABLE IF EXISTS job_runs;
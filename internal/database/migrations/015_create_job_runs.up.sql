@@ -0,0 +1,31 @@
+-- One row per run of a scheduled job. The unique key stops two replicas
+-- from both running a job for the same scheduled time.
+CREATE TABLE IF NOT EXISTS job_runs (
+    id BIGSERIAL PRIMARY KEY,
+    job_name VARCHAR(100) NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ,
+    status VARCHAR(20) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'succeeded', 'failed')),
+    detail TEXT,
+    error TEXT,
+    UNIQUE (job_name, scheduled_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_started_at ON job_runs(job_name, started_at DESC);
+
+-- Appointment counts per day, doctor and status, refreshed by the
+-- refresh_statistics job. Days are calendar days in the hospital's time
+-- zone, whichever zone the refreshing session runs in.
+CREATE MATERIALIZED VIEW IF NOT EXISTS appointment_daily_stats AS
+SELECT
+    (appointment_date AT TIME ZONE current_setting('hospital.timezone'))::date AS day,
+    doctor_id,
+    COALESCE(status, 'scheduled') AS status,
+    COUNT(*) AS appointments
+FROM appointments
+GROUP BY 1, 2, 3;
+
+-- A unique index lets the view be refreshed without blocking readers.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_appointment_daily_stats
+    ON appointment_daily_stats(day, doctor_id, status);
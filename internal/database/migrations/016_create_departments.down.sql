@@ -0,0 +1,9 @@
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_room_no_overlap;
+ALTER TABLE appointments DROP COLUMN IF EXISTS room_id, DROP COLUMN IF EXISTS department_id;
+DROP TABLE IF EXISTS equipment_bookings;
+DROP TABLE IF EXISTS equipment;
+DROP TABLE IF EXISTS rooms;
+DROP TABLE IF EXISTS doctor_specialties;
+DROP TABLE IF EXISTS specialties;
+DROP TABLE IF EXISTS department_doctors;
+DROP TABLE IF EXISTS departments;
@@ -0,0 +1,90 @@
+CREATE TABLE IF NOT EXISTS departments (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS department_doctors (
+    department_id INTEGER NOT NULL REFERENCES departments(id) ON DELETE CASCADE,
+    doctor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (department_id, doctor_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_department_doctors_doctor_id ON department_doctors(doctor_id);
+
+CREATE TABLE IF NOT EXISTS specialties (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    department_id INTEGER REFERENCES departments(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_specialties_name ON specialties(LOWER(name));
+
+CREATE TABLE IF NOT EXISTS doctor_specialties (
+    doctor_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    specialty_id INTEGER NOT NULL REFERENCES specialties(id) ON DELETE CASCADE,
+    PRIMARY KEY (doctor_id, specialty_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_doctor_specialties_specialty_id ON doctor_specialties(specialty_id);
+
+-- The free-text specialty of each doctor's profile becomes a specialty
+-- the doctor has.
+INSERT INTO specialties (name)
+SELECT DISTINCT ON (LOWER(specialty)) specialty
+FROM doctor_profiles
+WHERE specialty IS NOT NULL AND specialty <> ''
+ORDER BY LOWER(specialty), specialty
+ON CONFLICT DO NOTHING;
+
+INSERT INTO doctor_specialties (doctor_id, specialty_id)
+SELECT p.doctor_id, s.id
+FROM doctor_profiles p
+JOIN specialties s ON LOWER(s.name) = LOWER(p.specialty)
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS rooms (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    department_id INTEGER REFERENCES departments(id) ON DELETE SET NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE TABLE IF NOT EXISTS equipment (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    department_id INTEGER REFERENCES departments(id) ON DELETE SET NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+-- A booking may be for an appointment, in which case it follows the
+-- appointment when it moves and is released when it is cancelled.
+CREATE TABLE IF NOT EXISTS equipment_bookings (
+    id SERIAL PRIMARY KEY,
+    equipment_id INTEGER NOT NULL REFERENCES equipment(id) ON DELETE CASCADE,
+    appointment_id INTEGER REFERENCES appointments(id) ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    booked_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at),
+    CONSTRAINT equipment_bookings_no_overlap EXCLUDE USING gist (
+        equipment_id WITH =,
+        tstzrange(starts_at, ends_at, '[)') WITH &&
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_equipment_bookings_appointment_id ON equipment_bookings(appointment_id);
+
+ALTER TABLE appointments
+    ADD COLUMN IF NOT EXISTS department_id INTEGER REFERENCES departments(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS room_id INTEGER REFERENCES rooms(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_appointments_department_id ON appointments(department_id);
+
+ALTER TABLE appointments ADD CONSTRAINT appointments_room_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        tstzrange(appointment_date, end_time, '[)') WITH &&
+    ) WHERE (room_id IS NOT NULL AND COALESCE(status, 'scheduled') NOT IN ('cancelled', 'no_show'));
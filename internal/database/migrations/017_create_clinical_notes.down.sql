@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS clinical_note_addenda;
+DROP TABLE IF EXISTS clinical_note_versions;
+DROP TABLE IF EXISTS clinical_notes;
+DROP FUNCTION IF EXISTS lock_signed_clinical_note();
+DROP FUNCTION IF EXISTS forbid_clinical_note_rewrite();
@@ -0,0 +1,105 @@
+-- A clinical note is a SOAP note, usually the encounter note of one
+-- appointment. Its content lives in append-only versions; signing the
+-- note locks it, and anything written afterwards is an addendum.
+CREATE TABLE IF NOT EXISTS clinical_notes (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    appointment_id INTEGER REFERENCES appointments(id) ON DELETE SET NULL,
+    -- NULL only for notes carried over from appointments with neither a
+    -- doctor nor a creator.
+    author_id INTEGER REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'signed')),
+    current_version INTEGER NOT NULL DEFAULT 1,
+    signed_by INTEGER REFERENCES users(id),
+    signed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_clinical_notes_patient_id ON clinical_notes(patient_id, created_at DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_clinical_notes_appointment_id ON clinical_notes(appointment_id);
+
+CREATE TABLE IF NOT EXISTS clinical_note_versions (
+    id SERIAL PRIMARY KEY,
+    note_id INTEGER NOT NULL REFERENCES clinical_notes(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    subjective TEXT,
+    objective TEXT,
+    assessment TEXT,
+    plan TEXT,
+    created_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (note_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS clinical_note_addenda (
+    id SERIAL PRIMARY KEY,
+    note_id INTEGER NOT NULL REFERENCES clinical_notes(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_clinical_note_addenda_note_id ON clinical_note_addenda(note_id);
+
+-- Versions and addenda are never changed. They are only deleted along
+-- with their note, which happens when the patient is deleted.
+CREATE OR REPLACE FUNCTION forbid_clinical_note_rewrite() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND NOT EXISTS (SELECT 1 FROM clinical_notes WHERE id = OLD.note_id) THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION '% rows cannot be changed', TG_TABLE_NAME
+        USING ERRCODE = 'integrity_constraint_violation';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER clinical_note_versions_append_only
+    BEFORE UPDATE OR DELETE ON clinical_note_versions
+    FOR EACH ROW EXECUTE FUNCTION forbid_clinical_note_rewrite();
+
+CREATE TRIGGER clinical_note_addenda_append_only
+    BEFORE UPDATE OR DELETE ON clinical_note_addenda
+    FOR EACH ROW EXECUTE FUNCTION forbid_clinical_note_rewrite();
+
+-- A signed note takes no new versions and keeps its signature. Only the
+-- link to a deleted appointment may still be cleared.
+CREATE OR REPLACE FUNCTION lock_signed_clinical_note() RETURNS trigger AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'clinical_note_versions' THEN
+        IF EXISTS (SELECT 1 FROM clinical_notes WHERE id = NEW.note_id AND status = 'signed') THEN
+            RAISE EXCEPTION 'clinical note % is signed', NEW.note_id
+                USING ERRCODE = 'integrity_constraint_violation';
+        END IF;
+    ELSIF OLD.status = 'signed'
+        AND (NEW.status, NEW.current_version, NEW.signed_by, NEW.signed_at, NEW.patient_id, NEW.author_id)
+            IS DISTINCT FROM (OLD.status, OLD.current_version, OLD.signed_by, OLD.signed_at, OLD.patient_id, OLD.author_id) THEN
+        RAISE EXCEPTION 'clinical note % is signed', OLD.id
+            USING ERRCODE = 'integrity_constraint_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER clinical_notes_lock_signed
+    BEFORE UPDATE ON clinical_notes
+    FOR EACH ROW EXECUTE FUNCTION lock_signed_clinical_note();
+
+CREATE TRIGGER clinical_note_versions_lock_signed
+    BEFORE INSERT ON clinical_note_versions
+    FOR EACH ROW EXECUTE FUNCTION lock_signed_clinical_note();
+
+-- Diagnoses and treatment plans recorded on appointments become the first
+-- version of each appointment's note, as its assessment and plan. The
+-- appointment columns stay as a copy of the latest version.
+WITH moved AS (
+    INSERT INTO clinical_notes (patient_id, appointment_id, author_id, created_at, updated_at)
+    SELECT patient_id, id, COALESCE(doctor_id, created_by), COALESCE(updated_at, NOW()), COALESCE(updated_at, NOW())
+    FROM appointments
+    WHERE patient_id IS NOT NULL AND (diagnosis IS NOT NULL OR treatment_plan IS NOT NULL)
+    RETURNING id, appointment_id, author_id, created_at
+)
+INSERT INTO clinical_note_versions (note_id, version, assessment, plan, created_by, created_at)
+SELECT m.id, 1, a.diagnosis, a.treatment_plan, m.author_id, m.created_at
+FROM moved m
+JOIN appointments a ON a.id = m.appointment_id;
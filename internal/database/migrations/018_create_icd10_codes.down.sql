@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS encounter_diagnoses;
+DROP TABLE IF EXISTS icd10_codes;
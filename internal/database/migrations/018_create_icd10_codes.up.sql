@@ -0,0 +1,32 @@
+-- The ICD-10-CM catalog, loaded from the CMS release files with
+-- cmd/icd10import. Codes dropped from a later release are kept, inactive,
+-- so diagnoses already recorded with them stay valid.
+CREATE TABLE IF NOT EXISTS icd10_codes (
+    code VARCHAR(8) PRIMARY KEY,
+    description TEXT NOT NULL,
+    billable BOOLEAN NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    search TSVECTOR GENERATED ALWAYS AS (to_tsvector('english', description)) STORED
+);
+
+CREATE INDEX IF NOT EXISTS idx_icd10_codes_code_prefix ON icd10_codes(code text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_icd10_codes_search ON icd10_codes USING GIN (search);
+
+-- A diagnosis made at an encounter. An appointment has at most one
+-- primary diagnosis.
+CREATE TABLE IF NOT EXISTS encounter_diagnoses (
+    id SERIAL PRIMARY KEY,
+    appointment_id INTEGER NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    patient_id INTEGER NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    code VARCHAR(8) NOT NULL REFERENCES icd10_codes(code),
+    rank VARCHAR(20) NOT NULL DEFAULT 'secondary' CHECK (rank IN ('primary', 'secondary')),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'resolved', 'ruled_out')),
+    notes TEXT,
+    recorded_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (appointment_id, code)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_encounter_diagnoses_primary ON encounter_diagnoses(appointment_id) WHERE rank = 'primary';
+CREATE INDEX IF NOT EXISTS idx_encounter_diagnoses_patient_id ON encounter_diagnoses(patient_id, code);
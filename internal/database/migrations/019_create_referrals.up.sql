@@ -0,0 +1,31 @@
+-- A referral sends a patient from one doctor to another doctor or to a
+-- department. It moves sent -> accepted -> scheduled -> completed, or
+-- from sent to declined. Accepting puts the receiving doctor on the
+-- patient's care team; booking from the referral schedules it, and the
+-- appointment's outcome completes it or makes it bookable again.
+CREATE TABLE IF NOT EXISTS referrals (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    source_doctor_id INTEGER NOT NULL REFERENCES users(id),
+    target_doctor_id INTEGER REFERENCES users(id),
+    target_department_id INTEGER REFERENCES departments(id),
+    reason TEXT NOT NULL,
+    urgency VARCHAR(20) NOT NULL DEFAULT 'routine' CHECK (urgency IN ('routine', 'urgent', 'emergency')),
+    clinical_context TEXT,
+    note_id INTEGER REFERENCES clinical_notes(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'sent' CHECK (status IN ('sent', 'accepted', 'declined', 'scheduled', 'completed')),
+    responded_by INTEGER REFERENCES users(id),
+    responded_at TIMESTAMPTZ,
+    decline_reason TEXT,
+    appointment_id INTEGER REFERENCES appointments(id) ON DELETE SET NULL,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (target_doctor_id IS NOT NULL OR target_department_id IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_referrals_patient_id ON referrals(patient_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_referrals_source_doctor_id ON referrals(source_doctor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_referrals_target_doctor_id ON referrals(target_doctor_id, status);
+CREATE INDEX IF NOT EXISTS idx_referrals_target_department_id ON referrals(target_department_id, status) WHERE target_doctor_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_referrals_appointment_id ON referrals(appointment_id);
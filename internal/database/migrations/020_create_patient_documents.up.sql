@@ -0,0 +1,21 @@
+-- Files attached to a patient's record: scanned letters, ID documents,
+-- reports. The bytes live in the configured blob store under a key derived
+-- from their SHA-256, so identical files are stored once; a patient
+-- cannot hold the same file twice.
+CREATE TABLE IF NOT EXISTS patient_documents (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    category VARCHAR(30) NOT NULL CHECK (category IN ('referral_letter', 'id_document', 'imaging_report', 'lab_report', 'consent_form', 'correspondence', 'other')),
+    description TEXT,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    sha256 CHAR(64) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    uploaded_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (patient_id, sha256)
+);
+
+CREATE INDEX IF NOT EXISTS idx_patient_documents_patient_id ON patient_documents(patient_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_patient_documents_sha256 ON patient_documents(sha256);
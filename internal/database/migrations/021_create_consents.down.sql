@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS patient_consents;
+DROP TABLE IF EXISTS consent_templates;
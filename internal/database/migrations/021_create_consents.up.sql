@@ -0,0 +1,51 @@
+-- Consent templates hold the wording a patient agrees to, versioned per
+-- purpose. Publishing new wording adds the next version; consent is only
+-- recorded against the current one, and earlier versions are kept so
+-- that every record shows exactly what was signed.
+CREATE TABLE IF NOT EXISTS consent_templates (
+    id SERIAL PRIMARY KEY,
+    purpose VARCHAR(30) NOT NULL CHECK (purpose IN ('treatment', 'data_sharing', 'sms_reminders')),
+    version INTEGER NOT NULL CHECK (version > 0),
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    validity_days INTEGER CHECK (validity_days > 0),
+    created_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (purpose, version),
+    UNIQUE (id, purpose)
+);
+
+INSERT INTO consent_templates (purpose, version, title, body) VALUES
+('treatment', 1, 'Consent to examination and treatment',
+ 'I agree to be examined and treated by the hospital''s clinical staff. The proposed treatment, its benefits, risks and alternatives have been explained to me, and I understand that I may withdraw this consent at any time.'),
+('data_sharing', 1, 'Consent to share my records with partner organisations',
+ 'I agree that the hospital may share my medical records with the partner organisations it works with for my care. I understand that I may withdraw this consent at any time, after which no further records will be shared.'),
+('sms_reminders', 1, 'Consent to appointment reminders by text message',
+ 'I agree to receive reminders of my appointments by text message at the mobile number I have given. Messages name the doctor and the time of the appointment. I may withdraw this consent at any time.')
+ON CONFLICT DO NOTHING;
+
+-- A patient's consent to one template version, signed by the patient or
+-- their representative. It is in force until it expires or is revoked.
+-- Recording a new consent for a purpose revokes the previous one, so at
+-- most one per purpose is unrevoked.
+CREATE TABLE IF NOT EXISTS patient_consents (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    template_id INTEGER NOT NULL,
+    purpose VARCHAR(30) NOT NULL,
+    signed_by VARCHAR(200) NOT NULL,
+    signed_at TIMESTAMPTZ NOT NULL,
+    witness_name VARCHAR(200),
+    recorded_by INTEGER REFERENCES users(id),
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    revoked_by INTEGER REFERENCES users(id),
+    revocation_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (template_id, purpose) REFERENCES consent_templates(id, purpose),
+    CHECK (expires_at IS NULL OR expires_at > signed_at),
+    CHECK ((revoked_at IS NULL) = (revocation_reason IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_patient_consents_patient_id ON patient_consents(patient_id, created_at DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_patient_consents_unrevoked ON patient_consents(patient_id, purpose) WHERE revoked_at IS NULL;
@@ -0,0 +1,92 @@
+-- The price list. Money is held in integer minor units of the billing
+-- currency (cents for USD) and tax rates in basis points, so 7.5% is 750.
+-- Invoice lines copy the price and rate, so changing the catalog never
+-- alters an invoice already raised.
+CREATE TABLE IF NOT EXISTS billable_services (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(30) NOT NULL UNIQUE,
+    name VARCHAR(200) NOT NULL,
+    description TEXT,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('consultation', 'procedure', 'lab', 'imaging', 'medication', 'other')),
+    department_id INTEGER REFERENCES departments(id) ON DELETE SET NULL,
+    price_minor BIGINT NOT NULL CHECK (price_minor >= 0),
+    tax_rate_bp INTEGER NOT NULL DEFAULT 0 CHECK (tax_rate_bp BETWEEN 0 AND 10000),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_billable_services_consultation ON billable_services(department_id) WHERE category = 'consultation' AND active;
+
+-- An invoice is drafted, usually from a completed appointment, then
+-- issued, after which only payments change it. Its status follows the
+-- payments: issued, partially_paid, paid. A draft, or an issued invoice
+-- with nothing paid on it, can be voided. The totals are the sums of the
+-- lines and paid_minor is payments less refunds. Invoices are financial
+-- records, so a patient who has any cannot be deleted.
+CREATE TABLE IF NOT EXISTS invoices (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL REFERENCES patients(id) ON DELETE RESTRICT,
+    appointment_id INTEGER REFERENCES appointments(id) ON DELETE SET NULL,
+    currency CHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'issued', 'partially_paid', 'paid', 'void')),
+    subtotal_minor BIGINT NOT NULL DEFAULT 0,
+    discount_minor BIGINT NOT NULL DEFAULT 0,
+    tax_minor BIGINT NOT NULL DEFAULT 0,
+    total_minor BIGINT NOT NULL DEFAULT 0,
+    paid_minor BIGINT NOT NULL DEFAULT 0,
+    notes TEXT,
+    due_date DATE,
+    issued_at TIMESTAMPTZ,
+    voided_at TIMESTAMPTZ,
+    void_reason TEXT,
+    created_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (paid_minor BETWEEN 0 AND total_minor),
+    CHECK ((status = 'draft') = (issued_at IS NULL) OR status = 'void'),
+    CHECK ((status = 'void') = (voided_at IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoices_patient_id ON invoices(patient_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_invoices_open ON invoices(patient_id) WHERE status IN ('issued', 'partially_paid');
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_appointment_id ON invoices(appointment_id) WHERE status <> 'void';
+
+CREATE TABLE IF NOT EXISTS invoice_lines (
+    id SERIAL PRIMARY KEY,
+    invoice_id INTEGER NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    service_id INTEGER REFERENCES billable_services(id) ON DELETE SET NULL,
+    description VARCHAR(200) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price_minor BIGINT NOT NULL CHECK (unit_price_minor >= 0),
+    tax_rate_bp INTEGER NOT NULL CHECK (tax_rate_bp BETWEEN 0 AND 10000),
+    subtotal_minor BIGINT NOT NULL,
+    discount_minor BIGINT NOT NULL DEFAULT 0,
+    tax_minor BIGINT NOT NULL,
+    total_minor BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (discount_minor BETWEEN 0 AND subtotal_minor),
+    CHECK (total_minor = subtotal_minor - discount_minor + tax_minor)
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_invoice_id ON invoice_lines(invoice_id, id);
+
+-- Money received against an invoice, and money given back. A refund
+-- returns part or all of one earlier payment.
+CREATE TABLE IF NOT EXISTS payments (
+    id SERIAL PRIMARY KEY,
+    invoice_id INTEGER NOT NULL REFERENCES invoices(id),
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('payment', 'refund')),
+    method VARCHAR(20) NOT NULL CHECK (method IN ('cash', 'card', 'bank_transfer', 'cheque', 'insurance')),
+    amount_minor BIGINT NOT NULL CHECK (amount_minor > 0),
+    reference VARCHAR(100),
+    refund_of INTEGER REFERENCES payments(id),
+    reason TEXT,
+    received_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((kind = 'refund') = (refund_of IS NOT NULL)),
+    CHECK (kind = 'payment' OR reason IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_invoice_id ON payments(invoice_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_payments_refund_of ON payments(refund_of) WHERE refund_of IS NOT NULL;
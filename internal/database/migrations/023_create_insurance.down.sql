@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS claims;
+DROP TABLE IF EXISTS claim_batches;
+DROP TABLE IF EXISTS patient_coverages;
+DROP TABLE IF EXISTS insurance_payers;
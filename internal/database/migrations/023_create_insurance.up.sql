@@ -0,0 +1,96 @@
+-- Insurance companies and health plans that claims are sent to.
+-- payer_code is the identifier the clearinghouse knows the payer by.
+CREATE TABLE IF NOT EXISTS insurance_payers (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL UNIQUE,
+    payer_code VARCHAR(30) NOT NULL UNIQUE,
+    phone VARCHAR(30),
+    email VARCHAR(200),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A patient's insurance policy. Priority 1 is primary, 2 secondary and 3
+-- tertiary; a patient cannot have two policies with the same priority in
+-- force on the same day. valid_to is the last covered day, or NULL for
+-- open-ended cover. The patient pays copay_minor of each claim, then
+-- coinsurance_bp of the rest.
+CREATE TABLE IF NOT EXISTS patient_coverages (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL REFERENCES patients(id) ON DELETE CASCADE,
+    payer_id INTEGER NOT NULL REFERENCES insurance_payers(id),
+    priority SMALLINT NOT NULL CHECK (priority BETWEEN 1 AND 3),
+    policy_number VARCHAR(50) NOT NULL,
+    member_number VARCHAR(50) NOT NULL,
+    group_number VARCHAR(50),
+    subscriber_name VARCHAR(200),
+    relationship VARCHAR(10) NOT NULL DEFAULT 'self' CHECK (relationship IN ('self', 'spouse', 'child', 'other')),
+    valid_from DATE NOT NULL,
+    valid_to DATE,
+    copay_minor BIGINT NOT NULL DEFAULT 0 CHECK (copay_minor >= 0),
+    coinsurance_bp INTEGER NOT NULL DEFAULT 0 CHECK (coinsurance_bp BETWEEN 0 AND 10000),
+    created_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (valid_to IS NULL OR valid_to >= valid_from),
+    CHECK (relationship = 'self' OR subscriber_name IS NOT NULL),
+    CONSTRAINT patient_coverages_priority_no_overlap EXCLUDE USING gist (
+        patient_id WITH =,
+        priority WITH =,
+        daterange(valid_from, valid_to, '[]') WITH &&
+    )
+);
+
+-- Claims are sent to a payer in batches, each exported as one file.
+CREATE TABLE IF NOT EXISTS claim_batches (
+    id SERIAL PRIMARY KEY,
+    payer_id INTEGER NOT NULL REFERENCES insurance_payers(id),
+    created_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A claim asks one coverage's payer to pay its share of an invoice. It is
+-- drafted, submitted in a batch, then adjudicated, when the payer says
+-- how much it will pay, and finally paid; or it is denied. Drafts and
+-- submitted claims can be cancelled. billed_minor is the invoice total
+-- less what other claims on it expect, so a secondary claim bills what
+-- the primary left. payer_share_minor is what the payer is expected to
+-- pay, worked out from the copay and coinsurance copied from the
+-- coverage, and after adjudication what it agreed to.
+CREATE TABLE IF NOT EXISTS claims (
+    id SERIAL PRIMARY KEY,
+    invoice_id INTEGER NOT NULL REFERENCES invoices(id),
+    patient_id INTEGER NOT NULL REFERENCES patients(id),
+    coverage_id INTEGER NOT NULL REFERENCES patient_coverages(id),
+    payer_id INTEGER NOT NULL REFERENCES insurance_payers(id),
+    batch_id INTEGER REFERENCES claim_batches(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'submitted', 'adjudicated', 'paid', 'denied', 'cancelled')),
+    service_date DATE NOT NULL,
+    billed_minor BIGINT NOT NULL CHECK (billed_minor > 0),
+    copay_minor BIGINT NOT NULL,
+    coinsurance_bp INTEGER NOT NULL,
+    payer_share_minor BIGINT NOT NULL,
+    patient_share_minor BIGINT NOT NULL,
+    paid_minor BIGINT NOT NULL DEFAULT 0,
+    payment_id INTEGER REFERENCES payments(id),
+    payer_reference VARCHAR(50),
+    denial_reason TEXT,
+    submitted_at TIMESTAMPTZ,
+    adjudicated_at TIMESTAMPTZ,
+    closed_at TIMESTAMPTZ,
+    created_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (payer_share_minor >= 0 AND patient_share_minor >= 0),
+    CHECK (payer_share_minor + patient_share_minor = billed_minor),
+    CHECK (paid_minor BETWEEN 0 AND payer_share_minor),
+    CHECK ((status = 'paid') = (payment_id IS NOT NULL)),
+    CHECK (status <> 'denied' OR denial_reason IS NOT NULL),
+    CHECK ((status = 'draft') = (batch_id IS NULL) OR status = 'cancelled')
+);
+
+CREATE INDEX IF NOT EXISTS idx_claims_invoice_id ON claims(invoice_id);
+CREATE INDEX IF NOT EXISTS idx_claims_batch_id ON claims(batch_id);
+CREATE INDEX IF NOT EXISTS idx_claims_drafts ON claims(payer_id) WHERE status = 'draft';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_claims_open ON claims(invoice_id, coverage_id) WHERE status NOT IN ('denied', 'cancelled');
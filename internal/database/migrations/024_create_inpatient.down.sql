@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS bed_stays;
+DROP TABLE IF EXISTS admissions;
+DROP TABLE IF EXISTS beds;
+DROP TABLE IF EXISTS wards;
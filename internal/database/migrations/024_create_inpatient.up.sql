@@ -0,0 +1,65 @@
+-- Wards hold beds. A bed is free, occupied by an admitted patient, being
+-- cleaned after one left, or blocked (out of service). Only admissions
+-- make a bed occupied, and a bed only becomes occupied from free.
+CREATE TABLE IF NOT EXISTS wards (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    department_id INTEGER REFERENCES departments(id) ON DELETE SET NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS beds (
+    id SERIAL PRIMARY KEY,
+    ward_id INTEGER NOT NULL REFERENCES wards(id),
+    label VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'free' CHECK (status IN ('free', 'occupied', 'cleaning', 'blocked')),
+    status_note TEXT,
+    status_changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (ward_id, label)
+);
+
+-- An admission is a patient's inpatient stay, from admission to
+-- discharge. A patient has at most one admission open at a time.
+CREATE TABLE IF NOT EXISTS admissions (
+    id SERIAL PRIMARY KEY,
+    patient_id INTEGER NOT NULL REFERENCES patients(id),
+    attending_doctor_id INTEGER NOT NULL REFERENCES users(id),
+    appointment_id INTEGER REFERENCES appointments(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'admitted' CHECK (status IN ('admitted', 'discharged')),
+    admitted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    admitted_by INTEGER REFERENCES users(id),
+    discharged_at TIMESTAMPTZ,
+    discharged_by INTEGER REFERENCES users(id),
+    discharge_disposition VARCHAR(20) CHECK (discharge_disposition IN ('home', 'transferred_out', 'against_advice', 'deceased', 'other')),
+    discharge_notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((status = 'discharged') = (discharged_at IS NOT NULL AND discharge_disposition IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_admissions_open_patient ON admissions(patient_id) WHERE status = 'admitted';
+CREATE INDEX IF NOT EXISTS idx_admissions_patient_id ON admissions(patient_id, admitted_at DESC);
+CREATE INDEX IF NOT EXISTS idx_admissions_attending_doctor_id ON admissions(attending_doctor_id) WHERE status = 'admitted';
+
+-- bed_stays is where an admitted patient was, bed by bed. The stay open
+-- on a bed is its occupant: the unique index is what guarantees a bed is
+-- never given to two patients at once.
+CREATE TABLE IF NOT EXISTS bed_stays (
+    id SERIAL PRIMARY KEY,
+    admission_id INTEGER NOT NULL REFERENCES admissions(id) ON DELETE CASCADE,
+    bed_id INTEGER NOT NULL REFERENCES beds(id),
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ended_at TIMESTAMPTZ,
+    reason TEXT,
+    assigned_by INTEGER REFERENCES users(id),
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bed_stays_open_bed ON bed_stays(bed_id) WHERE ended_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bed_stays_open_admission ON bed_stays(admission_id) WHERE ended_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_bed_stays_admission_id ON bed_stays(admission_id, started_at);
+CREATE INDEX IF NOT EXISTS idx_bed_stays_bed_id ON bed_stays(bed_id, started_at);
@@ -0,0 +1,87 @@
+package database_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scratchDatabase returns a migrator for TEST_DATABASE_URL, emptied first,
+// and a connection to it. The database is wiped, so it must be one kept for
+// tests; without the variable the test is skipped.
+func scratchDatabase(t *testing.T) (*migrate.Migrate, *pgx.Conn) {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	m, err := migrate.New("file://migrations", url)
+	require.NoError(t, err)
+	t.Cleanup(func() { m.Close() })
+	require.NoError(t, m.Drop())
+
+	// Drop removes the migrations table too; a new migrator recreates it.
+	m, err = migrate.New("file://migrations", url)
+	require.NoError(t, err)
+	t.Cleanup(func() { m.Close() })
+
+	conn, err := pgx.Connect(context.Background(), url)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close(context.Background()) })
+	return m, conn
+}
+
+func TestOverlapMigrationCancelsExistingDoubleBookings(t *testing.T) {
+	m, conn := scratchDatabase(t)
+	ctx := context.Background()
+	require.NoError(t, m.Migrate(5))
+
+	_, err := conn.Exec(ctx, `
+		INSERT INTO users (id, email, password_hash, role, first_name, last_name)
+		VALUES (1, 'house@example.com', 'x', 'doctor', 'Gregory', 'House');
+		INSERT INTO patients (id, first_name, last_name) VALUES (1, 'Asha', 'Rao'), (2, 'Ravi', 'Iyer');
+		INSERT INTO appointments (id, patient_id, doctor_id, appointment_date, notes) VALUES
+			(1, 1, 1, '2025-03-03 09:00', NULL),
+			(2, 2, 1, '2025-03-03 09:15', 'Follow-up'),
+			(3, 1, 1, '2025-03-03 09:30', NULL),
+			(4, 2, NULL, '2025-03-03 11:00', NULL),
+			(5, 2, NULL, '2025-03-03 11:10', NULL);
+		INSERT INTO appointments (id, patient_id, doctor_id, appointment_date, status) VALUES
+			(6, 1, 1, '2025-03-03 09:05', 'cancelled');`)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Migrate(6))
+
+	rows, err := conn.Query(ctx, `SELECT id, status, COALESCE(notes, '') FROM appointments ORDER BY id`)
+	require.NoError(t, err)
+	type appointment struct {
+		status, notes string
+	}
+	got := map[int32]appointment{}
+	for rows.Next() {
+		var id int32
+		var a appointment
+		require.NoError(t, rows.Scan(&id, &a.status, &a.notes))
+		got[id] = a
+	}
+	require.NoError(t, rows.Err())
+
+	// 2 overlaps 1 with the same doctor and 5 overlaps 4 for the same
+	// patient. 3 only overlapped 2, which is cancelled, and 6 was already.
+	assert.Equal(t, "scheduled", got[1].status)
+	assert.Equal(t, "cancelled", got[2].status)
+	assert.Equal(t, "Follow-up\nCancelled when double bookings were disallowed: overlapped appointment 1.", got[2].notes)
+	assert.Equal(t, "scheduled", got[3].status)
+	assert.Equal(t, "scheduled", got[4].status)
+	assert.Equal(t, "cancelled", got[5].status)
+	assert.Contains(t, got[5].notes, "overlapped appointment 4")
+	assert.Equal(t, "cancelled", got[6].status)
+	assert.Empty(t, got[6].notes)
+}
@@ -0,0 +1,31 @@
+-- name: CreateAppointmentSeries :one
+INSERT INTO appointment_series (patient_id, doctor_id, rrule, starts_at, duration_minutes, notes, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetAppointmentSeries :one
+SELECT * FROM appointment_series WHERE id = $1;
+
+-- name: UpdateAppointmentSeries :one
+UPDATE appointment_series
+SET
+    doctor_id = COALESCE(sqlc.narg(doctor_id), doctor_id),
+    starts_at = COALESCE(sqlc.narg(starts_at), starts_at),
+    duration_minutes = COALESCE(sqlc.narg(duration_minutes), duration_minutes),
+    notes = COALESCE(sqlc.narg(notes), notes)
+WHERE id = $1
+RETURNING *;
+
+-- name: CancelAppointmentSeries :execrows
+UPDATE appointment_series SET cancelled_at = NOW()
+WHERE id = $1 AND cancelled_at IS NULL;
+
+-- name: DeleteAppointmentSeries :exec
+DELETE FROM appointment_series WHERE id = $1;
+
+-- name: ListSeriesAppointments :many
+SELECT id, patient_id, doctor_id, appointment_date, status, notes,
+       diagnosis, treatment_plan, created_by, created_at, updated_at, end_time, series_id
+FROM appointments
+WHERE series_id = $1
+ORDER BY appointment_date;
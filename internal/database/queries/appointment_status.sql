@@ -0,0 +1,23 @@
+-- name: SetAppointmentStatus :execrows
+UPDATE appointments
+SET status = sqlc.arg(to_status), updated_at = NOW()
+WHERE id = sqlc.arg(id) AND COALESCE(status, 'scheduled') = sqlc.arg(from_status)::text;
+
+-- name: CreateAppointmentStatusHistory :one
+INSERT INTO appointment_status_history (appointment_id, from_status, to_status, reason, changed_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, appointment_id, from_status, to_status, reason, changed_by, changed_at;
+
+-- name: ListAppointmentStatusHistory :many
+SELECT h.id, h.appointment_id, h.from_status, h.to_status, h.reason, h.changed_by, h.changed_at,
+       COALESCE(u.first_name || ' ' || u.last_name, '') AS changed_by_name
+FROM appointment_status_history h
+LEFT JOIN users u ON h.changed_by = u.id
+WHERE h.appointment_id = $1
+ORDER BY h.changed_at, h.id;
+
+-- name: ListOverdueAppointments :many
+SELECT id FROM appointments
+WHERE COALESCE(status, 'scheduled') = 'scheduled' AND appointment_date < sqlc.arg(before)
+ORDER BY appointment_date
+LIMIT sqlc.arg(batch_size);
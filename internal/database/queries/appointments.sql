@@ -1,6 +1,7 @@
 -- name: GetAppointments :many
 SELECT
-    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.status,
+    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.end_time, a.series_id,
+    a.department_id, a.room_id, a.status,
     a.notes, a.diagnosis, a.treatment_plan, a.created_by,
     a.created_at, a.updated_at,
     p.first_name || ' ' || p.last_name as patient_name,
@@ -8,12 +9,14 @@ SELECT
 FROM appointments a
 JOIN patients p ON a.patient_id = p.id
 LEFT JOIN users u ON a.doctor_id = u.id
+WHERE (sqlc.narg(department_id)::int IS NULL OR a.department_id = sqlc.narg(department_id)::int)
 ORDER BY a.appointment_date DESC
-LIMIT $1 OFFSET $2;
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: GetAppointmentsByDoctor :many
 SELECT
-    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.status,
+    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.end_time, a.series_id,
+    a.department_id, a.room_id, a.status,
     a.notes, a.diagnosis, a.treatment_plan, a.created_by,
     a.created_at, a.updated_at,
     p.first_name || ' ' || p.last_name as patient_name,
@@ -21,13 +24,15 @@ SELECT
 FROM appointments a
 JOIN patients p ON a.patient_id = p.id
 LEFT JOIN users u ON a.doctor_id = u.id
-WHERE a.doctor_id = $1
+WHERE a.doctor_id = sqlc.narg(doctor_id)
+  AND (sqlc.narg(department_id)::int IS NULL OR a.department_id = sqlc.narg(department_id)::int)
 ORDER BY a.appointment_date DESC
-LIMIT $2 OFFSET $3;
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: GetAppointmentByID :one
 SELECT
-    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.status,
+    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.end_time, a.series_id,
+    a.department_id, a.room_id, a.status,
     a.notes, a.diagnosis, a.treatment_plan, a.created_by,
     a.created_at, a.updated_at,
     p.first_name || ' ' || p.last_name as patient_name,
@@ -38,16 +43,18 @@ LEFT JOIN users u ON a.doctor_id = u.id
 WHERE a.id = $1;
 
 -- name: CreateAppointment :one
-INSERT INTO appointments (patient_id, doctor_id, appointment_date, notes, created_by)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO appointments (patient_id, doctor_id, appointment_date, end_time, notes, created_by, series_id, department_id, room_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING id, patient_id, doctor_id, appointment_date, status, notes,
-          diagnosis, treatment_plan, created_by, created_at, updated_at;
+          diagnosis, treatment_plan, created_by, created_at, updated_at, end_time, series_id,
+          department_id, room_id;
 
 -- name: UpdateAppointment :one
 UPDATE appointments
 SET
     doctor_id = COALESCE(sqlc.narg(doctor_id), doctor_id),
     appointment_date = COALESCE(sqlc.narg(appointment_date), appointment_date),
+    end_time = COALESCE(sqlc.narg(end_time), end_time),
     status = COALESCE(sqlc.narg(status), status),
     notes = COALESCE(sqlc.narg(notes), notes),
     diagnosis = COALESCE(sqlc.narg(diagnosis), diagnosis),
@@ -55,7 +62,8 @@ SET
     updated_at = NOW()
 WHERE id = $1
 RETURNING id, patient_id, doctor_id, appointment_date, status, notes,
-          diagnosis, treatment_plan, created_by, created_at, updated_at;
+          diagnosis, treatment_plan, created_by, created_at, updated_at, end_time, series_id,
+          department_id, room_id;
 
 -- name: DeleteAppointment :exec
 DELETE FROM appointments WHERE id = $1;
@@ -68,7 +76,8 @@ SELECT COUNT(*) FROM appointments WHERE status = $1;
 
 -- name: GetTodaysAppointments :many
 SELECT
-    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.status,
+    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.end_time, a.series_id,
+    a.department_id, a.room_id, a.status,
     a.notes, a.diagnosis, a.treatment_plan, a.created_by,
     a.created_at, a.updated_at,
     p.first_name || ' ' || p.last_name as patient_name,
@@ -81,7 +90,8 @@ ORDER BY a.appointment_date;
 
 -- name: GetAppointmentsByDateRange :many
 SELECT
-    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.status,
+    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.end_time, a.series_id,
+    a.department_id, a.room_id, a.status,
     a.notes, a.diagnosis, a.treatment_plan, a.created_by,
     a.created_at, a.updated_at,
     p.first_name || ' ' || p.last_name as patient_name,
@@ -94,7 +104,8 @@ ORDER BY a.appointment_date;
 
 -- name: GetPatientAppointments :many
 SELECT
-    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.status,
+    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.end_time, a.series_id,
+    a.department_id, a.room_id, a.status,
     a.notes, a.diagnosis, a.treatment_plan, a.created_by,
     a.created_at, a.updated_at,
     p.first_name || ' ' || p.last_name as patient_name,
@@ -104,3 +115,13 @@ JOIN patients p ON a.patient_id = p.id
 LEFT JOIN users u ON a.doctor_id = u.id
 WHERE a.patient_id = $1
 ORDER BY a.appointment_date DESC;
+
+-- name: ListOverlappingAppointments :many
+SELECT a.id, a.patient_id, a.doctor_id, a.room_id, a.appointment_date, a.end_time
+FROM appointments a
+WHERE a.id <> sqlc.arg(exclude_id)
+  AND COALESCE(a.status, 'scheduled') NOT IN ('cancelled', 'no_show')
+  AND (a.doctor_id = sqlc.narg(doctor_id) OR a.patient_id = sqlc.narg(patient_id) OR a.room_id = sqlc.narg(room_id))
+  AND a.appointment_date < sqlc.arg(end_time)
+  AND a.end_time > sqlc.arg(start_time)
+ORDER BY a.appointment_date;
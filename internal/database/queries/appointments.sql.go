@@ -34,18 +34,23 @@ func (q *Queries) CountAppointmentsByStatus(ctx context.Context, status *string)
 }
 
 const CreateAppointment = `-- name: CreateAppointment :one
-INSERT INTO appointments (patient_id, doctor_id, appointment_date, notes, created_by)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO appointments (patient_id, doctor_id, appointment_date, end_time, notes, created_by, series_id, department_id, room_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING id, patient_id, doctor_id, appointment_date, status, notes,
-          diagnosis, treatment_plan, created_by, created_at, updated_at
+          diagnosis, treatment_plan, created_by, created_at, updated_at, end_time, series_id,
+          department_id, room_id
 `
 
 type CreateAppointmentParams struct {
-	PatientID       *int32           `db:"patient_id" json:"patient_id"`
-	DoctorID        *int32           `db:"doctor_id" json:"doctor_id"`
-	AppointmentDate pgtype.Timestamp `db:"appointment_date" json:"appointment_date"`
-	Notes           *string          `db:"notes" json:"notes"`
-	CreatedBy       *int32           `db:"created_by" json:"created_by"`
+	PatientID       *int32             `db:"patient_id" json:"patient_id"`
+	DoctorID        *int32             `db:"doctor_id" json:"doctor_id"`
+	AppointmentDate pgtype.Timestamptz `db:"appointment_date" json:"appointment_date"`
+	EndTime         pgtype.Timestamptz `db:"end_time" json:"end_time"`
+	Notes           *string            `db:"notes" json:"notes"`
+	CreatedBy       *int32             `db:"created_by" json:"created_by"`
+	SeriesID        *int32             `db:"series_id" json:"series_id"`
+	DepartmentID    *int32             `db:"department_id" json:"department_id"`
+	RoomID          *int32             `db:"room_id" json:"room_id"`
 }
 
 func (q *Queries) CreateAppointment(ctx context.Context, arg CreateAppointmentParams) (*Appointment, error) {
@@ -53,8 +58,12 @@ func (q *Queries) CreateAppointment(ctx context.Context, arg CreateAppointmentPa
 		arg.PatientID,
 		arg.DoctorID,
 		arg.AppointmentDate,
+		arg.EndTime,
 		arg.Notes,
 		arg.CreatedBy,
+		arg.SeriesID,
+		arg.DepartmentID,
+		arg.RoomID,
 	)
 	var i Appointment
 	err := row.Scan(
@@ -69,6 +78,10 @@ func (q *Queries) CreateAppointment(ctx context.Context, arg CreateAppointmentPa
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EndTime,
+		&i.SeriesID,
+		&i.DepartmentID,
+		&i.RoomID,
 	)
 	return &i, err
 }
@@ -84,7 +97,8 @@ func (q *Queries) DeleteAppointment(ctx context.Context, id int32) error {
 
 const GetAppointmentByID = `-- name: GetAppointmentByID :one
 SELECT
-    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.status,
+    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.end_time, a.series_id,
+    a.department_id, a.room_id, a.status,
     a.notes, a.diagnosis, a.treatment_plan, a.created_by,
     a.created_at, a.updated_at,
     p.first_name || ' ' || p.last_name as patient_name,
@@ -96,19 +110,23 @@ WHERE a.id = $1
 `
 
 type GetAppointmentByIDRow struct {
-	ID              int32            `db:"id" json:"id"`
-	PatientID       *int32           `db:"patient_id" json:"patient_id"`
-	DoctorID        *int32           `db:"doctor_id" json:"doctor_id"`
-	AppointmentDate pgtype.Timestamp `db:"appointment_date" json:"appointment_date"`
-	Status          *string          `db:"status" json:"status"`
-	Notes           *string          `db:"notes" json:"notes"`
-	Diagnosis       *string          `db:"diagnosis" json:"diagnosis"`
-	TreatmentPlan   *string          `db:"treatment_plan" json:"treatment_plan"`
-	CreatedBy       *int32           `db:"created_by" json:"created_by"`
-	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt       pgtype.Timestamp `db:"updated_at" json:"updated_at"`
-	PatientName     interface{}      `db:"patient_name" json:"patient_name"`
-	DoctorName      interface{}      `db:"doctor_name" json:"doctor_name"`
+	ID              int32              `db:"id" json:"id"`
+	PatientID       *int32             `db:"patient_id" json:"patient_id"`
+	DoctorID        *int32             `db:"doctor_id" json:"doctor_id"`
+	AppointmentDate pgtype.Timestamptz `db:"appointment_date" json:"appointment_date"`
+	EndTime         pgtype.Timestamptz `db:"end_time" json:"end_time"`
+	SeriesID        *int32             `db:"series_id" json:"series_id"`
+	DepartmentID    *int32             `db:"department_id" json:"department_id"`
+	RoomID          *int32             `db:"room_id" json:"room_id"`
+	Status          *string            `db:"status" json:"status"`
+	Notes           *string            `db:"notes" json:"notes"`
+	Diagnosis       *string            `db:"diagnosis" json:"diagnosis"`
+	TreatmentPlan   *string            `db:"treatment_plan" json:"treatment_plan"`
+	CreatedBy       *int32             `db:"created_by" json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	PatientName     interface{}        `db:"patient_name" json:"patient_name"`
+	DoctorName      interface{}        `db:"doctor_name" json:"doctor_name"`
 }
 
 func (q *Queries) GetAppointmentByID(ctx context.Context, id int32) (*GetAppointmentByIDRow, error) {
@@ -119,6 +137,10 @@ func (q *Queries) GetAppointmentByID(ctx context.Context, id int32) (*GetAppoint
 		&i.PatientID,
 		&i.DoctorID,
 		&i.AppointmentDate,
+		&i.EndTime,
+		&i.SeriesID,
+		&i.DepartmentID,
+		&i.RoomID,
 		&i.Status,
 		&i.Notes,
 		&i.Diagnosis,
@@ -134,7 +156,8 @@ func (q *Queries) GetAppointmentByID(ctx context.Context, id int32) (*GetAppoint
 
 const GetAppointments = `-- name: GetAppointments :many
 SELECT
-    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.status,
+    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.end_time, a.series_id,
+    a.department_id, a.room_id, a.status,
     a.notes, a.diagnosis, a.treatment_plan, a.created_by,
     a.created_at, a.updated_at,
     p.first_name || ' ' || p.last_name as patient_name,
@@ -142,33 +165,39 @@ SELECT
 FROM appointments a
 JOIN patients p ON a.patient_id = p.id
 LEFT JOIN users u ON a.doctor_id = u.id
+WHERE ($1::int IS NULL OR a.department_id = $1::int)
 ORDER BY a.appointment_date DESC
-LIMIT $1 OFFSET $2
+LIMIT $2 OFFSET $3
 `
 
 type GetAppointmentsParams struct {
-	Limit  int32 `db:"limit" json:"limit"`
-	Offset int32 `db:"offset" json:"offset"`
+	DepartmentID *int32 `db:"department_id" json:"department_id"`
+	Limit        int32  `db:"limit" json:"limit"`
+	Offset       int32  `db:"offset" json:"offset"`
 }
 
 type GetAppointmentsRow struct {
-	ID              int32            `db:"id" json:"id"`
-	PatientID       *int32           `db:"patient_id" json:"patient_id"`
-	DoctorID        *int32           `db:"doctor_id" json:"doctor_id"`
-	AppointmentDate pgtype.Timestamp `db:"appointment_date" json:"appointment_date"`
-	Status          *string          `db:"status" json:"status"`
-	Notes           *string          `db:"notes" json:"notes"`
-	Diagnosis       *string          `db:"diagnosis" json:"diagnosis"`
-	TreatmentPlan   *string          `db:"treatment_plan" json:"treatment_plan"`
-	CreatedBy       *int32           `db:"created_by" json:"created_by"`
-	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt       pgtype.Timestamp `db:"updated_at" json:"updated_at"`
-	PatientName     interface{}      `db:"patient_name" json:"patient_name"`
-	DoctorName      interface{}      `db:"doctor_name" json:"doctor_name"`
+	ID              int32              `db:"id" json:"id"`
+	PatientID       *int32             `db:"patient_id" json:"patient_id"`
+	DoctorID        *int32             `db:"doctor_id" json:"doctor_id"`
+	AppointmentDate pgtype.Timestamptz `db:"appointment_date" json:"appointment_date"`
+	EndTime         pgtype.Timestamptz `db:"end_time" json:"end_time"`
+	SeriesID        *int32             `db:"series_id" json:"series_id"`
+	DepartmentID    *int32             `db:"department_id" json:"department_id"`
+	RoomID          *int32             `db:"room_id" json:"room_id"`
+	Status          *string            `db:"status" json:"status"`
+	Notes           *string            `db:"notes" json:"notes"`
+	Diagnosis       *string            `db:"diagnosis" json:"diagnosis"`
+	TreatmentPlan   *string            `db:"treatment_plan" json:"treatment_plan"`
+	CreatedBy       *int32             `db:"created_by" json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	PatientName     interface{}        `db:"patient_name" json:"patient_name"`
+	DoctorName      interface{}        `db:"doctor_name" json:"doctor_name"`
 }
 
 func (q *Queries) GetAppointments(ctx context.Context, arg GetAppointmentsParams) ([]*GetAppointmentsRow, error) {
-	rows, err := q.db.Query(ctx, GetAppointments, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, GetAppointments, arg.DepartmentID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +210,10 @@ func (q *Queries) GetAppointments(ctx context.Context, arg GetAppointmentsParams
 			&i.PatientID,
 			&i.DoctorID,
 			&i.AppointmentDate,
+			&i.EndTime,
+			&i.SeriesID,
+			&i.DepartmentID,
+			&i.RoomID,
 			&i.Status,
 			&i.Notes,
 			&i.Diagnosis,
@@ -203,7 +236,8 @@ func (q *Queries) GetAppointments(ctx context.Context, arg GetAppointmentsParams
 
 const GetAppointmentsByDateRange = `-- name: GetAppointmentsByDateRange :many
 SELECT
-    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.status,
+    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.end_time, a.series_id,
+    a.department_id, a.room_id, a.status,
     a.notes, a.diagnosis, a.treatment_plan, a.created_by,
     a.created_at, a.updated_at,
     p.first_name || ' ' || p.last_name as patient_name,
@@ -216,24 +250,28 @@ ORDER BY a.appointment_date
 `
 
 type GetAppointmentsByDateRangeParams struct {
-	AppointmentDate   pgtype.Timestamp `db:"appointment_date" json:"appointment_date"`
-	AppointmentDate_2 pgtype.Timestamp `db:"appointment_date_2" json:"appointment_date_2"`
+	AppointmentDate   pgtype.Timestamptz `db:"appointment_date" json:"appointment_date"`
+	AppointmentDate_2 pgtype.Timestamptz `db:"appointment_date_2" json:"appointment_date_2"`
 }
 
 type GetAppointmentsByDateRangeRow struct {
-	ID              int32            `db:"id" json:"id"`
-	PatientID       *int32           `db:"patient_id" json:"patient_id"`
-	DoctorID        *int32           `db:"doctor_id" json:"doctor_id"`
-	AppointmentDate pgtype.Timestamp `db:"appointment_date" json:"appointment_date"`
-	Status          *string          `db:"status" json:"status"`
-	Notes           *string          `db:"notes" json:"notes"`
-	Diagnosis       *string          `db:"diagnosis" json:"diagnosis"`
-	TreatmentPlan   *string          `db:"treatment_plan" json:"treatment_plan"`
-	CreatedBy       *int32           `db:"created_by" json:"created_by"`
-	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt       pgtype.Timestamp `db:"updated_at" json:"updated_at"`
-	PatientName     interface{}      `db:"patient_name" json:"patient_name"`
-	DoctorName      interface{}      `db:"doctor_name" json:"doctor_name"`
+	ID              int32              `db:"id" json:"id"`
+	PatientID       *int32             `db:"patient_id" json:"patient_id"`
+	DoctorID        *int32             `db:"doctor_id" json:"doctor_id"`
+	AppointmentDate pgtype.Timestamptz `db:"appointment_date" json:"appointment_date"`
+	EndTime         pgtype.Timestamptz `db:"end_time" json:"end_time"`
+	SeriesID        *int32             `db:"series_id" json:"series_id"`
+	DepartmentID    *int32             `db:"department_id" json:"department_id"`
+	RoomID          *int32             `db:"room_id" json:"room_id"`
+	Status          *string            `db:"status" json:"status"`
+	Notes           *string            `db:"notes" json:"notes"`
+	Diagnosis       *string            `db:"diagnosis" json:"diagnosis"`
+	TreatmentPlan   *string            `db:"treatment_plan" json:"treatment_plan"`
+	CreatedBy       *int32             `db:"created_by" json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	PatientName     interface{}        `db:"patient_name" json:"patient_name"`
+	DoctorName      interface{}        `db:"doctor_name" json:"doctor_name"`
 }
 
 func (q *Queries) GetAppointmentsByDateRange(ctx context.Context, arg GetAppointmentsByDateRangeParams) ([]*GetAppointmentsByDateRangeRow, error) {
@@ -250,6 +288,10 @@ func (q *Queries) GetAppointmentsByDateRange(ctx context.Context, arg GetAppoint
 			&i.PatientID,
 			&i.DoctorID,
 			&i.AppointmentDate,
+			&i.EndTime,
+			&i.SeriesID,
+			&i.DepartmentID,
+			&i.RoomID,
 			&i.Status,
 			&i.Notes,
 			&i.Diagnosis,
@@ -272,7 +314,8 @@ func (q *Queries) GetAppointmentsByDateRange(ctx context.Context, arg GetAppoint
 
 const GetAppointmentsByDoctor = `-- name: GetAppointmentsByDoctor :many
 SELECT
-    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.status,
+    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.end_time, a.series_id,
+    a.department_id, a.room_id, a.status,
     a.notes, a.diagnosis, a.treatment_plan, a.created_by,
     a.created_at, a.updated_at,
     p.first_name || ' ' || p.last_name as patient_name,
@@ -281,34 +324,45 @@ FROM appointments a
 JOIN patients p ON a.patient_id = p.id
 LEFT JOIN users u ON a.doctor_id = u.id
 WHERE a.doctor_id = $1
+  AND ($2::int IS NULL OR a.department_id = $2::int)
 ORDER BY a.appointment_date DESC
-LIMIT $2 OFFSET $3
+LIMIT $3 OFFSET $4
 `
 
 type GetAppointmentsByDoctorParams struct {
-	DoctorID *int32 `db:"doctor_id" json:"doctor_id"`
-	Limit    int32  `db:"limit" json:"limit"`
-	Offset   int32  `db:"offset" json:"offset"`
+	DoctorID     *int32 `db:"doctor_id" json:"doctor_id"`
+	DepartmentID *int32 `db:"department_id" json:"department_id"`
+	Limit        int32  `db:"limit" json:"limit"`
+	Offset       int32  `db:"offset" json:"offset"`
 }
 
 type GetAppointmentsByDoctorRow struct {
-	ID              int32            `db:"id" json:"id"`
-	PatientID       *int32           `db:"patient_id" json:"patient_id"`
-	DoctorID        *int32           `db:"doctor_id" json:"doctor_id"`
-	AppointmentDate pgtype.Timestamp `db:"appointment_date" json:"appointment_date"`
-	Status          *string          `db:"status" json:"status"`
-	Notes           *string          `db:"notes" json:"notes"`
-	Diagnosis       *string          `db:"diagnosis" json:"diagnosis"`
-	TreatmentPlan   *string          `db:"treatment_plan" json:"treatment_plan"`
-	CreatedBy       *int32           `db:"created_by" json:"created_by"`
-	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt       pgtype.Timestamp `db:"updated_at" json:"updated_at"`
-	PatientName     interface{}      `db:"patient_name" json:"patient_name"`
-	DoctorName      interface{}      `db:"doctor_name" json:"doctor_name"`
+	ID              int32              `db:"id" json:"id"`
+	PatientID       *int32             `db:"patient_id" json:"patient_id"`
+	DoctorID        *int32             `db:"doctor_id" json:"doctor_id"`
+	AppointmentDate pgtype.Timestamptz `db:"appointment_date" json:"appointment_date"`
+	EndTime         pgtype.Timestamptz `db:"end_time" json:"end_time"`
+	SeriesID        *int32             `db:"series_id" json:"series_id"`
+	DepartmentID    *int32             `db:"department_id" json:"department_id"`
+	RoomID          *int32             `db:"room_id" json:"room_id"`
+	Status          *string            `db:"status" json:"status"`
+	Notes           *string            `db:"notes" json:"notes"`
+	Diagnosis       *string            `db:"diagnosis" json:"diagnosis"`
+	TreatmentPlan   *string            `db:"treatment_plan" json:"treatment_plan"`
+	CreatedBy       *int32             `db:"created_by" json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	PatientName     interface{}        `db:"patient_name" json:"patient_name"`
+	DoctorName      interface{}        `db:"doctor_name" json:"doctor_name"`
 }
 
 func (q *Queries) GetAppointmentsByDoctor(ctx context.Context, arg GetAppointmentsByDoctorParams) ([]*GetAppointmentsByDoctorRow, error) {
-	rows, err := q.db.Query(ctx, GetAppointmentsByDoctor, arg.DoctorID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, GetAppointmentsByDoctor,
+		arg.DoctorID,
+		arg.DepartmentID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -321,6 +375,10 @@ func (q *Queries) GetAppointmentsByDoctor(ctx context.Context, arg GetAppointmen
 			&i.PatientID,
 			&i.DoctorID,
 			&i.AppointmentDate,
+			&i.EndTime,
+			&i.SeriesID,
+			&i.DepartmentID,
+			&i.RoomID,
 			&i.Status,
 			&i.Notes,
 			&i.Diagnosis,
@@ -343,7 +401,8 @@ func (q *Queries) GetAppointmentsByDoctor(ctx context.Context, arg GetAppointmen
 
 const GetPatientAppointments = `-- name: GetPatientAppointments :many
 SELECT
-    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.status,
+    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.end_time, a.series_id,
+    a.department_id, a.room_id, a.status,
     a.notes, a.diagnosis, a.treatment_plan, a.created_by,
     a.created_at, a.updated_at,
     p.first_name || ' ' || p.last_name as patient_name,
@@ -356,19 +415,23 @@ ORDER BY a.appointment_date DESC
 `
 
 type GetPatientAppointmentsRow struct {
-	ID              int32            `db:"id" json:"id"`
-	PatientID       *int32           `db:"patient_id" json:"patient_id"`
-	DoctorID        *int32           `db:"doctor_id" json:"doctor_id"`
-	AppointmentDate pgtype.Timestamp `db:"appointment_date" json:"appointment_date"`
-	Status          *string          `db:"status" json:"status"`
-	Notes           *string          `db:"notes" json:"notes"`
-	Diagnosis       *string          `db:"diagnosis" json:"diagnosis"`
-	TreatmentPlan   *string          `db:"treatment_plan" json:"treatment_plan"`
-	CreatedBy       *int32           `db:"created_by" json:"created_by"`
-	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt       pgtype.Timestamp `db:"updated_at" json:"updated_at"`
-	PatientName     interface{}      `db:"patient_name" json:"patient_name"`
-	DoctorName      interface{}      `db:"doctor_name" json:"doctor_name"`
+	ID              int32              `db:"id" json:"id"`
+	PatientID       *int32             `db:"patient_id" json:"patient_id"`
+	DoctorID        *int32             `db:"doctor_id" json:"doctor_id"`
+	AppointmentDate pgtype.Timestamptz `db:"appointment_date" json:"appointment_date"`
+	EndTime         pgtype.Timestamptz `db:"end_time" json:"end_time"`
+	SeriesID        *int32             `db:"series_id" json:"series_id"`
+	DepartmentID    *int32             `db:"department_id" json:"department_id"`
+	RoomID          *int32             `db:"room_id" json:"room_id"`
+	Status          *string            `db:"status" json:"status"`
+	Notes           *string            `db:"notes" json:"notes"`
+	Diagnosis       *string            `db:"diagnosis" json:"diagnosis"`
+	TreatmentPlan   *string            `db:"treatment_plan" json:"treatment_plan"`
+	CreatedBy       *int32             `db:"created_by" json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	PatientName     interface{}        `db:"patient_name" json:"patient_name"`
+	DoctorName      interface{}        `db:"doctor_name" json:"doctor_name"`
 }
 
 func (q *Queries) GetPatientAppointments(ctx context.Context, patientID *int32) ([]*GetPatientAppointmentsRow, error) {
@@ -385,6 +448,10 @@ func (q *Queries) GetPatientAppointments(ctx context.Context, patientID *int32)
 			&i.PatientID,
 			&i.DoctorID,
 			&i.AppointmentDate,
+			&i.EndTime,
+			&i.SeriesID,
+			&i.DepartmentID,
+			&i.RoomID,
 			&i.Status,
 			&i.Notes,
 			&i.Diagnosis,
@@ -407,7 +474,8 @@ func (q *Queries) GetPatientAppointments(ctx context.Context, patientID *int32)
 
 const GetTodaysAppointments = `-- name: GetTodaysAppointments :many
 SELECT
-    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.status,
+    a.id, a.patient_id, a.doctor_id, a.appointment_date, a.end_time, a.series_id,
+    a.department_id, a.room_id, a.status,
     a.notes, a.diagnosis, a.treatment_plan, a.created_by,
     a.created_at, a.updated_at,
     p.first_name || ' ' || p.last_name as patient_name,
@@ -420,19 +488,23 @@ ORDER BY a.appointment_date
 `
 
 type GetTodaysAppointmentsRow struct {
-	ID              int32            `db:"id" json:"id"`
-	PatientID       *int32           `db:"patient_id" json:"patient_id"`
-	DoctorID        *int32           `db:"doctor_id" json:"doctor_id"`
-	AppointmentDate pgtype.Timestamp `db:"appointment_date" json:"appointment_date"`
-	Status          *string          `db:"status" json:"status"`
-	Notes           *string          `db:"notes" json:"notes"`
-	Diagnosis       *string          `db:"diagnosis" json:"diagnosis"`
-	TreatmentPlan   *string          `db:"treatment_plan" json:"treatment_plan"`
-	CreatedBy       *int32           `db:"created_by" json:"created_by"`
-	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt       pgtype.Timestamp `db:"updated_at" json:"updated_at"`
-	PatientName     interface{}      `db:"patient_name" json:"patient_name"`
-	DoctorName      interface{}      `db:"doctor_name" json:"doctor_name"`
+	ID              int32              `db:"id" json:"id"`
+	PatientID       *int32             `db:"patient_id" json:"patient_id"`
+	DoctorID        *int32             `db:"doctor_id" json:"doctor_id"`
+	AppointmentDate pgtype.Timestamptz `db:"appointment_date" json:"appointment_date"`
+	EndTime         pgtype.Timestamptz `db:"end_time" json:"end_time"`
+	SeriesID        *int32             `db:"series_id" json:"series_id"`
+	DepartmentID    *int32             `db:"department_id" json:"department_id"`
+	RoomID          *int32             `db:"room_id" json:"room_id"`
+	Status          *string            `db:"status" json:"status"`
+	Notes           *string            `db:"notes" json:"notes"`
+	Diagnosis       *string            `db:"diagnosis" json:"diagnosis"`
+	TreatmentPlan   *string            `db:"treatment_plan" json:"treatment_plan"`
+	CreatedBy       *int32             `db:"created_by" json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	PatientName     interface{}        `db:"patient_name" json:"patient_name"`
+	DoctorName      interface{}        `db:"doctor_name" json:"doctor_name"`
 }
 
 func (q *Queries) GetTodaysAppointments(ctx context.Context) ([]*GetTodaysAppointmentsRow, error) {
@@ -449,6 +521,10 @@ func (q *Queries) GetTodaysAppointments(ctx context.Context) ([]*GetTodaysAppoin
 			&i.PatientID,
 			&i.DoctorID,
 			&i.AppointmentDate,
+			&i.EndTime,
+			&i.SeriesID,
+			&i.DepartmentID,
+			&i.RoomID,
 			&i.Status,
 			&i.Notes,
 			&i.Diagnosis,
@@ -469,29 +545,95 @@ func (q *Queries) GetTodaysAppointments(ctx context.Context) ([]*GetTodaysAppoin
 	return items, nil
 }
 
+const ListOverlappingAppointments = `-- name: ListOverlappingAppointments :many
+SELECT a.id, a.patient_id, a.doctor_id, a.room_id, a.appointment_date, a.end_time
+FROM appointments a
+WHERE a.id <> $1
+  AND COALESCE(a.status, 'scheduled') NOT IN ('cancelled', 'no_show')
+  AND (a.doctor_id = $2 OR a.patient_id = $3 OR a.room_id = $4)
+  AND a.appointment_date < $5
+  AND a.end_time > $6
+ORDER BY a.appointment_date
+`
+
+type ListOverlappingAppointmentsParams struct {
+	ExcludeID int32              `db:"exclude_id" json:"exclude_id"`
+	DoctorID  *int32             `db:"doctor_id" json:"doctor_id"`
+	PatientID *int32             `db:"patient_id" json:"patient_id"`
+	RoomID    *int32             `db:"room_id" json:"room_id"`
+	EndTime   pgtype.Timestamptz `db:"end_time" json:"end_time"`
+	StartTime pgtype.Timestamptz `db:"start_time" json:"start_time"`
+}
+
+type ListOverlappingAppointmentsRow struct {
+	ID              int32              `db:"id" json:"id"`
+	PatientID       *int32             `db:"patient_id" json:"patient_id"`
+	DoctorID        *int32             `db:"doctor_id" json:"doctor_id"`
+	RoomID          *int32             `db:"room_id" json:"room_id"`
+	AppointmentDate pgtype.Timestamptz `db:"appointment_date" json:"appointment_date"`
+	EndTime         pgtype.Timestamptz `db:"end_time" json:"end_time"`
+}
+
+func (q *Queries) ListOverlappingAppointments(ctx context.Context, arg ListOverlappingAppointmentsParams) ([]*ListOverlappingAppointmentsRow, error) {
+	rows, err := q.db.Query(ctx, ListOverlappingAppointments,
+		arg.ExcludeID,
+		arg.DoctorID,
+		arg.PatientID,
+		arg.RoomID,
+		arg.EndTime,
+		arg.StartTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListOverlappingAppointmentsRow
+	for rows.Next() {
+		var i ListOverlappingAppointmentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PatientID,
+			&i.DoctorID,
+			&i.RoomID,
+			&i.AppointmentDate,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const UpdateAppointment = `-- name: UpdateAppointment :one
 UPDATE appointments
 SET
     doctor_id = COALESCE($2, doctor_id),
     appointment_date = COALESCE($3, appointment_date),
-    status = COALESCE($4, status),
-    notes = COALESCE($5, notes),
-    diagnosis = COALESCE($6, diagnosis),
-    treatment_plan = COALESCE($7, treatment_plan),
+    end_time = COALESCE($4, end_time),
+    status = COALESCE($5, status),
+    notes = COALESCE($6, notes),
+    diagnosis = COALESCE($7, diagnosis),
+    treatment_plan = COALESCE($8, treatment_plan),
     updated_at = NOW()
 WHERE id = $1
 RETURNING id, patient_id, doctor_id, appointment_date, status, notes,
-          diagnosis, treatment_plan, created_by, created_at, updated_at
+          diagnosis, treatment_plan, created_by, created_at, updated_at, end_time, series_id,
+          department_id, room_id
 `
 
 type UpdateAppointmentParams struct {
-	ID              int32            `db:"id" json:"id"`
-	DoctorID        *int32           `db:"doctor_id" json:"doctor_id"`
-	AppointmentDate pgtype.Timestamp `db:"appointment_date" json:"appointment_date"`
-	Status          *string          `db:"status" json:"status"`
-	Notes           *string          `db:"notes" json:"notes"`
-	Diagnosis       *string          `db:"diagnosis" json:"diagnosis"`
-	TreatmentPlan   *string          `db:"treatment_plan" json:"treatment_plan"`
+	ID              int32              `db:"id" json:"id"`
+	DoctorID        *int32             `db:"doctor_id" json:"doctor_id"`
+	AppointmentDate pgtype.Timestamptz `db:"appointment_date" json:"appointment_date"`
+	EndTime         pgtype.Timestamptz `db:"end_time" json:"end_time"`
+	Status          *string            `db:"status" json:"status"`
+	Notes           *string            `db:"notes" json:"notes"`
+	Diagnosis       *string            `db:"diagnosis" json:"diagnosis"`
+	TreatmentPlan   *string            `db:"treatment_plan" json:"treatment_plan"`
 }
 
 func (q *Queries) UpdateAppointment(ctx context.Context, arg UpdateAppointmentParams) (*Appointment, error) {
@@ -499,6 +641,7 @@ func (q *Queries) UpdateAppointment(ctx context.Context, arg UpdateAppointmentPa
 		arg.ID,
 		arg.DoctorID,
 		arg.AppointmentDate,
+		arg.EndTime,
 		arg.Status,
 		arg.Notes,
 		arg.Diagnosis,
@@ -517,6 +660,10 @@ func (q *Queries) UpdateAppointment(ctx context.Context, arg UpdateAppointmentPa
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EndTime,
+		&i.SeriesID,
+		&i.DepartmentID,
+		&i.RoomID,
 	)
 	return &i, err
 }
@@ -0,0 +1,36 @@
+-- name: GetLastAuditHash :one
+SELECT hash FROM audit_log
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: CreateAuditEntry :one
+INSERT INTO audit_log (
+    occurred_at, actor_id, actor_role, action, resource_type, resource_id,
+    patient_id, changes, ip_address, request_id, prev_hash, hash
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id, occurred_at, actor_id, actor_role, action, resource_type,
+          resource_id, patient_id, changes, ip_address, request_id,
+          prev_hash, hash;
+
+-- name: ListAuditEntries :many
+SELECT id, occurred_at, actor_id, actor_role, action, resource_type,
+       resource_id, patient_id, changes, ip_address, request_id,
+       prev_hash, hash
+FROM audit_log
+WHERE
+    (sqlc.narg(patient_id)::int IS NULL OR patient_id = sqlc.narg(patient_id)) AND
+    (sqlc.narg(actor_id)::int IS NULL OR actor_id = sqlc.narg(actor_id)) AND
+    (sqlc.narg(from_time)::timestamptz IS NULL OR occurred_at >= sqlc.narg(from_time)) AND
+    (sqlc.narg(to_time)::timestamptz IS NULL OR occurred_at <= sqlc.narg(to_time))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListAuditEntriesAfter :many
+SELECT id, occurred_at, actor_id, actor_role, action, resource_type,
+       resource_id, patient_id, changes, ip_address, request_id,
+       prev_hash, hash
+FROM audit_log
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
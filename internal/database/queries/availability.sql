@@ -0,0 +1,81 @@
+-- name: GetDoctorProfile :one
+SELECT doctor_id, specialty, updated_at
+FROM doctor_profiles
+WHERE doctor_id = $1;
+
+-- name: UpsertDoctorProfile :exec
+INSERT INTO doctor_profiles (doctor_id, specialty)
+VALUES ($1, $2)
+ON CONFLICT (doctor_id) DO UPDATE SET specialty = EXCLUDED.specialty, updated_at = NOW();
+
+-- name: ListDoctorsWithSpecialty :many
+SELECT u.id, u.first_name, u.last_name, p.specialty
+FROM users u
+LEFT JOIN doctor_profiles p ON p.doctor_id = u.id
+WHERE u.role = 'doctor'
+  AND (sqlc.narg(specialty)::text IS NULL OR EXISTS (
+    SELECT 1 FROM doctor_specialties ds
+    JOIN specialties s ON s.id = ds.specialty_id
+    WHERE ds.doctor_id = u.id AND LOWER(s.name) = LOWER(sqlc.narg(specialty)::text)
+  ))
+  AND (sqlc.narg(department_id)::int IS NULL OR EXISTS (
+    SELECT 1 FROM department_doctors dd
+    WHERE dd.doctor_id = u.id AND dd.department_id = sqlc.narg(department_id)::int
+  ))
+ORDER BY u.first_name, u.last_name;
+
+-- name: DeleteAvailabilityTemplates :exec
+DELETE FROM availability_templates WHERE doctor_id = $1;
+
+-- name: CreateAvailabilityTemplate :one
+INSERT INTO availability_templates (doctor_id, weekday, start_time, end_time, slot_minutes)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, doctor_id, weekday, start_time, end_time, slot_minutes;
+
+-- name: CreateAvailabilityBreak :exec
+INSERT INTO availability_breaks (template_id, start_time, end_time)
+VALUES ($1, $2, $3);
+
+-- name: ListAvailabilityTemplates :many
+SELECT id, doctor_id, weekday, start_time, end_time, slot_minutes
+FROM availability_templates
+WHERE doctor_id = $1
+ORDER BY weekday, start_time;
+
+-- name: ListAvailabilityBreaks :many
+SELECT b.id, b.template_id, b.start_time, b.end_time
+FROM availability_breaks b
+JOIN availability_templates t ON b.template_id = t.id
+WHERE t.doctor_id = $1
+ORDER BY b.start_time;
+
+-- name: CreateAvailabilityException :one
+INSERT INTO availability_exceptions (doctor_id, starts_at, ends_at, kind, reason, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, doctor_id, starts_at, ends_at, kind, reason, created_by, created_at;
+
+-- name: DeleteAvailabilityException :execrows
+DELETE FROM availability_exceptions WHERE id = $1 AND doctor_id = $2;
+
+-- name: ListAvailabilityExceptions :many
+SELECT id, doctor_id, starts_at, ends_at, kind, reason, created_by, created_at
+FROM availability_exceptions
+WHERE doctor_id = $1 AND ends_at > $2 AND starts_at < $3
+ORDER BY starts_at;
+
+-- name: ListDoctorBusyTimes :many
+SELECT appointment_date, end_time
+FROM appointments
+WHERE doctor_id = $1
+  AND COALESCE(status, 'scheduled') NOT IN ('cancelled', 'no_show')
+  AND appointment_date < $3
+  AND end_time > $2
+UNION ALL
+SELECT starts_at, ends_at
+FROM waitlist_holds
+WHERE doctor_id = $1
+  AND status = 'pending'
+  AND expires_at > NOW()
+  AND starts_at < $3
+  AND ends_at > $2
+ORDER BY appointment_date;
@@ -0,0 +1,149 @@
+-- name: CreateBillableService :one
+INSERT INTO billable_services (code, name, description, category, department_id, price_minor, tax_rate_bp, active)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: UpdateBillableService :one
+UPDATE billable_services
+SET code = $2, name = $3, description = $4, category = $5, department_id = $6,
+    price_minor = $7, tax_rate_bp = $8, active = $9, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetBillableService :one
+SELECT * FROM billable_services WHERE id = $1;
+
+-- name: ListBillableServices :many
+SELECT * FROM billable_services
+WHERE (sqlc.narg(category)::text IS NULL OR category = sqlc.narg(category))
+  AND (sqlc.narg(active)::boolean IS NULL OR active = sqlc.narg(active))
+  AND (sqlc.narg(query)::text IS NULL OR name ILIKE '%' || sqlc.narg(query) || '%' OR code ILIKE sqlc.narg(query) || '%')
+ORDER BY category, name;
+
+-- The active consultation of the department, or failing that the one
+-- with no department.
+-- name: GetDefaultConsultation :one
+SELECT * FROM billable_services
+WHERE category = 'consultation' AND active
+  AND (department_id = sqlc.narg(department_id) OR department_id IS NULL)
+ORDER BY department_id IS NULL, id
+LIMIT 1;
+
+-- name: CreateInvoice :one
+INSERT INTO invoices (patient_id, appointment_id, currency, notes, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetInvoice :one
+SELECT * FROM invoices WHERE id = $1;
+
+-- Locks the invoice for the rest of the transaction, so that lines and
+-- payments on it are applied one at a time.
+-- name: LockInvoice :one
+SELECT * FROM invoices WHERE id = $1 FOR UPDATE;
+
+-- name: ListInvoices :many
+SELECT * FROM invoices
+WHERE (sqlc.narg(patient_id)::int IS NULL OR patient_id = sqlc.narg(patient_id))
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListOpenInvoices :many
+SELECT * FROM invoices
+WHERE patient_id = $1 AND currency = $2 AND status IN ('issued', 'partially_paid')
+ORDER BY due_date NULLS LAST, id;
+
+-- name: AddInvoiceLine :one
+INSERT INTO invoice_lines (invoice_id, service_id, description, quantity, unit_price_minor, tax_rate_bp,
+                           subtotal_minor, discount_minor, tax_minor, total_minor)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: ListInvoiceLines :many
+SELECT * FROM invoice_lines WHERE invoice_id = $1 ORDER BY id;
+
+-- name: CountInvoiceLines :one
+SELECT COUNT(*) FROM invoice_lines WHERE invoice_id = $1;
+
+-- name: DeleteInvoiceLine :execrows
+DELETE FROM invoice_lines WHERE id = $1 AND invoice_id = $2;
+
+-- Sets the invoice's amounts to the sums of its lines.
+-- name: UpdateInvoiceTotals :one
+UPDATE invoices i
+SET subtotal_minor = t.subtotal_minor, discount_minor = t.discount_minor,
+    tax_minor = t.tax_minor, total_minor = t.total_minor, updated_at = NOW()
+FROM (
+    SELECT COALESCE(SUM(subtotal_minor), 0)::bigint AS subtotal_minor,
+           COALESCE(SUM(discount_minor), 0)::bigint AS discount_minor,
+           COALESCE(SUM(tax_minor), 0)::bigint AS tax_minor,
+           COALESCE(SUM(total_minor), 0)::bigint AS total_minor
+    FROM invoice_lines WHERE invoice_id = sqlc.arg(id)
+) t
+WHERE i.id = sqlc.arg(id)
+RETURNING i.*;
+
+-- An invoice for nothing is paid as soon as it is issued.
+-- name: IssueInvoice :one
+UPDATE invoices
+SET status = CASE WHEN total_minor = 0 THEN 'paid' ELSE 'issued' END,
+    issued_at = NOW(), due_date = $2, updated_at = NOW()
+WHERE id = $1 AND status = 'draft'
+RETURNING *;
+
+-- name: VoidInvoice :one
+UPDATE invoices
+SET status = 'void', voided_at = NOW(), void_reason = $2, updated_at = NOW()
+WHERE id = $1 AND status IN ('draft', 'issued') AND paid_minor = 0
+RETURNING *;
+
+-- Adds amount, negative for a refund, to what was paid and moves the
+-- status to match.
+-- name: ApplyInvoicePayment :one
+UPDATE invoices
+SET paid_minor = paid_minor + sqlc.arg(amount)::bigint,
+    status = CASE
+        WHEN paid_minor + sqlc.arg(amount)::bigint = total_minor THEN 'paid'
+        WHEN paid_minor + sqlc.arg(amount)::bigint = 0 THEN 'issued'
+        ELSE 'partially_paid'
+    END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status IN ('issued', 'partially_paid', 'paid')
+  AND paid_minor + sqlc.arg(amount)::bigint BETWEEN 0 AND total_minor
+RETURNING *;
+
+-- name: CreatePayment :one
+INSERT INTO payments (invoice_id, kind, method, amount_minor, reference, refund_of, reason, received_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetPayment :one
+SELECT * FROM payments WHERE id = $1;
+
+-- name: ListPayments :many
+SELECT * FROM payments WHERE invoice_id = $1 ORDER BY created_at, id;
+
+-- name: GetRefundedAmount :one
+SELECT COALESCE(SUM(amount_minor), 0)::bigint FROM payments WHERE refund_of = $1;
+
+-- name: GetPatientBalance :one
+SELECT COALESCE(SUM(total_minor - paid_minor), 0)::bigint AS outstanding_minor,
+       COALESCE(SUM(total_minor - paid_minor) FILTER (WHERE due_date < sqlc.arg(today)::date), 0)::bigint AS overdue_minor,
+       COUNT(*)::int AS open_invoices
+FROM invoices
+WHERE patient_id = sqlc.arg(patient_id) AND currency = sqlc.arg(currency) AND status IN ('issued', 'partially_paid');
+
+-- Patients who owe at least min_balance, largest balance first.
+-- name: ListOutstandingBalances :many
+SELECT patient_id,
+       SUM(total_minor - paid_minor)::bigint AS outstanding_minor,
+       COALESCE(SUM(total_minor - paid_minor) FILTER (WHERE due_date < sqlc.arg(today)::date), 0)::bigint AS overdue_minor,
+       COUNT(*)::int AS open_invoices
+FROM invoices
+WHERE currency = sqlc.arg(currency) AND status IN ('issued', 'partially_paid')
+GROUP BY patient_id
+HAVING SUM(total_minor - paid_minor) >= sqlc.arg(min_balance)::bigint
+ORDER BY outstanding_minor DESC, patient_id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,26 @@
+-- name: CreateCalendarToken :one
+INSERT INTO calendar_tokens (doctor_id, token_hash)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetCalendarTokenByHash :one
+SELECT * FROM calendar_tokens
+WHERE token_hash = $1 AND revoked_at IS NULL;
+
+-- name: ListCalendarTokens :many
+SELECT * FROM calendar_tokens
+WHERE doctor_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeCalendarToken :execrows
+UPDATE calendar_tokens
+SET revoked_at = NOW()
+WHERE id = $1 AND doctor_id = $2 AND revoked_at IS NULL;
+
+-- name: TouchCalendarToken :exec
+UPDATE calendar_tokens
+SET last_used_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteRevokedCalendarTokens :execrows
+DELETE FROM calendar_tokens WHERE revoked_at < $1;
@@ -0,0 +1,71 @@
+-- name: AddCareTeamMember :one
+INSERT INTO care_team_members (patient_id, doctor_id, source, added_by)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (patient_id, doctor_id, source) DO UPDATE SET added_by = care_team_members.added_by
+RETURNING id, patient_id, doctor_id, source, added_by, created_at;
+
+-- name: RemoveCareTeamMember :execrows
+DELETE FROM care_team_members
+WHERE patient_id = $1 AND doctor_id = $2 AND source = $3;
+
+-- name: ListCareTeam :many
+SELECT m.doctor_id, m.source, m.created_at AS since,
+       u.first_name || ' ' || u.last_name AS doctor_name
+FROM care_team_members m
+JOIN users u ON m.doctor_id = u.id
+WHERE m.patient_id = $1
+UNION ALL
+SELECT a.doctor_id, 'appointment' AS source, MIN(a.created_at)::timestamptz AS since,
+       u.first_name || ' ' || u.last_name AS doctor_name
+FROM appointments a
+JOIN users u ON a.doctor_id = u.id
+WHERE a.patient_id = $1 AND COALESCE(a.status, 'scheduled') NOT IN ('cancelled', 'no_show')
+GROUP BY a.doctor_id, u.first_name, u.last_name
+ORDER BY doctor_name, source;
+
+-- name: DoctorHasPatientAccess :one
+SELECT EXISTS (
+    SELECT 1 FROM care_team_members m
+    WHERE m.doctor_id = sqlc.arg(doctor_id)::int AND m.patient_id = sqlc.arg(patient_id)::int
+) OR EXISTS (
+    SELECT 1 FROM appointments a
+    WHERE a.doctor_id = sqlc.arg(doctor_id)::int AND a.patient_id = sqlc.arg(patient_id)::int
+      AND COALESCE(a.status, 'scheduled') NOT IN ('cancelled', 'no_show')
+) AS has_access;
+
+-- name: GetPatientsForDoctor :many
+SELECT p.id, p.first_name, p.last_name, p.email, p.phone, p.date_of_birth, p.gender,
+       p.address, p.medical_history, p.allergies, p.emergency_contact_name,
+       p.emergency_contact_phone, p.created_by, p.created_at, p.updated_at,
+       p.email_bidx, p.phone_bidx
+FROM patients p
+WHERE EXISTS (
+    SELECT 1 FROM care_team_members m WHERE m.patient_id = p.id AND m.doctor_id = sqlc.arg(doctor_id)::int
+) OR EXISTS (
+    SELECT 1 FROM appointments a WHERE a.patient_id = p.id AND a.doctor_id = sqlc.arg(doctor_id)::int
+      AND COALESCE(a.status, 'scheduled') NOT IN ('cancelled', 'no_show')
+)
+ORDER BY p.created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: SearchPatientsForDoctor :many
+SELECT p.id, p.first_name, p.last_name, p.email, p.phone, p.date_of_birth, p.gender,
+       p.address, p.medical_history, p.allergies, p.emergency_contact_name,
+       p.emergency_contact_phone, p.created_by, p.created_at, p.updated_at,
+       p.email_bidx, p.phone_bidx
+FROM patients p
+WHERE (
+    LOWER(p.first_name) LIKE LOWER('%' || sqlc.arg(keyword)::text || '%') OR
+    LOWER(p.last_name) LIKE LOWER('%' || sqlc.arg(keyword)::text || '%') OR
+    p.email_bidx = sqlc.narg(email_bidx) OR
+    p.phone_bidx = sqlc.narg(phone_bidx)
+) AND (
+    EXISTS (
+        SELECT 1 FROM care_team_members m WHERE m.patient_id = p.id AND m.doctor_id = sqlc.arg(doctor_id)::int
+    ) OR EXISTS (
+        SELECT 1 FROM appointments a WHERE a.patient_id = p.id AND a.doctor_id = sqlc.arg(doctor_id)::int
+          AND COALESCE(a.status, 'scheduled') NOT IN ('cancelled', 'no_show')
+    )
+)
+ORDER BY p.created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
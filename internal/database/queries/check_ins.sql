@@ -0,0 +1,9 @@
+-- name: CreateCheckIn :one
+INSERT INTO appointment_check_ins (appointment_id, priority, checked_in_by)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListTodaysCheckIns :many
+SELECT c.* FROM appointment_check_ins c
+JOIN appointments a ON a.id = c.appointment_id
+WHERE DATE(a.appointment_date) = CURRENT_DATE;
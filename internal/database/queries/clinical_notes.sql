@@ -0,0 +1,67 @@
+-- name: CreateClinicalNote :one
+INSERT INTO clinical_notes (patient_id, appointment_id, author_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetClinicalNote :one
+SELECT n.id, n.patient_id, n.appointment_id, n.author_id, n.status, n.current_version,
+       n.signed_by, n.signed_at, n.created_at, n.updated_at,
+       v.subjective, v.objective, v.assessment, v.plan
+FROM clinical_notes n
+JOIN clinical_note_versions v ON v.note_id = n.id AND v.version = n.current_version
+WHERE n.id = $1;
+
+-- name: GetClinicalNoteByAppointment :one
+SELECT n.id, n.patient_id, n.appointment_id, n.author_id, n.status, n.current_version,
+       n.signed_by, n.signed_at, n.created_at, n.updated_at,
+       v.subjective, v.objective, v.assessment, v.plan
+FROM clinical_notes n
+JOIN clinical_note_versions v ON v.note_id = n.id AND v.version = n.current_version
+WHERE n.appointment_id = $1;
+
+-- name: ListPatientClinicalNotes :many
+SELECT n.id, n.patient_id, n.appointment_id, n.author_id, n.status, n.current_version,
+       n.signed_by, n.signed_at, n.created_at, n.updated_at,
+       v.subjective, v.objective, v.assessment, v.plan
+FROM clinical_notes n
+JOIN clinical_note_versions v ON v.note_id = n.id AND v.version = n.current_version
+WHERE n.patient_id = $1
+ORDER BY n.created_at DESC, n.id DESC;
+
+-- name: LockClinicalNote :one
+SELECT * FROM clinical_notes
+WHERE id = $1
+FOR UPDATE;
+
+-- name: SetClinicalNoteVersion :exec
+UPDATE clinical_notes SET current_version = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: SignClinicalNote :execrows
+UPDATE clinical_notes
+SET status = 'signed', signed_by = sqlc.arg(signed_by), signed_at = NOW()
+WHERE id = sqlc.arg(id) AND status = 'draft' AND current_version = sqlc.arg(version);
+
+-- name: CreateClinicalNoteVersion :one
+INSERT INTO clinical_note_versions (note_id, version, subjective, objective, assessment, plan, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListClinicalNoteVersions :many
+SELECT * FROM clinical_note_versions
+WHERE note_id = $1
+ORDER BY version;
+
+-- name: CreateClinicalNoteAddendum :one
+INSERT INTO clinical_note_addenda (note_id, body, created_by)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListClinicalNoteAddenda :many
+SELECT * FROM clinical_note_addenda
+WHERE note_id = $1
+ORDER BY created_at, id;
+
+-- name: SetAppointmentClinicalSummary :exec
+UPDATE appointments SET diagnosis = $2, treatment_plan = $3, updated_at = NOW()
+WHERE id = $1;
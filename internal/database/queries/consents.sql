@@ -0,0 +1,64 @@
+-- Adds the next version of the purpose's template.
+-- name: CreateConsentTemplate :one
+INSERT INTO consent_templates (purpose, version, title, body, validity_days, created_by)
+SELECT sqlc.arg(purpose)::text, COALESCE(MAX(version), 0) + 1, sqlc.arg(title)::text, sqlc.arg(body)::text,
+       sqlc.narg(validity_days)::int, sqlc.narg(created_by)::int
+FROM consent_templates WHERE purpose = sqlc.arg(purpose)::text
+RETURNING *;
+
+-- name: GetConsentTemplate :one
+SELECT * FROM consent_templates WHERE id = $1;
+
+-- name: GetCurrentConsentTemplate :one
+SELECT * FROM consent_templates WHERE purpose = $1 ORDER BY version DESC LIMIT 1;
+
+-- name: ListConsentTemplates :many
+SELECT * FROM consent_templates
+WHERE sqlc.narg(purpose)::text IS NULL OR purpose = sqlc.narg(purpose)
+ORDER BY purpose, version DESC;
+
+-- name: CreatePatientConsent :one
+INSERT INTO patient_consents (patient_id, template_id, purpose, signed_by, signed_at, witness_name, recorded_by, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetPatientConsent :one
+SELECT c.*, t.version AS template_version, t.title AS template_title,
+       u.first_name || ' ' || u.last_name AS recorded_by_name
+FROM patient_consents c
+JOIN consent_templates t ON t.id = c.template_id
+LEFT JOIN users u ON u.id = c.recorded_by
+WHERE c.id = $1;
+
+-- name: ListPatientConsents :many
+SELECT c.*, t.version AS template_version, t.title AS template_title,
+       u.first_name || ' ' || u.last_name AS recorded_by_name
+FROM patient_consents c
+JOIN consent_templates t ON t.id = c.template_id
+LEFT JOIN users u ON u.id = c.recorded_by
+WHERE c.patient_id = $1
+ORDER BY c.created_at DESC, c.id DESC;
+
+-- The most recently recorded consent for the purpose, whether or not it
+-- is still in force.
+-- name: GetLatestPatientConsent :one
+SELECT c.*, t.version AS template_version, t.title AS template_title,
+       u.first_name || ' ' || u.last_name AS recorded_by_name
+FROM patient_consents c
+JOIN consent_templates t ON t.id = c.template_id
+LEFT JOIN users u ON u.id = c.recorded_by
+WHERE c.patient_id = $1 AND c.purpose = $2
+ORDER BY c.created_at DESC, c.id DESC
+LIMIT 1;
+
+-- name: RevokePatientConsent :execrows
+UPDATE patient_consents
+SET revoked_at = NOW(), revoked_by = $2, revocation_reason = $3
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- Revokes whatever consent for the purpose is unrevoked, before a new one
+-- is recorded.
+-- name: SupersedePatientConsent :exec
+UPDATE patient_consents
+SET revoked_at = NOW(), revoked_by = $3, revocation_reason = $4
+WHERE patient_id = $1 AND purpose = $2 AND revoked_at IS NULL;
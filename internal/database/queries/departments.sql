@@ -0,0 +1,73 @@
+-- name: CreateDepartment :one
+INSERT INTO departments (name, description)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetDepartment :one
+SELECT * FROM departments WHERE id = $1;
+
+-- name: ListDepartments :many
+SELECT * FROM departments ORDER BY name;
+
+-- name: UpdateDepartment :one
+UPDATE departments SET name = $2, description = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteDepartment :execrows
+DELETE FROM departments WHERE id = $1;
+
+-- name: AddDepartmentDoctor :exec
+INSERT INTO department_doctors (department_id, doctor_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveDepartmentDoctor :execrows
+DELETE FROM department_doctors WHERE department_id = $1 AND doctor_id = $2;
+
+-- name: IsDepartmentDoctor :one
+SELECT EXISTS (
+    SELECT 1 FROM department_doctors WHERE department_id = $1 AND doctor_id = $2
+);
+
+-- name: ListDepartmentDoctors :many
+SELECT u.id, u.first_name, u.last_name, p.specialty
+FROM department_doctors d
+JOIN users u ON u.id = d.doctor_id
+LEFT JOIN doctor_profiles p ON p.doctor_id = u.id
+WHERE d.department_id = $1
+ORDER BY u.first_name, u.last_name;
+
+-- name: CreateSpecialty :one
+INSERT INTO specialties (name, department_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpsertSpecialty :one
+INSERT INTO specialties (name)
+VALUES ($1)
+ON CONFLICT ((LOWER(name))) DO UPDATE SET name = specialties.name
+RETURNING *;
+
+-- name: ListSpecialties :many
+SELECT * FROM specialties
+WHERE sqlc.narg(department_id)::int IS NULL OR department_id = sqlc.narg(department_id)::int
+ORDER BY name;
+
+-- name: DeleteSpecialty :execrows
+DELETE FROM specialties WHERE id = $1;
+
+-- name: AddDoctorSpecialty :exec
+INSERT INTO doctor_specialties (doctor_id, specialty_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveDoctorSpecialty :execrows
+DELETE FROM doctor_specialties WHERE doctor_id = $1 AND specialty_id = $2;
+
+-- name: ListDoctorSpecialties :many
+SELECT s.id, s.name, s.department_id
+FROM doctor_specialties ds
+JOIN specialties s ON s.id = ds.specialty_id
+WHERE ds.doctor_id = $1
+ORDER BY s.name;
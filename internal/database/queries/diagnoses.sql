@@ -0,0 +1,50 @@
+-- name: CreateEncounterDiagnosis :one
+INSERT INTO encounter_diagnoses (appointment_id, patient_id, code, rank, status, notes, recorded_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetEncounterDiagnosis :one
+SELECT d.id, d.appointment_id, d.patient_id, d.code, d.rank, d.status, d.notes, d.recorded_by, d.created_at, d.updated_at, c.description
+FROM encounter_diagnoses d
+JOIN icd10_codes c ON c.code = d.code
+WHERE d.id = $1;
+
+-- name: ListAppointmentDiagnoses :many
+SELECT d.id, d.appointment_id, d.patient_id, d.code, d.rank, d.status, d.notes, d.recorded_by, d.created_at, d.updated_at, c.description
+FROM encounter_diagnoses d
+JOIN icd10_codes c ON c.code = d.code
+WHERE d.appointment_id = $1
+ORDER BY d.rank, d.created_at, d.id;
+
+-- name: UpdateEncounterDiagnosis :one
+UPDATE encounter_diagnoses
+SET rank = $2, status = $3, notes = $4, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteEncounterDiagnosis :execrows
+DELETE FROM encounter_diagnoses WHERE id = $1;
+
+-- One row per code the patient was ever diagnosed with, in the state the
+-- latest encounter left it. Codes ruled out last time are left out unless
+-- asked for by status.
+-- name: ListPatientProblems :many
+WITH history AS (
+    SELECT d.code, d.status, d.appointment_id, a.appointment_date,
+           MIN(a.appointment_date) OVER (PARTITION BY d.code) AS first_recorded,
+           COUNT(*) OVER (PARTITION BY d.code) AS encounters,
+           ROW_NUMBER() OVER (PARTITION BY d.code ORDER BY a.appointment_date DESC, d.updated_at DESC) AS n
+    FROM encounter_diagnoses d
+    JOIN appointments a ON a.id = d.appointment_id
+    WHERE d.patient_id = sqlc.arg(patient_id)
+)
+SELECT h.code, c.description, h.status,
+       h.first_recorded::timestamptz AS first_recorded,
+       h.appointment_date AS last_recorded,
+       h.appointment_id AS last_appointment_id,
+       h.encounters::integer AS encounters
+FROM history h
+JOIN icd10_codes c ON c.code = h.code
+WHERE h.n = 1
+  AND (sqlc.narg(status)::text IS NULL AND h.status <> 'ruled_out' OR h.status = sqlc.narg(status))
+ORDER BY h.appointment_date DESC, h.code;
@@ -0,0 +1,39 @@
+-- name: CreateEmergencyAccessGrant :one
+INSERT INTO emergency_access_grants (patient_id, doctor_id, justification, expires_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, patient_id, doctor_id, justification, granted_at, expires_at,
+          review_status, reviewed_by, reviewed_at, review_notes;
+
+-- name: GetActiveEmergencyAccessGrant :one
+SELECT id, patient_id, doctor_id, justification, granted_at, expires_at,
+       review_status, reviewed_by, reviewed_at, review_notes
+FROM emergency_access_grants
+WHERE doctor_id = $1 AND patient_id = $2 AND expires_at > NOW()
+ORDER BY expires_at DESC
+LIMIT 1;
+
+-- name: GetEmergencyAccessGrant :one
+SELECT id, patient_id, doctor_id, justification, granted_at, expires_at,
+       review_status, reviewed_by, reviewed_at, review_notes
+FROM emergency_access_grants
+WHERE id = $1;
+
+-- name: ListEmergencyAccessGrants :many
+SELECT
+    g.id, g.patient_id, g.doctor_id, g.justification, g.granted_at, g.expires_at,
+    g.review_status, g.reviewed_by, g.reviewed_at, g.review_notes,
+    p.first_name || ' ' || p.last_name as patient_name,
+    u.first_name || ' ' || u.last_name as doctor_name
+FROM emergency_access_grants g
+JOIN patients p ON g.patient_id = p.id
+JOIN users u ON g.doctor_id = u.id
+WHERE sqlc.narg(review_status)::varchar IS NULL OR g.review_status = sqlc.narg(review_status)
+ORDER BY g.granted_at
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ReviewEmergencyAccessGrant :one
+UPDATE emergency_access_grants
+SET review_status = $2, reviewed_by = $3, reviewed_at = NOW(), review_notes = $4
+WHERE id = $1 AND review_status = 'pending'
+RETURNING id, patient_id, doctor_id, justification, granted_at, expires_at,
+          review_status, reviewed_by, reviewed_at, review_notes;
@@ -0,0 +1,26 @@
+-- name: ListEncryptionKeys :many
+SELECT id, wrapped_key, master_key_id, created_at, retired_at
+FROM encryption_keys
+ORDER BY id;
+
+-- The key new values are sealed with: the newest one not retired.
+-- name: GetActiveEncryptionKeyID :one
+SELECT id FROM encryption_keys
+WHERE retired_at IS NULL
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: CreateEncryptionKey :one
+INSERT INTO encryption_keys (wrapped_key, master_key_id)
+VALUES ($1, $2)
+RETURNING id, wrapped_key, master_key_id, created_at, retired_at;
+
+-- name: RewrapEncryptionKey :exec
+UPDATE encryption_keys
+SET wrapped_key = $2, master_key_id = $3
+WHERE id = $1;
+
+-- name: RetireEncryptionKeysExcept :exec
+UPDATE encryption_keys
+SET retired_at = NOW()
+WHERE id <> $1 AND retired_at IS NULL;
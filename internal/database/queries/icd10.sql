@@ -0,0 +1,27 @@
+-- name: DeactivateIcd10Codes :execrows
+UPDATE icd10_codes SET active = FALSE WHERE active;
+
+-- name: UpsertIcd10Codes :execrows
+INSERT INTO icd10_codes (code, description, billable)
+SELECT unnest(sqlc.arg(codes)::text[]), unnest(sqlc.arg(descriptions)::text[]), unnest(sqlc.arg(billable)::boolean[])
+ON CONFLICT (code) DO UPDATE
+SET description = EXCLUDED.description, billable = EXCLUDED.billable, active = TRUE;
+
+-- name: GetIcd10Code :one
+SELECT code, description, billable, active FROM icd10_codes WHERE code = $1;
+
+-- name: SearchIcd10CodesByPrefix :many
+SELECT code, description, billable, active FROM icd10_codes
+WHERE code LIKE sqlc.arg(prefix)::text || '%'
+  AND active
+  AND (sqlc.narg(billable)::boolean IS NULL OR billable = sqlc.narg(billable))
+ORDER BY code
+LIMIT sqlc.arg(max_results);
+
+-- name: SearchIcd10CodesByText :many
+SELECT code, description, billable, active FROM icd10_codes
+WHERE search @@ to_tsquery('english', sqlc.arg(query))
+  AND active
+  AND (sqlc.narg(billable)::boolean IS NULL OR billable = sqlc.narg(billable))
+ORDER BY ts_rank(search, to_tsquery('english', sqlc.arg(query))) DESC, code
+LIMIT sqlc.arg(max_results);
@@ -0,0 +1,169 @@
+-- name: CreateWard :one
+INSERT INTO wards (name, department_id, active)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateWard :one
+UPDATE wards
+SET name = $2, department_id = $3, active = $4, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetWard :one
+SELECT * FROM wards WHERE id = $1;
+
+-- name: ListWards :many
+SELECT * FROM wards
+WHERE sqlc.narg(active)::boolean IS NULL OR active = sqlc.narg(active)
+ORDER BY name;
+
+-- name: CreateBed :one
+INSERT INTO beds (ward_id, label)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: RenameBed :one
+UPDATE beds SET label = $2 WHERE id = $1
+RETURNING *;
+
+-- name: GetBed :one
+SELECT * FROM beds WHERE id = $1;
+
+-- name: ListWardBeds :many
+SELECT * FROM beds WHERE ward_id = $1 ORDER BY label;
+
+-- Occupancy is managed by admissions, so an occupied bed cannot be
+-- changed here and no bed is made occupied.
+-- name: SetBedStatus :one
+UPDATE beds
+SET status = sqlc.arg(status), status_note = sqlc.narg(status_note), status_changed_at = NOW()
+WHERE id = sqlc.arg(id) AND status <> 'occupied' AND sqlc.arg(status) <> 'occupied'
+RETURNING *;
+
+-- OccupyBed takes a free bed in an active ward. The row lock taken by the
+-- update makes a concurrent admission to the same bed wait and then find
+-- it occupied.
+-- name: OccupyBed :execrows
+UPDATE beds
+SET status = 'occupied', status_note = NULL, status_changed_at = NOW()
+WHERE id = $1 AND status = 'free'
+  AND EXISTS (SELECT 1 FROM wards w WHERE w.id = beds.ward_id AND w.active);
+
+-- A bed a patient has left needs cleaning before it is given out again.
+-- name: VacateBed :exec
+UPDATE beds
+SET status = 'cleaning', status_note = NULL, status_changed_at = NOW()
+WHERE id = $1 AND status = 'occupied';
+
+-- name: CreateAdmission :one
+INSERT INTO admissions (patient_id, attending_doctor_id, appointment_id, reason, admitted_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: LockAdmission :one
+SELECT * FROM admissions WHERE id = $1 FOR UPDATE;
+
+-- name: SetAttendingDoctor :exec
+UPDATE admissions SET attending_doctor_id = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: DischargeAdmission :one
+UPDATE admissions
+SET status = 'discharged', discharged_at = NOW(), discharged_by = $2,
+    discharge_disposition = $3, discharge_notes = $4, updated_at = NOW()
+WHERE id = $1 AND status = 'admitted'
+RETURNING *;
+
+-- name: GetAdmission :one
+SELECT a.*,
+       p.first_name || ' ' || p.last_name AS patient_name,
+       u.first_name || ' ' || u.last_name AS attending_doctor_name,
+       s.bed_id, b.label AS bed_label, b.ward_id, w.name AS ward_name
+FROM admissions a
+JOIN patients p ON p.id = a.patient_id
+JOIN users u ON u.id = a.attending_doctor_id
+LEFT JOIN bed_stays s ON s.admission_id = a.id AND s.ended_at IS NULL
+LEFT JOIN beds b ON b.id = s.bed_id
+LEFT JOIN wards w ON w.id = b.ward_id
+WHERE a.id = $1;
+
+-- care_doctor_id limits the list to patients under the doctor's care: on
+-- their care team, with one of their appointments, or admitted under
+-- them.
+-- name: ListAdmissions :many
+SELECT a.*,
+       p.first_name || ' ' || p.last_name AS patient_name,
+       u.first_name || ' ' || u.last_name AS attending_doctor_name,
+       s.bed_id, b.label AS bed_label, b.ward_id, w.name AS ward_name
+FROM admissions a
+JOIN patients p ON p.id = a.patient_id
+JOIN users u ON u.id = a.attending_doctor_id
+LEFT JOIN bed_stays s ON s.admission_id = a.id AND s.ended_at IS NULL
+LEFT JOIN beds b ON b.id = s.bed_id
+LEFT JOIN wards w ON w.id = b.ward_id
+WHERE (sqlc.narg(status)::text IS NULL OR a.status = sqlc.narg(status))
+  AND (sqlc.narg(ward_id)::int IS NULL OR b.ward_id = sqlc.narg(ward_id))
+  AND (sqlc.narg(patient_id)::int IS NULL OR a.patient_id = sqlc.narg(patient_id))
+  AND (sqlc.narg(care_doctor_id)::int IS NULL
+       OR a.attending_doctor_id = sqlc.narg(care_doctor_id)
+       OR EXISTS (SELECT 1 FROM care_team_members m WHERE m.patient_id = a.patient_id AND m.doctor_id = sqlc.narg(care_doctor_id))
+       OR EXISTS (SELECT 1 FROM appointments ap WHERE ap.patient_id = a.patient_id AND ap.doctor_id = sqlc.narg(care_doctor_id)
+                   AND COALESCE(ap.status, 'scheduled') NOT IN ('cancelled', 'no_show')))
+ORDER BY a.admitted_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CreateBedStay :one
+INSERT INTO bed_stays (admission_id, bed_id, reason, assigned_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: EndBedStay :one
+UPDATE bed_stays SET ended_at = NOW()
+WHERE admission_id = $1 AND ended_at IS NULL
+RETURNING *;
+
+-- name: ListAdmissionStays :many
+SELECT s.*, b.label AS bed_label, b.ward_id, w.name AS ward_name
+FROM bed_stays s
+JOIN beds b ON b.id = s.bed_id
+JOIN wards w ON w.id = b.ward_id
+WHERE s.admission_id = $1
+ORDER BY s.started_at, s.id;
+
+-- name: ListBoardBeds :many
+SELECT b.*, w.name AS ward_name,
+       a.id AS admission_id, a.patient_id,
+       p.first_name || ' ' || p.last_name AS patient_name,
+       a.attending_doctor_id, a.admitted_at, s.started_at AS in_bed_since
+FROM beds b
+JOIN wards w ON w.id = b.ward_id
+LEFT JOIN bed_stays s ON s.bed_id = b.id AND s.ended_at IS NULL
+LEFT JOIN admissions a ON a.id = s.admission_id
+LEFT JOIN patients p ON p.id = a.patient_id
+WHERE w.active AND (sqlc.narg(ward_id)::int IS NULL OR w.id = sqlc.narg(ward_id))
+ORDER BY w.name, b.label;
+
+-- WardCensus counts, for each active ward, the patients in it at the
+-- end of the period from since to until and the movements during it. A
+-- stay whose previous bed was in the same ward is a bed move, not a
+-- transfer in, and one followed by no stay is a discharge.
+-- name: WardCensus :many
+WITH stays AS (
+    SELECT b.ward_id, s.started_at, s.ended_at,
+           LAG(b.ward_id) OVER (PARTITION BY s.admission_id ORDER BY s.started_at, s.id) AS previous_ward_id,
+           LEAD(b.ward_id) OVER (PARTITION BY s.admission_id ORDER BY s.started_at, s.id) AS next_ward_id
+    FROM bed_stays s
+    JOIN beds b ON b.id = s.bed_id
+)
+SELECT w.id AS ward_id, w.name AS ward_name,
+       (SELECT COUNT(*) FROM beds b WHERE b.ward_id = w.id AND b.status <> 'blocked')::bigint AS beds,
+       COUNT(s.ward_id) FILTER (WHERE s.started_at < sqlc.arg(until)::timestamptz AND (s.ended_at IS NULL OR s.ended_at >= sqlc.arg(until)::timestamptz))::bigint AS census,
+       COUNT(s.ward_id) FILTER (WHERE s.previous_ward_id IS NULL AND s.started_at >= sqlc.arg(since)::timestamptz AND s.started_at < sqlc.arg(until)::timestamptz)::bigint AS admissions,
+       COUNT(s.ward_id) FILTER (WHERE s.previous_ward_id <> w.id AND s.started_at >= sqlc.arg(since)::timestamptz AND s.started_at < sqlc.arg(until)::timestamptz)::bigint AS transfers_in,
+       COUNT(s.ward_id) FILTER (WHERE s.next_ward_id <> w.id AND s.ended_at < sqlc.arg(until)::timestamptz)::bigint AS transfers_out,
+       COUNT(s.ward_id) FILTER (WHERE s.next_ward_id IS NULL AND s.ended_at < sqlc.arg(until)::timestamptz)::bigint AS discharges
+FROM wards w
+LEFT JOIN stays s ON s.ward_id = w.id AND (s.ended_at IS NULL OR s.ended_at >= sqlc.arg(since)::timestamptz)
+WHERE w.active
+GROUP BY w.id, w.name
+ORDER BY w.name;
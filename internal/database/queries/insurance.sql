@@ -0,0 +1,159 @@
+-- name: CreateInsurancePayer :one
+INSERT INTO insurance_payers (name, payer_code, phone, email, active)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: UpdateInsurancePayer :one
+UPDATE insurance_payers
+SET name = $2, payer_code = $3, phone = $4, email = $5, active = $6, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetInsurancePayer :one
+SELECT * FROM insurance_payers WHERE id = $1;
+
+-- name: ListInsurancePayers :many
+SELECT * FROM insurance_payers
+WHERE (sqlc.narg(active)::boolean IS NULL OR active = sqlc.narg(active))
+ORDER BY name;
+
+-- name: CreatePatientCoverage :one
+INSERT INTO patient_coverages (patient_id, payer_id, priority, policy_number, member_number, group_number,
+                               subscriber_name, relationship, valid_from, valid_to, copay_minor, coinsurance_bp, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING *;
+
+-- name: UpdatePatientCoverage :one
+UPDATE patient_coverages
+SET payer_id = $2, priority = $3, policy_number = $4, member_number = $5, group_number = $6,
+    subscriber_name = $7, relationship = $8, valid_from = $9, valid_to = $10, copay_minor = $11,
+    coinsurance_bp = $12, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetPatientCoverage :one
+SELECT c.*, p.name AS payer_name, p.payer_code
+FROM patient_coverages c
+JOIN insurance_payers p ON p.id = c.payer_id
+WHERE c.id = $1;
+
+-- Coverage still in force first, by priority, then lapsed policies.
+-- name: ListPatientCoverages :many
+SELECT c.*, p.name AS payer_name, p.payer_code
+FROM patient_coverages c
+JOIN insurance_payers p ON p.id = c.payer_id
+WHERE c.patient_id = $1
+ORDER BY (c.valid_to IS NULL OR c.valid_to >= CURRENT_DATE) DESC, c.priority, c.valid_from DESC;
+
+-- The patient's policies with an active payer covering day, primary first.
+-- name: ListCoveragesInForce :many
+SELECT c.*, p.name AS payer_name, p.payer_code
+FROM patient_coverages c
+JOIN insurance_payers p ON p.id = c.payer_id
+WHERE c.patient_id = sqlc.arg(patient_id) AND p.active
+  AND c.valid_from <= sqlc.arg(day)::date
+  AND (c.valid_to IS NULL OR c.valid_to >= sqlc.arg(day)::date)
+ORDER BY c.priority;
+
+-- name: DeletePatientCoverage :execrows
+DELETE FROM patient_coverages WHERE id = $1;
+
+-- name: CreateClaim :one
+INSERT INTO claims (invoice_id, patient_id, coverage_id, payer_id, service_date, billed_minor, copay_minor,
+                    coinsurance_bp, payer_share_minor, patient_share_minor, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetClaim :one
+SELECT c.*, p.name AS payer_name
+FROM claims c
+JOIN insurance_payers p ON p.id = c.payer_id
+WHERE c.id = $1;
+
+-- name: LockClaim :one
+SELECT * FROM claims WHERE id = $1 FOR UPDATE;
+
+-- name: ListClaims :many
+SELECT c.*, p.name AS payer_name
+FROM claims c
+JOIN insurance_payers p ON p.id = c.payer_id
+WHERE (sqlc.narg(status)::text IS NULL OR c.status = sqlc.narg(status))
+  AND (sqlc.narg(payer_id)::int IS NULL OR c.payer_id = sqlc.narg(payer_id))
+  AND (sqlc.narg(invoice_id)::int IS NULL OR c.invoice_id = sqlc.narg(invoice_id))
+  AND (sqlc.narg(patient_id)::int IS NULL OR c.patient_id = sqlc.narg(patient_id))
+  AND (sqlc.narg(batch_id)::int IS NULL OR c.batch_id = sqlc.narg(batch_id))
+ORDER BY c.created_at DESC, c.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListBatchClaims :many
+SELECT c.*, p.name AS payer_name
+FROM claims c
+JOIN insurance_payers p ON p.id = c.payer_id
+WHERE c.batch_id = $1
+ORDER BY c.id;
+
+-- What the payers of the invoice's other claims are expected to pay.
+-- name: GetExpectedPayerShare :one
+SELECT COALESCE(SUM(payer_share_minor), 0)::bigint FROM claims
+WHERE invoice_id = $1 AND status NOT IN ('denied', 'cancelled');
+
+-- name: ListClaimLines :many
+SELECT l.id, l.description, l.quantity, l.unit_price_minor, l.total_minor, s.code AS service_code
+FROM invoice_lines l
+LEFT JOIN billable_services s ON s.id = l.service_id
+WHERE l.invoice_id = $1
+ORDER BY l.id;
+
+-- name: SubmitDraftClaims :execrows
+UPDATE claims
+SET status = 'submitted', batch_id = sqlc.arg(batch_id), submitted_at = NOW(), updated_at = NOW()
+WHERE payer_id = sqlc.arg(payer_id) AND status = 'draft';
+
+-- A payer share of zero is a denial.
+-- name: AdjudicateClaim :one
+UPDATE claims
+SET status = sqlc.arg(status), payer_share_minor = sqlc.arg(payer_share_minor),
+    patient_share_minor = billed_minor - sqlc.arg(payer_share_minor),
+    payer_reference = sqlc.narg(payer_reference), denial_reason = sqlc.narg(denial_reason),
+    adjudicated_at = NOW(),
+    closed_at = CASE WHEN sqlc.arg(status) = 'denied' THEN NOW() END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND status = 'submitted'
+RETURNING *;
+
+-- name: CancelClaim :one
+UPDATE claims
+SET status = 'cancelled', closed_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status IN ('draft', 'submitted')
+RETURNING *;
+
+-- name: PayClaim :one
+UPDATE claims
+SET status = 'paid', paid_minor = $2, payment_id = $3, closed_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'adjudicated'
+RETURNING *;
+
+-- name: CreateClaimBatch :one
+INSERT INTO claim_batches (payer_id, created_by)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetClaimBatch :one
+SELECT b.id, b.payer_id, b.created_by, b.created_at, p.name AS payer_name, p.payer_code,
+       COUNT(c.id) AS claim_count, COALESCE(SUM(c.billed_minor), 0)::bigint AS billed_minor
+FROM claim_batches b
+JOIN insurance_payers p ON p.id = b.payer_id
+LEFT JOIN claims c ON c.batch_id = b.id
+WHERE b.id = $1
+GROUP BY b.id, p.id;
+
+-- name: ListClaimBatches :many
+SELECT b.id, b.payer_id, b.created_by, b.created_at, p.name AS payer_name, p.payer_code,
+       COUNT(c.id) AS claim_count, COALESCE(SUM(c.billed_minor), 0)::bigint AS billed_minor
+FROM claim_batches b
+JOIN insurance_payers p ON p.id = b.payer_id
+LEFT JOIN claims c ON c.batch_id = b.id
+WHERE (sqlc.narg(payer_id)::int IS NULL OR b.payer_id = sqlc.narg(payer_id))
+GROUP BY b.id, p.id
+ORDER BY b.created_at DESC, b.id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,27 @@
+-- name: TryJobLock :one
+SELECT pg_try_advisory_lock(hashtext('job:' || sqlc.arg(job_name)::text));
+
+-- name: ReleaseJobLock :exec
+SELECT pg_advisory_unlock(hashtext('job:' || sqlc.arg(job_name)::text));
+
+-- name: StartJobRun :one
+INSERT INTO job_runs (job_name, scheduled_at)
+VALUES ($1, $2)
+ON CONFLICT (job_name, scheduled_at) DO NOTHING
+RETURNING id;
+
+-- name: FinishJobRun :exec
+UPDATE job_runs
+SET status = $2, detail = $3, error = $4, finished_at = NOW()
+WHERE id = $1;
+
+-- name: ListJobRuns :many
+SELECT * FROM job_runs
+WHERE (sqlc.narg(job_name)::text IS NULL OR job_name = sqlc.narg(job_name)::text)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY started_at DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListLatestJobRuns :many
+SELECT DISTINCT ON (job_name) * FROM job_runs
+ORDER BY job_name, started_at DESC;
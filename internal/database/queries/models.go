@@ -8,45 +8,563 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Admission struct {
+	ID                   int32              `db:"id" json:"id"`
+	PatientID            int32              `db:"patient_id" json:"patient_id"`
+	AttendingDoctorID    int32              `db:"attending_doctor_id" json:"attending_doctor_id"`
+	AppointmentID        *int32             `db:"appointment_id" json:"appointment_id"`
+	Reason               string             `db:"reason" json:"reason"`
+	Status               string             `db:"status" json:"status"`
+	AdmittedAt           pgtype.Timestamptz `db:"admitted_at" json:"admitted_at"`
+	AdmittedBy           *int32             `db:"admitted_by" json:"admitted_by"`
+	DischargedAt         pgtype.Timestamptz `db:"discharged_at" json:"discharged_at"`
+	DischargedBy         *int32             `db:"discharged_by" json:"discharged_by"`
+	DischargeDisposition *string            `db:"discharge_disposition" json:"discharge_disposition"`
+	DischargeNotes       *string            `db:"discharge_notes" json:"discharge_notes"`
+	CreatedAt            pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 type Appointment struct {
-	ID              int32            `db:"id" json:"id"`
-	PatientID       *int32           `db:"patient_id" json:"patient_id"`
-	DoctorID        *int32           `db:"doctor_id" json:"doctor_id"`
-	AppointmentDate pgtype.Timestamp `db:"appointment_date" json:"appointment_date"`
-	Status          *string          `db:"status" json:"status"`
-	Notes           *string          `db:"notes" json:"notes"`
-	Diagnosis       *string          `db:"diagnosis" json:"diagnosis"`
-	TreatmentPlan   *string          `db:"treatment_plan" json:"treatment_plan"`
-	CreatedBy       *int32           `db:"created_by" json:"created_by"`
-	CreatedAt       pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt       pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	ID              int32              `db:"id" json:"id"`
+	PatientID       *int32             `db:"patient_id" json:"patient_id"`
+	DoctorID        *int32             `db:"doctor_id" json:"doctor_id"`
+	AppointmentDate pgtype.Timestamptz `db:"appointment_date" json:"appointment_date"`
+	Status          *string            `db:"status" json:"status"`
+	Notes           *string            `db:"notes" json:"notes"`
+	Diagnosis       *string            `db:"diagnosis" json:"diagnosis"`
+	TreatmentPlan   *string            `db:"treatment_plan" json:"treatment_plan"`
+	CreatedBy       *int32             `db:"created_by" json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	EndTime         pgtype.Timestamptz `db:"end_time" json:"end_time"`
+	SeriesID        *int32             `db:"series_id" json:"series_id"`
+	DepartmentID    *int32             `db:"department_id" json:"department_id"`
+	RoomID          *int32             `db:"room_id" json:"room_id"`
+}
+
+type AppointmentCheckIn struct {
+	AppointmentID int32              `db:"appointment_id" json:"appointment_id"`
+	ArrivedAt     pgtype.Timestamptz `db:"arrived_at" json:"arrived_at"`
+	Priority      string             `db:"priority" json:"priority"`
+	CheckedInBy   *int32             `db:"checked_in_by" json:"checked_in_by"`
+}
+
+type AppointmentSeries struct {
+	ID              int32              `db:"id" json:"id"`
+	PatientID       int32              `db:"patient_id" json:"patient_id"`
+	DoctorID        *int32             `db:"doctor_id" json:"doctor_id"`
+	Rrule           string             `db:"rrule" json:"rrule"`
+	StartsAt        pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	DurationMinutes int32              `db:"duration_minutes" json:"duration_minutes"`
+	Notes           *string            `db:"notes" json:"notes"`
+	CreatedBy       *int32             `db:"created_by" json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	CancelledAt     pgtype.Timestamptz `db:"cancelled_at" json:"cancelled_at"`
+}
+
+type AppointmentStatusHistory struct {
+	ID            int32              `db:"id" json:"id"`
+	AppointmentID int32              `db:"appointment_id" json:"appointment_id"`
+	FromStatus    *string            `db:"from_status" json:"from_status"`
+	ToStatus      string             `db:"to_status" json:"to_status"`
+	Reason        *string            `db:"reason" json:"reason"`
+	ChangedBy     *int32             `db:"changed_by" json:"changed_by"`
+	ChangedAt     pgtype.Timestamptz `db:"changed_at" json:"changed_at"`
+}
+
+type AuditLog struct {
+	ID           int64              `db:"id" json:"id"`
+	OccurredAt   pgtype.Timestamptz `db:"occurred_at" json:"occurred_at"`
+	ActorID      *int32             `db:"actor_id" json:"actor_id"`
+	ActorRole    string             `db:"actor_role" json:"actor_role"`
+	Action       string             `db:"action" json:"action"`
+	ResourceType string             `db:"resource_type" json:"resource_type"`
+	ResourceID   *int32             `db:"resource_id" json:"resource_id"`
+	PatientID    *int32             `db:"patient_id" json:"patient_id"`
+	Changes      []byte             `db:"changes" json:"changes"`
+	IpAddress    string             `db:"ip_address" json:"ip_address"`
+	RequestID    string             `db:"request_id" json:"request_id"`
+	PrevHash     string             `db:"prev_hash" json:"prev_hash"`
+	Hash         string             `db:"hash" json:"hash"`
+}
+
+type AvailabilityBreak struct {
+	ID         int32       `db:"id" json:"id"`
+	TemplateID int32       `db:"template_id" json:"template_id"`
+	StartTime  pgtype.Time `db:"start_time" json:"start_time"`
+	EndTime    pgtype.Time `db:"end_time" json:"end_time"`
+}
+
+type AvailabilityException struct {
+	ID        int32              `db:"id" json:"id"`
+	DoctorID  int32              `db:"doctor_id" json:"doctor_id"`
+	StartsAt  pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	EndsAt    pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	Kind      string             `db:"kind" json:"kind"`
+	Reason    *string            `db:"reason" json:"reason"`
+	CreatedBy *int32             `db:"created_by" json:"created_by"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type AvailabilityTemplate struct {
+	ID          int32       `db:"id" json:"id"`
+	DoctorID    int32       `db:"doctor_id" json:"doctor_id"`
+	Weekday     int16       `db:"weekday" json:"weekday"`
+	StartTime   pgtype.Time `db:"start_time" json:"start_time"`
+	EndTime     pgtype.Time `db:"end_time" json:"end_time"`
+	SlotMinutes int32       `db:"slot_minutes" json:"slot_minutes"`
+}
+
+type Bed struct {
+	ID              int32              `db:"id" json:"id"`
+	WardID          int32              `db:"ward_id" json:"ward_id"`
+	Label           string             `db:"label" json:"label"`
+	Status          string             `db:"status" json:"status"`
+	StatusNote      *string            `db:"status_note" json:"status_note"`
+	StatusChangedAt pgtype.Timestamptz `db:"status_changed_at" json:"status_changed_at"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type BedStay struct {
+	ID          int32              `db:"id" json:"id"`
+	AdmissionID int32              `db:"admission_id" json:"admission_id"`
+	BedID       int32              `db:"bed_id" json:"bed_id"`
+	StartedAt   pgtype.Timestamptz `db:"started_at" json:"started_at"`
+	EndedAt     pgtype.Timestamptz `db:"ended_at" json:"ended_at"`
+	Reason      *string            `db:"reason" json:"reason"`
+	AssignedBy  *int32             `db:"assigned_by" json:"assigned_by"`
+}
+
+type BillableService struct {
+	ID           int32              `db:"id" json:"id"`
+	Code         string             `db:"code" json:"code"`
+	Name         string             `db:"name" json:"name"`
+	Description  *string            `db:"description" json:"description"`
+	Category     string             `db:"category" json:"category"`
+	DepartmentID *int32             `db:"department_id" json:"department_id"`
+	PriceMinor   int64              `db:"price_minor" json:"price_minor"`
+	TaxRateBp    int32              `db:"tax_rate_bp" json:"tax_rate_bp"`
+	Active       bool               `db:"active" json:"active"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type CalendarToken struct {
+	ID         int32              `db:"id" json:"id"`
+	DoctorID   int32              `db:"doctor_id" json:"doctor_id"`
+	TokenHash  []byte             `db:"token_hash" json:"token_hash"`
+	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	LastUsedAt pgtype.Timestamptz `db:"last_used_at" json:"last_used_at"`
+	RevokedAt  pgtype.Timestamptz `db:"revoked_at" json:"revoked_at"`
+}
+
+type CareTeamMember struct {
+	ID        int32              `db:"id" json:"id"`
+	PatientID int32              `db:"patient_id" json:"patient_id"`
+	DoctorID  int32              `db:"doctor_id" json:"doctor_id"`
+	Source    string             `db:"source" json:"source"`
+	AddedBy   *int32             `db:"added_by" json:"added_by"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type Claim struct {
+	ID                int32              `db:"id" json:"id"`
+	InvoiceID         int32              `db:"invoice_id" json:"invoice_id"`
+	PatientID         int32              `db:"patient_id" json:"patient_id"`
+	CoverageID        int32              `db:"coverage_id" json:"coverage_id"`
+	PayerID           int32              `db:"payer_id" json:"payer_id"`
+	BatchID           *int32             `db:"batch_id" json:"batch_id"`
+	Status            string             `db:"status" json:"status"`
+	ServiceDate       pgtype.Date        `db:"service_date" json:"service_date"`
+	BilledMinor       int64              `db:"billed_minor" json:"billed_minor"`
+	CopayMinor        int64              `db:"copay_minor" json:"copay_minor"`
+	CoinsuranceBp     int32              `db:"coinsurance_bp" json:"coinsurance_bp"`
+	PayerShareMinor   int64              `db:"payer_share_minor" json:"payer_share_minor"`
+	PatientShareMinor int64              `db:"patient_share_minor" json:"patient_share_minor"`
+	PaidMinor         int64              `db:"paid_minor" json:"paid_minor"`
+	PaymentID         *int32             `db:"payment_id" json:"payment_id"`
+	PayerReference    *string            `db:"payer_reference" json:"payer_reference"`
+	DenialReason      *string            `db:"denial_reason" json:"denial_reason"`
+	SubmittedAt       pgtype.Timestamptz `db:"submitted_at" json:"submitted_at"`
+	AdjudicatedAt     pgtype.Timestamptz `db:"adjudicated_at" json:"adjudicated_at"`
+	ClosedAt          pgtype.Timestamptz `db:"closed_at" json:"closed_at"`
+	CreatedBy         *int32             `db:"created_by" json:"created_by"`
+	CreatedAt         pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type ClaimBatch struct {
+	ID        int32              `db:"id" json:"id"`
+	PayerID   int32              `db:"payer_id" json:"payer_id"`
+	CreatedBy *int32             `db:"created_by" json:"created_by"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type ClinicalNote struct {
+	ID             int32              `db:"id" json:"id"`
+	PatientID      int32              `db:"patient_id" json:"patient_id"`
+	AppointmentID  *int32             `db:"appointment_id" json:"appointment_id"`
+	AuthorID       *int32             `db:"author_id" json:"author_id"`
+	Status         string             `db:"status" json:"status"`
+	CurrentVersion int32              `db:"current_version" json:"current_version"`
+	SignedBy       *int32             `db:"signed_by" json:"signed_by"`
+	SignedAt       pgtype.Timestamptz `db:"signed_at" json:"signed_at"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type ClinicalNoteAddendum struct {
+	ID        int32              `db:"id" json:"id"`
+	NoteID    int32              `db:"note_id" json:"note_id"`
+	Body      string             `db:"body" json:"body"`
+	CreatedBy int32              `db:"created_by" json:"created_by"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type ClinicalNoteVersion struct {
+	ID         int32              `db:"id" json:"id"`
+	NoteID     int32              `db:"note_id" json:"note_id"`
+	Version    int32              `db:"version" json:"version"`
+	Subjective *string            `db:"subjective" json:"subjective"`
+	Objective  *string            `db:"objective" json:"objective"`
+	Assessment *string            `db:"assessment" json:"assessment"`
+	Plan       *string            `db:"plan" json:"plan"`
+	CreatedBy  *int32             `db:"created_by" json:"created_by"`
+	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type ConsentTemplate struct {
+	ID           int32              `db:"id" json:"id"`
+	Purpose      string             `db:"purpose" json:"purpose"`
+	Version      int32              `db:"version" json:"version"`
+	Title        string             `db:"title" json:"title"`
+	Body         string             `db:"body" json:"body"`
+	ValidityDays *int32             `db:"validity_days" json:"validity_days"`
+	CreatedBy    *int32             `db:"created_by" json:"created_by"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type Department struct {
+	ID          int32              `db:"id" json:"id"`
+	Name        string             `db:"name" json:"name"`
+	Description *string            `db:"description" json:"description"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type DoctorProfile struct {
+	DoctorID  int32              `db:"doctor_id" json:"doctor_id"`
+	Specialty *string            `db:"specialty" json:"specialty"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type EmergencyAccessGrant struct {
+	ID            int32              `db:"id" json:"id"`
+	PatientID     int32              `db:"patient_id" json:"patient_id"`
+	DoctorID      int32              `db:"doctor_id" json:"doctor_id"`
+	Justification string             `db:"justification" json:"justification"`
+	GrantedAt     pgtype.Timestamptz `db:"granted_at" json:"granted_at"`
+	ExpiresAt     pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	ReviewStatus  string             `db:"review_status" json:"review_status"`
+	ReviewedBy    *int32             `db:"reviewed_by" json:"reviewed_by"`
+	ReviewedAt    pgtype.Timestamptz `db:"reviewed_at" json:"reviewed_at"`
+	ReviewNotes   *string            `db:"review_notes" json:"review_notes"`
+}
+
+type EncounterDiagnosis struct {
+	ID            int32              `db:"id" json:"id"`
+	AppointmentID int32              `db:"appointment_id" json:"appointment_id"`
+	PatientID     int32              `db:"patient_id" json:"patient_id"`
+	Code          string             `db:"code" json:"code"`
+	Rank          string             `db:"rank" json:"rank"`
+	Status        string             `db:"status" json:"status"`
+	Notes         *string            `db:"notes" json:"notes"`
+	RecordedBy    *int32             `db:"recorded_by" json:"recorded_by"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type EncryptionKey struct {
+	ID          int32              `db:"id" json:"id"`
+	WrappedKey  []byte             `db:"wrapped_key" json:"wrapped_key"`
+	MasterKeyID string             `db:"master_key_id" json:"master_key_id"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	RetiredAt   pgtype.Timestamptz `db:"retired_at" json:"retired_at"`
+}
+
+type Equipment struct {
+	ID           int32  `db:"id" json:"id"`
+	Name         string `db:"name" json:"name"`
+	DepartmentID *int32 `db:"department_id" json:"department_id"`
+	Active       bool   `db:"active" json:"active"`
+}
+
+type EquipmentBooking struct {
+	ID            int32              `db:"id" json:"id"`
+	EquipmentID   int32              `db:"equipment_id" json:"equipment_id"`
+	AppointmentID *int32             `db:"appointment_id" json:"appointment_id"`
+	StartsAt      pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	EndsAt        pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	BookedBy      *int32             `db:"booked_by" json:"booked_by"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type Icd10Code struct {
+	Code        string      `db:"code" json:"code"`
+	Description string      `db:"description" json:"description"`
+	Billable    bool        `db:"billable" json:"billable"`
+	Active      bool        `db:"active" json:"active"`
+	Search      interface{} `db:"search" json:"search"`
+}
+
+type InsurancePayer struct {
+	ID        int32              `db:"id" json:"id"`
+	Name      string             `db:"name" json:"name"`
+	PayerCode string             `db:"payer_code" json:"payer_code"`
+	Phone     *string            `db:"phone" json:"phone"`
+	Email     *string            `db:"email" json:"email"`
+	Active    bool               `db:"active" json:"active"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type Invoice struct {
+	ID            int32              `db:"id" json:"id"`
+	PatientID     int32              `db:"patient_id" json:"patient_id"`
+	AppointmentID *int32             `db:"appointment_id" json:"appointment_id"`
+	Currency      string             `db:"currency" json:"currency"`
+	Status        string             `db:"status" json:"status"`
+	SubtotalMinor int64              `db:"subtotal_minor" json:"subtotal_minor"`
+	DiscountMinor int64              `db:"discount_minor" json:"discount_minor"`
+	TaxMinor      int64              `db:"tax_minor" json:"tax_minor"`
+	TotalMinor    int64              `db:"total_minor" json:"total_minor"`
+	PaidMinor     int64              `db:"paid_minor" json:"paid_minor"`
+	Notes         *string            `db:"notes" json:"notes"`
+	DueDate       pgtype.Date        `db:"due_date" json:"due_date"`
+	IssuedAt      pgtype.Timestamptz `db:"issued_at" json:"issued_at"`
+	VoidedAt      pgtype.Timestamptz `db:"voided_at" json:"voided_at"`
+	VoidReason    *string            `db:"void_reason" json:"void_reason"`
+	CreatedBy     *int32             `db:"created_by" json:"created_by"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type InvoiceLine struct {
+	ID             int32              `db:"id" json:"id"`
+	InvoiceID      int32              `db:"invoice_id" json:"invoice_id"`
+	ServiceID      *int32             `db:"service_id" json:"service_id"`
+	Description    string             `db:"description" json:"description"`
+	Quantity       int32              `db:"quantity" json:"quantity"`
+	UnitPriceMinor int64              `db:"unit_price_minor" json:"unit_price_minor"`
+	TaxRateBp      int32              `db:"tax_rate_bp" json:"tax_rate_bp"`
+	SubtotalMinor  int64              `db:"subtotal_minor" json:"subtotal_minor"`
+	DiscountMinor  int64              `db:"discount_minor" json:"discount_minor"`
+	TaxMinor       int64              `db:"tax_minor" json:"tax_minor"`
+	TotalMinor     int64              `db:"total_minor" json:"total_minor"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type JobRun struct {
+	ID          int64              `db:"id" json:"id"`
+	JobName     string             `db:"job_name" json:"job_name"`
+	ScheduledAt pgtype.Timestamptz `db:"scheduled_at" json:"scheduled_at"`
+	StartedAt   pgtype.Timestamptz `db:"started_at" json:"started_at"`
+	FinishedAt  pgtype.Timestamptz `db:"finished_at" json:"finished_at"`
+	Status      string             `db:"status" json:"status"`
+	Detail      *string            `db:"detail" json:"detail"`
+	Error       *string            `db:"error" json:"error"`
+}
+
+type NotificationOutbox struct {
+	ID            int64              `db:"id" json:"id"`
+	AppointmentID int32              `db:"appointment_id" json:"appointment_id"`
+	Template      string             `db:"template" json:"template"`
+	Channel       string             `db:"channel" json:"channel"`
+	SendAt        pgtype.Timestamptz `db:"send_at" json:"send_at"`
+	Status        string             `db:"status" json:"status"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	LastError     *string            `db:"last_error" json:"last_error"`
+	LockedUntil   pgtype.Timestamptz `db:"locked_until" json:"locked_until"`
+	SentAt        pgtype.Timestamptz `db:"sent_at" json:"sent_at"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type NotificationPreference struct {
+	PatientID    int32              `db:"patient_id" json:"patient_id"`
+	Language     string             `db:"language" json:"language"`
+	EmailEnabled bool               `db:"email_enabled" json:"email_enabled"`
+	SmsEnabled   bool               `db:"sms_enabled" json:"sms_enabled"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type NotificationTemplate struct {
+	Template  string             `db:"template" json:"template"`
+	Channel   string             `db:"channel" json:"channel"`
+	Language  string             `db:"language" json:"language"`
+	Subject   string             `db:"subject" json:"subject"`
+	Body      string             `db:"body" json:"body"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
 type Patient struct {
-	ID                    int32            `db:"id" json:"id"`
-	FirstName             string           `db:"first_name" json:"first_name"`
-	LastName              string           `db:"last_name" json:"last_name"`
-	Email                 *string          `db:"email" json:"email"`
-	Phone                 *string          `db:"phone" json:"phone"`
-	DateOfBirth           pgtype.Date      `db:"date_of_birth" json:"date_of_birth"`
-	Gender                *string          `db:"gender" json:"gender"`
-	Address               *string          `db:"address" json:"address"`
-	MedicalHistory        *string          `db:"medical_history" json:"medical_history"`
-	Allergies             *string          `db:"allergies" json:"allergies"`
-	EmergencyContactName  *string          `db:"emergency_contact_name" json:"emergency_contact_name"`
-	EmergencyContactPhone *string          `db:"emergency_contact_phone" json:"emergency_contact_phone"`
-	CreatedBy             *int32           `db:"created_by" json:"created_by"`
-	CreatedAt             pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt             pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	ID                    int32              `db:"id" json:"id"`
+	FirstName             string             `db:"first_name" json:"first_name"`
+	LastName              string             `db:"last_name" json:"last_name"`
+	Email                 *string            `db:"email" json:"email"`
+	Phone                 *string            `db:"phone" json:"phone"`
+	DateOfBirth           pgtype.Date        `db:"date_of_birth" json:"date_of_birth"`
+	Gender                *string            `db:"gender" json:"gender"`
+	Address               *string            `db:"address" json:"address"`
+	MedicalHistory        *string            `db:"medical_history" json:"medical_history"`
+	Allergies             *string            `db:"allergies" json:"allergies"`
+	EmergencyContactName  *string            `db:"emergency_contact_name" json:"emergency_contact_name"`
+	EmergencyContactPhone *string            `db:"emergency_contact_phone" json:"emergency_contact_phone"`
+	CreatedBy             *int32             `db:"created_by" json:"created_by"`
+	CreatedAt             pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	EmailBidx             *string            `db:"email_bidx" json:"email_bidx"`
+	PhoneBidx             *string            `db:"phone_bidx" json:"phone_bidx"`
+}
+
+type PatientConsent struct {
+	ID               int32              `db:"id" json:"id"`
+	PatientID        int32              `db:"patient_id" json:"patient_id"`
+	TemplateID       int32              `db:"template_id" json:"template_id"`
+	Purpose          string             `db:"purpose" json:"purpose"`
+	SignedBy         string             `db:"signed_by" json:"signed_by"`
+	SignedAt         pgtype.Timestamptz `db:"signed_at" json:"signed_at"`
+	WitnessName      *string            `db:"witness_name" json:"witness_name"`
+	RecordedBy       *int32             `db:"recorded_by" json:"recorded_by"`
+	ExpiresAt        pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	RevokedAt        pgtype.Timestamptz `db:"revoked_at" json:"revoked_at"`
+	RevokedBy        *int32             `db:"revoked_by" json:"revoked_by"`
+	RevocationReason *string            `db:"revocation_reason" json:"revocation_reason"`
+	CreatedAt        pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type PatientCoverage struct {
+	ID             int32              `db:"id" json:"id"`
+	PatientID      int32              `db:"patient_id" json:"patient_id"`
+	PayerID        int32              `db:"payer_id" json:"payer_id"`
+	Priority       int16              `db:"priority" json:"priority"`
+	PolicyNumber   string             `db:"policy_number" json:"policy_number"`
+	MemberNumber   string             `db:"member_number" json:"member_number"`
+	GroupNumber    *string            `db:"group_number" json:"group_number"`
+	SubscriberName *string            `db:"subscriber_name" json:"subscriber_name"`
+	Relationship   string             `db:"relationship" json:"relationship"`
+	ValidFrom      pgtype.Date        `db:"valid_from" json:"valid_from"`
+	ValidTo        pgtype.Date        `db:"valid_to" json:"valid_to"`
+	CopayMinor     int64              `db:"copay_minor" json:"copay_minor"`
+	CoinsuranceBp  int32              `db:"coinsurance_bp" json:"coinsurance_bp"`
+	CreatedBy      *int32             `db:"created_by" json:"created_by"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type PatientDocument struct {
+	ID          int32              `db:"id" json:"id"`
+	PatientID   int32              `db:"patient_id" json:"patient_id"`
+	Category    string             `db:"category" json:"category"`
+	Description *string            `db:"description" json:"description"`
+	Filename    string             `db:"filename" json:"filename"`
+	ContentType string             `db:"content_type" json:"content_type"`
+	SizeBytes   int64              `db:"size_bytes" json:"size_bytes"`
+	Sha256      string             `db:"sha256" json:"sha256"`
+	StorageKey  string             `db:"storage_key" json:"storage_key"`
+	UploadedBy  *int32             `db:"uploaded_by" json:"uploaded_by"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type Payment struct {
+	ID          int32              `db:"id" json:"id"`
+	InvoiceID   int32              `db:"invoice_id" json:"invoice_id"`
+	Kind        string             `db:"kind" json:"kind"`
+	Method      string             `db:"method" json:"method"`
+	AmountMinor int64              `db:"amount_minor" json:"amount_minor"`
+	Reference   *string            `db:"reference" json:"reference"`
+	RefundOf    *int32             `db:"refund_of" json:"refund_of"`
+	Reason      *string            `db:"reason" json:"reason"`
+	ReceivedBy  *int32             `db:"received_by" json:"received_by"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type Referral struct {
+	ID                 int32              `db:"id" json:"id"`
+	PatientID          int32              `db:"patient_id" json:"patient_id"`
+	SourceDoctorID     int32              `db:"source_doctor_id" json:"source_doctor_id"`
+	TargetDoctorID     *int32             `db:"target_doctor_id" json:"target_doctor_id"`
+	TargetDepartmentID *int32             `db:"target_department_id" json:"target_department_id"`
+	Reason             string             `db:"reason" json:"reason"`
+	Urgency            string             `db:"urgency" json:"urgency"`
+	ClinicalContext    *string            `db:"clinical_context" json:"clinical_context"`
+	NoteID             *int32             `db:"note_id" json:"note_id"`
+	Status             string             `db:"status" json:"status"`
+	RespondedBy        *int32             `db:"responded_by" json:"responded_by"`
+	RespondedAt        pgtype.Timestamptz `db:"responded_at" json:"responded_at"`
+	DeclineReason      *string            `db:"decline_reason" json:"decline_reason"`
+	AppointmentID      *int32             `db:"appointment_id" json:"appointment_id"`
+	CompletedAt        pgtype.Timestamptz `db:"completed_at" json:"completed_at"`
+	CreatedAt          pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type Room struct {
+	ID           int32  `db:"id" json:"id"`
+	Name         string `db:"name" json:"name"`
+	DepartmentID *int32 `db:"department_id" json:"department_id"`
+	Active       bool   `db:"active" json:"active"`
+}
+
+type Specialty struct {
+	ID           int32  `db:"id" json:"id"`
+	Name         string `db:"name" json:"name"`
+	DepartmentID *int32 `db:"department_id" json:"department_id"`
 }
 
 type User struct {
-	ID           int32            `db:"id" json:"id"`
-	Email        string           `db:"email" json:"email"`
-	PasswordHash string           `db:"password_hash" json:"password_hash"`
-	Role         string           `db:"role" json:"role"`
-	FirstName    string           `db:"first_name" json:"first_name"`
-	LastName     string           `db:"last_name" json:"last_name"`
-	CreatedAt    pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt    pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	ID           int32              `db:"id" json:"id"`
+	Email        string             `db:"email" json:"email"`
+	PasswordHash string             `db:"password_hash" json:"password_hash"`
+	Role         string             `db:"role" json:"role"`
+	FirstName    string             `db:"first_name" json:"first_name"`
+	LastName     string             `db:"last_name" json:"last_name"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type WaitlistEntry struct {
+	ID              int32              `db:"id" json:"id"`
+	PatientID       int32              `db:"patient_id" json:"patient_id"`
+	DoctorID        *int32             `db:"doctor_id" json:"doctor_id"`
+	Specialty       *string            `db:"specialty" json:"specialty"`
+	Earliest        pgtype.Timestamptz `db:"earliest" json:"earliest"`
+	Latest          pgtype.Timestamptz `db:"latest" json:"latest"`
+	DurationMinutes int32              `db:"duration_minutes" json:"duration_minutes"`
+	Notes           *string            `db:"notes" json:"notes"`
+	Status          string             `db:"status" json:"status"`
+	CreatedBy       *int32             `db:"created_by" json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type WaitlistHold struct {
+	ID            int32              `db:"id" json:"id"`
+	EntryID       int32              `db:"entry_id" json:"entry_id"`
+	DoctorID      int32              `db:"doctor_id" json:"doctor_id"`
+	StartsAt      pgtype.Timestamptz `db:"starts_at" json:"starts_at"`
+	EndsAt        pgtype.Timestamptz `db:"ends_at" json:"ends_at"`
+	SlotEndsAt    pgtype.Timestamptz `db:"slot_ends_at" json:"slot_ends_at"`
+	Status        string             `db:"status" json:"status"`
+	ExpiresAt     pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	AppointmentID *int32             `db:"appointment_id" json:"appointment_id"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	ResolvedAt    pgtype.Timestamptz `db:"resolved_at" json:"resolved_at"`
+}
+
+type Ward struct {
+	ID           int32              `db:"id" json:"id"`
+	Name         string             `db:"name" json:"name"`
+	DepartmentID *int32             `db:"department_id" json:"department_id"`
+	Active       bool               `db:"active" json:"active"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
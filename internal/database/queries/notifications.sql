@@ -0,0 +1,65 @@
+-- Reminders whose time has already passed are not queued.
+-- name: EnqueueNotification :exec
+INSERT INTO notification_outbox (appointment_id, template, channel, send_at)
+SELECT sqlc.arg(appointment_id)::int, sqlc.arg(template)::text, sqlc.arg(channel)::text, sqlc.arg(send_at)::timestamptz
+WHERE sqlc.arg(send_at)::timestamptz > NOW()
+ON CONFLICT (appointment_id, template, channel, send_at) WHERE status = 'pending' DO NOTHING;
+
+-- name: CancelPendingNotifications :exec
+UPDATE notification_outbox SET status = 'cancelled', locked_until = NULL
+WHERE appointment_id = $1 AND status = 'pending';
+
+-- Leases up to batch_size due messages to the caller for lease_seconds.
+-- SKIP LOCKED lets several workers claim disjoint batches.
+-- name: ClaimDueNotifications :many
+UPDATE notification_outbox
+SET locked_until = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::int), attempts = attempts + 1
+WHERE id IN (
+    SELECT o.id FROM notification_outbox o
+    WHERE o.status = 'pending'
+      AND o.send_at <= NOW()
+      AND (o.locked_until IS NULL OR o.locked_until < NOW())
+    ORDER BY o.send_at
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: FinishNotification :exec
+UPDATE notification_outbox
+SET status = sqlc.arg(status)::text,
+    sent_at = CASE WHEN sqlc.arg(status)::text = 'sent' THEN NOW() END,
+    last_error = sqlc.narg(last_error),
+    locked_until = NULL
+WHERE id = sqlc.arg(id);
+
+-- Keeps the message pending but leased until the retry is due.
+-- name: DeferNotification :exec
+UPDATE notification_outbox
+SET last_error = sqlc.arg(last_error), locked_until = NOW() + make_interval(secs => sqlc.arg(delay_seconds)::int)
+WHERE id = sqlc.arg(id);
+
+-- name: GetNotificationTemplate :one
+SELECT * FROM notification_templates
+WHERE template = $1 AND channel = $2 AND language = $3;
+
+-- name: ListNotificationTemplates :many
+SELECT * FROM notification_templates ORDER BY template, channel, language;
+
+-- name: UpsertNotificationTemplate :one
+INSERT INTO notification_templates (template, channel, language, subject, body)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (template, channel, language)
+DO UPDATE SET subject = EXCLUDED.subject, body = EXCLUDED.body, updated_at = NOW()
+RETURNING *;
+
+-- name: GetNotificationPreferences :one
+SELECT * FROM notification_preferences WHERE patient_id = $1;
+
+-- name: UpsertNotificationPreferences :one
+INSERT INTO notification_preferences (patient_id, language, email_enabled, sms_enabled)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (patient_id)
+DO UPDATE SET language = EXCLUDED.language, email_enabled = EXCLUDED.email_enabled,
+              sms_enabled = EXCLUDED.sms_enabled, updated_at = NOW()
+RETURNING *;
@@ -0,0 +1,36 @@
+-- name: CreatePatientDocument :one
+INSERT INTO patient_documents (patient_id, category, description, filename, content_type, size_bytes, sha256, storage_key, uploaded_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetPatientDocument :one
+SELECT d.*, u.first_name || ' ' || u.last_name AS uploaded_by_name
+FROM patient_documents d
+LEFT JOIN users u ON u.id = d.uploaded_by
+WHERE d.id = $1;
+
+-- name: GetPatientDocumentByHash :one
+SELECT d.*, u.first_name || ' ' || u.last_name AS uploaded_by_name
+FROM patient_documents d
+LEFT JOIN users u ON u.id = d.uploaded_by
+WHERE d.patient_id = $1 AND d.sha256 = $2;
+
+-- name: ListPatientDocuments :many
+SELECT d.*, u.first_name || ' ' || u.last_name AS uploaded_by_name
+FROM patient_documents d
+LEFT JOIN users u ON u.id = d.uploaded_by
+WHERE d.patient_id = sqlc.arg(patient_id)
+  AND (sqlc.narg(category)::text IS NULL OR d.category = sqlc.narg(category))
+ORDER BY d.created_at DESC, d.id DESC;
+
+-- name: DeletePatientDocument :execrows
+DELETE FROM patient_documents WHERE id = $1;
+
+-- How many documents, of any patient, still point at a stored blob.
+-- name: CountDocumentsByStorageKey :one
+SELECT COUNT(*) FROM patient_documents WHERE storage_key = $1;
+
+-- Serializes writers of one stored blob for the rest of the transaction,
+-- so a blob is never deleted while a document referring to it is added.
+-- name: LockStorageKey :exec
+SELECT pg_advisory_xact_lock(hashtext('blob:' || sqlc.arg(storage_key)::text));
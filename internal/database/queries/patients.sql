@@ -1,7 +1,8 @@
 -- name: GetPatients :many
 SELECT id, first_name, last_name, email, phone, date_of_birth, gender,
        address, medical_history, allergies, emergency_contact_name,
-       emergency_contact_phone, created_by, created_at, updated_at
+       emergency_contact_phone, created_by, created_at, updated_at,
+       email_bidx, phone_bidx
 FROM patients
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
@@ -9,20 +10,26 @@ LIMIT $1 OFFSET $2;
 -- name: GetPatientByID :one
 SELECT id, first_name, last_name, email, phone, date_of_birth, gender,
        address, medical_history, allergies, emergency_contact_name,
-       emergency_contact_phone, created_by, created_at, updated_at
+       emergency_contact_phone, created_by, created_at, updated_at,
+       email_bidx, phone_bidx
 FROM patients
 WHERE id = $1;
 
+-- name: NextPatientID :one
+SELECT nextval(pg_get_serial_sequence('patients', 'id'))::int AS id;
+
 -- name: CreatePatient :one
 INSERT INTO patients (
-    first_name, last_name, email, phone, date_of_birth,
+    id, first_name, last_name, email, phone, date_of_birth,
     gender, address, medical_history, allergies,
-    emergency_contact_name, emergency_contact_phone, created_by
+    emergency_contact_name, emergency_contact_phone, created_by,
+    email_bidx, phone_bidx
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 RETURNING id, first_name, last_name, email, phone, date_of_birth, gender,
           address, medical_history, allergies, emergency_contact_name,
-          emergency_contact_phone, created_by, created_at, updated_at;
+          emergency_contact_phone, created_by, created_at, updated_at,
+          email_bidx, phone_bidx;
 
 -- name: UpdatePatient :one
 UPDATE patients
@@ -38,11 +45,14 @@ SET
     allergies = COALESCE(sqlc.narg(allergies), allergies),
     emergency_contact_name = COALESCE(sqlc.narg(emergency_contact_name), emergency_contact_name),
     emergency_contact_phone = COALESCE(sqlc.narg(emergency_contact_phone), emergency_contact_phone),
+    email_bidx = COALESCE(sqlc.narg(email_bidx), email_bidx),
+    phone_bidx = COALESCE(sqlc.narg(phone_bidx), phone_bidx),
     updated_at = NOW()
 WHERE id = $1
 RETURNING id, first_name, last_name, email, phone, date_of_birth, gender,
           address, medical_history, allergies, emergency_contact_name,
-          emergency_contact_phone, created_by, created_at, updated_at;
+          emergency_contact_phone, created_by, created_at, updated_at,
+          email_bidx, phone_bidx;
 
 -- name: DeletePatient :exec
 DELETE FROM patients WHERE id = $1;
@@ -53,12 +63,53 @@ SELECT COUNT(*) FROM patients;
 -- name: SearchPatients :many
 SELECT id, first_name, last_name, email, phone, date_of_birth, gender,
        address, medical_history, allergies, emergency_contact_name,
-       emergency_contact_phone, created_by, created_at, updated_at
+       emergency_contact_phone, created_by, created_at, updated_at,
+       email_bidx, phone_bidx
 FROM patients
 WHERE
     LOWER(first_name) LIKE LOWER('%' || $1 || '%') OR
     LOWER(last_name) LIKE LOWER('%' || $1 || '%') OR
-    LOWER(email) LIKE LOWER('%' || $1 || '%') OR
-    phone LIKE '%' || $1 || '%'
+    email_bidx = $4 OR
+    phone_bidx = $5
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
+
+-- name: ListPatientsAfterID :many
+SELECT id, first_name, last_name, email, phone, date_of_birth, gender,
+       address, medical_history, allergies, emergency_contact_name,
+       emergency_contact_phone, created_by, created_at, updated_at,
+       email_bidx, phone_bidx
+FROM patients
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
+
+-- Patients with a protected column that is plaintext or sealed in an
+-- older format.
+-- name: ListPatientsToSeal :many
+SELECT id, first_name, last_name, email, phone, date_of_birth, gender,
+       address, medical_history, allergies, emergency_contact_name,
+       emergency_contact_phone, created_by, created_at, updated_at,
+       email_bidx, phone_bidx
+FROM patients
+WHERE id > $1
+  AND (email NOT LIKE 'enc:v2:%' OR phone NOT LIKE 'enc:v2:%'
+       OR address NOT LIKE 'enc:v2:%' OR medical_history NOT LIKE 'enc:v2:%'
+       OR allergies NOT LIKE 'enc:v2:%' OR emergency_contact_name NOT LIKE 'enc:v2:%'
+       OR emergency_contact_phone NOT LIKE 'enc:v2:%')
+ORDER BY id
+LIMIT $2;
+
+-- name: UpdatePatientProtectedFields :exec
+UPDATE patients
+SET
+    email = $2,
+    phone = $3,
+    address = $4,
+    medical_history = $5,
+    allergies = $6,
+    emergency_contact_name = $7,
+    emergency_contact_phone = $8,
+    email_bidx = $9,
+    phone_bidx = $10
+WHERE id = $1;
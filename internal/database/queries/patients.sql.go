@@ -24,17 +24,20 @@ func (q *Queries) CountPatients(ctx context.Context) (int64, error) {
 
 const CreatePatient = `-- name: CreatePatient :one
 INSERT INTO patients (
-    first_name, last_name, email, phone, date_of_birth,
+    id, first_name, last_name, email, phone, date_of_birth,
     gender, address, medical_history, allergies,
-    emergency_contact_name, emergency_contact_phone, created_by
+    emergency_contact_name, emergency_contact_phone, created_by,
+    email_bidx, phone_bidx
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 RETURNING id, first_name, last_name, email, phone, date_of_birth, gender,
           address, medical_history, allergies, emergency_contact_name,
-          emergency_contact_phone, created_by, created_at, updated_at
+          emergency_contact_phone, created_by, created_at, updated_at,
+          email_bidx, phone_bidx
 `
 
 type CreatePatientParams struct {
+	ID                    int32       `db:"id" json:"id"`
 	FirstName             string      `db:"first_name" json:"first_name"`
 	LastName              string      `db:"last_name" json:"last_name"`
 	Email                 *string     `db:"email" json:"email"`
@@ -47,10 +50,13 @@ type CreatePatientParams struct {
 	EmergencyContactName  *string     `db:"emergency_contact_name" json:"emergency_contact_name"`
 	EmergencyContactPhone *string     `db:"emergency_contact_phone" json:"emergency_contact_phone"`
 	CreatedBy             *int32      `db:"created_by" json:"created_by"`
+	EmailBidx             *string     `db:"email_bidx" json:"email_bidx"`
+	PhoneBidx             *string     `db:"phone_bidx" json:"phone_bidx"`
 }
 
 func (q *Queries) CreatePatient(ctx context.Context, arg CreatePatientParams) (*Patient, error) {
 	row := q.db.QueryRow(ctx, CreatePatient,
+		arg.ID,
 		arg.FirstName,
 		arg.LastName,
 		arg.Email,
@@ -63,6 +69,8 @@ func (q *Queries) CreatePatient(ctx context.Context, arg CreatePatientParams) (*
 		arg.EmergencyContactName,
 		arg.EmergencyContactPhone,
 		arg.CreatedBy,
+		arg.EmailBidx,
+		arg.PhoneBidx,
 	)
 	var i Patient
 	err := row.Scan(
@@ -81,6 +89,8 @@ func (q *Queries) CreatePatient(ctx context.Context, arg CreatePatientParams) (*
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EmailBidx,
+		&i.PhoneBidx,
 	)
 	return &i, err
 }
@@ -97,7 +107,8 @@ func (q *Queries) DeletePatient(ctx context.Context, id int32) error {
 const GetPatientByID = `-- name: GetPatientByID :one
 SELECT id, first_name, last_name, email, phone, date_of_birth, gender,
        address, medical_history, allergies, emergency_contact_name,
-       emergency_contact_phone, created_by, created_at, updated_at
+       emergency_contact_phone, created_by, created_at, updated_at,
+       email_bidx, phone_bidx
 FROM patients
 WHERE id = $1
 `
@@ -121,6 +132,8 @@ func (q *Queries) GetPatientByID(ctx context.Context, id int32) (*Patient, error
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EmailBidx,
+		&i.PhoneBidx,
 	)
 	return &i, err
 }
@@ -128,7 +141,8 @@ func (q *Queries) GetPatientByID(ctx context.Context, id int32) (*Patient, error
 const GetPatients = `-- name: GetPatients :many
 SELECT id, first_name, last_name, email, phone, date_of_birth, gender,
        address, medical_history, allergies, emergency_contact_name,
-       emergency_contact_phone, created_by, created_at, updated_at
+       emergency_contact_phone, created_by, created_at, updated_at,
+       email_bidx, phone_bidx
 FROM patients
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -164,6 +178,120 @@ func (q *Queries) GetPatients(ctx context.Context, arg GetPatientsParams) ([]*Pa
 			&i.CreatedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.EmailBidx,
+			&i.PhoneBidx,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListPatientsAfterID = `-- name: ListPatientsAfterID :many
+SELECT id, first_name, last_name, email, phone, date_of_birth, gender,
+       address, medical_history, allergies, emergency_contact_name,
+       emergency_contact_phone, created_by, created_at, updated_at,
+       email_bidx, phone_bidx
+FROM patients
+WHERE id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListPatientsAfterIDParams struct {
+	ID    int32 `db:"id" json:"id"`
+	Limit int32 `db:"limit" json:"limit"`
+}
+
+func (q *Queries) ListPatientsAfterID(ctx context.Context, arg ListPatientsAfterIDParams) ([]*Patient, error) {
+	rows, err := q.db.Query(ctx, ListPatientsAfterID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Patient
+	for rows.Next() {
+		var i Patient
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Phone,
+			&i.DateOfBirth,
+			&i.Gender,
+			&i.Address,
+			&i.MedicalHistory,
+			&i.Allergies,
+			&i.EmergencyContactName,
+			&i.EmergencyContactPhone,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EmailBidx,
+			&i.PhoneBidx,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListPatientsToSeal = `-- name: ListPatientsToSeal :many
+SELECT id, first_name, last_name, email, phone, date_of_birth, gender,
+       address, medical_history, allergies, emergency_contact_name,
+       emergency_contact_phone, created_by, created_at, updated_at,
+       email_bidx, phone_bidx
+FROM patients
+WHERE id > $1
+  AND (email NOT LIKE 'enc:v2:%' OR phone NOT LIKE 'enc:v2:%'
+       OR address NOT LIKE 'enc:v2:%' OR medical_history NOT LIKE 'enc:v2:%'
+       OR allergies NOT LIKE 'enc:v2:%' OR emergency_contact_name NOT LIKE 'enc:v2:%'
+       OR emergency_contact_phone NOT LIKE 'enc:v2:%')
+ORDER BY id
+LIMIT $2
+`
+
+type ListPatientsToSealParams struct {
+	ID    int32 `db:"id" json:"id"`
+	Limit int32 `db:"limit" json:"limit"`
+}
+
+func (q *Queries) ListPatientsToSeal(ctx context.Context, arg ListPatientsToSealParams) ([]*Patient, error) {
+	rows, err := q.db.Query(ctx, ListPatientsToSeal, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Patient
+	for rows.Next() {
+		var i Patient
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Phone,
+			&i.DateOfBirth,
+			&i.Gender,
+			&i.Address,
+			&i.MedicalHistory,
+			&i.Allergies,
+			&i.EmergencyContactName,
+			&i.EmergencyContactPhone,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EmailBidx,
+			&i.PhoneBidx,
 		); err != nil {
 			return nil, err
 		}
@@ -175,28 +303,48 @@ func (q *Queries) GetPatients(ctx context.Context, arg GetPatientsParams) ([]*Pa
 	return items, nil
 }
 
+const NextPatientID = `-- name: NextPatientID :one
+SELECT nextval(pg_get_serial_sequence('patients', 'id'))::int AS id
+`
+
+func (q *Queries) NextPatientID(ctx context.Context) (int32, error) {
+	row := q.db.QueryRow(ctx, NextPatientID)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
 const SearchPatients = `-- name: SearchPatients :many
 SELECT id, first_name, last_name, email, phone, date_of_birth, gender,
        address, medical_history, allergies, emergency_contact_name,
-       emergency_contact_phone, created_by, created_at, updated_at
+       emergency_contact_phone, created_by, created_at, updated_at,
+       email_bidx, phone_bidx
 FROM patients
 WHERE
     LOWER(first_name) LIKE LOWER('%' || $1 || '%') OR
     LOWER(last_name) LIKE LOWER('%' || $1 || '%') OR
-    LOWER(email) LIKE LOWER('%' || $1 || '%') OR
-    phone LIKE '%' || $1 || '%'
+    email_bidx = $4 OR
+    phone_bidx = $5
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
 `
 
 type SearchPatientsParams struct {
-	Column1 *string `db:"column_1" json:"column_1"`
-	Limit   int32   `db:"limit" json:"limit"`
-	Offset  int32   `db:"offset" json:"offset"`
+	Column1   *string `db:"column_1" json:"column_1"`
+	Limit     int32   `db:"limit" json:"limit"`
+	Offset    int32   `db:"offset" json:"offset"`
+	EmailBidx *string `db:"email_bidx" json:"email_bidx"`
+	PhoneBidx *string `db:"phone_bidx" json:"phone_bidx"`
 }
 
 func (q *Queries) SearchPatients(ctx context.Context, arg SearchPatientsParams) ([]*Patient, error) {
-	rows, err := q.db.Query(ctx, SearchPatients, arg.Column1, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, SearchPatients,
+		arg.Column1,
+		arg.Limit,
+		arg.Offset,
+		arg.EmailBidx,
+		arg.PhoneBidx,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -220,6 +368,8 @@ func (q *Queries) SearchPatients(ctx context.Context, arg SearchPatientsParams)
 			&i.CreatedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.EmailBidx,
+			&i.PhoneBidx,
 		); err != nil {
 			return nil, err
 		}
@@ -245,11 +395,14 @@ SET
     allergies = COALESCE($10, allergies),
     emergency_contact_name = COALESCE($11, emergency_contact_name),
     emergency_contact_phone = COALESCE($12, emergency_contact_phone),
+    email_bidx = COALESCE($13, email_bidx),
+    phone_bidx = COALESCE($14, phone_bidx),
     updated_at = NOW()
 WHERE id = $1
 RETURNING id, first_name, last_name, email, phone, date_of_birth, gender,
           address, medical_history, allergies, emergency_contact_name,
-          emergency_contact_phone, created_by, created_at, updated_at
+          emergency_contact_phone, created_by, created_at, updated_at,
+          email_bidx, phone_bidx
 `
 
 type UpdatePatientParams struct {
@@ -265,6 +418,8 @@ type UpdatePatientParams struct {
 	Allergies             *string     `db:"allergies" json:"allergies"`
 	EmergencyContactName  *string     `db:"emergency_contact_name" json:"emergency_contact_name"`
 	EmergencyContactPhone *string     `db:"emergency_contact_phone" json:"emergency_contact_phone"`
+	EmailBidx             *string     `db:"email_bidx" json:"email_bidx"`
+	PhoneBidx             *string     `db:"phone_bidx" json:"phone_bidx"`
 }
 
 func (q *Queries) UpdatePatient(ctx context.Context, arg UpdatePatientParams) (*Patient, error) {
@@ -281,6 +436,8 @@ func (q *Queries) UpdatePatient(ctx context.Context, arg UpdatePatientParams) (*
 		arg.Allergies,
 		arg.EmergencyContactName,
 		arg.EmergencyContactPhone,
+		arg.EmailBidx,
+		arg.PhoneBidx,
 	)
 	var i Patient
 	err := row.Scan(
@@ -299,6 +456,52 @@ func (q *Queries) UpdatePatient(ctx context.Context, arg UpdatePatientParams) (*
 		&i.CreatedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EmailBidx,
+		&i.PhoneBidx,
 	)
 	return &i, err
 }
+
+const UpdatePatientProtectedFields = `-- name: UpdatePatientProtectedFields :exec
+UPDATE patients
+SET
+    email = $2,
+    phone = $3,
+    address = $4,
+    medical_history = $5,
+    allergies = $6,
+    emergency_contact_name = $7,
+    emergency_contact_phone = $8,
+    email_bidx = $9,
+    phone_bidx = $10
+WHERE id = $1
+`
+
+type UpdatePatientProtectedFieldsParams struct {
+	ID                    int32   `db:"id" json:"id"`
+	Email                 *string `db:"email" json:"email"`
+	Phone                 *string `db:"phone" json:"phone"`
+	Address               *string `db:"address" json:"address"`
+	MedicalHistory        *string `db:"medical_history" json:"medical_history"`
+	Allergies             *string `db:"allergies" json:"allergies"`
+	EmergencyContactName  *string `db:"emergency_contact_name" json:"emergency_contact_name"`
+	EmergencyContactPhone *string `db:"emergency_contact_phone" json:"emergency_contact_phone"`
+	EmailBidx             *string `db:"email_bidx" json:"email_bidx"`
+	PhoneBidx             *string `db:"phone_bidx" json:"phone_bidx"`
+}
+
+func (q *Queries) UpdatePatientProtectedFields(ctx context.Context, arg UpdatePatientProtectedFieldsParams) error {
+	_, err := q.db.Exec(ctx, UpdatePatientProtectedFields,
+		arg.ID,
+		arg.Email,
+		arg.Phone,
+		arg.Address,
+		arg.MedicalHistory,
+		arg.Allergies,
+		arg.EmergencyContactName,
+		arg.EmergencyContactPhone,
+		arg.EmailBidx,
+		arg.PhoneBidx,
+	)
+	return err
+}
@@ -6,33 +6,268 @@ package queries
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	AcceptReferral(ctx context.Context, arg AcceptReferralParams) (int64, error)
+	AddCareTeamMember(ctx context.Context, arg AddCareTeamMemberParams) (*CareTeamMember, error)
+	AddDepartmentDoctor(ctx context.Context, arg AddDepartmentDoctorParams) error
+	AddDoctorSpecialty(ctx context.Context, arg AddDoctorSpecialtyParams) error
+	AddInvoiceLine(ctx context.Context, arg AddInvoiceLineParams) (*InvoiceLine, error)
+	AdjudicateClaim(ctx context.Context, arg AdjudicateClaimParams) (*Claim, error)
+	ApplyInvoicePayment(ctx context.Context, arg ApplyInvoicePaymentParams) (*Invoice, error)
+	CancelAppointmentSeries(ctx context.Context, id int32) (int64, error)
+	CancelClaim(ctx context.Context, id int32) (*Claim, error)
+	CancelPendingNotifications(ctx context.Context, appointmentID int32) error
+	ClaimDueNotifications(ctx context.Context, arg ClaimDueNotificationsParams) ([]*NotificationOutbox, error)
+	CompleteAppointmentReferral(ctx context.Context, appointmentID *int32) error
 	CountAppointments(ctx context.Context) (int64, error)
 	CountAppointmentsByStatus(ctx context.Context, status *string) (int64, error)
+	CountDocumentsByStorageKey(ctx context.Context, storageKey string) (int64, error)
+	CountInvoiceLines(ctx context.Context, invoiceID int32) (int64, error)
 	CountPatients(ctx context.Context) (int64, error)
+	CountRecentAppointmentsByStatus(ctx context.Context, days int32) ([]*CountRecentAppointmentsByStatusRow, error)
+	CreateAdmission(ctx context.Context, arg CreateAdmissionParams) (*Admission, error)
 	CreateAppointment(ctx context.Context, arg CreateAppointmentParams) (*Appointment, error)
+	CreateAppointmentSeries(ctx context.Context, arg CreateAppointmentSeriesParams) (*AppointmentSeries, error)
+	CreateAppointmentStatusHistory(ctx context.Context, arg CreateAppointmentStatusHistoryParams) (*AppointmentStatusHistory, error)
+	CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) (*AuditLog, error)
+	CreateAvailabilityBreak(ctx context.Context, arg CreateAvailabilityBreakParams) error
+	CreateAvailabilityException(ctx context.Context, arg CreateAvailabilityExceptionParams) (*AvailabilityException, error)
+	CreateAvailabilityTemplate(ctx context.Context, arg CreateAvailabilityTemplateParams) (*AvailabilityTemplate, error)
+	CreateBed(ctx context.Context, arg CreateBedParams) (*Bed, error)
+	CreateBedStay(ctx context.Context, arg CreateBedStayParams) (*BedStay, error)
+	CreateBillableService(ctx context.Context, arg CreateBillableServiceParams) (*BillableService, error)
+	CreateCalendarToken(ctx context.Context, arg CreateCalendarTokenParams) (*CalendarToken, error)
+	CreateCheckIn(ctx context.Context, arg CreateCheckInParams) (*AppointmentCheckIn, error)
+	CreateClaim(ctx context.Context, arg CreateClaimParams) (*Claim, error)
+	CreateClaimBatch(ctx context.Context, arg CreateClaimBatchParams) (*ClaimBatch, error)
+	CreateClinicalNote(ctx context.Context, arg CreateClinicalNoteParams) (*ClinicalNote, error)
+	CreateClinicalNoteAddendum(ctx context.Context, arg CreateClinicalNoteAddendumParams) (*ClinicalNoteAddendum, error)
+	CreateClinicalNoteVersion(ctx context.Context, arg CreateClinicalNoteVersionParams) (*ClinicalNoteVersion, error)
+	CreateConsentTemplate(ctx context.Context, arg CreateConsentTemplateParams) (*ConsentTemplate, error)
+	CreateDepartment(ctx context.Context, arg CreateDepartmentParams) (*Department, error)
+	CreateEmergencyAccessGrant(ctx context.Context, arg CreateEmergencyAccessGrantParams) (*EmergencyAccessGrant, error)
+	CreateEncounterDiagnosis(ctx context.Context, arg CreateEncounterDiagnosisParams) (*EncounterDiagnosis, error)
+	CreateEncryptionKey(ctx context.Context, arg CreateEncryptionKeyParams) (*EncryptionKey, error)
+	CreateEquipment(ctx context.Context, arg CreateEquipmentParams) (*Equipment, error)
+	CreateEquipmentBooking(ctx context.Context, arg CreateEquipmentBookingParams) (*EquipmentBooking, error)
+	CreateInsurancePayer(ctx context.Context, arg CreateInsurancePayerParams) (*InsurancePayer, error)
+	CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (*Invoice, error)
 	CreatePatient(ctx context.Context, arg CreatePatientParams) (*Patient, error)
+	CreatePatientConsent(ctx context.Context, arg CreatePatientConsentParams) (*PatientConsent, error)
+	CreatePatientCoverage(ctx context.Context, arg CreatePatientCoverageParams) (*PatientCoverage, error)
+	CreatePatientDocument(ctx context.Context, arg CreatePatientDocumentParams) (*PatientDocument, error)
+	CreatePayment(ctx context.Context, arg CreatePaymentParams) (*Payment, error)
+	CreateReferral(ctx context.Context, arg CreateReferralParams) (*Referral, error)
+	CreateRoom(ctx context.Context, arg CreateRoomParams) (*Room, error)
+	CreateSpecialty(ctx context.Context, arg CreateSpecialtyParams) (*Specialty, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (*User, error)
+	CreateWaitlistEntry(ctx context.Context, arg CreateWaitlistEntryParams) (*WaitlistEntry, error)
+	CreateWaitlistHold(ctx context.Context, arg CreateWaitlistHoldParams) (*WaitlistHold, error)
+	CreateWard(ctx context.Context, arg CreateWardParams) (*Ward, error)
+	DeactivateIcd10Codes(ctx context.Context) (int64, error)
+	DeclineReferral(ctx context.Context, arg DeclineReferralParams) (int64, error)
+	DeferNotification(ctx context.Context, arg DeferNotificationParams) error
 	DeleteAppointment(ctx context.Context, id int32) error
+	DeleteAppointmentEquipmentBookings(ctx context.Context, appointmentID *int32) error
+	DeleteAppointmentSeries(ctx context.Context, id int32) error
+	DeleteAvailabilityException(ctx context.Context, arg DeleteAvailabilityExceptionParams) (int64, error)
+	DeleteAvailabilityTemplates(ctx context.Context, doctorID int32) error
+	DeleteDepartment(ctx context.Context, id int32) (int64, error)
+	DeleteEncounterDiagnosis(ctx context.Context, id int32) (int64, error)
+	DeleteEquipmentBooking(ctx context.Context, id int32) (int64, error)
+	DeleteInvoiceLine(ctx context.Context, arg DeleteInvoiceLineParams) (int64, error)
 	DeletePatient(ctx context.Context, id int32) error
+	DeletePatientCoverage(ctx context.Context, id int32) (int64, error)
+	DeletePatientDocument(ctx context.Context, id int32) (int64, error)
+	DeleteRevokedCalendarTokens(ctx context.Context, revokedAt pgtype.Timestamptz) (int64, error)
+	DeleteSpecialty(ctx context.Context, id int32) (int64, error)
 	DeleteUser(ctx context.Context, id int32) error
+	DischargeAdmission(ctx context.Context, arg DischargeAdmissionParams) (*Admission, error)
+	DoctorHasPatientAccess(ctx context.Context, arg DoctorHasPatientAccessParams) (bool, error)
+	EndBedStay(ctx context.Context, admissionID int32) (*BedStay, error)
+	EnqueueNotification(ctx context.Context, arg EnqueueNotificationParams) error
+	FindWaitlistCandidates(ctx context.Context, arg FindWaitlistCandidatesParams) ([]*WaitlistEntry, error)
+	FinishJobRun(ctx context.Context, arg FinishJobRunParams) error
+	FinishNotification(ctx context.Context, arg FinishNotificationParams) error
+	GetActiveEmergencyAccessGrant(ctx context.Context, arg GetActiveEmergencyAccessGrantParams) (*EmergencyAccessGrant, error)
+	GetActiveEncryptionKeyID(ctx context.Context) (int32, error)
+	GetAdmission(ctx context.Context, id int32) (*GetAdmissionRow, error)
 	GetAppointmentByID(ctx context.Context, id int32) (*GetAppointmentByIDRow, error)
+	GetAppointmentSeries(ctx context.Context, id int32) (*AppointmentSeries, error)
 	GetAppointments(ctx context.Context, arg GetAppointmentsParams) ([]*GetAppointmentsRow, error)
 	GetAppointmentsByDateRange(ctx context.Context, arg GetAppointmentsByDateRangeParams) ([]*GetAppointmentsByDateRangeRow, error)
 	GetAppointmentsByDoctor(ctx context.Context, arg GetAppointmentsByDoctorParams) ([]*GetAppointmentsByDoctorRow, error)
+	GetBed(ctx context.Context, id int32) (*Bed, error)
+	GetBillableService(ctx context.Context, id int32) (*BillableService, error)
+	GetCalendarTokenByHash(ctx context.Context, tokenHash []byte) (*CalendarToken, error)
+	GetClaim(ctx context.Context, id int32) (*GetClaimRow, error)
+	GetClaimBatch(ctx context.Context, id int32) (*GetClaimBatchRow, error)
+	GetClinicalNote(ctx context.Context, id int32) (*GetClinicalNoteRow, error)
+	GetClinicalNoteByAppointment(ctx context.Context, appointmentID *int32) (*GetClinicalNoteByAppointmentRow, error)
+	GetConsentTemplate(ctx context.Context, id int32) (*ConsentTemplate, error)
+	GetCurrentConsentTemplate(ctx context.Context, purpose string) (*ConsentTemplate, error)
+	GetDefaultConsultation(ctx context.Context, departmentID *int32) (*BillableService, error)
+	GetDepartment(ctx context.Context, id int32) (*Department, error)
+	GetDoctorProfile(ctx context.Context, doctorID int32) (*DoctorProfile, error)
 	GetDoctors(ctx context.Context) ([]*GetDoctorsRow, error)
+	GetEmergencyAccessGrant(ctx context.Context, id int32) (*EmergencyAccessGrant, error)
+	GetEncounterDiagnosis(ctx context.Context, id int32) (*GetEncounterDiagnosisRow, error)
+	GetEquipment(ctx context.Context, id int32) (*Equipment, error)
+	GetEquipmentBooking(ctx context.Context, id int32) (*EquipmentBooking, error)
+	GetExpectedPayerShare(ctx context.Context, invoiceID int32) (int64, error)
+	GetIcd10Code(ctx context.Context, code string) (*GetIcd10CodeRow, error)
+	GetInsurancePayer(ctx context.Context, id int32) (*InsurancePayer, error)
+	GetInvoice(ctx context.Context, id int32) (*Invoice, error)
+	GetLastAuditHash(ctx context.Context) (string, error)
+	GetLatestPatientConsent(ctx context.Context, arg GetLatestPatientConsentParams) (*GetLatestPatientConsentRow, error)
+	GetNotificationPreferences(ctx context.Context, patientID int32) (*NotificationPreference, error)
+	GetNotificationTemplate(ctx context.Context, arg GetNotificationTemplateParams) (*NotificationTemplate, error)
 	GetPatientAppointments(ctx context.Context, patientID *int32) ([]*GetPatientAppointmentsRow, error)
+	GetPatientBalance(ctx context.Context, arg GetPatientBalanceParams) (*GetPatientBalanceRow, error)
 	GetPatientByID(ctx context.Context, id int32) (*Patient, error)
+	GetPatientConsent(ctx context.Context, id int32) (*GetPatientConsentRow, error)
+	GetPatientCoverage(ctx context.Context, id int32) (*GetPatientCoverageRow, error)
+	GetPatientDocument(ctx context.Context, id int32) (*GetPatientDocumentRow, error)
+	GetPatientDocumentByHash(ctx context.Context, arg GetPatientDocumentByHashParams) (*GetPatientDocumentByHashRow, error)
 	GetPatients(ctx context.Context, arg GetPatientsParams) ([]*Patient, error)
+	GetPatientsForDoctor(ctx context.Context, arg GetPatientsForDoctorParams) ([]*Patient, error)
+	GetPayment(ctx context.Context, id int32) (*Payment, error)
+	GetReferral(ctx context.Context, id int32) (*GetReferralRow, error)
+	GetRefundedAmount(ctx context.Context, refundOf *int32) (int64, error)
+	GetRoom(ctx context.Context, id int32) (*Room, error)
 	GetTodaysAppointments(ctx context.Context) ([]*GetTodaysAppointmentsRow, error)
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	GetUserByID(ctx context.Context, id int32) (*User, error)
+	GetWaitlistEntry(ctx context.Context, id int32) (*WaitlistEntry, error)
+	GetWaitlistHold(ctx context.Context, id int32) (*WaitlistHold, error)
+	GetWard(ctx context.Context, id int32) (*Ward, error)
+	IsDepartmentDoctor(ctx context.Context, arg IsDepartmentDoctorParams) (bool, error)
+	IssueInvoice(ctx context.Context, arg IssueInvoiceParams) (*Invoice, error)
+	ListActiveWaitlistHolds(ctx context.Context, arg ListActiveWaitlistHoldsParams) ([]*WaitlistHold, error)
+	ListAdmissionStays(ctx context.Context, admissionID int32) ([]*ListAdmissionStaysRow, error)
+	ListAdmissions(ctx context.Context, arg ListAdmissionsParams) ([]*ListAdmissionsRow, error)
+	ListAppointmentDiagnoses(ctx context.Context, appointmentID int32) ([]*ListAppointmentDiagnosesRow, error)
+	ListAppointmentStatusHistory(ctx context.Context, appointmentID int32) ([]*ListAppointmentStatusHistoryRow, error)
+	ListAuditEntries(ctx context.Context, arg ListAuditEntriesParams) ([]*AuditLog, error)
+	ListAuditEntriesAfter(ctx context.Context, arg ListAuditEntriesAfterParams) ([]*AuditLog, error)
+	ListAvailabilityBreaks(ctx context.Context, doctorID int32) ([]*AvailabilityBreak, error)
+	ListAvailabilityExceptions(ctx context.Context, arg ListAvailabilityExceptionsParams) ([]*AvailabilityException, error)
+	ListAvailabilityTemplates(ctx context.Context, doctorID int32) ([]*AvailabilityTemplate, error)
+	ListBatchClaims(ctx context.Context, batchID *int32) ([]*ListBatchClaimsRow, error)
+	ListBillableServices(ctx context.Context, arg ListBillableServicesParams) ([]*BillableService, error)
+	ListBoardBeds(ctx context.Context, wardID *int32) ([]*ListBoardBedsRow, error)
+	ListCalendarTokens(ctx context.Context, doctorID int32) ([]*CalendarToken, error)
+	ListCareTeam(ctx context.Context, patientID int32) ([]*ListCareTeamRow, error)
+	ListClaimBatches(ctx context.Context, arg ListClaimBatchesParams) ([]*ListClaimBatchesRow, error)
+	ListClaimLines(ctx context.Context, invoiceID int32) ([]*ListClaimLinesRow, error)
+	ListClaims(ctx context.Context, arg ListClaimsParams) ([]*ListClaimsRow, error)
+	ListClinicalNoteAddenda(ctx context.Context, noteID int32) ([]*ClinicalNoteAddendum, error)
+	ListClinicalNoteVersions(ctx context.Context, noteID int32) ([]*ClinicalNoteVersion, error)
+	ListConsentTemplates(ctx context.Context, purpose *string) ([]*ConsentTemplate, error)
+	ListCoveragesInForce(ctx context.Context, arg ListCoveragesInForceParams) ([]*ListCoveragesInForceRow, error)
+	ListDepartmentDoctors(ctx context.Context, departmentID int32) ([]*ListDepartmentDoctorsRow, error)
+	ListDepartments(ctx context.Context) ([]*Department, error)
+	ListDoctorBusyTimes(ctx context.Context, arg ListDoctorBusyTimesParams) ([]*ListDoctorBusyTimesRow, error)
+	ListDoctorSpecialties(ctx context.Context, doctorID int32) ([]*Specialty, error)
+	ListDoctorsWithSpecialty(ctx context.Context, arg ListDoctorsWithSpecialtyParams) ([]*ListDoctorsWithSpecialtyRow, error)
+	ListEmergencyAccessGrants(ctx context.Context, arg ListEmergencyAccessGrantsParams) ([]*ListEmergencyAccessGrantsRow, error)
+	ListEncryptionKeys(ctx context.Context) ([]*EncryptionKey, error)
+	ListEquipment(ctx context.Context, departmentID *int32) ([]*Equipment, error)
+	ListEquipmentBookings(ctx context.Context, arg ListEquipmentBookingsParams) ([]*EquipmentBooking, error)
+	ListExpiredWaitlistHolds(ctx context.Context) ([]*WaitlistHold, error)
+	ListInsurancePayers(ctx context.Context, active *bool) ([]*InsurancePayer, error)
+	ListInvoiceLines(ctx context.Context, invoiceID int32) ([]*InvoiceLine, error)
+	ListInvoices(ctx context.Context, arg ListInvoicesParams) ([]*Invoice, error)
+	ListJobRuns(ctx context.Context, arg ListJobRunsParams) ([]*JobRun, error)
+	ListLatestJobRuns(ctx context.Context) ([]*JobRun, error)
+	ListNotificationTemplates(ctx context.Context) ([]*NotificationTemplate, error)
+	ListOpenInvoices(ctx context.Context, arg ListOpenInvoicesParams) ([]*Invoice, error)
+	ListOutstandingBalances(ctx context.Context, arg ListOutstandingBalancesParams) ([]*ListOutstandingBalancesRow, error)
+	ListOverdueAppointments(ctx context.Context, arg ListOverdueAppointmentsParams) ([]int32, error)
+	ListOverlappingAppointments(ctx context.Context, arg ListOverlappingAppointmentsParams) ([]*ListOverlappingAppointmentsRow, error)
+	ListPatientClinicalNotes(ctx context.Context, patientID int32) ([]*ListPatientClinicalNotesRow, error)
+	ListPatientConsents(ctx context.Context, patientID int32) ([]*ListPatientConsentsRow, error)
+	ListPatientCoverages(ctx context.Context, patientID int32) ([]*ListPatientCoveragesRow, error)
+	ListPatientDocuments(ctx context.Context, arg ListPatientDocumentsParams) ([]*ListPatientDocumentsRow, error)
+	ListPatientProblems(ctx context.Context, arg ListPatientProblemsParams) ([]*ListPatientProblemsRow, error)
+	ListPatientsAfterID(ctx context.Context, arg ListPatientsAfterIDParams) ([]*Patient, error)
+	ListPatientsToSeal(ctx context.Context, arg ListPatientsToSealParams) ([]*Patient, error)
+	ListPayments(ctx context.Context, invoiceID int32) ([]*Payment, error)
+	ListReferrals(ctx context.Context, arg ListReferralsParams) ([]*ListReferralsRow, error)
+	ListRoomBookings(ctx context.Context, arg ListRoomBookingsParams) ([]*ListRoomBookingsRow, error)
+	ListRooms(ctx context.Context, departmentID *int32) ([]*Room, error)
+	ListSeriesAppointments(ctx context.Context, seriesID *int32) ([]*Appointment, error)
+	ListSpecialties(ctx context.Context, departmentID *int32) ([]*Specialty, error)
+	ListTodaysCheckIns(ctx context.Context) ([]*AppointmentCheckIn, error)
+	ListWaitlistEntries(ctx context.Context, status *string) ([]*WaitlistEntry, error)
+	ListWaitlistHolds(ctx context.Context, entryID int32) ([]*WaitlistHold, error)
+	ListWardBeds(ctx context.Context, wardID int32) ([]*Bed, error)
+	ListWards(ctx context.Context, active *bool) ([]*Ward, error)
+	LockAdmission(ctx context.Context, id int32) (*Admission, error)
+	LockClaim(ctx context.Context, id int32) (*Claim, error)
+	LockClinicalNote(ctx context.Context, id int32) (*ClinicalNote, error)
+	LockInvoice(ctx context.Context, id int32) (*Invoice, error)
+	LockStorageKey(ctx context.Context, storageKey string) error
+	MoveAppointmentEquipmentBookings(ctx context.Context, arg MoveAppointmentEquipmentBookingsParams) error
+	NextPatientID(ctx context.Context) (int32, error)
+	OccupyBed(ctx context.Context, id int32) (int64, error)
+	PayClaim(ctx context.Context, arg PayClaimParams) (*Claim, error)
+	RefreshAppointmentDailyStats(ctx context.Context) error
+	ReleaseJobLock(ctx context.Context, jobName string) error
+	RemoveCareTeamMember(ctx context.Context, arg RemoveCareTeamMemberParams) (int64, error)
+	RemoveDepartmentDoctor(ctx context.Context, arg RemoveDepartmentDoctorParams) (int64, error)
+	RemoveDoctorSpecialty(ctx context.Context, arg RemoveDoctorSpecialtyParams) (int64, error)
+	RenameBed(ctx context.Context, arg RenameBedParams) (*Bed, error)
+	ReopenAppointmentReferral(ctx context.Context, appointmentID *int32) error
+	ResolveWaitlistHold(ctx context.Context, arg ResolveWaitlistHoldParams) (int64, error)
+	RetireEncryptionKeysExcept(ctx context.Context, id int32) error
+	ReviewEmergencyAccessGrant(ctx context.Context, arg ReviewEmergencyAccessGrantParams) (*EmergencyAccessGrant, error)
+	RevokeCalendarToken(ctx context.Context, arg RevokeCalendarTokenParams) (int64, error)
+	RevokePatientConsent(ctx context.Context, arg RevokePatientConsentParams) (int64, error)
+	RewrapEncryptionKey(ctx context.Context, arg RewrapEncryptionKeyParams) error
+	ScheduleReferral(ctx context.Context, arg ScheduleReferralParams) (int64, error)
+	SearchIcd10CodesByPrefix(ctx context.Context, arg SearchIcd10CodesByPrefixParams) ([]*SearchIcd10CodesByPrefixRow, error)
+	SearchIcd10CodesByText(ctx context.Context, arg SearchIcd10CodesByTextParams) ([]*SearchIcd10CodesByTextRow, error)
 	SearchPatients(ctx context.Context, arg SearchPatientsParams) ([]*Patient, error)
+	SearchPatientsForDoctor(ctx context.Context, arg SearchPatientsForDoctorParams) ([]*Patient, error)
+	SetAppointmentClinicalSummary(ctx context.Context, arg SetAppointmentClinicalSummaryParams) error
+	SetAppointmentStatus(ctx context.Context, arg SetAppointmentStatusParams) (int64, error)
+	SetAttendingDoctor(ctx context.Context, arg SetAttendingDoctorParams) error
+	SetBedStatus(ctx context.Context, arg SetBedStatusParams) (*Bed, error)
+	SetClinicalNoteVersion(ctx context.Context, arg SetClinicalNoteVersionParams) error
+	SetWaitlistEntryStatus(ctx context.Context, arg SetWaitlistEntryStatusParams) (int64, error)
+	SignClinicalNote(ctx context.Context, arg SignClinicalNoteParams) (int64, error)
+	StartJobRun(ctx context.Context, arg StartJobRunParams) (int64, error)
+	SubmitDraftClaims(ctx context.Context, arg SubmitDraftClaimsParams) (int64, error)
+	SupersedePatientConsent(ctx context.Context, arg SupersedePatientConsentParams) error
+	TouchCalendarToken(ctx context.Context, id int32) error
+	TryJobLock(ctx context.Context, jobName string) (bool, error)
 	UpdateAppointment(ctx context.Context, arg UpdateAppointmentParams) (*Appointment, error)
+	UpdateAppointmentSeries(ctx context.Context, arg UpdateAppointmentSeriesParams) (*AppointmentSeries, error)
+	UpdateBillableService(ctx context.Context, arg UpdateBillableServiceParams) (*BillableService, error)
+	UpdateDepartment(ctx context.Context, arg UpdateDepartmentParams) (*Department, error)
+	UpdateEncounterDiagnosis(ctx context.Context, arg UpdateEncounterDiagnosisParams) (*EncounterDiagnosis, error)
+	UpdateEquipment(ctx context.Context, arg UpdateEquipmentParams) (*Equipment, error)
+	UpdateInsurancePayer(ctx context.Context, arg UpdateInsurancePayerParams) (*InsurancePayer, error)
+	UpdateInvoiceTotals(ctx context.Context, id int32) (*Invoice, error)
 	UpdatePatient(ctx context.Context, arg UpdatePatientParams) (*Patient, error)
+	UpdatePatientCoverage(ctx context.Context, arg UpdatePatientCoverageParams) (*PatientCoverage, error)
+	UpdatePatientProtectedFields(ctx context.Context, arg UpdatePatientProtectedFieldsParams) error
+	UpdateRoom(ctx context.Context, arg UpdateRoomParams) (*Room, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (*User, error)
+	UpdateWard(ctx context.Context, arg UpdateWardParams) (*Ward, error)
+	UpsertDoctorProfile(ctx context.Context, arg UpsertDoctorProfileParams) error
+	UpsertIcd10Codes(ctx context.Context, arg UpsertIcd10CodesParams) (int64, error)
+	UpsertNotificationPreferences(ctx context.Context, arg UpsertNotificationPreferencesParams) (*NotificationPreference, error)
+	UpsertNotificationTemplate(ctx context.Context, arg UpsertNotificationTemplateParams) (*NotificationTemplate, error)
+	UpsertSpecialty(ctx context.Context, name string) (*Specialty, error)
+	VacateBed(ctx context.Context, id int32) error
+	VoidInvoice(ctx context.Context, arg VoidInvoiceParams) (*Invoice, error)
+	WardCensus(ctx context.Context, arg WardCensusParams) ([]*WardCensusRow, error)
 }
 
 var _ Querier = (*Queries)(nil)
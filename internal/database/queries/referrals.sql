@@ -0,0 +1,63 @@
+-- name: CreateReferral :one
+INSERT INTO referrals (patient_id, source_doctor_id, target_doctor_id, target_department_id, reason, urgency, clinical_context, note_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetReferral :one
+SELECT r.*,
+       s.first_name || ' ' || s.last_name AS source_doctor_name,
+       t.first_name || ' ' || t.last_name AS target_doctor_name,
+       d.name AS target_department_name
+FROM referrals r
+JOIN users s ON s.id = r.source_doctor_id
+LEFT JOIN users t ON t.id = r.target_doctor_id
+LEFT JOIN departments d ON d.id = r.target_department_id
+WHERE r.id = $1;
+
+-- Referrals matching every filter given, most urgent first, then newest.
+-- A receiver gets the referrals addressed to them and those addressed to
+-- one of their departments that no doctor has taken yet.
+-- name: ListReferrals :many
+SELECT r.*,
+       s.first_name || ' ' || s.last_name AS source_doctor_name,
+       t.first_name || ' ' || t.last_name AS target_doctor_name,
+       d.name AS target_department_name
+FROM referrals r
+JOIN users s ON s.id = r.source_doctor_id
+LEFT JOIN users t ON t.id = r.target_doctor_id
+LEFT JOIN departments d ON d.id = r.target_department_id
+WHERE (sqlc.narg(patient_id)::int IS NULL OR r.patient_id = sqlc.narg(patient_id))
+  AND (sqlc.narg(status)::text IS NULL OR r.status = sqlc.narg(status))
+  AND (sqlc.narg(source_doctor_id)::int IS NULL OR r.source_doctor_id = sqlc.narg(source_doctor_id))
+  AND (sqlc.narg(receiver_id)::int IS NULL
+       OR r.target_doctor_id = sqlc.narg(receiver_id)
+       OR (r.target_doctor_id IS NULL AND r.target_department_id IN (
+           SELECT department_id FROM department_doctors WHERE doctor_id = sqlc.narg(receiver_id))))
+ORDER BY CASE r.urgency WHEN 'emergency' THEN 0 WHEN 'urgent' THEN 1 ELSE 2 END, r.created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: AcceptReferral :execrows
+UPDATE referrals
+SET status = 'accepted', target_doctor_id = sqlc.arg(doctor_id), responded_by = sqlc.arg(doctor_id),
+    responded_at = NOW(), updated_at = NOW()
+WHERE id = sqlc.arg(id) AND status = 'sent';
+
+-- name: DeclineReferral :execrows
+UPDATE referrals
+SET status = 'declined', responded_by = $2, decline_reason = $3, responded_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'sent';
+
+-- name: ScheduleReferral :execrows
+UPDATE referrals
+SET status = 'scheduled', appointment_id = $2, updated_at = NOW()
+WHERE id = $1 AND status = 'accepted';
+
+-- name: CompleteAppointmentReferral :exec
+UPDATE referrals
+SET status = 'completed', completed_at = NOW(), updated_at = NOW()
+WHERE appointment_id = $1 AND status = 'scheduled';
+
+-- name: ReopenAppointmentReferral :exec
+UPDATE referrals
+SET status = 'accepted', appointment_id = NULL, updated_at = NOW()
+WHERE appointment_id = $1 AND status = 'scheduled';
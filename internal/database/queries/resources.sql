@@ -0,0 +1,69 @@
+-- name: CreateRoom :one
+INSERT INTO rooms (name, department_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetRoom :one
+SELECT * FROM rooms WHERE id = $1;
+
+-- name: ListRooms :many
+SELECT * FROM rooms
+WHERE sqlc.narg(department_id)::int IS NULL OR department_id = sqlc.narg(department_id)::int
+ORDER BY name;
+
+-- name: UpdateRoom :one
+UPDATE rooms SET name = $2, department_id = $3, active = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: ListRoomBookings :many
+SELECT id, appointment_date, end_time
+FROM appointments
+WHERE room_id = sqlc.arg(room_id)
+  AND COALESCE(status, 'scheduled') NOT IN ('cancelled', 'no_show')
+  AND appointment_date < sqlc.arg(until)
+  AND end_time > sqlc.arg(since)
+ORDER BY appointment_date;
+
+-- name: CreateEquipment :one
+INSERT INTO equipment (name, department_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetEquipment :one
+SELECT * FROM equipment WHERE id = $1;
+
+-- name: ListEquipment :many
+SELECT * FROM equipment
+WHERE sqlc.narg(department_id)::int IS NULL OR department_id = sqlc.narg(department_id)::int
+ORDER BY name;
+
+-- name: UpdateEquipment :one
+UPDATE equipment SET name = $2, department_id = $3, active = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateEquipmentBooking :one
+INSERT INTO equipment_bookings (equipment_id, appointment_id, starts_at, ends_at, booked_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetEquipmentBooking :one
+SELECT * FROM equipment_bookings WHERE id = $1;
+
+-- name: ListEquipmentBookings :many
+SELECT * FROM equipment_bookings
+WHERE equipment_id = sqlc.arg(equipment_id)
+  AND starts_at < sqlc.arg(until)
+  AND ends_at > sqlc.arg(since)
+ORDER BY starts_at;
+
+-- name: DeleteEquipmentBooking :execrows
+DELETE FROM equipment_bookings WHERE id = $1;
+
+-- name: MoveAppointmentEquipmentBookings :exec
+UPDATE equipment_bookings SET starts_at = $2, ends_at = $3
+WHERE appointment_id = $1;
+
+-- name: DeleteAppointmentEquipmentBookings :exec
+DELETE FROM equipment_bookings WHERE appointment_id = $1;
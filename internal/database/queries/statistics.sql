@@ -0,0 +1,9 @@
+-- name: RefreshAppointmentDailyStats :exec
+REFRESH MATERIALIZED VIEW CONCURRENTLY appointment_daily_stats;
+
+-- name: CountRecentAppointmentsByStatus :many
+SELECT status, SUM(appointments)::bigint AS appointments
+FROM appointment_daily_stats
+WHERE day > CURRENT_DATE - sqlc.arg(days)::int
+GROUP BY status
+ORDER BY status;
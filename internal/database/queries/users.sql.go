@@ -64,13 +64,13 @@ ORDER BY first_name, last_name
 `
 
 type GetDoctorsRow struct {
-	ID        int32            `db:"id" json:"id"`
-	Email     string           `db:"email" json:"email"`
-	Role      string           `db:"role" json:"role"`
-	FirstName string           `db:"first_name" json:"first_name"`
-	LastName  string           `db:"last_name" json:"last_name"`
-	CreatedAt pgtype.Timestamp `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+	ID        int32              `db:"id" json:"id"`
+	Email     string             `db:"email" json:"email"`
+	Role      string             `db:"role" json:"role"`
+	FirstName string             `db:"first_name" json:"first_name"`
+	LastName  string             `db:"last_name" json:"last_name"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
 func (q *Queries) GetDoctors(ctx context.Context) ([]*GetDoctorsRow, error) {
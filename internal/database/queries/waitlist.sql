@@ -0,0 +1,71 @@
+-- name: CreateWaitlistEntry :one
+INSERT INTO waitlist_entries (patient_id, doctor_id, specialty, earliest, latest, duration_minutes, notes, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetWaitlistEntry :one
+SELECT * FROM waitlist_entries WHERE id = $1;
+
+-- name: ListWaitlistEntries :many
+SELECT * FROM waitlist_entries
+WHERE sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)
+ORDER BY created_at;
+
+-- name: SetWaitlistEntryStatus :execrows
+UPDATE waitlist_entries SET status = sqlc.arg(to_status)
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(from_status);
+
+-- Waiting entries that fit [starts_at, ends_at) with the doctor and have
+-- not been offered this slot before: entries asking for the doctor by
+-- name first, then by specialty, oldest first.
+-- name: FindWaitlistCandidates :many
+SELECT e.* FROM waitlist_entries e
+WHERE e.status = 'waiting'
+  AND (e.doctor_id = sqlc.arg(doctor_id)
+       OR (e.doctor_id IS NULL AND EXISTS (
+           SELECT 1 FROM doctor_specialties ds
+           JOIN specialties s ON s.id = ds.specialty_id
+           WHERE ds.doctor_id = sqlc.arg(doctor_id) AND LOWER(s.name) = LOWER(e.specialty)
+       )))
+  AND e.earliest <= sqlc.arg(starts_at)
+  AND sqlc.arg(starts_at) + make_interval(mins => e.duration_minutes) <= LEAST(e.latest, sqlc.arg(ends_at)::timestamptz)
+  AND NOT EXISTS (
+      SELECT 1 FROM waitlist_holds h
+      WHERE h.entry_id = e.id AND h.doctor_id = sqlc.arg(doctor_id) AND h.starts_at = sqlc.arg(starts_at)
+  )
+ORDER BY (e.doctor_id IS NULL), e.created_at
+LIMIT 20;
+
+-- name: CreateWaitlistHold :one
+INSERT INTO waitlist_holds (entry_id, doctor_id, starts_at, ends_at, slot_ends_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetWaitlistHold :one
+SELECT * FROM waitlist_holds WHERE id = $1;
+
+-- name: ListWaitlistHolds :many
+SELECT * FROM waitlist_holds WHERE entry_id = $1 ORDER BY created_at;
+
+-- name: ResolveWaitlistHold :execrows
+UPDATE waitlist_holds
+SET status = sqlc.arg(status), appointment_id = sqlc.narg(appointment_id), resolved_at = NOW()
+WHERE id = sqlc.arg(id) AND status = 'pending';
+
+-- name: ListExpiredWaitlistHolds :many
+SELECT * FROM waitlist_holds
+WHERE status = 'pending' AND expires_at <= NOW()
+ORDER BY expires_at;
+
+-- Unexpired pending holds on the doctor's time in [starts_at, ends_at),
+-- other than those held for patient_id.
+-- name: ListActiveWaitlistHolds :many
+SELECT h.* FROM waitlist_holds h
+JOIN waitlist_entries e ON e.id = h.entry_id
+WHERE h.doctor_id = sqlc.arg(doctor_id)
+  AND h.status = 'pending'
+  AND h.expires_at > NOW()
+  AND h.starts_at < sqlc.arg(ends_at)
+  AND h.ends_at > sqlc.arg(starts_at)
+  AND e.patient_id <> sqlc.arg(patient_id)
+ORDER BY h.starts_at;
@@ -0,0 +1,268 @@
+package documents
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/pdf"
+)
+
+const (
+	dateLayout     = "2 Jan 2006"
+	dateTimeLayout = "2 Jan 2006 15:04"
+)
+
+// Patient identifies the patient a document is about.
+type Patient struct {
+	Name string
+	MRN  string
+	// DateOfBirth is left zero when unknown.
+	DateOfBirth time.Time
+	Gender      string
+}
+
+// Diagnosis is one coded diagnosis of a visit.
+type Diagnosis struct {
+	Code        string
+	Description string
+	Primary     bool
+	Status      string
+}
+
+// VisitSummary is what the patient takes home after an appointment.
+// Times are printed as given, so callers convert them to the hospital's
+// time zone first.
+type VisitSummary struct {
+	IssuedAt      time.Time
+	Patient       Patient
+	Doctor        string
+	Department    string
+	VisitAt       time.Time
+	Diagnosis     string
+	Diagnoses     []Diagnosis
+	TreatmentPlan string
+}
+
+// Medication is one line of a prescription.
+type Medication struct {
+	Name         string
+	Dosage       string
+	Frequency    string
+	Duration     string
+	Quantity     string
+	Instructions string
+}
+
+// Prescription lists the medications a doctor prescribes to a patient.
+type Prescription struct {
+	IssuedAt    time.Time
+	Patient     Patient
+	Doctor      string
+	Allergies   string
+	Medications []Medication
+	Notes       string
+}
+
+// PatientCard is the wallet-sized card with the patient's MRN as a
+// barcode.
+type PatientCard struct {
+	IssuedAt time.Time
+	Patient  Patient
+}
+
+// Renderer renders documents with one hospital template. It is safe for
+// concurrent use.
+type Renderer struct {
+	tmpl *Template
+}
+
+func NewRenderer(tmpl *Template) *Renderer {
+	return &Renderer{tmpl: tmpl}
+}
+
+// VisitSummary renders an A4 visit summary.
+func (r *Renderer) VisitSummary(v VisitSummary) []byte {
+	l := newLayout(r.tmpl, "Visit Summary")
+	patientSection(l, v.Patient)
+
+	l.heading("Visit")
+	l.field("Date", formatTime(v.VisitAt, dateTimeLayout))
+	l.field("Doctor", v.Doctor)
+	if v.Department != "" {
+		l.field("Department", v.Department)
+	}
+
+	l.heading("Diagnosis")
+	if v.Diagnosis != "" {
+		l.paragraph(v.Diagnosis, pdf.Helvetica, 10, 0)
+	}
+	for _, d := range v.Diagnoses {
+		label := d.Code
+		var notes []string
+		if d.Primary {
+			notes = append(notes, "primary")
+		}
+		if d.Status != "" && d.Status != "active" {
+			notes = append(notes, strings.ReplaceAll(d.Status, "_", " "))
+		}
+		desc := d.Description
+		if len(notes) > 0 {
+			desc += " (" + strings.Join(notes, ", ") + ")"
+		}
+		l.field(label, desc)
+	}
+	if v.Diagnosis == "" && len(v.Diagnoses) == 0 {
+		l.paragraph("No diagnosis recorded.", pdf.Helvetica, 10, 0)
+	}
+
+	l.heading("Treatment Plan")
+	plan := v.TreatmentPlan
+	if plan == "" {
+		plan = "No treatment plan recorded."
+	}
+	l.paragraph(plan, pdf.Helvetica, 10, 0)
+
+	issued(l, v.IssuedAt, v.Doctor)
+	return l.finish()
+}
+
+// Prescription renders an A4 prescription with a signature line.
+func (r *Renderer) Prescription(p Prescription) []byte {
+	l := newLayout(r.tmpl, "Prescription")
+	patientSection(l, p.Patient)
+	if p.Allergies != "" {
+		l.field("Allergies", p.Allergies)
+	}
+
+	l.heading("Medications")
+	for i, m := range p.Medications {
+		l.ensure(3 * 10 * lineGap)
+		l.space(4)
+		title := fmt.Sprintf("%d. %s", i+1, m.Name)
+		if m.Dosage != "" {
+			title += " " + m.Dosage
+		}
+		l.paragraph(title, pdf.HelveticaBold, 10, 0)
+
+		var details []string
+		for _, d := range []struct{ label, value string }{
+			{"Frequency", m.Frequency},
+			{"Duration", m.Duration},
+			{"Quantity", m.Quantity},
+		} {
+			if d.value != "" {
+				details = append(details, d.label+": "+d.value)
+			}
+		}
+		if len(details) > 0 {
+			l.paragraph(strings.Join(details, "   "), pdf.Helvetica, 10, 14)
+		}
+		if m.Instructions != "" {
+			l.paragraph(m.Instructions, pdf.Helvetica, 10, 14)
+		}
+	}
+
+	if p.Notes != "" {
+		l.heading("Notes")
+		l.paragraph(p.Notes, pdf.Helvetica, 10, 0)
+	}
+
+	issued(l, p.IssuedAt, p.Doctor)
+	l.ensure(50)
+	l.space(40)
+	l.page.SetStrokeColor(0, 0, 0)
+	l.page.Line(margin, l.y, margin+200, l.y, 0.5)
+	l.space(12)
+	l.page.Text(margin, l.y, pdf.Helvetica, 9, "Signature, "+p.Doctor)
+	return l.finish()
+}
+
+// PatientCard renders an ID-1 card with the MRN as a Code 128 barcode.
+func (r *Renderer) PatientCard(c PatientCard) ([]byte, error) {
+	bars, err := pdf.Code128(c.Patient.MRN)
+	if err != nil {
+		return nil, err
+	}
+
+	const pad = 12.0
+	t := r.tmpl
+	doc := pdf.New("Patient Card")
+	p := doc.AddPage(pdf.ID1Width, pdf.ID1Height)
+	inner := pdf.ID1Width - 2*pad
+
+	p.SetFillColor(t.accent[0], t.accent[1], t.accent[2])
+	p.FillRect(0, 0, pdf.ID1Width, 30)
+	x := pad
+	if t.logo != nil {
+		// The banner is colored, so the logo sits on a white tile.
+		w, h := fit(t.logo, 20, 20)
+		p.SetFillColor(255, 255, 255)
+		p.FillRect(pad-2, 5-2+(20-h)/2, w+4, h+4)
+		p.Image(t.logo, pad, 5+(20-h)/2, w, h)
+		x += w + 8
+	}
+	p.SetFillColor(255, 255, 255)
+	p.Text(x, 19, pdf.HelveticaBold, 10, truncate(t.HospitalName, pdf.HelveticaBold, 10, pdf.ID1Width-pad-x))
+
+	p.SetFillColor(0, 0, 0)
+	p.Text(pad, 50, pdf.HelveticaBold, 11, truncate(c.Patient.Name, pdf.HelveticaBold, 11, inner))
+	var line []string
+	if !c.Patient.DateOfBirth.IsZero() {
+		line = append(line, "DOB "+c.Patient.DateOfBirth.Format(dateLayout))
+	}
+	if c.Patient.Gender != "" {
+		line = append(line, c.Patient.Gender)
+	}
+	p.SetFillColor(60, 60, 60)
+	if len(line) > 0 {
+		p.Text(pad, 63, pdf.Helvetica, 8, strings.Join(line, "   "))
+	}
+	if !c.IssuedAt.IsZero() {
+		p.Text(pad, 74, pdf.Helvetica, 8, "Issued "+c.IssuedAt.Format(dateLayout))
+	}
+
+	// Keep a ten-module quiet zone on both sides and at most a point per
+	// module, which scanners read comfortably.
+	modules := 20
+	for _, w := range bars {
+		modules += w
+	}
+	module := min(1.0, inner/float64(modules))
+	barsWidth := float64(modules-20) * module
+	p.SetFillColor(0, 0, 0)
+	p.Barcode(bars, (pdf.ID1Width-barsWidth)/2, 84, module, 40)
+
+	label := "MRN " + c.Patient.MRN
+	p.Text((pdf.ID1Width-pdf.Helvetica.Width(label, 8))/2, 134, pdf.Helvetica, 8, label)
+	if t.Footer != "" {
+		p.SetFillColor(90, 90, 90)
+		p.Text(pad, 147, pdf.Helvetica, 5, truncate(t.Footer, pdf.Helvetica, 5, inner))
+	}
+	return doc.Bytes(), nil
+}
+
+func patientSection(l *layout, p Patient) {
+	l.heading("Patient")
+	l.field("Name", p.Name)
+	l.field("MRN", p.MRN)
+	if !p.DateOfBirth.IsZero() {
+		l.field("Date of birth", p.DateOfBirth.Format(dateLayout))
+	}
+	if p.Gender != "" {
+		l.field("Gender", p.Gender)
+	}
+}
+
+func issued(l *layout, at time.Time, by string) {
+	l.space(16)
+	l.ensure(10 * lineGap)
+	l.paragraph(fmt.Sprintf("Issued %s by %s", formatTime(at, dateTimeLayout), by), pdf.Helvetica, 9, 0)
+}
+
+func formatTime(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
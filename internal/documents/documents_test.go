@@ -0,0 +1,134 @@
+package documents_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prem0x01/hospital/internal/documents"
+)
+
+// Run with -update after an intentional layout change and review the
+// regenerated files in a PDF viewer before committing them.
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".pdf")
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(want, got), "%s differs from the golden file; rerun with -update if the change is intended", path)
+}
+
+func renderer(t *testing.T) *documents.Renderer {
+	t.Helper()
+	tmpl, err := documents.LoadTemplate(filepath.Join("testdata", "template.json"))
+	require.NoError(t, err)
+	return documents.NewRenderer(tmpl)
+}
+
+var (
+	issued  = time.Date(2025, 3, 3, 10, 15, 0, 0, time.UTC)
+	patient = documents.Patient{
+		Name:        "Zoë Ashworth-Lindqvist",
+		MRN:         "00000422",
+		DateOfBirth: time.Date(1987, 11, 23, 0, 0, 0, 0, time.UTC),
+		Gender:      "female",
+	}
+)
+
+func TestVisitSummary(t *testing.T) {
+	plan := strings.Repeat("Take paracetamol 1 g every six hours as needed for pain, no more than 4 g a day. ", 8) +
+		"\n\nReturn if the fever persists beyond three days.\n" +
+		strings.Repeat("Rest, fluids and a gradual return to normal activity over the coming fortnight. ", 60)
+
+	out := renderer(t).VisitSummary(documents.VisitSummary{
+		IssuedAt:   issued,
+		Patient:    patient,
+		Doctor:     "Dr. Amara Okafor",
+		Department: "General Medicine",
+		VisitAt:    time.Date(2025, 3, 3, 9, 30, 0, 0, time.UTC),
+		Diagnosis:  "Viral upper respiratory tract infection.",
+		Diagnoses: []documents.Diagnosis{
+			{Code: "J06.9", Description: "Acute upper respiratory infection, unspecified", Primary: true, Status: "active"},
+			{Code: "R50.9", Description: "Fever, unspecified", Status: "resolved"},
+		},
+		TreatmentPlan: plan,
+	})
+	golden(t, "visit_summary", out)
+	assert.Contains(t, string(out), "/Count 2", "the long treatment plan continues on a second page")
+	assert.Contains(t, string(out), "(Page 2 of 2)")
+}
+
+func TestPrescription(t *testing.T) {
+	out := renderer(t).Prescription(documents.Prescription{
+		IssuedAt:  issued,
+		Patient:   patient,
+		Doctor:    "Dr. Amara Okafor",
+		Allergies: "Penicillin",
+		Medications: []documents.Medication{
+			{Name: "Clarithromycin", Dosage: "500 mg", Frequency: "twice daily", Duration: "7 days", Quantity: "14 tablets", Instructions: "Take with food."},
+			{Name: "Paracetamol", Dosage: "1 g", Frequency: "every 6 hours as needed"},
+		},
+		Notes: "Review in one week if symptoms persist.",
+	})
+	golden(t, "prescription", out)
+}
+
+func TestPatientCard(t *testing.T) {
+	out, err := renderer(t).PatientCard(documents.PatientCard{IssuedAt: issued, Patient: patient})
+	require.NoError(t, err)
+	golden(t, "patient_card", out)
+}
+
+func TestPatientCardWithDefaultTemplate(t *testing.T) {
+	out, err := documents.NewRenderer(documents.DefaultTemplate()).PatientCard(documents.PatientCard{Patient: patient})
+	require.NoError(t, err)
+	golden(t, "patient_card_default", out)
+	assert.NotContains(t, string(out), "/XObject")
+}
+
+func TestRenderingIsDeterministic(t *testing.T) {
+	r := renderer(t)
+	p := documents.Prescription{IssuedAt: issued, Patient: patient, Doctor: "Dr. Amara Okafor"}
+	assert.Equal(t, r.Prescription(p), r.Prescription(p))
+}
+
+func TestPatientCardRejectsUnencodableMRN(t *testing.T) {
+	_, err := documents.NewRenderer(documents.DefaultTemplate()).PatientCard(documents.PatientCard{Patient: documents.Patient{MRN: "é"}})
+	assert.Error(t, err)
+}
+
+func TestLoadTemplate(t *testing.T) {
+	tmpl, err := documents.LoadTemplate("")
+	require.NoError(t, err)
+	assert.Equal(t, "Hospital", tmpl.HospitalName)
+
+	dir := t.TempDir()
+	write := func(body string) string {
+		path := filepath.Join(dir, "template.json")
+		require.NoError(t, os.WriteFile(path, []byte(body), 0o644))
+		return path
+	}
+
+	_, err = documents.LoadTemplate(write(`{"hospital_name": "Clinic", "accent_color": "blue"}`))
+	assert.ErrorContains(t, err, "invalid color")
+	_, err = documents.LoadTemplate(write(`{"hospital_name": ""}`))
+	assert.ErrorContains(t, err, "hospital_name is required")
+	_, err = documents.LoadTemplate(write(`{"hospital_name": "Clinic", "logo": "missing.png"}`))
+	assert.ErrorContains(t, err, "failed to read document logo")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "logo.txt"), []byte("not an image"), 0o644))
+	_, err = documents.LoadTemplate(write(`{"hospital_name": "Clinic", "logo": "logo.txt"}`))
+	assert.ErrorContains(t, err, "invalid document logo")
+}
@@ -0,0 +1,192 @@
+package documents
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/prem0x01/hospital/internal/pdf"
+)
+
+// A4 page geometry, in points.
+const (
+	margin      = 50.0
+	labelWidth  = 120.0
+	contentTop  = 130.0
+	footerSpace = 60.0
+	lineGap     = 1.35
+)
+
+// layout flows content down A4 pages, starting a new page with the
+// hospital header whenever the next block does not fit.
+type layout struct {
+	tmpl  *Template
+	title string
+	doc   *pdf.Document
+	page  *pdf.Page
+	pages []*pdf.Page
+	y     float64
+}
+
+func newLayout(tmpl *Template, title string) *layout {
+	l := &layout{tmpl: tmpl, title: title, doc: pdf.New(title)}
+	l.newPage()
+	return l
+}
+
+func (l *layout) width() float64 { return pdf.A4Width - 2*margin }
+
+func (l *layout) newPage() {
+	p := l.doc.AddPage(pdf.A4Width, pdf.A4Height)
+	l.page = p
+	l.pages = append(l.pages, p)
+	t := l.tmpl
+
+	x := margin
+	if t.logo != nil {
+		w, h := fit(t.logo, 60, 60)
+		p.Image(t.logo, margin, 40+(60-h)/2, w, h)
+		x += w + 12
+	}
+	p.SetFillColor(0, 0, 0)
+	p.Text(x, 58, pdf.HelveticaBold, 16, t.HospitalName)
+	p.SetFillColor(90, 90, 90)
+	for i, line := range t.Header {
+		p.Text(x, 74+float64(i)*11, pdf.Helvetica, 9, line)
+	}
+
+	p.SetFillColor(t.accent[0], t.accent[1], t.accent[2])
+	p.Text(pdf.A4Width-margin-pdf.HelveticaBold.Width(l.title, 14), 58, pdf.HelveticaBold, 14, l.title)
+	p.SetStrokeColor(t.accent[0], t.accent[1], t.accent[2])
+	p.Line(margin, 110, pdf.A4Width-margin, 110, 1.5)
+	p.SetFillColor(0, 0, 0)
+	l.y = contentTop
+}
+
+// ensure starts a new page unless h more points fit on this one.
+func (l *layout) ensure(h float64) {
+	if l.y+h > pdf.A4Height-footerSpace {
+		l.newPage()
+	}
+}
+
+func (l *layout) space(h float64) { l.y += h }
+
+// heading starts a section. It is kept with at least two lines of what
+// follows.
+func (l *layout) heading(s string) {
+	l.ensure(18 + 3*10*lineGap)
+	l.y += 18
+	t := l.tmpl
+	l.page.SetFillColor(t.accent[0], t.accent[1], t.accent[2])
+	l.page.Text(margin, l.y, pdf.HelveticaBold, 11, s)
+	l.page.SetStrokeColor(200, 200, 200)
+	l.page.Line(margin, l.y+4, pdf.A4Width-margin, l.y+4, 0.5)
+	l.page.SetFillColor(0, 0, 0)
+	l.y += 8
+}
+
+// field prints a label with its value wrapped beside it.
+func (l *layout) field(label, value string) {
+	if value == "" {
+		value = "-"
+	}
+	const size = 10
+	lines := wrap(value, pdf.Helvetica, size, l.width()-labelWidth)
+	for i, line := range lines {
+		l.ensure(size * lineGap)
+		l.y += size * lineGap
+		if i == 0 {
+			l.page.Text(margin, l.y, pdf.HelveticaBold, size, label)
+		}
+		l.page.Text(margin+labelWidth, l.y, pdf.Helvetica, size, line)
+	}
+}
+
+// paragraph prints text wrapped to the page width, indented by indent.
+func (l *layout) paragraph(text string, font pdf.Font, size, indent float64) {
+	for _, line := range wrap(text, font, size, l.width()-indent) {
+		l.ensure(size * lineGap)
+		l.y += size * lineGap
+		l.page.Text(margin+indent, l.y, font, size, line)
+	}
+}
+
+// finish draws the footer on every page and returns the PDF.
+func (l *layout) finish() []byte {
+	for i, p := range l.pages {
+		y := pdf.A4Height - 30
+		p.SetStrokeColor(200, 200, 200)
+		p.Line(margin, y-14, pdf.A4Width-margin, y-14, 0.5)
+		p.SetFillColor(90, 90, 90)
+		if l.tmpl.Footer != "" {
+			p.Text(margin, y, pdf.Helvetica, 8, l.tmpl.Footer)
+		}
+		num := fmt.Sprintf("Page %d of %d", i+1, len(l.pages))
+		p.Text(pdf.A4Width-margin-pdf.Helvetica.Width(num, 8), y, pdf.Helvetica, 8, num)
+	}
+	return l.doc.Bytes()
+}
+
+// fit scales img to fit within w by h, keeping its aspect ratio.
+func fit(img *pdf.Image, w, h float64) (float64, float64) {
+	scale := min(w/float64(img.Width), h/float64(img.Height))
+	return float64(img.Width) * scale, float64(img.Height) * scale
+}
+
+// wrap breaks text into lines no wider than width. Line breaks in the text
+// are kept; words longer than a line are split.
+func wrap(text string, font pdf.Font, size, width float64) []string {
+	var lines []string
+	for _, para := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			for font.Width(word, size) > width {
+				if line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				n := fitPrefix(word, font, size, width)
+				lines = append(lines, word[:n])
+				word = word[n:]
+			}
+			switch {
+			case line == "":
+				line = word
+			case font.Width(line+" "+word, size) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// fitPrefix returns the length in bytes of the longest prefix of word that
+// fits in width, always at least one character.
+func fitPrefix(word string, font pdf.Font, size, width float64) int {
+	n := 0
+	for i, r := range word {
+		end := i + utf8.RuneLen(r)
+		if n > 0 && font.Width(word[:end], size) > width {
+			break
+		}
+		n = end
+	}
+	return n
+}
+
+// truncate shortens s with an ellipsis so it fits in width.
+func truncate(s string, font pdf.Font, size, width float64) string {
+	if font.Width(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && font.Width(string(runes)+"…", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
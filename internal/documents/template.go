@@ -0,0 +1,96 @@
+// Package documents renders the PDFs handed to patients: visit summaries,
+// prescriptions and patient ID cards. Rendering depends only on its input
+// and the hospital template, so a document can be regenerated byte for
+// byte and compared against golden files.
+package documents
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/prem0x01/hospital/internal/pdf"
+)
+
+// Template holds what each hospital customises on its documents.
+type Template struct {
+	HospitalName string `json:"hospital_name"`
+	// Header lines are printed under the hospital name, typically the
+	// address and switchboard number.
+	Header []string `json:"header"`
+	Footer string   `json:"footer"`
+	// Logo is a JPEG or PNG file, relative to the template file.
+	Logo string `json:"logo"`
+	// AccentColor is used for the title, rules and the card banner, as
+	// "#rrggbb".
+	AccentColor string `json:"accent_color"`
+
+	logo   *pdf.Image
+	accent [3]uint8
+}
+
+const defaultAccent = "#1f4e79"
+
+// DefaultTemplate is used when no template file is configured.
+func DefaultTemplate() *Template {
+	t := &Template{HospitalName: "Hospital", AccentColor: defaultAccent}
+	t.accent, _ = parseColor(defaultAccent)
+	return t
+}
+
+// LoadTemplate reads a template from a JSON file in the same shape as
+// Template. An empty path returns DefaultTemplate.
+func LoadTemplate(path string) (*Template, error) {
+	if path == "" {
+		return DefaultTemplate(), nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document template: %w", err)
+	}
+
+	t := DefaultTemplate()
+	if err := json.Unmarshal(raw, t); err != nil {
+		return nil, fmt.Errorf("invalid document template: %w", err)
+	}
+	if strings.TrimSpace(t.HospitalName) == "" {
+		return nil, fmt.Errorf("invalid document template: hospital_name is required")
+	}
+	if t.accent, err = parseColor(t.AccentColor); err != nil {
+		return nil, fmt.Errorf("invalid document template: %w", err)
+	}
+
+	if t.Logo != "" {
+		logoPath := t.Logo
+		if !filepath.IsAbs(logoPath) {
+			logoPath = filepath.Join(filepath.Dir(path), logoPath)
+		}
+		data, err := os.ReadFile(logoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read document logo: %w", err)
+		}
+		if t.logo, err = pdf.LoadImage(data); err != nil {
+			return nil, fmt.Errorf("invalid document logo: %w", err)
+		}
+	}
+	return t, nil
+}
+
+func parseColor(s string) ([3]uint8, error) {
+	var c [3]uint8
+	if len(s) != 7 || s[0] != '#' {
+		return c, fmt.Errorf("invalid color %q, want #rrggbb", s)
+	}
+	for i := range c {
+		v, err := strconv.ParseUint(s[1+2*i:3+2*i], 16, 8)
+		if err != nil {
+			return c, fmt.Errorf("invalid color %q, want #rrggbb", s)
+		}
+		c[i] = uint8(v)
+	}
+	return c, nil
+}
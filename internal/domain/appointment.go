@@ -1,39 +1,87 @@
 package domain
 
 import (
-	//	"time"
+	"fmt"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Appointment struct {
-	ID              int32            `json:"id" db:"id"`
-	PatientID       *int32           `json:"patient_id" db:"patient_id"`
-	DoctorID        *int32           `json:"doctor_id" db:"doctor_id"`
-	AppointmentDate pgtype.Timestamp `json:"appointment_date" db:"appointment_date"`
-	Status          *string          `json:"status" db:"status"`
-	Notes           *string          `json:"notes" db:"notes"`
-	Diagnosis       *string          `json:"diagnosis" db:"diagnosis"`
-	TreatmentPlan   *string          `json:"treatment_plan" db:"treatment_plan"`
-	CreatedBy       *int32           `json:"created_by" db:"created_by"`
-	CreatedAt       pgtype.Timestamp `json:"created_at" db:"created_at"`
-	UpdatedAt       pgtype.Timestamp `json:"updated_at" db:"updated_at"`
-	PatientName     string           `json:"patient_name,omitempty"`
-	DoctorName      string           `json:"doctor_name,omitempty"`
+	ID              int32              `json:"id" db:"id"`
+	PatientID       *int32             `json:"patient_id" db:"patient_id"`
+	DoctorID        *int32             `json:"doctor_id" db:"doctor_id"`
+	AppointmentDate pgtype.Timestamptz `json:"appointment_date" db:"appointment_date"`
+	EndTime         pgtype.Timestamptz `json:"end_time" db:"end_time"`
+	SeriesID        *int32             `json:"series_id,omitempty" db:"series_id"`
+	DepartmentID    *int32             `json:"department_id" db:"department_id"`
+	RoomID          *int32             `json:"room_id" db:"room_id"`
+	Status          *string            `json:"status" db:"status"`
+	Notes           *string            `json:"notes" db:"notes"`
+	Diagnosis       *string            `json:"diagnosis" db:"diagnosis"`
+	TreatmentPlan   *string            `json:"treatment_plan" db:"treatment_plan"`
+	CreatedBy       *int32             `json:"created_by" db:"created_by"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at" db:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at" db:"updated_at"`
+	PatientName     string             `json:"patient_name,omitempty"`
+	DoctorName      string             `json:"doctor_name,omitempty"`
 }
 
 type CreateAppointmentRequest struct {
 	PatientID       int32   `json:"patient_id" binding:"required"`
 	DoctorID        *int32  `json:"doctor_id"`
 	AppointmentDate string  `json:"appointment_date" binding:"required"`
+	DurationMinutes int     `json:"duration_minutes" binding:"omitempty,min=5,max=720"`
+	DepartmentID    *int32  `json:"department_id"`
+	RoomID          *int32  `json:"room_id"`
 	Notes           *string `json:"notes"`
+	// OverrideAvailability books the doctor outside their working hours.
+	OverrideAvailability bool `json:"override_availability"`
 }
 
 type UpdateAppointmentRequest struct {
 	DoctorID        *int32  `json:"doctor_id"`
 	AppointmentDate *string `json:"appointment_date"`
-	Status          *string `json:"status"`
-	Notes           *string `json:"notes"`
-	Diagnosis       *string `json:"diagnosis"`
-	TreatmentPlan   *string `json:"treatment_plan"`
+	DurationMinutes *int    `json:"duration_minutes" binding:"omitempty,min=5,max=720"`
+	DepartmentID    *int32  `json:"department_id"`
+	RoomID          *int32  `json:"room_id"`
+	Status          *string `json:"status" binding:"omitempty,oneof=scheduled checked_in in_progress completed cancelled no_show"`
+	// StatusReason is required when Status is cancelled.
+	StatusReason *string `json:"status_reason"`
+	Notes        *string `json:"notes"`
+	// Diagnosis and TreatmentPlan are saved as the assessment and plan of
+	// a new version of the appointment's clinical note.
+	Diagnosis     *string `json:"diagnosis"`
+	TreatmentPlan *string `json:"treatment_plan"`
+
+	OverrideAvailability bool `json:"override_availability"`
+}
+
+// AppointmentConflict is an existing booking that overlaps the one being
+// saved. It carries no clinical fields so it can be shown to any caller.
+type AppointmentConflict struct {
+	ID              int32              `json:"id"`
+	PatientID       *int32             `json:"patient_id"`
+	DoctorID        *int32             `json:"doctor_id"`
+	RoomID          *int32             `json:"room_id"`
+	AppointmentDate pgtype.Timestamptz `json:"appointment_date"`
+	EndTime         pgtype.Timestamptz `json:"end_time"`
+}
+
+// AppointmentFilter narrows appointment lists.
+type AppointmentFilter struct {
+	DepartmentID *int32
+}
+
+// AppointmentConflictError is returned when a doctor, patient or room
+// would be booked twice at the same time.
+type AppointmentConflictError struct {
+	Conflicts []AppointmentConflict
+}
+
+func (e *AppointmentConflictError) Error() string {
+	return fmt.Sprintf("appointment overlaps %d existing appointment(s)", len(e.Conflicts))
+}
+
+func (e *AppointmentConflictError) Unwrap() error {
+	return ErrConflict
 }
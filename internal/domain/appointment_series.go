@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// Scopes of an edit to an appointment that belongs to a series.
+const (
+	SeriesScopeThis      = "this"
+	SeriesScopeFollowing = "following"
+	SeriesScopeAll       = "all"
+)
+
+// AppointmentSeries is a recurring booking. RRule is an RFC 5545
+// recurrence rule whose first occurrence is StartsAt.
+type AppointmentSeries struct {
+	ID              int32         `json:"id"`
+	PatientID       int32         `json:"patient_id"`
+	DoctorID        *int32        `json:"doctor_id"`
+	RRule           string        `json:"rrule"`
+	StartsAt        time.Time     `json:"starts_at"`
+	DurationMinutes int32         `json:"duration_minutes"`
+	Notes           *string       `json:"notes"`
+	CreatedBy       *int32        `json:"created_by"`
+	CreatedAt       time.Time     `json:"created_at"`
+	CancelledAt     *time.Time    `json:"cancelled_at"`
+	Appointments    []Appointment `json:"appointments,omitempty"`
+}
+
+type CreateAppointmentSeriesRequest struct {
+	PatientID int32  `json:"patient_id" binding:"required"`
+	DoctorID  *int32 `json:"doctor_id"`
+	// AppointmentDate is the first occurrence; RRule repeats it.
+	AppointmentDate      string  `json:"appointment_date" binding:"required"`
+	DurationMinutes      int     `json:"duration_minutes" binding:"omitempty,min=5,max=720"`
+	RRule                string  `json:"rrule" binding:"required"`
+	Notes                *string `json:"notes"`
+	OverrideAvailability bool    `json:"override_availability"`
+}
+
+type CancelAppointmentSeriesRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// OccurrenceFailure is an occurrence of a series that could not be booked,
+// moved or cancelled. AppointmentID is nil if it was never booked.
+type OccurrenceFailure struct {
+	AppointmentID   *int32                `json:"appointment_id,omitempty"`
+	AppointmentDate time.Time             `json:"appointment_date"`
+	Error           string                `json:"error"`
+	Conflicts       []AppointmentConflict `json:"conflicts,omitempty"`
+}
+
+// AppointmentSeriesResult reports which occurrences an operation on a
+// series succeeded for and which it did not.
+type AppointmentSeriesResult struct {
+	Series    *AppointmentSeries  `json:"series,omitempty"`
+	Succeeded []Appointment       `json:"succeeded"`
+	Failed    []OccurrenceFailure `json:"failed"`
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	AppointmentScheduled  = "scheduled"
+	AppointmentCheckedIn  = "checked_in"
+	AppointmentInProgress = "in_progress"
+	AppointmentCompleted  = "completed"
+	AppointmentCancelled  = "cancelled"
+	AppointmentNoShow     = "no_show"
+)
+
+type statusTransition struct {
+	from string
+	to   string
+}
+
+// appointmentTransitions lists every allowed status change and the roles
+// that may make it. Completed, cancelled and no_show are final.
+var appointmentTransitions = map[statusTransition][]string{
+	{AppointmentScheduled, AppointmentCheckedIn}:  {RoleReceptionist},
+	{AppointmentScheduled, AppointmentCancelled}:  {RoleReceptionist, RoleDoctor},
+	{AppointmentScheduled, AppointmentNoShow}:     {RoleReceptionist},
+	{AppointmentCheckedIn, AppointmentInProgress}: {RoleDoctor},
+	{AppointmentCheckedIn, AppointmentCancelled}:  {RoleReceptionist, RoleDoctor},
+	{AppointmentInProgress, AppointmentCompleted}: {RoleDoctor},
+}
+
+// CheckAppointmentTransition returns ErrConflict if an appointment cannot
+// go from one status to the other, and ErrForbidden if it can but not by
+// role.
+func CheckAppointmentTransition(from, to, role string) error {
+	roles, ok := appointmentTransitions[statusTransition{from, to}]
+	if !ok {
+		return fmt.Errorf("%w: appointment cannot go from %s to %s", ErrConflict, from, to)
+	}
+	for _, r := range roles {
+		if r == role {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s cannot move an appointment from %s to %s", ErrForbidden, role, from, to)
+}
+
+// AppointmentStatusChange is one entry of an appointment's status history.
+// FromStatus is nil for the entry written when the appointment was booked.
+type AppointmentStatusChange struct {
+	ID            int32     `json:"id"`
+	AppointmentID int32     `json:"appointment_id"`
+	FromStatus    *string   `json:"from_status"`
+	ToStatus      string    `json:"to_status"`
+	Reason        *string   `json:"reason,omitempty"`
+	ChangedBy     *int32    `json:"changed_by"`
+	ChangedByName string    `json:"changed_by_name,omitempty"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
+
+type AppointmentStatusRequest struct {
+	Status string  `json:"status" binding:"required,oneof=scheduled checked_in in_progress completed cancelled no_show"`
+	Reason *string `json:"reason"`
+}
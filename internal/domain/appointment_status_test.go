@@ -0,0 +1,34 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppointmentHappyPath(t *testing.T) {
+	assert.NoError(t, domain.CheckAppointmentTransition(domain.AppointmentScheduled, domain.AppointmentCheckedIn, domain.RoleReceptionist))
+	assert.NoError(t, domain.CheckAppointmentTransition(domain.AppointmentCheckedIn, domain.AppointmentInProgress, domain.RoleDoctor))
+	assert.NoError(t, domain.CheckAppointmentTransition(domain.AppointmentInProgress, domain.AppointmentCompleted, domain.RoleDoctor))
+}
+
+func TestFinalStatusesCannotBeLeft(t *testing.T) {
+	for _, from := range []string{domain.AppointmentCompleted, domain.AppointmentCancelled, domain.AppointmentNoShow} {
+		err := domain.CheckAppointmentTransition(from, domain.AppointmentScheduled, domain.RoleReceptionist)
+		assert.ErrorIs(t, err, domain.ErrConflict, from)
+	}
+}
+
+func TestTransitionsAreRoleRestricted(t *testing.T) {
+	err := domain.CheckAppointmentTransition(domain.AppointmentCheckedIn, domain.AppointmentInProgress, domain.RoleReceptionist)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	err = domain.CheckAppointmentTransition(domain.AppointmentScheduled, domain.AppointmentNoShow, domain.RoleDoctor)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestSkippingStatesIsRejected(t *testing.T) {
+	err := domain.CheckAppointmentTransition(domain.AppointmentScheduled, domain.AppointmentCompleted, domain.RoleDoctor)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
@@ -0,0 +1,101 @@
+package domain
+
+import "time"
+
+const (
+	AuditActionRead   = "read"
+	AuditActionList   = "list"
+	AuditActionSearch = "search"
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+	// AuditActionNotify marks a message about a patient sent outside the
+	// system, e.g. an appointment reminder.
+	AuditActionNotify = "notify"
+	// AuditActionEmergencyAccess marks a break-the-glass grant, the
+	// highest priority event in the log.
+	AuditActionEmergencyAccess = "emergency_access"
+)
+
+const (
+	ResourcePatient           = "patient"
+	ResourceAppointment       = "appointment"
+	ResourceEmergencyAccess   = "emergency_access_grant"
+	ResourceCareTeamMember    = "care_team_member"
+	ResourceAppointmentSeries = "appointment_series"
+	ResourceWaitlistEntry     = "waitlist_entry"
+	ResourceWaitlistHold      = "waitlist_hold"
+	ResourceNotification      = "notification"
+	ResourceCalendarToken     = "calendar_token"
+	ResourceQueue             = "queue"
+	ResourceClinicalNote      = "clinical_note"
+	ResourceDiagnosis         = "encounter_diagnosis"
+	ResourceReferral          = "referral"
+	ResourcePatientDocument   = "patient_document"
+	ResourceConsent           = "consent"
+	ResourceInvoice           = "invoice"
+	ResourcePayment           = "payment"
+	ResourceCoverage          = "insurance_coverage"
+	ResourceClaim             = "claim"
+	ResourceAdmission         = "admission"
+	ResourceBedBoard          = "bed_board"
+	ResourceUser              = "user"
+)
+
+// Actor identifies who is performing an operation and from where. It is
+// built by the handlers from the authenticated request and passed down to
+// the services so that every access to patient data can be attributed.
+type Actor struct {
+	UserID    int32  `json:"user_id"`
+	Role      string `json:"role"`
+	IPAddress string `json:"ip_address"`
+	RequestID string `json:"request_id"`
+}
+
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type AuditEntry struct {
+	ID           int64                  `json:"id"`
+	OccurredAt   time.Time              `json:"occurred_at"`
+	ActorID      *int32                 `json:"actor_id"`
+	ActorRole    string                 `json:"actor_role"`
+	Action       string                 `json:"action"`
+	ResourceType string                 `json:"resource_type"`
+	ResourceID   *int32                 `json:"resource_id"`
+	PatientID    *int32                 `json:"patient_id"`
+	Changes      map[string]FieldChange `json:"changes,omitempty"`
+	IPAddress    string                 `json:"ip_address"`
+	RequestID    string                 `json:"request_id"`
+	PrevHash     string                 `json:"prev_hash"`
+	Hash         string                 `json:"hash"`
+}
+
+type AuditFilter struct {
+	PatientID *int32
+	ActorID   *int32
+	From      *time.Time
+	To        *time.Time
+	Limit     int32
+	Offset    int32
+}
+
+type AuditVerifyResult struct {
+	Valid          bool   `json:"valid"`
+	EntriesChecked int64  `json:"entries_checked"`
+	BrokenAtID     int64  `json:"broken_at_id,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// AuditStatus reports whether audit entries are being written. Entries
+// that could not be written are kept in memory and retried; while any are
+// pending the audit log is unhealthy.
+type AuditStatus struct {
+	Healthy        bool       `json:"healthy"`
+	PendingEntries int        `json:"pending_entries"`
+	Failures       int64      `json:"failures"`
+	LastFailure    *time.Time `json:"last_failure,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+}
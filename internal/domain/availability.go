@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+const (
+	ExceptionLeave   = "leave"
+	ExceptionHoliday = "holiday"
+	ExceptionOther   = "other"
+)
+
+// AvailabilityTemplate is one block of a doctor's weekly working hours.
+// Weekday is 0 for Sunday through 6 for Saturday; times are "HH:MM".
+type AvailabilityTemplate struct {
+	ID          int32               `json:"id,omitempty"`
+	Weekday     int                 `json:"weekday" binding:"min=0,max=6"`
+	StartTime   string              `json:"start_time" binding:"required"`
+	EndTime     string              `json:"end_time" binding:"required"`
+	SlotMinutes int                 `json:"slot_minutes" binding:"omitempty,min=5,max=480"`
+	Breaks      []AvailabilityBreak `json:"breaks" binding:"dive"`
+}
+
+type AvailabilityBreak struct {
+	StartTime string `json:"start_time" binding:"required"`
+	EndTime   string `json:"end_time" binding:"required"`
+}
+
+// AvailabilityException blocks a doctor's time outside the weekly
+// template, e.g. for leave or a public holiday.
+type AvailabilityException struct {
+	ID        int32     `json:"id"`
+	DoctorID  int32     `json:"doctor_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Kind      string    `json:"kind"`
+	Reason    *string   `json:"reason"`
+	CreatedBy *int32    `json:"created_by"`
+}
+
+type DoctorAvailability struct {
+	DoctorID   int32                   `json:"doctor_id"`
+	Specialty  *string                 `json:"specialty"`
+	Templates  []AvailabilityTemplate  `json:"templates"`
+	Exceptions []AvailabilityException `json:"exceptions"`
+}
+
+type DoctorProfile struct {
+	DoctorID  int32   `json:"doctor_id"`
+	Name      string  `json:"name"`
+	Specialty *string `json:"specialty"`
+}
+
+// Slot is a free, bookable period of a doctor's time.
+type Slot struct {
+	DoctorID   int32     `json:"doctor_id"`
+	DoctorName string    `json:"doctor_name,omitempty"`
+	Specialty  *string   `json:"specialty,omitempty"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+}
+
+// SetAvailabilityRequest replaces a doctor's weekly template.
+type SetAvailabilityRequest struct {
+	Specialty *string                `json:"specialty"`
+	Templates []AvailabilityTemplate `json:"templates" binding:"dive"`
+}
+
+type CreateAvailabilityExceptionRequest struct {
+	StartsAt string  `json:"starts_at" binding:"required"`
+	EndsAt   string  `json:"ends_at" binding:"required"`
+	Kind     string  `json:"kind" binding:"required,oneof=leave holiday other"`
+	Reason   *string `json:"reason"`
+}
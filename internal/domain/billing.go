@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	ServiceConsultation = "consultation"
+	ServiceProcedure    = "procedure"
+	ServiceLab          = "lab"
+	ServiceImaging      = "imaging"
+	ServiceMedication   = "medication"
+	ServiceOther        = "other"
+)
+
+// Invoice statuses. Drafts can be edited; once issued, the status follows
+// the payments.
+const (
+	InvoiceDraft         = "draft"
+	InvoiceIssued        = "issued"
+	InvoicePartiallyPaid = "partially_paid"
+	InvoicePaid          = "paid"
+	InvoiceVoid          = "void"
+)
+
+const (
+	PaymentKindPayment = "payment"
+	PaymentKindRefund  = "refund"
+)
+
+const (
+	PaymentCash         = "cash"
+	PaymentCard         = "card"
+	PaymentBankTransfer = "bank_transfer"
+	PaymentCheque       = "cheque"
+	PaymentInsurance    = "insurance"
+)
+
+// BillableService is an entry in the price catalog. All money in billing
+// is in integer minor units of the billing currency, such as cents, and
+// rates are in basis points: a TaxRateBP of 750 is 7.5%.
+type BillableService struct {
+	ID           int32     `json:"id"`
+	Code         string    `json:"code"`
+	Name         string    `json:"name"`
+	Description  *string   `json:"description"`
+	Category     string    `json:"category"`
+	DepartmentID *int32    `json:"department_id"`
+	PriceMinor   int64     `json:"price_minor"`
+	TaxRateBP    int32     `json:"tax_rate_bp"`
+	Active       bool      `json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// SaveBillableServiceRequest creates or replaces a catalog entry. A
+// consultation with a department is the default charge for that
+// department's appointments; one without is the default for the rest.
+type SaveBillableServiceRequest struct {
+	Code         string  `json:"code" binding:"required,max=30"`
+	Name         string  `json:"name" binding:"required,max=200"`
+	Description  *string `json:"description"`
+	Category     string  `json:"category" binding:"required,oneof=consultation procedure lab imaging medication other"`
+	DepartmentID *int32  `json:"department_id"`
+	PriceMinor   int64   `json:"price_minor" binding:"min=0,max=10000000000"`
+	TaxRateBP    int32   `json:"tax_rate_bp" binding:"min=0,max=10000"`
+	Active       *bool   `json:"active"`
+}
+
+// Invoice bills a patient, usually for one appointment. The amounts are
+// the sums of its lines; PaidMinor is what was paid less what was
+// refunded, and BalanceMinor what is still owed.
+type Invoice struct {
+	ID            int32         `json:"id"`
+	PatientID     int32         `json:"patient_id"`
+	AppointmentID *int32        `json:"appointment_id"`
+	Currency      string        `json:"currency"`
+	Status        string        `json:"status"`
+	SubtotalMinor int64         `json:"subtotal_minor"`
+	DiscountMinor int64         `json:"discount_minor"`
+	TaxMinor      int64         `json:"tax_minor"`
+	TotalMinor    int64         `json:"total_minor"`
+	PaidMinor     int64         `json:"paid_minor"`
+	BalanceMinor  int64         `json:"balance_minor"`
+	Notes         *string       `json:"notes"`
+	DueDate       pgtype.Date   `json:"due_date"`
+	IssuedAt      *time.Time    `json:"issued_at"`
+	VoidedAt      *time.Time    `json:"voided_at"`
+	VoidReason    *string       `json:"void_reason"`
+	CreatedBy     *int32        `json:"created_by"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+	Lines         []InvoiceLine `json:"lines,omitempty"`
+	Payments      []Payment     `json:"payments,omitempty"`
+}
+
+// InvoiceLine is one charge on an invoice, with the price and tax rate
+// copied from the catalog when it was added. ServiceCode is only filled
+// in for claims.
+type InvoiceLine struct {
+	ID             int32   `json:"id"`
+	InvoiceID      int32   `json:"invoice_id"`
+	ServiceID      *int32  `json:"service_id"`
+	ServiceCode    *string `json:"service_code,omitempty"`
+	Description    string  `json:"description"`
+	Quantity       int32   `json:"quantity"`
+	UnitPriceMinor int64   `json:"unit_price_minor"`
+	TaxRateBP      int32   `json:"tax_rate_bp"`
+	SubtotalMinor  int64   `json:"subtotal_minor"`
+	DiscountMinor  int64   `json:"discount_minor"`
+	TaxMinor       int64   `json:"tax_minor"`
+	TotalMinor     int64   `json:"total_minor"`
+}
+
+// Payment is money received against an invoice, or with Kind refund,
+// money given back from the earlier payment RefundOf.
+type Payment struct {
+	ID          int32     `json:"id"`
+	InvoiceID   int32     `json:"invoice_id"`
+	Kind        string    `json:"kind"`
+	Method      string    `json:"method"`
+	AmountMinor int64     `json:"amount_minor"`
+	Reference   *string   `json:"reference"`
+	RefundOf    *int32    `json:"refund_of"`
+	Reason      *string   `json:"reason"`
+	ReceivedBy  *int32    `json:"received_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// InvoiceFilter narrows an invoice list.
+type InvoiceFilter struct {
+	PatientID *int32
+	Status    *string
+	Limit     int32
+	Offset    int32
+}
+
+// PatientBalance is what a patient owes on issued invoices. OverdueMinor
+// is the part of it on invoices past their due date.
+type PatientBalance struct {
+	PatientID        int32     `json:"patient_id"`
+	Currency         string    `json:"currency"`
+	OutstandingMinor int64     `json:"outstanding_minor"`
+	OverdueMinor     int64     `json:"overdue_minor"`
+	OpenInvoices     int32     `json:"open_invoices"`
+	Invoices         []Invoice `json:"invoices,omitempty"`
+}
+
+// InvoiceLineRequest adds a line, either from the catalog by ServiceID or
+// as a free-text charge with Description and UnitPriceMinor. For catalog
+// lines, UnitPriceMinor and TaxRateBP override the catalog's. The
+// discount is DiscountMinor plus DiscountPercent of the line's subtotal.
+type InvoiceLineRequest struct {
+	ServiceID       *int32  `json:"service_id"`
+	Description     *string `json:"description" binding:"omitempty,max=200"`
+	Quantity        int32   `json:"quantity" binding:"omitempty,min=1,max=10000"`
+	UnitPriceMinor  *int64  `json:"unit_price_minor" binding:"omitempty,min=0,max=10000000000"`
+	TaxRateBP       *int32  `json:"tax_rate_bp" binding:"omitempty,min=0,max=10000"`
+	DiscountMinor   int64   `json:"discount_minor" binding:"min=0"`
+	DiscountPercent int32   `json:"discount_percent" binding:"min=0,max=100"`
+}
+
+// CreateInvoiceRequest drafts an invoice for a completed appointment.
+// Without Items it charges the default consultation for the
+// appointment's department.
+type CreateInvoiceRequest struct {
+	Items []InvoiceLineRequest `json:"items" binding:"omitempty,dive"`
+	Notes *string              `json:"notes"`
+}
+
+// IssueInvoiceRequest finalises a draft. DueDate is "2006-01-02" and
+// defaults to the billing terms after today.
+type IssueInvoiceRequest struct {
+	DueDate *string `json:"due_date"`
+}
+
+type VoidInvoiceRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type RecordPaymentRequest struct {
+	Method      string  `json:"method" binding:"required,oneof=cash card bank_transfer cheque insurance"`
+	AmountMinor int64   `json:"amount_minor" binding:"required,min=1"`
+	Reference   *string `json:"reference" binding:"omitempty,max=100"`
+}
+
+// RefundPaymentRequest gives back part or all of a payment by the same
+// method.
+type RefundPaymentRequest struct {
+	AmountMinor int64   `json:"amount_minor" binding:"required,min=1"`
+	Reason      string  `json:"reason" binding:"required"`
+	Reference   *string `json:"reference" binding:"omitempty,max=100"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// CalendarToken gives read access to a doctor's appointment feed to anyone
+// who holds it, until it is revoked.
+type CalendarToken struct {
+	ID         int32      `json:"id"`
+	DoctorID   int32      `json:"doctor_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// NewCalendarToken is returned once, when a token is created. Token and
+// URL cannot be retrieved again later.
+type NewCalendarToken struct {
+	CalendarToken
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Ways a doctor can be on a patient's care team. Appointment membership is
+// derived from the appointments table; the others are stored explicitly.
+const (
+	CareTeamSourceAppointment = "appointment"
+	CareTeamSourceAssignment  = "assignment"
+	CareTeamSourceReferral    = "referral"
+)
+
+type CareTeamMember struct {
+	PatientID  int32     `json:"patient_id"`
+	DoctorID   int32     `json:"doctor_id"`
+	DoctorName string    `json:"doctor_name,omitempty"`
+	Source     string    `json:"source"`
+	AddedBy    *int32    `json:"added_by,omitempty"`
+	Since      time.Time `json:"since"`
+}
+
+type AddCareTeamMemberRequest struct {
+	DoctorID int32  `json:"doctor_id" binding:"required"`
+	Source   string `json:"source" binding:"omitempty,oneof=assignment referral"`
+}
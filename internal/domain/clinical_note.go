@@ -0,0 +1,77 @@
+package domain
+
+import "time"
+
+const (
+	ClinicalNoteDraft  = "draft"
+	ClinicalNoteSigned = "signed"
+)
+
+// SOAP holds the four sections of a clinical note.
+type SOAP struct {
+	Subjective *string `json:"subjective"`
+	Objective  *string `json:"objective"`
+	Assessment *string `json:"assessment"`
+	Plan       *string `json:"plan"`
+}
+
+// ClinicalNote is a SOAP note about a patient, usually the encounter note
+// of one appointment. Its sections are those of the current version.
+// Once signed it cannot be edited; later additions are addenda.
+type ClinicalNote struct {
+	ID             int32      `json:"id"`
+	PatientID      int32      `json:"patient_id"`
+	AppointmentID  *int32     `json:"appointment_id"`
+	AuthorID       *int32     `json:"author_id"`
+	Status         string     `json:"status"`
+	CurrentVersion int32      `json:"current_version"`
+	SignedBy       *int32     `json:"signed_by"`
+	SignedAt       *time.Time `json:"signed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	SOAP
+	Addenda []ClinicalNoteAddendum `json:"addenda,omitempty"`
+}
+
+// ClinicalNoteVersion is one saved state of a note's sections. Versions
+// are never changed.
+type ClinicalNoteVersion struct {
+	ID        int32     `json:"id"`
+	NoteID    int32     `json:"note_id"`
+	Version   int32     `json:"version"`
+	CreatedBy *int32    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	SOAP
+}
+
+// ClinicalNoteAddendum is text added to a note after it was signed.
+type ClinicalNoteAddendum struct {
+	ID        int32     `json:"id"`
+	NoteID    int32     `json:"note_id"`
+	Body      string    `json:"body"`
+	CreatedBy int32     `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateClinicalNoteRequest struct {
+	AppointmentID *int32 `json:"appointment_id"`
+	SOAP
+}
+
+// UpdateClinicalNoteRequest saves a new version of a draft note. The
+// sections replace those of BaseVersion, which must still be the current
+// version so that concurrent edits are not lost.
+type UpdateClinicalNoteRequest struct {
+	BaseVersion int32 `json:"base_version" binding:"required,min=1"`
+	SOAP
+}
+
+// SignClinicalNoteRequest signs Version, which must be the current
+// version, so the signer signs what they last read.
+type SignClinicalNoteRequest struct {
+	Version int32 `json:"version" binding:"required,min=1"`
+}
+
+type CreateAddendumRequest struct {
+	Body string `json:"body" binding:"required"`
+}
@@ -0,0 +1,100 @@
+package domain
+
+import "time"
+
+// Consent purposes. Other features check a patient's consent for one of
+// these before going ahead.
+const (
+	ConsentTreatment    = "treatment"
+	ConsentDataSharing  = "data_sharing"
+	ConsentSMSReminders = "sms_reminders"
+)
+
+const (
+	ConsentActive  = "active"
+	ConsentExpired = "expired"
+	ConsentRevoked = "revoked"
+)
+
+// ConsentTemplate is one version of the wording a patient agrees to for a
+// purpose. ValidityDays, if set, is how long consent to it lasts unless
+// the record gives its own expiry.
+type ConsentTemplate struct {
+	ID           int32     `json:"id"`
+	Purpose      string    `json:"purpose"`
+	Version      int32     `json:"version"`
+	Title        string    `json:"title"`
+	Body         string    `json:"body"`
+	ValidityDays *int32    `json:"validity_days"`
+	Current      bool      `json:"current"`
+	CreatedBy    *int32    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Consent records a patient's agreement to one template version, signed
+// by the patient or a representative and optionally witnessed.
+type Consent struct {
+	ID               int32      `json:"id"`
+	PatientID        int32      `json:"patient_id"`
+	TemplateID       int32      `json:"template_id"`
+	TemplateVersion  int32      `json:"template_version"`
+	TemplateTitle    string     `json:"template_title"`
+	Purpose          string     `json:"purpose"`
+	Status           string     `json:"status"`
+	SignedBy         string     `json:"signed_by"`
+	SignedAt         time.Time  `json:"signed_at"`
+	WitnessName      *string    `json:"witness_name"`
+	RecordedBy       *int32     `json:"recorded_by"`
+	RecordedByName   string     `json:"recorded_by_name,omitempty"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	RevokedAt        *time.Time `json:"revoked_at"`
+	RevokedBy        *int32     `json:"revoked_by"`
+	RevocationReason *string    `json:"revocation_reason"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// StatusAt reports whether the consent is in force at now.
+func (c *Consent) StatusAt(now time.Time) string {
+	switch {
+	case c.RevokedAt != nil:
+		return ConsentRevoked
+	case c.ExpiresAt != nil && !now.Before(*c.ExpiresAt):
+		return ConsentExpired
+	default:
+		return ConsentActive
+	}
+}
+
+// ConsentCheck answers whether a patient has consented to a purpose.
+// Consent is the latest record for the purpose, in force or not.
+type ConsentCheck struct {
+	PatientID int32    `json:"patient_id"`
+	Purpose   string   `json:"purpose"`
+	Granted   bool     `json:"granted"`
+	Reason    string   `json:"reason,omitempty"`
+	Consent   *Consent `json:"consent"`
+}
+
+// CreateConsentTemplateRequest publishes the next version of the
+// purpose's template.
+type CreateConsentTemplateRequest struct {
+	Purpose      string `json:"purpose" binding:"required,oneof=treatment data_sharing sms_reminders"`
+	Title        string `json:"title" binding:"required,max=200"`
+	Body         string `json:"body" binding:"required"`
+	ValidityDays *int32 `json:"validity_days" binding:"omitempty,min=1"`
+}
+
+// RecordConsentRequest records consent to the current version of a
+// template. SignedAt defaults to now and ExpiresAt to the template's
+// validity; both are RFC 3339 or wall-clock times in the hospital's zone.
+type RecordConsentRequest struct {
+	TemplateID  int32   `json:"template_id" binding:"required"`
+	SignedBy    string  `json:"signed_by" binding:"required,max=200"`
+	SignedAt    *string `json:"signed_at"`
+	WitnessName *string `json:"witness_name" binding:"omitempty,max=200"`
+	ExpiresAt   *string `json:"expires_at"`
+}
+
+type RevokeConsentRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
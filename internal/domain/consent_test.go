@@ -0,0 +1,30 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsentWithoutExpiryStaysActive(t *testing.T) {
+	signed := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	c := domain.Consent{SignedAt: signed}
+	assert.Equal(t, domain.ConsentActive, c.StatusAt(signed.AddDate(10, 0, 0)))
+}
+
+func TestConsentExpiresAtItsExpiry(t *testing.T) {
+	expires := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	c := domain.Consent{ExpiresAt: &expires}
+	assert.Equal(t, domain.ConsentActive, c.StatusAt(expires.Add(-time.Second)))
+	assert.Equal(t, domain.ConsentExpired, c.StatusAt(expires))
+}
+
+func TestRevocationOutranksExpiry(t *testing.T) {
+	expires := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	revoked := expires.AddDate(0, -6, 0)
+	c := domain.Consent{ExpiresAt: &expires, RevokedAt: &revoked}
+	assert.Equal(t, domain.ConsentRevoked, c.StatusAt(revoked))
+	assert.Equal(t, domain.ConsentRevoked, c.StatusAt(expires.AddDate(1, 0, 0)))
+}
@@ -0,0 +1,93 @@
+package domain
+
+import "time"
+
+type Department struct {
+	ID          int32     `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DepartmentDetail is a department with its doctors, specialties, rooms
+// and equipment.
+type DepartmentDetail struct {
+	Department
+	Doctors     []DoctorProfile `json:"doctors"`
+	Specialties []Specialty     `json:"specialties"`
+	Rooms       []Room          `json:"rooms"`
+	Equipment   []Equipment     `json:"equipment"`
+}
+
+type SaveDepartmentRequest struct {
+	Name        string  `json:"name" binding:"required,max=100"`
+	Description *string `json:"description"`
+}
+
+type Specialty struct {
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	DepartmentID *int32 `json:"department_id"`
+}
+
+type CreateSpecialtyRequest struct {
+	Name         string `json:"name" binding:"required,max=100"`
+	DepartmentID *int32 `json:"department_id"`
+}
+
+// Room is a consulting or treatment room. Appointments may be booked into
+// a room, and a room holds one appointment at a time.
+type Room struct {
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	DepartmentID *int32 `json:"department_id"`
+	Active       bool   `json:"active"`
+}
+
+// Equipment is a bookable item such as an ultrasound machine.
+type Equipment struct {
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	DepartmentID *int32 `json:"department_id"`
+	Active       bool   `json:"active"`
+}
+
+// SaveResourceRequest creates or updates a room or piece of equipment.
+// Active defaults to true.
+type SaveResourceRequest struct {
+	Name         string `json:"name" binding:"required,max=100"`
+	DepartmentID *int32 `json:"department_id"`
+	Active       *bool  `json:"active"`
+}
+
+// ResourceBooking is a period a room or piece of equipment is in use. It
+// carries no patient or clinical fields.
+type ResourceBooking struct {
+	ID            int32     `json:"id"`
+	AppointmentID *int32    `json:"appointment_id"`
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+	BookedBy      *int32    `json:"booked_by,omitempty"`
+}
+
+// CreateEquipmentBookingRequest books equipment either for an appointment,
+// for the appointment's time, or for an explicit period.
+type CreateEquipmentBookingRequest struct {
+	AppointmentID *int32  `json:"appointment_id"`
+	StartsAt      *string `json:"starts_at"`
+	EndsAt        *string `json:"ends_at"`
+}
+
+// ResourceConflictError is returned when a room or piece of equipment is
+// already booked for part of the requested time.
+type ResourceConflictError struct {
+	Conflicts []ResourceBooking
+}
+
+func (e *ResourceConflictError) Error() string {
+	return "resource is already booked for part of that time"
+}
+
+func (e *ResourceConflictError) Unwrap() error {
+	return ErrConflict
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+const (
+	DiagnosisPrimary   = "primary"
+	DiagnosisSecondary = "secondary"
+)
+
+const (
+	DiagnosisActive   = "active"
+	DiagnosisResolved = "resolved"
+	DiagnosisRuledOut = "ruled_out"
+)
+
+// Icd10Code is an entry of the ICD-10-CM catalog. Only billable codes can
+// be recorded as diagnoses; inactive ones were dropped from the latest
+// imported release.
+type Icd10Code struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Billable    bool   `json:"billable"`
+	Active      bool   `json:"active"`
+}
+
+// Icd10Search filters a catalog search. Query is either the start of a
+// code or words of the description.
+type Icd10Search struct {
+	Query    string
+	Billable *bool
+	Limit    int
+}
+
+// EncounterDiagnosis is a coded diagnosis made at an appointment.
+type EncounterDiagnosis struct {
+	ID            int32     `json:"id"`
+	AppointmentID int32     `json:"appointment_id"`
+	PatientID     int32     `json:"patient_id"`
+	Code          string    `json:"code"`
+	Description   string    `json:"description"`
+	Rank          string    `json:"rank"`
+	Status        string    `json:"status"`
+	Notes         *string   `json:"notes"`
+	RecordedBy    *int32    `json:"recorded_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type CreateDiagnosisRequest struct {
+	Code   string  `json:"code" binding:"required"`
+	Rank   string  `json:"rank" binding:"omitempty,oneof=primary secondary"`
+	Status string  `json:"status" binding:"omitempty,oneof=active resolved ruled_out"`
+	Notes  *string `json:"notes"`
+}
+
+// UpdateDiagnosisRequest changes the fields that are set.
+type UpdateDiagnosisRequest struct {
+	Rank   *string `json:"rank" binding:"omitempty,oneof=primary secondary"`
+	Status *string `json:"status" binding:"omitempty,oneof=active resolved ruled_out"`
+	Notes  *string `json:"notes"`
+}
+
+// Problem is an entry of a patient's problem list: a code the patient has
+// been diagnosed with, as of the latest encounter that recorded it.
+type Problem struct {
+	Code              string    `json:"code"`
+	Description       string    `json:"description"`
+	Status            string    `json:"status"`
+	FirstRecorded     time.Time `json:"first_recorded"`
+	LastRecorded      time.Time `json:"last_recorded"`
+	LastAppointmentID int32     `json:"last_appointment_id"`
+	Encounters        int32     `json:"encounters"`
+}
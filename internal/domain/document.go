@@ -0,0 +1,19 @@
+package domain
+
+// PrescriptionItem is one medication on a prescription.
+type PrescriptionItem struct {
+	Medication   string `json:"medication" binding:"required"`
+	Dosage       string `json:"dosage"`
+	Frequency    string `json:"frequency"`
+	Duration     string `json:"duration"`
+	Quantity     string `json:"quantity"`
+	Instructions string `json:"instructions"`
+}
+
+// CreatePrescriptionRequest describes a prescription to print for an
+// appointment. Prescriptions are rendered, not stored; the audit log keeps
+// the fact that one was issued.
+type CreatePrescriptionRequest struct {
+	Items []PrescriptionItem `json:"items" binding:"required,min=1,max=20,dive"`
+	Notes *string            `json:"notes"`
+}
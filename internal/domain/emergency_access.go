@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+const (
+	ReviewPending      = "pending"
+	ReviewAcknowledged = "acknowledged"
+	ReviewFlagged      = "flagged"
+)
+
+// EmergencyAccessGrant is a time-limited "break-the-glass" override that
+// lets a doctor open the chart of a patient outside their care team.
+type EmergencyAccessGrant struct {
+	ID            int32      `json:"id"`
+	PatientID     int32      `json:"patient_id"`
+	DoctorID      int32      `json:"doctor_id"`
+	Justification string     `json:"justification"`
+	GrantedAt     time.Time  `json:"granted_at"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	ReviewStatus  string     `json:"review_status"`
+	ReviewedBy    *int32     `json:"reviewed_by"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	ReviewNotes   *string    `json:"review_notes"`
+	PatientName   string     `json:"patient_name,omitempty"`
+	DoctorName    string     `json:"doctor_name,omitempty"`
+}
+
+type EmergencyAccessRequest struct {
+	Justification   string `json:"justification" binding:"required,min=10"`
+	DurationMinutes int    `json:"duration_minutes" binding:"omitempty,min=1,max=480"`
+}
+
+type EmergencyAccessReviewRequest struct {
+	Decision string  `json:"decision" binding:"required,oneof=acknowledged flagged"`
+	Notes    *string `json:"notes"`
+}
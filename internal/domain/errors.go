@@ -0,0 +1,17 @@
+package domain
+
+import "errors"
+
+// Sentinel errors returned by the services; handlers map them to HTTP
+// status codes with errors.Is.
+var (
+	ErrInvalid   = errors.New("invalid request")
+	ErrNotFound  = errors.New("not found")
+	ErrForbidden = errors.New("forbidden")
+	ErrConflict  = errors.New("conflict")
+	// ErrUnavailable means a doctor is not working at the requested time.
+	ErrUnavailable = errors.New("outside doctor availability")
+	// ErrNoConsent means the patient has not consented to what was asked,
+	// or the consent has expired or been revoked.
+	ErrNoConsent = errors.New("no patient consent")
+)
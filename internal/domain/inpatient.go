@@ -0,0 +1,248 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Bed statuses. A bed is occupied only by admitting or transferring a
+// patient into it, and is left to be cleaned when the patient moves out.
+const (
+	BedFree     = "free"
+	BedOccupied = "occupied"
+	BedCleaning = "cleaning"
+	BedBlocked  = "blocked"
+)
+
+const (
+	AdmissionAdmitted   = "admitted"
+	AdmissionDischarged = "discharged"
+)
+
+// Where a patient went on discharge.
+const (
+	DischargeHome           = "home"
+	DischargeTransferredOut = "transferred_out"
+	DischargeAgainstAdvice  = "against_advice"
+	DischargeDeceased       = "deceased"
+	DischargeOther          = "other"
+)
+
+// CheckBedStatusChange returns an error if staff may not set a bed from
+// one status to the other by hand. Occupancy is only changed by
+// admissions, transfers and discharges.
+func CheckBedStatusChange(from, to string) error {
+	if to == BedOccupied {
+		return fmt.Errorf("%w: a bed is occupied by admitting or transferring a patient to it", ErrInvalid)
+	}
+	if from == BedOccupied {
+		return fmt.Errorf("%w: the bed is occupied; transfer or discharge the patient first", ErrConflict)
+	}
+	return nil
+}
+
+// Ward is an inpatient ward. An inactive ward keeps its beds and history
+// but takes no new patients and is left off the board and census.
+type Ward struct {
+	ID           int32     `json:"id"`
+	Name         string    `json:"name"`
+	DepartmentID *int32    `json:"department_id"`
+	Active       bool      `json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// SaveWardRequest creates or updates a ward. Active defaults to true.
+type SaveWardRequest struct {
+	Name         string `json:"name" binding:"required,max=100"`
+	DepartmentID *int32 `json:"department_id"`
+	Active       *bool  `json:"active"`
+}
+
+type Bed struct {
+	ID              int32     `json:"id"`
+	WardID          int32     `json:"ward_id"`
+	Label           string    `json:"label"`
+	Status          string    `json:"status"`
+	StatusNote      *string   `json:"status_note"`
+	StatusChangedAt time.Time `json:"status_changed_at"`
+}
+
+// SaveBedRequest adds a bed to a ward or relabels one. Labels are unique
+// within a ward.
+type SaveBedRequest struct {
+	Label string `json:"label" binding:"required,max=20"`
+}
+
+// SetBedStatusRequest marks a bed cleaned, being cleaned or out of
+// service. A note, such as why a bed is blocked, is shown on the board.
+type SetBedStatusRequest struct {
+	Status string  `json:"status" binding:"required,oneof=free cleaning blocked"`
+	Note   *string `json:"note"`
+}
+
+// Admission is a patient's inpatient stay. BedID, BedLabel, WardID and
+// WardName are where the patient is now, and are unset once discharged.
+// Stays lists every bed the patient has been in, first to last.
+type Admission struct {
+	ID                   int32      `json:"id"`
+	PatientID            int32      `json:"patient_id"`
+	PatientName          string     `json:"patient_name"`
+	AttendingDoctorID    int32      `json:"attending_doctor_id"`
+	AttendingDoctorName  string     `json:"attending_doctor_name"`
+	AppointmentID        *int32     `json:"appointment_id"`
+	Reason               string     `json:"reason"`
+	Status               string     `json:"status"`
+	BedID                *int32     `json:"bed_id"`
+	BedLabel             *string    `json:"bed_label"`
+	WardID               *int32     `json:"ward_id"`
+	WardName             *string    `json:"ward_name"`
+	AdmittedAt           time.Time  `json:"admitted_at"`
+	AdmittedBy           *int32     `json:"admitted_by"`
+	DischargedAt         *time.Time `json:"discharged_at"`
+	DischargedBy         *int32     `json:"discharged_by"`
+	DischargeDisposition *string    `json:"discharge_disposition"`
+	DischargeNotes       *string    `json:"discharge_notes"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+	Stays                []BedStay  `json:"stays,omitempty"`
+}
+
+// BedStay is a period an admitted patient spent in one bed. EndedAt is
+// unset for the bed the patient is in.
+type BedStay struct {
+	ID          int32      `json:"id"`
+	AdmissionID int32      `json:"admission_id"`
+	BedID       int32      `json:"bed_id"`
+	BedLabel    string     `json:"bed_label"`
+	WardID      int32      `json:"ward_id"`
+	WardName    string     `json:"ward_name"`
+	StartedAt   time.Time  `json:"started_at"`
+	EndedAt     *time.Time `json:"ended_at"`
+	Reason      *string    `json:"reason"`
+	AssignedBy  *int32     `json:"assigned_by"`
+}
+
+// AdmitRequest admits a patient to a free bed. AttendingDoctorID defaults
+// to the admitting doctor.
+type AdmitRequest struct {
+	PatientID         int32  `json:"patient_id" binding:"required"`
+	BedID             int32  `json:"bed_id" binding:"required"`
+	AttendingDoctorID *int32 `json:"attending_doctor_id"`
+	AppointmentID     *int32 `json:"appointment_id"`
+	Reason            string `json:"reason" binding:"required"`
+}
+
+// TransferRequest moves an admitted patient to another free bed, in the
+// same ward or another, optionally handing them to another doctor.
+type TransferRequest struct {
+	BedID             int32   `json:"bed_id" binding:"required"`
+	AttendingDoctorID *int32  `json:"attending_doctor_id"`
+	Reason            *string `json:"reason"`
+}
+
+type DischargeRequest struct {
+	Disposition string  `json:"disposition" binding:"required,oneof=home transferred_out against_advice deceased other"`
+	Notes       *string `json:"notes"`
+}
+
+// AdmissionFilter narrows an admission list. CareDoctorID limits it to
+// patients under that doctor's care.
+type AdmissionFilter struct {
+	Status       *string
+	WardID       *int32
+	PatientID    *int32
+	CareDoctorID *int32
+	Limit        int32
+	Offset       int32
+}
+
+// BedCounts sums a ward's beds by status. OccupancyPercent is occupied
+// beds out of those not blocked, to one decimal place.
+type BedCounts struct {
+	Total            int     `json:"total"`
+	Free             int     `json:"free"`
+	Occupied         int     `json:"occupied"`
+	Cleaning         int     `json:"cleaning"`
+	Blocked          int     `json:"blocked"`
+	OccupancyPercent float64 `json:"occupancy_percent"`
+}
+
+// CountBeds sums beds with the given statuses.
+func CountBeds(statuses []string) BedCounts {
+	var c BedCounts
+	for _, s := range statuses {
+		c.Total++
+		switch s {
+		case BedFree:
+			c.Free++
+		case BedOccupied:
+			c.Occupied++
+		case BedCleaning:
+			c.Cleaning++
+		case BedBlocked:
+			c.Blocked++
+		}
+	}
+	c.OccupancyPercent = OccupancyPercent(int64(c.Occupied), int64(c.Total-c.Blocked))
+	return c
+}
+
+// OccupancyPercent is occupied out of beds as a percentage to one decimal
+// place, or 0 for a ward without beds.
+func OccupancyPercent(occupied, beds int64) float64 {
+	if beds <= 0 {
+		return 0
+	}
+	return math.Round(float64(occupied)*1000/float64(beds)) / 10
+}
+
+// BoardBed is a bed on the occupancy board. Occupant is set while a
+// patient is in it.
+type BoardBed struct {
+	BedID           int32        `json:"bed_id"`
+	Label           string       `json:"label"`
+	Status          string       `json:"status"`
+	StatusNote      *string      `json:"status_note"`
+	StatusChangedAt time.Time    `json:"status_changed_at"`
+	Occupant        *BedOccupant `json:"occupant"`
+}
+
+// BedOccupant is the patient in a bed. Like the queue, the board carries
+// only the patient's initials so it can be shown on a ward display; the
+// admission has the rest.
+type BedOccupant struct {
+	AdmissionID       int32     `json:"admission_id"`
+	PatientID         int32     `json:"patient_id"`
+	PatientName       string    `json:"-"`
+	PatientInitials   string    `json:"patient_initials"`
+	AttendingDoctorID int32     `json:"attending_doctor_id"`
+	AdmittedAt        time.Time `json:"admitted_at"`
+	InBedSince        time.Time `json:"in_bed_since"`
+}
+
+// WardBoard is the live state of a ward's beds.
+type WardBoard struct {
+	WardID   int32      `json:"ward_id"`
+	WardName string     `json:"ward_name"`
+	Counts   BedCounts  `json:"counts"`
+	Beds     []BoardBed `json:"beds"`
+}
+
+// WardCensus is a ward's census for one day: the patients in it at the
+// end of the day (or now, for today) and the day's movements. A move
+// between beds of the same ward is neither a transfer in nor out. Beds
+// counts the ward's beds that are not blocked.
+type WardCensus struct {
+	WardID           int32   `json:"ward_id"`
+	WardName         string  `json:"ward_name"`
+	Date             string  `json:"date"`
+	Beds             int64   `json:"beds"`
+	Census           int64   `json:"census"`
+	OccupancyPercent float64 `json:"occupancy_percent"`
+	Admissions       int64   `json:"admissions"`
+	TransfersIn      int64   `json:"transfers_in"`
+	TransfersOut     int64   `json:"transfers_out"`
+	Discharges       int64   `json:"discharges"`
+}
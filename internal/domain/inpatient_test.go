@@ -0,0 +1,26 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBedsAreNotOccupiedByHand(t *testing.T) {
+	assert.ErrorIs(t, domain.CheckBedStatusChange(domain.BedFree, domain.BedOccupied), domain.ErrInvalid)
+	assert.ErrorIs(t, domain.CheckBedStatusChange(domain.BedOccupied, domain.BedCleaning), domain.ErrConflict)
+	assert.NoError(t, domain.CheckBedStatusChange(domain.BedCleaning, domain.BedFree))
+	assert.NoError(t, domain.CheckBedStatusChange(domain.BedFree, domain.BedBlocked))
+}
+
+func TestCountBeds(t *testing.T) {
+	c := domain.CountBeds([]string{domain.BedOccupied, domain.BedOccupied, domain.BedFree, domain.BedCleaning, domain.BedBlocked, domain.BedFree, domain.BedFree})
+	assert.Equal(t, domain.BedCounts{Total: 7, Free: 3, Occupied: 2, Cleaning: 1, Blocked: 1, OccupancyPercent: 33.3}, c)
+}
+
+func TestOccupancyOfWardWithoutBeds(t *testing.T) {
+	assert.Zero(t, domain.OccupancyPercent(0, 0))
+	assert.Zero(t, domain.CountBeds(nil).OccupancyPercent)
+	assert.Equal(t, 100.0, domain.OccupancyPercent(4, 4))
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Coverage priorities: the primary policy is claimed against first and
+// the others for what it leaves.
+const (
+	CoveragePrimary   = 1
+	CoverageSecondary = 2
+	CoverageTertiary  = 3
+)
+
+// The patient's relationship to the policy's subscriber.
+const (
+	RelationshipSelf   = "self"
+	RelationshipSpouse = "spouse"
+	RelationshipChild  = "child"
+	RelationshipOther  = "other"
+)
+
+const (
+	ClaimDraft       = "draft"
+	ClaimSubmitted   = "submitted"
+	ClaimAdjudicated = "adjudicated"
+	ClaimPaid        = "paid"
+	ClaimDenied      = "denied"
+	ClaimCancelled   = "cancelled"
+)
+
+// claimTransitions lists every allowed claim status change. Paid, denied
+// and cancelled are final.
+var claimTransitions = map[statusTransition]bool{
+	{ClaimDraft, ClaimSubmitted}:       true,
+	{ClaimDraft, ClaimCancelled}:       true,
+	{ClaimSubmitted, ClaimAdjudicated}: true,
+	{ClaimSubmitted, ClaimDenied}:      true,
+	{ClaimSubmitted, ClaimCancelled}:   true,
+	{ClaimAdjudicated, ClaimPaid}:      true,
+}
+
+// CheckClaimTransition returns ErrConflict if a claim cannot go from one
+// status to the other.
+func CheckClaimTransition(from, to string) error {
+	if !claimTransitions[statusTransition{from, to}] {
+		return fmt.Errorf("%w: claim cannot go from %s to %s", ErrConflict, from, to)
+	}
+	return nil
+}
+
+// InsurancePayer is an insurer claims are sent to. PayerCode is the
+// identifier the clearinghouse knows it by.
+type InsurancePayer struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	PayerCode string    `json:"payer_code"`
+	Phone     *string   `json:"phone"`
+	Email     *string   `json:"email"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SaveInsurancePayerRequest struct {
+	Name      string  `json:"name" binding:"required,max=200"`
+	PayerCode string  `json:"payer_code" binding:"required,max=30"`
+	Phone     *string `json:"phone" binding:"omitempty,max=30"`
+	Email     *string `json:"email" binding:"omitempty,email"`
+	Active    *bool   `json:"active"`
+}
+
+// Coverage is a patient's insurance policy. ValidTo is the last covered
+// day, or unset for open-ended cover. Under it the patient pays
+// CopayMinor of each claim and then CoinsuranceBP of the rest.
+type Coverage struct {
+	ID             int32       `json:"id"`
+	PatientID      int32       `json:"patient_id"`
+	PayerID        int32       `json:"payer_id"`
+	PayerName      string      `json:"payer_name"`
+	PayerCode      string      `json:"payer_code"`
+	Priority       int16       `json:"priority"`
+	PolicyNumber   string      `json:"policy_number"`
+	MemberNumber   string      `json:"member_number"`
+	GroupNumber    *string     `json:"group_number"`
+	SubscriberName *string     `json:"subscriber_name"`
+	Relationship   string      `json:"relationship"`
+	ValidFrom      pgtype.Date `json:"valid_from"`
+	ValidTo        pgtype.Date `json:"valid_to"`
+	CopayMinor     int64       `json:"copay_minor"`
+	CoinsuranceBP  int32       `json:"coinsurance_bp"`
+	CreatedBy      *int32      `json:"created_by"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// SaveCoverageRequest adds or replaces a policy. ValidFrom and ValidTo
+// are "2006-01-02". SubscriberName is required unless the patient is the
+// subscriber.
+type SaveCoverageRequest struct {
+	PayerID        int32   `json:"payer_id" binding:"required"`
+	Priority       int16   `json:"priority" binding:"required,min=1,max=3"`
+	PolicyNumber   string  `json:"policy_number" binding:"required,max=50"`
+	MemberNumber   string  `json:"member_number" binding:"required,max=50"`
+	GroupNumber    *string `json:"group_number" binding:"omitempty,max=50"`
+	SubscriberName *string `json:"subscriber_name" binding:"omitempty,max=200"`
+	Relationship   string  `json:"relationship" binding:"omitempty,oneof=self spouse child other"`
+	ValidFrom      string  `json:"valid_from" binding:"required"`
+	ValidTo        *string `json:"valid_to"`
+	CopayMinor     int64   `json:"copay_minor" binding:"min=0,max=10000000000"`
+	CoinsuranceBP  int32   `json:"coinsurance_bp" binding:"min=0,max=10000"`
+}
+
+// Claim asks a coverage's payer to pay its share of an invoice.
+// BilledMinor is what is claimed: the invoice total less what the
+// invoice's other claims expect. PayerShareMinor and PatientShareMinor
+// split it under the policy's copay and coinsurance, and once the claim
+// is adjudicated, as the payer decided.
+type Claim struct {
+	ID                int32       `json:"id"`
+	InvoiceID         int32       `json:"invoice_id"`
+	PatientID         int32       `json:"patient_id"`
+	CoverageID        int32       `json:"coverage_id"`
+	PayerID           int32       `json:"payer_id"`
+	PayerName         string      `json:"payer_name,omitempty"`
+	BatchID           *int32      `json:"batch_id"`
+	Status            string      `json:"status"`
+	ServiceDate       pgtype.Date `json:"service_date"`
+	BilledMinor       int64       `json:"billed_minor"`
+	CopayMinor        int64       `json:"copay_minor"`
+	CoinsuranceBP     int32       `json:"coinsurance_bp"`
+	PayerShareMinor   int64       `json:"payer_share_minor"`
+	PatientShareMinor int64       `json:"patient_share_minor"`
+	PaidMinor         int64       `json:"paid_minor"`
+	PaymentID         *int32      `json:"payment_id"`
+	PayerReference    *string     `json:"payer_reference"`
+	DenialReason      *string     `json:"denial_reason"`
+	SubmittedAt       *time.Time  `json:"submitted_at"`
+	AdjudicatedAt     *time.Time  `json:"adjudicated_at"`
+	ClosedAt          *time.Time  `json:"closed_at"`
+	CreatedBy         *int32      `json:"created_by"`
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
+}
+
+// ClaimFilter narrows a claim list.
+type ClaimFilter struct {
+	Status    *string
+	PayerID   *int32
+	InvoiceID *int32
+	PatientID *int32
+	BatchID   *int32
+	Limit     int32
+	Offset    int32
+}
+
+// CreateClaimRequest claims an invoice against CoverageID, or by default
+// against the first policy in force on the day of service that has not
+// been claimed against yet.
+type CreateClaimRequest struct {
+	CoverageID *int32 `json:"coverage_id"`
+}
+
+// AdjudicateClaimRequest records the payer's decision. A PayerShareMinor
+// of zero denies the claim and needs a DenialReason.
+type AdjudicateClaimRequest struct {
+	PayerShareMinor *int64  `json:"payer_share_minor" binding:"required,min=0"`
+	PayerReference  *string `json:"payer_reference" binding:"omitempty,max=50"`
+	DenialReason    *string `json:"denial_reason"`
+}
+
+// ClaimPaymentRequest records the payer's payment of an adjudicated
+// claim, which is credited to the invoice.
+type ClaimPaymentRequest struct {
+	AmountMinor int64   `json:"amount_minor" binding:"required,min=1"`
+	Reference   *string `json:"reference" binding:"omitempty,max=100"`
+}
+
+// ClaimBatch is a set of claims submitted to one payer in one file.
+type ClaimBatch struct {
+	ID          int32     `json:"id"`
+	PayerID     int32     `json:"payer_id"`
+	PayerName   string    `json:"payer_name"`
+	PayerCode   string    `json:"payer_code"`
+	ClaimCount  int64     `json:"claim_count"`
+	BilledMinor int64     `json:"billed_minor"`
+	CreatedBy   *int32    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CreateClaimBatchRequest submits every draft claim to the payer.
+type CreateClaimBatchRequest struct {
+	PayerID int32 `json:"payer_id" binding:"required"`
+}
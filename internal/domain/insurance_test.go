@@ -0,0 +1,25 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimHappyPath(t *testing.T) {
+	assert.NoError(t, domain.CheckClaimTransition(domain.ClaimDraft, domain.ClaimSubmitted))
+	assert.NoError(t, domain.CheckClaimTransition(domain.ClaimSubmitted, domain.ClaimAdjudicated))
+	assert.NoError(t, domain.CheckClaimTransition(domain.ClaimAdjudicated, domain.ClaimPaid))
+}
+
+func TestFinalClaimStatusesCannotBeLeft(t *testing.T) {
+	for _, from := range []string{domain.ClaimPaid, domain.ClaimDenied, domain.ClaimCancelled} {
+		assert.ErrorIs(t, domain.CheckClaimTransition(from, domain.ClaimSubmitted), domain.ErrConflict, from)
+	}
+}
+
+func TestAdjudicatedClaimCannotBeCancelled(t *testing.T) {
+	assert.ErrorIs(t, domain.CheckClaimTransition(domain.ClaimAdjudicated, domain.ClaimCancelled), domain.ErrConflict)
+	assert.ErrorIs(t, domain.CheckClaimTransition(domain.ClaimDraft, domain.ClaimAdjudicated), domain.ErrConflict)
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+const (
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// Job is a background task run on a cron schedule.
+type Job struct {
+	Name     string    `json:"name"`
+	Schedule string    `json:"schedule"`
+	NextRun  time.Time `json:"next_run"`
+	LastRun  *JobRun   `json:"last_run"`
+}
+
+// JobRun is one run of a job. Detail summarises what a successful run did.
+type JobRun struct {
+	ID          int64      `json:"id"`
+	JobName     string     `json:"job_name"`
+	ScheduledAt time.Time  `json:"scheduled_at"`
+	StartedAt   time.Time  `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+	Status      string     `json:"status"`
+	Detail      *string    `json:"detail,omitempty"`
+	Error       *string    `json:"error,omitempty"`
+}
+
+type JobRunFilter struct {
+	JobName *string
+	Status  *string
+	Limit   int32
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// TemplateAppointmentReminder is sent ahead of each appointment at the
+// configured reminder offsets.
+const TemplateAppointmentReminder = "appointment_reminder"
+
+const DefaultNotificationLanguage = "en"
+
+const (
+	NotificationPending   = "pending"
+	NotificationSent      = "sent"
+	NotificationFailed    = "failed"
+	NotificationSkipped   = "skipped"
+	NotificationCancelled = "cancelled"
+)
+
+// NotificationTemplate is a Go text/template for one template, channel and
+// language. Subject is unused for SMS.
+type NotificationTemplate struct {
+	Template  string    `json:"template"`
+	Channel   string    `json:"channel"`
+	Language  string    `json:"language"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SaveNotificationTemplateRequest struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body" binding:"required"`
+}
+
+type NotificationPreferences struct {
+	PatientID    int32  `json:"patient_id"`
+	Language     string `json:"language" binding:"required,min=2,max=10"`
+	EmailEnabled bool   `json:"email_enabled"`
+	SMSEnabled   bool   `json:"sms_enabled"`
+}
+
+// OutboxMessage is a queued notification about an appointment.
+type OutboxMessage struct {
+	ID            int64
+	AppointmentID int32
+	Template      string
+	Channel       string
+	SendAt        time.Time
+	Attempts      int32
+}
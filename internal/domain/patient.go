@@ -2,27 +2,80 @@ package domain
 
 import (
 	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Patient struct {
-	ID                    int32            `json:"id" db:"id"`
-	FirstName             string           `json:"first_name" db:"first_name"`
-	LastName              string           `json:"last_name" db:"last_name"`
-	Email                 *string          `json:"email" db:"email"`
-	Phone                 *string          `json:"phone" db:"phone"`
-	DateOfBirth           pgtype.Date      `json:"date_of_birth" db:"date_of_birth"`
-	Gender                *string          `json:"gender" db:"gender"`
-	Address               *string          `json:"address" db:"address"`
-	MedicalHistory        *string          `json:"medical_history" db:"medical_history"`
-	Allergies             *string          `json:"allergies" db:"allergies"`
-	EmergencyContactName  *string          `json:"emergency_contact_name" db:"emergency_contact_name"`
-	EmergencyContactPhone *string          `json:"emergency_contact_phone" db:"emergency_contact_phone"`
-	CreatedBy             *int32           `json:"created_by" db:"created_by"`
-	CreatedAt             pgtype.Timestamp `json:"created_at" db:"created_at"`
-	UpdatedAt             pgtype.Timestamp `json:"updated_at" db:"updated_at"`
+	ID                    int32              `json:"id" db:"id"`
+	MRN                   string             `json:"mrn" db:"-"`
+	FirstName             string             `json:"first_name" db:"first_name"`
+	LastName              string             `json:"last_name" db:"last_name"`
+	Email                 *string            `json:"email" db:"email"`
+	Phone                 *string            `json:"phone" db:"phone"`
+	DateOfBirth           pgtype.Date        `json:"date_of_birth" db:"date_of_birth"`
+	Gender                *string            `json:"gender" db:"gender"`
+	Address               *string            `json:"address" db:"address"`
+	MedicalHistory        *string            `json:"medical_history" db:"medical_history"`
+	Allergies             *string            `json:"allergies" db:"allergies"`
+	EmergencyContactName  *string            `json:"emergency_contact_name" db:"emergency_contact_name"`
+	EmergencyContactPhone *string            `json:"emergency_contact_phone" db:"emergency_contact_phone"`
+	CreatedBy             *int32             `json:"created_by" db:"created_by"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at" db:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at" db:"updated_at"`
+}
+
+// ProtectedPatientFields are stored encrypted at rest and are never written
+// to the audit log in clear; only the fact that they changed is recorded.
+var ProtectedPatientFields = []string{
+	"email",
+	"phone",
+	"address",
+	"medical_history",
+	"allergies",
+	"emergency_contact_name",
+	"emergency_contact_phone",
+}
+
+// MRN returns the medical record number printed on patient cards and
+// documents: the patient ID padded to seven digits followed by a Luhn
+// check digit, so a mistyped number is caught before it is looked up.
+func MRN(patientID int32) string {
+	digits := fmt.Sprintf("%07d", patientID)
+	return digits + string(rune('0'+luhn(digits+"0")))
+}
+
+// ParseMRN validates a medical record number and returns the patient ID.
+func ParseMRN(mrn string) (int32, error) {
+	if len(mrn) < 8 || strings.Trim(mrn, "0123456789") != "" || luhn(mrn) != 0 {
+		return 0, fmt.Errorf("%w: malformed medical record number", ErrInvalid)
+	}
+	id, err := strconv.ParseInt(mrn[:len(mrn)-1], 10, 32)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: malformed medical record number", ErrInvalid)
+	}
+	return int32(id), nil
+}
+
+// luhn returns zero when s passes the Luhn check. With a placeholder zero
+// as the last digit, it returns the check digit to put in its place.
+func luhn(s string) int {
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		d := int(s[len(s)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
 }
 
 type NullDate struct {
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+const (
+	DocumentReferralLetter = "referral_letter"
+	DocumentIDDocument     = "id_document"
+	DocumentImagingReport  = "imaging_report"
+	DocumentLabReport      = "lab_report"
+	DocumentConsentForm    = "consent_form"
+	DocumentCorrespondence = "correspondence"
+	DocumentOther          = "other"
+)
+
+// PatientDocument is a file attached to a patient's record. Its contents
+// are kept in the blob store; ContentType is sniffed from the bytes, not
+// taken from the upload.
+type PatientDocument struct {
+	ID             int32     `json:"id"`
+	PatientID      int32     `json:"patient_id"`
+	Category       string    `json:"category"`
+	Description    *string   `json:"description"`
+	Filename       string    `json:"filename"`
+	ContentType    string    `json:"content_type"`
+	SizeBytes      int64     `json:"size_bytes"`
+	SHA256         string    `json:"sha256"`
+	StorageKey     string    `json:"-"`
+	UploadedBy     *int32    `json:"uploaded_by"`
+	UploadedByName string    `json:"uploaded_by_name,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// UploadDocumentRequest carries the form fields of an upload; the file
+// itself is passed alongside.
+type UploadDocumentRequest struct {
+	Category    string  `form:"category" binding:"required,oneof=referral_letter id_document imaging_report lab_report consent_form correspondence other"`
+	Description *string `form:"description"`
+}
@@ -0,0 +1,27 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMRN(t *testing.T) {
+	assert.Equal(t, "00000422", domain.MRN(42))
+	assert.Equal(t, "12345674", domain.MRN(1234567))
+
+	for _, id := range []int32{1, 42, 99999, 1234567, 12345678} {
+		got, err := domain.ParseMRN(domain.MRN(id))
+		require.NoError(t, err)
+		assert.Equal(t, id, got)
+	}
+}
+
+func TestParseMRNRejectsTypos(t *testing.T) {
+	for _, mrn := range []string{"", "0000042", "00000421", "00000242", "0000042x", "00000000"} {
+		_, err := domain.ParseMRN(mrn)
+		assert.ErrorIs(t, err, domain.ErrInvalid, mrn)
+	}
+}
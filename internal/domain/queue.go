@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// Check-in priorities. Within a doctor's queue, patients are seen in
+// priority order and then by appointment time.
+const (
+	PriorityRoutine   = "routine"
+	PriorityUrgent    = "urgent"
+	PriorityEmergency = "emergency"
+)
+
+// PriorityRank orders priorities: the higher the rank, the sooner the
+// patient is seen. Unknown priorities rank as routine.
+func PriorityRank(priority string) int {
+	switch priority {
+	case PriorityEmergency:
+		return 2
+	case PriorityUrgent:
+		return 1
+	}
+	return 0
+}
+
+type CheckInRequest struct {
+	Priority string `json:"priority" binding:"omitempty,oneof=routine urgent emergency"`
+}
+
+// CheckIn records when a patient arrived for an appointment.
+type CheckIn struct {
+	AppointmentID int32     `json:"appointment_id"`
+	ArrivedAt     time.Time `json:"arrived_at"`
+	Priority      string    `json:"priority"`
+	CheckedInBy   *int32    `json:"checked_in_by"`
+}
+
+// QueueFilter narrows the queue to one doctor, specialty or department.
+type QueueFilter struct {
+	DoctorID     *int32
+	Specialty    *string
+	DepartmentID *int32
+}
+
+// QueueEntry is a patient who has arrived for today's appointment. It
+// carries only the patient's initials so the queue can be shown on a
+// waiting-room display. Position is 0 for the patient being seen and
+// counts from 1 for those waiting.
+type QueueEntry struct {
+	AppointmentID        int32      `json:"appointment_id"`
+	Position             int        `json:"position"`
+	PatientInitials      string     `json:"patient_initials"`
+	AppointmentDate      time.Time  `json:"appointment_date"`
+	Status               string     `json:"status"`
+	Priority             string     `json:"priority"`
+	ArrivedAt            *time.Time `json:"arrived_at"`
+	EstimatedWaitMinutes int        `json:"estimated_wait_minutes"`
+}
+
+type DoctorQueue struct {
+	DoctorID   int32        `json:"doctor_id"`
+	DoctorName string       `json:"doctor_name"`
+	Specialty  *string      `json:"specialty"`
+	Entries    []QueueEntry `json:"entries"`
+}
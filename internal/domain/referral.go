@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+const (
+	ReferralSent      = "sent"
+	ReferralAccepted  = "accepted"
+	ReferralDeclined  = "declined"
+	ReferralScheduled = "scheduled"
+	ReferralCompleted = "completed"
+)
+
+const (
+	UrgencyRoutine   = "routine"
+	UrgencyUrgent    = "urgent"
+	UrgencyEmergency = "emergency"
+)
+
+// Referral sends a patient from one doctor to another doctor or to a
+// department. A department referral is taken by whichever of its doctors
+// accepts it first, who then becomes its target doctor.
+type Referral struct {
+	ID                   int32      `json:"id"`
+	PatientID            int32      `json:"patient_id"`
+	SourceDoctorID       int32      `json:"source_doctor_id"`
+	SourceDoctorName     string     `json:"source_doctor_name,omitempty"`
+	TargetDoctorID       *int32     `json:"target_doctor_id"`
+	TargetDoctorName     string     `json:"target_doctor_name,omitempty"`
+	TargetDepartmentID   *int32     `json:"target_department_id"`
+	TargetDepartmentName *string    `json:"target_department_name,omitempty"`
+	Reason               string     `json:"reason"`
+	Urgency              string     `json:"urgency"`
+	ClinicalContext      *string    `json:"clinical_context"`
+	NoteID               *int32     `json:"note_id"`
+	Status               string     `json:"status"`
+	RespondedBy          *int32     `json:"responded_by"`
+	RespondedAt          *time.Time `json:"responded_at"`
+	DeclineReason        *string    `json:"decline_reason"`
+	AppointmentID        *int32     `json:"appointment_id"`
+	CompletedAt          *time.Time `json:"completed_at"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// ReferralFilter narrows a referral list. SourceDoctorID and ReceiverID
+// pick the referrals a doctor sent or can act on.
+type ReferralFilter struct {
+	PatientID      *int32
+	Status         *string
+	SourceDoctorID *int32
+	ReceiverID     *int32
+	Limit          int32
+	Offset         int32
+}
+
+// CreateReferralRequest needs a target doctor, a target department or
+// both; with both, the doctor must belong to the department. NoteID
+// attaches one of the patient's clinical notes.
+type CreateReferralRequest struct {
+	PatientID          int32   `json:"patient_id" binding:"required"`
+	TargetDoctorID     *int32  `json:"target_doctor_id"`
+	TargetDepartmentID *int32  `json:"target_department_id"`
+	Reason             string  `json:"reason" binding:"required"`
+	Urgency            string  `json:"urgency" binding:"omitempty,oneof=routine urgent emergency"`
+	ClinicalContext    *string `json:"clinical_context"`
+	NoteID             *int32  `json:"note_id"`
+}
+
+type DeclineReferralRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// BookReferralRequest books the appointment an accepted referral asks
+// for, with the receiving doctor and in the target department.
+type BookReferralRequest struct {
+	AppointmentDate      string  `json:"appointment_date" binding:"required"`
+	DurationMinutes      int     `json:"duration_minutes" binding:"omitempty,min=5,max=720"`
+	RoomID               *int32  `json:"room_id"`
+	Notes                *string `json:"notes"`
+	OverrideAvailability bool    `json:"override_availability"`
+}
@@ -6,15 +6,21 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const (
+	RoleReceptionist = "receptionist"
+	RoleDoctor       = "doctor"
+	RoleCompliance   = "compliance"
+)
+
 type User struct {
-	ID           int32            `json:"id" db:"id"`
-	Email        string           `json:"email" db:"email"`
-	PasswordHash string           `json:"-" db:"password_hash"`
-	Role         string           `json:"role" db:"role"`
-	FirstName    string           `json:"first_name" db:"first_name"`
-	LastName     string           `json:"last_name" db:"last_name"`
-	CreatedAt    pgtype.Timestamp `json:"created_at" db:"created_at"`
-	UpdatedAt    pgtype.Timestamp `json:"updated_at" db:"updated_at"`
+	ID           int32              `json:"id" db:"id"`
+	Email        string             `json:"email" db:"email"`
+	PasswordHash string             `json:"-" db:"password_hash"`
+	Role         string             `json:"role" db:"role"`
+	FirstName    string             `json:"first_name" db:"first_name"`
+	LastName     string             `json:"last_name" db:"last_name"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at" db:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at" db:"updated_at"`
 }
 
 type LoginRequest struct {
@@ -22,10 +28,12 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required,min=6"`
 }
 
-type RegisterRequest struct {
+// CreateUserRequest creates an account on behalf of a signed-in
+// receptionist or compliance officer. There is no self-registration.
+type CreateUserRequest struct {
 	Email     string `json:"email" binding:"required,email"`
 	Password  string `json:"password" binding:"required,min=6"`
-	Role      string `json:"role" binding:"required,oneof=receptionist doctor"`
+	Role      string `json:"role" binding:"required,oneof=receptionist doctor compliance"`
 	FirstName string `json:"first_name" binding:"required"`
 	LastName  string `json:"last_name" binding:"required"`
 }
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+const (
+	WaitlistWaiting   = "waiting"
+	WaitlistOffered   = "offered"
+	WaitlistBooked    = "booked"
+	WaitlistCancelled = "cancelled"
+)
+
+const (
+	HoldPending   = "pending"
+	HoldConfirmed = "confirmed"
+	HoldDeclined  = "declined"
+	HoldExpired   = "expired"
+)
+
+// WaitlistEntry is a patient waiting for an appointment with DoctorID, or
+// with any doctor of Specialty, that fits between Earliest and Latest.
+type WaitlistEntry struct {
+	ID              int32          `json:"id"`
+	PatientID       int32          `json:"patient_id"`
+	DoctorID        *int32         `json:"doctor_id"`
+	Specialty       *string        `json:"specialty"`
+	Earliest        time.Time      `json:"earliest"`
+	Latest          time.Time      `json:"latest"`
+	DurationMinutes int32          `json:"duration_minutes"`
+	Notes           *string        `json:"notes"`
+	Status          string         `json:"status"`
+	CreatedBy       *int32         `json:"created_by"`
+	CreatedAt       time.Time      `json:"created_at"`
+	Holds           []WaitlistHold `json:"holds,omitempty"`
+}
+
+// WaitlistHold reserves freed time for a waitlist entry until ExpiresAt.
+// SlotEndsAt is the end of the freed time, which may be later than EndsAt.
+type WaitlistHold struct {
+	ID            int32      `json:"id"`
+	EntryID       int32      `json:"entry_id"`
+	DoctorID      int32      `json:"doctor_id"`
+	StartsAt      time.Time  `json:"starts_at"`
+	EndsAt        time.Time  `json:"ends_at"`
+	SlotEndsAt    time.Time  `json:"slot_ends_at"`
+	Status        string     `json:"status"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	AppointmentID *int32     `json:"appointment_id"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ResolvedAt    *time.Time `json:"resolved_at"`
+}
+
+type CreateWaitlistEntryRequest struct {
+	PatientID int32   `json:"patient_id" binding:"required"`
+	DoctorID  *int32  `json:"doctor_id"`
+	Specialty *string `json:"specialty"`
+	// Earliest and Latest bound the whole appointment, in RFC 3339.
+	Earliest        string  `json:"earliest" binding:"required"`
+	Latest          string  `json:"latest" binding:"required"`
+	DurationMinutes int     `json:"duration_minutes" binding:"omitempty,min=5,max=720"`
+	Notes           *string `json:"notes"`
+}
@@ -0,0 +1,276 @@
+package fieldcrypt
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// prefix marks a sealed column value: enc:v2:<data key id>:<base64 nonce|ciphertext>.
+// Version 2 binds the column name and the row id as additional data;
+// version 1 bound only the column. Values without a prefix are legacy
+// plaintext. Both are only read by DecryptLegacy, so that old values can be
+// rewritten.
+const (
+	prefix   = "enc:v2:"
+	prefixV1 = "enc:v1:"
+)
+
+type WrappedKey struct {
+	ID          int32
+	Wrapped     []byte
+	MasterKeyID string
+	Retired     bool
+}
+
+// KeyStore persists wrapped data keys.
+type KeyStore interface {
+	ListKeys(ctx context.Context) ([]WrappedKey, error)
+	// ActiveKeyID returns the newest non-retired key, or 0 if there is
+	// none.
+	ActiveKeyID(ctx context.Context) (int32, error)
+	CreateKey(ctx context.Context, wrapped []byte, masterKeyID string) (int32, error)
+}
+
+// Keyring holds the unwrapped data keys in memory and seals and opens
+// column values with them. New values are always sealed with the newest
+// non-retired key, which is looked up in the store each time so that a
+// rotation by another process takes effect at once; any known key can open.
+type Keyring struct {
+	master *MasterKey
+	store  KeyStore
+
+	mu       sync.RWMutex
+	keys     map[int32][]byte
+	activeID int32
+}
+
+// NewKeyring loads every data key from store, creating the first one if
+// none is active yet.
+func NewKeyring(ctx context.Context, master *MasterKey, store KeyStore) (*Keyring, error) {
+	k := &Keyring{master: master, store: store}
+	if err := k.Reload(ctx); err != nil {
+		return nil, err
+	}
+
+	if k.ActiveKeyID() == 0 {
+		if _, err := k.Rotate(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// Reload re-reads the data keys, picking up keys created by a rotation in
+// another process.
+func (k *Keyring) Reload(ctx context.Context) error {
+	wrapped, err := k.store.ListKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load data keys: %w", err)
+	}
+
+	keys := make(map[int32][]byte, len(wrapped))
+	var activeID int32
+	for _, w := range wrapped {
+		if w.MasterKeyID != k.master.ID {
+			return fmt.Errorf("data key %d is wrapped by master key %s, configured master key is %s", w.ID, w.MasterKeyID, k.master.ID)
+		}
+		dataKey, err := k.master.Unwrap(w.Wrapped)
+		if err != nil {
+			return fmt.Errorf("data key %d: %w", w.ID, err)
+		}
+		keys[w.ID] = dataKey
+		if !w.Retired && w.ID > activeID {
+			activeID = w.ID
+		}
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.activeID = activeID
+	k.mu.Unlock()
+	return nil
+}
+
+// Rotate creates a new data key and makes it the one used for new values.
+func (k *Keyring) Rotate(ctx context.Context) (int32, error) {
+	dataKey, err := randomBytes(keySize)
+	if err != nil {
+		return 0, err
+	}
+	wrapped, err := k.master.Wrap(dataKey)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := k.store.CreateKey(ctx, wrapped, k.master.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to store data key: %w", err)
+	}
+
+	k.mu.Lock()
+	k.keys[id] = dataKey
+	k.activeID = id
+	k.mu.Unlock()
+	return id, nil
+}
+
+func (k *Keyring) ActiveKeyID() int32 {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.activeID
+}
+
+// Encrypt seals value for the given column of row rowID. The column name
+// and row id are bound as additional data so ciphertext cannot be moved
+// between columns or rows.
+func (k *Keyring) Encrypt(ctx context.Context, column string, rowID int32, value string) (string, error) {
+	id, err := k.store.ActiveKeyID(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up active data key: %w", err)
+	}
+	if id == 0 {
+		return "", errors.New("no active data key")
+	}
+	dataKey, err := k.key(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	k.mu.Lock()
+	k.activeID = id
+	k.mu.Unlock()
+
+	sealed, err := seal(dataKey, []byte(value), additionalData(column, rowID))
+	if err != nil {
+		return "", err
+	}
+	return prefix + strconv.Itoa(int(id)) + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value produced by Encrypt for the same column and row.
+// Version 1 values are refused, as they are not bound to their row, and so
+// is plaintext, which only a write bypassing Encrypt could have stored.
+func (k *Keyring) Decrypt(ctx context.Context, column string, rowID int32, value string) (string, error) {
+	return k.decrypt(ctx, column, rowID, value, false)
+}
+
+// DecryptLegacy is Decrypt that also opens version 1 values and returns
+// plaintext unchanged, for rewriting them in the current format.
+func (k *Keyring) DecryptLegacy(ctx context.Context, column string, rowID int32, value string) (string, error) {
+	return k.decrypt(ctx, column, rowID, value, true)
+}
+
+func (k *Keyring) decrypt(ctx context.Context, column string, rowID int32, value string, legacy bool) (string, error) {
+	var aad []byte
+	switch {
+	case strings.HasPrefix(value, prefix):
+		aad = additionalData(column, rowID)
+	case strings.HasPrefix(value, prefixV1):
+		if !legacy {
+			return "", fmt.Errorf("%s: value is sealed in the version 1 format, which is not bound to its row", column)
+		}
+		aad = []byte(column)
+	default:
+		if !legacy {
+			return "", fmt.Errorf("%s: value is not encrypted", column)
+		}
+		return value, nil
+	}
+
+	// Both prefixes are the same length.
+	parts := strings.SplitN(value[len(prefix):], ":", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("%s: malformed ciphertext", column)
+	}
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return "", fmt.Errorf("%s: malformed key id: %w", column, err)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("%s: malformed ciphertext: %w", column, err)
+	}
+
+	dataKey, err := k.key(ctx, int32(id))
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(dataKey, sealed, aad)
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to decrypt: %w", column, err)
+	}
+	return string(plaintext), nil
+}
+
+func additionalData(column string, rowID int32) []byte {
+	return []byte(column + "\x00" + strconv.Itoa(int(rowID)))
+}
+
+func (k *Keyring) key(ctx context.Context, id int32) ([]byte, error) {
+	k.mu.RLock()
+	dataKey := k.keys[id]
+	k.mu.RUnlock()
+	if dataKey != nil {
+		return dataKey, nil
+	}
+
+	if err := k.Reload(ctx); err != nil {
+		return nil, err
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if dataKey = k.keys[id]; dataKey == nil {
+		return nil, fmt.Errorf("unknown data key %d", id)
+	}
+	return dataKey, nil
+}
+
+// KeyID reports which data key sealed value, or 0 for plaintext.
+func KeyID(value string) int32 {
+	if !IsEncrypted(value) {
+		return 0
+	}
+	parts := strings.SplitN(value[len(prefix):], ":", 2)
+	id, _ := strconv.Atoi(parts[0])
+	return int32(id)
+}
+
+// IsEncrypted reports whether value is sealed, in either format.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix) || strings.HasPrefix(value, prefixV1)
+}
+
+// BlindIndex returns a keyed hash of the normalised value, allowing exact
+// match lookups on an encrypted column without revealing its content.
+func (k *Keyring) BlindIndex(column, value string) string {
+	mac := hmac.New(sha256.New, k.master.blindIndexKey())
+	mac.Write([]byte(column))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// NormalizeEmail and NormalizePhone reduce values to the form blind indexes
+// are computed over, so lookups are insensitive to case and formatting.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func NormalizePhone(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+}
@@ -0,0 +1,143 @@
+package fieldcrypt_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prem0x01/hospital/internal/fieldcrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryKeyStore struct {
+	keys []fieldcrypt.WrappedKey
+}
+
+func (m *memoryKeyStore) ListKeys(ctx context.Context) ([]fieldcrypt.WrappedKey, error) {
+	return m.keys, nil
+}
+
+func (m *memoryKeyStore) ActiveKeyID(ctx context.Context) (int32, error) {
+	var id int32
+	for _, k := range m.keys {
+		if !k.Retired && k.ID > id {
+			id = k.ID
+		}
+	}
+	return id, nil
+}
+
+func (m *memoryKeyStore) CreateKey(ctx context.Context, wrapped []byte, masterKeyID string) (int32, error) {
+	id := int32(len(m.keys) + 1)
+	m.keys = append(m.keys, fieldcrypt.WrappedKey{ID: id, Wrapped: wrapped, MasterKeyID: masterKeyID})
+	return id, nil
+}
+
+func newKeyring(t *testing.T, store *memoryKeyStore) *fieldcrypt.Keyring {
+	master, err := fieldcrypt.NewMasterKey(bytes.Repeat([]byte{7}, 32))
+	require.NoError(t, err)
+	keyring, err := fieldcrypt.NewKeyring(context.Background(), master, store)
+	require.NoError(t, err)
+	return keyring
+}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	keyring := newKeyring(t, &memoryKeyStore{})
+
+	sealed, err := keyring.Encrypt(context.Background(), "medical_history", 42, "asthma")
+	require.NoError(t, err)
+	assert.True(t, fieldcrypt.IsEncrypted(sealed))
+	assert.NotContains(t, sealed, "asthma")
+
+	plain, err := keyring.Decrypt(context.Background(), "medical_history", 42, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "asthma", plain)
+}
+
+func TestCiphertextIsBoundToColumn(t *testing.T) {
+	keyring := newKeyring(t, &memoryKeyStore{})
+
+	sealed, err := keyring.Encrypt(context.Background(), "allergies", 42, "penicillin")
+	require.NoError(t, err)
+
+	_, err = keyring.Decrypt(context.Background(), "address", 42, sealed)
+	assert.Error(t, err)
+}
+
+func TestCiphertextIsBoundToRow(t *testing.T) {
+	keyring := newKeyring(t, &memoryKeyStore{})
+
+	sealed, err := keyring.Encrypt(context.Background(), "allergies", 42, "penicillin")
+	require.NoError(t, err)
+
+	_, err = keyring.Decrypt(context.Background(), "allergies", 43, sealed)
+	assert.Error(t, err)
+}
+
+func TestPlaintextIsOnlyReadAsLegacy(t *testing.T) {
+	keyring := newKeyring(t, &memoryKeyStore{})
+
+	_, err := keyring.Decrypt(context.Background(), "address", 42, "12 MG Road")
+	assert.Error(t, err)
+
+	plain, err := keyring.DecryptLegacy(context.Background(), "address", 42, "12 MG Road")
+	require.NoError(t, err)
+	assert.Equal(t, "12 MG Road", plain)
+}
+
+func TestRotationKeepsOldValuesReadable(t *testing.T) {
+	store := &memoryKeyStore{}
+	keyring := newKeyring(t, store)
+
+	old, err := keyring.Encrypt(context.Background(), "phone", 42, "9800000000")
+	require.NoError(t, err)
+
+	id, err := keyring.Rotate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), id)
+
+	fresh, err := keyring.Encrypt(context.Background(), "phone", 42, "9800000000")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), fieldcrypt.KeyID(old))
+	assert.Equal(t, int32(2), fieldcrypt.KeyID(fresh))
+
+	plain, err := keyring.Decrypt(context.Background(), "phone", 42, old)
+	require.NoError(t, err)
+	assert.Equal(t, "9800000000", plain)
+}
+
+func TestEncryptUsesKeyRotatedElsewhere(t *testing.T) {
+	store := &memoryKeyStore{}
+	server := newKeyring(t, store)
+	rotator := newKeyring(t, store)
+
+	id, err := rotator.Rotate(context.Background())
+	require.NoError(t, err)
+	store.keys[0].Retired = true
+
+	sealed, err := server.Encrypt(context.Background(), "phone", 42, "9800000000")
+	require.NoError(t, err)
+	assert.Equal(t, id, fieldcrypt.KeyID(sealed))
+}
+
+func TestEncryptRefusesRetiredKeys(t *testing.T) {
+	store := &memoryKeyStore{}
+	keyring := newKeyring(t, store)
+	store.keys[0].Retired = true
+
+	_, err := keyring.Encrypt(context.Background(), "phone", 42, "9800000000")
+	assert.Error(t, err)
+}
+
+func TestBlindIndexIgnoresFormatting(t *testing.T) {
+	keyring := newKeyring(t, &memoryKeyStore{})
+
+	a := keyring.BlindIndex("phone", fieldcrypt.NormalizePhone("+91 98000-00000"))
+	b := keyring.BlindIndex("phone", fieldcrypt.NormalizePhone("919800000000"))
+	assert.Equal(t, a, b)
+
+	assert.Equal(t,
+		keyring.BlindIndex("email", fieldcrypt.NormalizeEmail(" Asha@Example.com")),
+		keyring.BlindIndex("email", fieldcrypt.NormalizeEmail("asha@example.com")))
+}
@@ -0,0 +1,137 @@
+// Package fieldcrypt implements envelope encryption for individual database
+// columns. Values are sealed with AES-256-GCM data keys; the data keys are
+// stored in the database wrapped by a master key that never leaves the
+// application's configuration.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const keySize = 32
+
+var ErrNoMasterKey = errors.New("no master key configured: set PHI_MASTER_KEY or PHI_MASTER_KEY_FILE")
+
+type MasterKey struct {
+	key []byte
+	// ID is a fingerprint of the key, stored next to every data key it
+	// wraps so a mismatched master key is reported instead of failing
+	// with an opaque authentication error.
+	ID string
+}
+
+// LoadMasterKey reads a base64 encoded 256-bit key either directly from
+// encoded or from the file at path.
+func LoadMasterKey(encoded, path string) (*MasterKey, error) {
+	if encoded == "" && path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, ErrNoMasterKey
+			}
+			return nil, fmt.Errorf("failed to read master key file: %w", err)
+		}
+		encoded = string(raw)
+	}
+	if encoded == "" {
+		return nil, ErrNoMasterKey
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("master key is not valid base64: %w", err)
+	}
+	return NewMasterKey(key)
+}
+
+func NewMasterKey(key []byte) (*MasterKey, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("master key must be %d bytes, got %d", keySize, len(key))
+	}
+
+	sum := sha256.Sum256(key)
+	return &MasterKey{key: key, ID: hex.EncodeToString(sum[:8])}, nil
+}
+
+// GenerateKey returns a new random key, base64 encoded, suitable for use
+// as a master key.
+func GenerateKey() (string, error) {
+	key, err := randomBytes(keySize)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func (m *MasterKey) Wrap(dataKey []byte) ([]byte, error) {
+	return seal(m.key, dataKey, []byte("data-key"))
+}
+
+func (m *MasterKey) Unwrap(wrapped []byte) ([]byte, error) {
+	dataKey, err := open(m.key, wrapped, []byte("data-key"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+// blindIndexKey derives the HMAC key used for blind indexes. It is tied to
+// the master key, so replacing the master key requires the indexes to be
+// recomputed.
+func (m *MasterKey) blindIndexKey() []byte {
+	mac := hmac.New(sha256.New, m.key)
+	mac.Write([]byte("blind-index"))
+	return mac.Sum(nil)
+}
+
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomBytes(gcm.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func open(key, sealed, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+// actorFromContext collects the identity the auth and request id
+// middlewares stored on the request.
+func actorFromContext(c *gin.Context) domain.Actor {
+	return domain.Actor{
+		UserID:    int32(c.GetInt("user_id")),
+		Role:      c.GetString("user_role"),
+		IPAddress: c.ClientIP(),
+		RequestID: c.GetString("request_id"),
+	}
+}
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	//"context"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -10,29 +12,34 @@ import (
 	"github.com/prem0x01/hospital/internal/repository"
 	"github.com/prem0x01/hospital/internal/services"
 	"github.com/prem0x01/hospital/internal/utils"
+	"github.com/prem0x01/hospital/internal/visibility"
 )
 
 type AppointmentHandler struct {
 	appointmentService services.IAppointmentService
+	fieldPolicy        visibility.Policy
 }
 
-func NewAppointmentHandler(appointmentService services.IAppointmentService) *AppointmentHandler {
-	return &AppointmentHandler{appointmentService: appointmentService}
+func NewAppointmentHandler(appointmentService services.IAppointmentService, fieldPolicy visibility.Policy) *AppointmentHandler {
+	return &AppointmentHandler{appointmentService: appointmentService, fieldPolicy: fieldPolicy}
 }
 
 func (h *AppointmentHandler) GetAppointments(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	departmentID, err := queryID(c, "department_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid department ID", err.Error()))
+		return
+	}
 
-	userRole := c.GetString("user_role")
-	userID := c.GetInt("user_id")
-
-	appointments, err := h.appointmentService.GetAppointments(limit, offset, userRole, userID)
+	appointments, err := h.appointmentService.GetAppointments(actorFromContext(c), limit, offset, domain.AppointmentFilter{DepartmentID: departmentID})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to get appointments", err.Error()))
 		return
 	}
 
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourceAppointment, appointments)
 	c.JSON(http.StatusOK, utils.SuccessResponse("Appointments retrieved successfully", appointments))
 }
 
@@ -43,12 +50,13 @@ func (h *AppointmentHandler) GetAppointment(c *gin.Context) {
 		return
 	}
 
-	appointment, err := h.appointmentService.GetAppointment(id)
+	appointment, err := h.appointmentService.GetAppointment(actorFromContext(c), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, utils.ErrorResponse("Appointment not found", err.Error()))
 		return
 	}
 
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourceAppointment, appointment)
 	c.JSON(http.StatusOK, utils.SuccessResponse("Appointment retrieved successfully", appointment))
 }
 
@@ -59,13 +67,13 @@ func (h *AppointmentHandler) CreateAppointment(c *gin.Context) {
 		return
 	}
 
-	userID := c.GetInt("user_id")
-	appointment, err := h.appointmentService.CreateAppointment(&req, userID)
+	appointment, err := h.appointmentService.CreateAppointment(actorFromContext(c), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to create appointment", err.Error()))
+		appointmentError(c, "Failed to create appointment", err)
 		return
 	}
 
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourceAppointment, appointment)
 	c.JSON(http.StatusCreated, utils.SuccessResponse("Appointment created successfully", appointment))
 }
 
@@ -82,8 +90,13 @@ func (h *AppointmentHandler) UpdateAppointment(c *gin.Context) {
 		return
 	}
 
-	if err := h.appointmentService.UpdateAppointment(id, &req); err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to update appointment", err.Error()))
+	if scope := c.Query("scope"); scope != "" {
+		h.updateSeries(c, id, scope, &req)
+		return
+	}
+
+	if err := h.appointmentService.UpdateAppointment(actorFromContext(c), id, &req); err != nil {
+		appointmentError(c, "Failed to update appointment", err)
 		return
 	}
 
@@ -104,7 +117,7 @@ func (h *AppointmentHandler) DeleteAppointment(c *gin.Context) {
 		return
 	}
 
-	if err := h.appointmentService.DeleteAppointment(id); err != nil {
+	if err := h.appointmentService.DeleteAppointment(actorFromContext(c), id); err != nil {
 		c.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to delete appointment", err.Error()))
 		return
 	}
@@ -112,6 +125,81 @@ func (h *AppointmentHandler) DeleteAppointment(c *gin.Context) {
 	c.JSON(http.StatusOK, utils.SuccessResponse("Appointment deleted successfully", nil))
 }
 
+func (h *AppointmentHandler) ChangeStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid appointment ID", err.Error()))
+		return
+	}
+
+	var req domain.AppointmentStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	appointment, err := h.appointmentService.ChangeStatus(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to change appointment status", err.Error()))
+		return
+	}
+
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourceAppointment, appointment)
+	c.JSON(http.StatusOK, utils.SuccessResponse("Appointment status changed", appointment))
+}
+
+func (h *AppointmentHandler) CheckIn(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid appointment ID", err.Error()))
+		return
+	}
+
+	// The body is optional: without one the patient is checked in as
+	// routine.
+	var req domain.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	appointment, err := h.appointmentService.CheckIn(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to check in", err.Error()))
+		return
+	}
+
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourceAppointment, appointment)
+	c.JSON(http.StatusOK, utils.SuccessResponse("Patient checked in", appointment))
+}
+
+func (h *AppointmentHandler) GetStatusHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid appointment ID", err.Error()))
+		return
+	}
+
+	history, err := h.appointmentService.GetStatusHistory(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get appointment history", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Appointment history retrieved successfully", history))
+}
+
+// appointmentError writes err, attaching the overlapping appointments to a
+// 409 response when the booking conflicts with others.
+func appointmentError(c *gin.Context, message string, err error) {
+	resp := utils.ErrorResponse(message, err.Error())
+	var conflict *domain.AppointmentConflictError
+	if errors.As(err, &conflict) {
+		resp.Data = gin.H{"conflicts": conflict.Conflicts}
+	}
+	c.JSON(errorStatus(err), resp)
+}
+
 func GetDashboardStats(patientRepo *repository.PatientRepository, appointmentRepo *repository.AppointmentRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
@@ -128,9 +216,17 @@ func GetDashboardStats(patientRepo *repository.PatientRepository, appointmentRep
 			return
 		}
 
+		recent, err := appointmentRepo.CountRecentByStatus(ctx, 30)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to get appointment statistics", err.Error()))
+			return
+		}
+
 		stats := map[string]interface{}{
 			"total_patients":     patientCount,
 			"total_appointments": appointmentCount,
+			// As of the last refresh_statistics job.
+			"appointments_last_30_days_by_status": recent,
 		}
 
 		c.JSON(http.StatusOK, utils.SuccessResponse("Dashboard stats retrieved successfully", stats))
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+func (h *AppointmentHandler) CreateSeries(c *gin.Context) {
+	var req domain.CreateAppointmentSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	result, err := h.appointmentService.CreateSeries(actorFromContext(c), &req)
+	if err != nil {
+		resp := utils.ErrorResponse("Failed to create appointment series", err.Error())
+		if result != nil {
+			resp.Data = result
+		}
+		c.JSON(errorStatus(err), resp)
+		return
+	}
+
+	h.applySeriesPolicy(c, result)
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Appointment series created", result))
+}
+
+func (h *AppointmentHandler) GetSeries(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid series ID", err.Error()))
+		return
+	}
+
+	series, err := h.appointmentService.GetSeries(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get appointment series", err.Error()))
+		return
+	}
+
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourceAppointment, series.Appointments)
+	c.JSON(http.StatusOK, utils.SuccessResponse("Appointment series retrieved successfully", series))
+}
+
+func (h *AppointmentHandler) CancelSeries(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid series ID", err.Error()))
+		return
+	}
+
+	var req domain.CancelAppointmentSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	result, err := h.appointmentService.CancelSeries(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to cancel appointment series", err.Error()))
+		return
+	}
+
+	h.applySeriesPolicy(c, result)
+	c.JSON(http.StatusOK, utils.SuccessResponse("Appointment series cancelled", result))
+}
+
+// updateSeries handles PUT /appointments/:id?scope=this|following|all.
+func (h *AppointmentHandler) updateSeries(c *gin.Context, id int, scope string, req *domain.UpdateAppointmentRequest) {
+	result, err := h.appointmentService.UpdateSeries(actorFromContext(c), id, scope, req)
+	if err != nil {
+		appointmentError(c, "Failed to update appointment series", err)
+		return
+	}
+
+	h.applySeriesPolicy(c, result)
+	c.JSON(http.StatusOK, utils.SuccessResponse("Appointments updated", result))
+}
+
+func (h *AppointmentHandler) applySeriesPolicy(c *gin.Context, result *domain.AppointmentSeriesResult) {
+	role := c.GetString("user_role")
+	h.fieldPolicy.Apply(role, domain.ResourceAppointment, result.Succeeded)
+	if result.Series != nil {
+		h.fieldPolicy.Apply(role, domain.ResourceAppointment, result.Series.Appointments)
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+// maxAuditEntries caps how many audit entries one request can list.
+const maxAuditEntries = 500
+
+type AuditHandler struct {
+	auditService *services.AuditService
+}
+
+func NewAuditHandler(auditService *services.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// GetAuditLog lists audit entries, newest first, filtered by
+// ?patient_id=&actor=&from=&to=. Times are RFC 3339 or plain dates.
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid limit", "limit must be a positive number"))
+		return
+	}
+	if limit > maxAuditEntries {
+		limit = maxAuditEntries
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid offset", "offset must not be negative"))
+		return
+	}
+
+	filter := domain.AuditFilter{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	if v := c.Query("patient_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient_id", err.Error()))
+			return
+		}
+		filter.PatientID = utils.Int32Ptr(int32(id))
+	}
+	if v := c.Query("actor"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid actor", err.Error()))
+			return
+		}
+		filter.ActorID = utils.Int32Ptr(int32(id))
+	}
+	if v := c.Query("from"); v != "" {
+		from, err := parseAuditTime(v, false)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid from", err.Error()))
+			return
+		}
+		filter.From = &from
+	}
+	if v := c.Query("to"); v != "" {
+		to, err := parseAuditTime(v, true)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid to", err.Error()))
+			return
+		}
+		filter.To = &to
+	}
+
+	entries, err := h.auditService.GetAuditLog(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to get audit log", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Audit log retrieved successfully", entries))
+}
+
+// AuditStatus reports whether audit entries are being written, and how
+// many are waiting to be retried.
+func (h *AuditHandler) AuditStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, utils.SuccessResponse("Audit status retrieved successfully", h.auditService.Status()))
+}
+
+// Health is an unauthenticated check for monitoring. It fails with 503
+// while audit entries cannot be written, so the outage can be alerted on;
+// the details are at GET /audit/status.
+func (h *AuditHandler) Health(c *gin.Context) {
+	status := h.auditService.Status()
+	if !status.Healthy {
+		c.JSON(http.StatusServiceUnavailable, utils.ErrorResponse("Audit log writes are failing",
+			strconv.Itoa(status.PendingEntries)+" audit entries pending"))
+		return
+	}
+	c.JSON(http.StatusOK, utils.SuccessResponse("OK", nil))
+}
+
+// parseAuditTime accepts RFC 3339 timestamps or dates; a bare date used as
+// an upper bound covers the whole day.
+func parseAuditTime(v string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Microsecond)
+	}
+	return t, nil
+}
@@ -33,18 +33,20 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	c.JSON(http.StatusOK, utils.SuccessResponse("Login successful", response))
 }
 
-func (h *AuthHandler) Register(c *gin.Context) {
-	var req domain.RegisterRequest
+// CreateUser creates a staff account. The route is limited to
+// receptionists and compliance officers; see AuthService.CreateUser.
+func (h *AuthHandler) CreateUser(c *gin.Context) {
+	var req domain.CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
 		return
 	}
 
-	response, err := h.authService.Register(&req)
+	user, err := h.authService.CreateUser(actorFromContext(c), &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Registration failed", err.Error()))
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create user", err.Error()))
 		return
 	}
 
-	c.JSON(http.StatusCreated, utils.SuccessResponse("Registration successful", response))
+	c.JSON(http.StatusCreated, utils.SuccessResponse("User created successfully", user))
 }
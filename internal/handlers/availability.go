@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/localtime"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+const defaultSlotDays = 7
+
+type AvailabilityHandler struct {
+	availabilityService *services.AvailabilityService
+	loc                 *time.Location
+}
+
+func NewAvailabilityHandler(availabilityService *services.AvailabilityService, loc *time.Location) *AvailabilityHandler {
+	return &AvailabilityHandler{availabilityService: availabilityService, loc: loc}
+}
+
+func (h *AvailabilityHandler) GetAvailability(c *gin.Context) {
+	doctorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid doctor ID", err.Error()))
+		return
+	}
+
+	availability, err := h.availabilityService.GetAvailability(doctorID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get availability", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Availability retrieved successfully", availability))
+}
+
+func (h *AvailabilityHandler) SetAvailability(c *gin.Context) {
+	doctorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid doctor ID", err.Error()))
+		return
+	}
+
+	var req domain.SetAvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	availability, err := h.availabilityService.SetAvailability(actorFromContext(c), doctorID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to set availability", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Availability updated successfully", availability))
+}
+
+func (h *AvailabilityHandler) AddException(c *gin.Context) {
+	doctorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid doctor ID", err.Error()))
+		return
+	}
+
+	var req domain.CreateAvailabilityExceptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	exception, err := h.availabilityService.AddException(actorFromContext(c), doctorID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to add exception", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Exception added successfully", exception))
+}
+
+func (h *AvailabilityHandler) DeleteException(c *gin.Context) {
+	doctorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid doctor ID", err.Error()))
+		return
+	}
+
+	exceptionID, err := strconv.Atoi(c.Param("exceptionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid exception ID", err.Error()))
+		return
+	}
+
+	if err := h.availabilityService.DeleteException(actorFromContext(c), doctorID, exceptionID); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to delete exception", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Exception deleted successfully", nil))
+}
+
+// GetDoctorSlots lists free slots between ?from= and ?to=, which default
+// to today and a week later. A plain date for to includes that whole day.
+func (h *AvailabilityHandler) GetDoctorSlots(c *gin.Context) {
+	doctorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid doctor ID", err.Error()))
+		return
+	}
+
+	today := localtime.StartOfDay(time.Now().In(h.loc), h.loc)
+	from, to := today, localtime.StartOfDay(today.AddDate(0, 0, defaultSlotDays), h.loc)
+	if v := c.Query("from"); v != "" {
+		if from, err = parseSlotTime(v, false, h.loc); err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid from", err.Error()))
+			return
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if to, err = parseSlotTime(v, true, h.loc); err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid to", err.Error()))
+			return
+		}
+	}
+
+	slots, err := h.availabilityService.GetDoctorSlots(doctorID, from, to)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get slots", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Slots retrieved successfully", slots))
+}
+
+// SearchSlots lists free slots on ?date= (default today) across doctors,
+// optionally limited to ?specialty= and ?department_id=.
+func (h *AvailabilityHandler) SearchSlots(c *gin.Context) {
+	date := time.Now().In(h.loc)
+	if v := c.Query("date"); v != "" {
+		var err error
+		if date, err = localtime.ParseDate(v, h.loc); err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid date", err.Error()))
+			return
+		}
+	}
+
+	departmentID, err := queryID(c, "department_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid department ID", err.Error()))
+		return
+	}
+
+	slots, err := h.availabilityService.SearchSlots(c.Query("specialty"), departmentID, date)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to search slots", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Slots retrieved successfully", slots))
+}
+
+// parseSlotTime accepts the appointment time formats or a plain date in
+// loc. A plain date used as an upper bound means the end of that day.
+func parseSlotTime(v string, endOfDay bool, loc *time.Location) (time.Time, error) {
+	t, err := localtime.ParseDate(v, loc)
+	if err != nil {
+		return localtime.Parse(v, loc)
+	}
+	if endOfDay {
+		t = localtime.StartOfDay(t.AddDate(0, 0, 1), loc)
+	}
+	return t, nil
+}
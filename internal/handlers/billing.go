@@ -0,0 +1,344 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+type BillingHandler struct {
+	billingService *services.BillingService
+}
+
+func NewBillingHandler(billingService *services.BillingService) *BillingHandler {
+	return &BillingHandler{billingService: billingService}
+}
+
+// ListServices lists the price catalog, filtered by ?category=, ?active=
+// and ?q=, which matches the start of a code or part of a name.
+func (h *BillingHandler) ListServices(c *gin.Context) {
+	var category, query *string
+	if v := c.Query("category"); v != "" {
+		category = &v
+	}
+	if v := c.Query("q"); v != "" {
+		query = &v
+	}
+	var active *bool
+	if v := c.Query("active"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid active", err.Error()))
+			return
+		}
+		active = &b
+	}
+
+	catalog, err := h.billingService.ListServices(category, active, query)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get billable services", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Billable services retrieved successfully", catalog))
+}
+
+func (h *BillingHandler) GetService(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid billable service ID", err.Error()))
+		return
+	}
+
+	service, err := h.billingService.GetService(id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get billable service", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Billable service retrieved successfully", service))
+}
+
+func (h *BillingHandler) CreateService(c *gin.Context) {
+	var req domain.SaveBillableServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	service, err := h.billingService.CreateService(&req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create billable service", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Billable service created successfully", service))
+}
+
+func (h *BillingHandler) UpdateService(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid billable service ID", err.Error()))
+		return
+	}
+
+	var req domain.SaveBillableServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	service, err := h.billingService.UpdateService(id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to update billable service", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Billable service updated successfully", service))
+}
+
+// CreateInvoice drafts the invoice for a completed appointment.
+func (h *BillingHandler) CreateInvoice(c *gin.Context) {
+	appointmentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid appointment ID", err.Error()))
+		return
+	}
+
+	var req domain.CreateInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	invoice, err := h.billingService.CreateInvoice(actorFromContext(c), appointmentID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create invoice", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Invoice created successfully", invoice))
+}
+
+// ListInvoices lists invoices, newest first, filtered by ?patient_id= and
+// ?status=.
+func (h *BillingHandler) ListInvoices(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	filter := domain.InvoiceFilter{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+	if s := c.Query("status"); s != "" {
+		filter.Status = &s
+	}
+	patientID, err := queryID(c, "patient_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient_id", err.Error()))
+		return
+	}
+	filter.PatientID = patientID
+
+	invoices, err := h.billingService.ListInvoices(actorFromContext(c), filter)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get invoices", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Invoices retrieved successfully", invoices))
+}
+
+func (h *BillingHandler) GetInvoice(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid invoice ID", err.Error()))
+		return
+	}
+
+	invoice, err := h.billingService.GetInvoice(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get invoice", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Invoice retrieved successfully", invoice))
+}
+
+func (h *BillingHandler) AddLine(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid invoice ID", err.Error()))
+		return
+	}
+
+	var req domain.InvoiceLineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	invoice, err := h.billingService.AddLine(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to add invoice line", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Invoice line added successfully", invoice))
+}
+
+func (h *BillingHandler) DeleteLine(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid invoice ID", err.Error()))
+		return
+	}
+	lineID, err := strconv.Atoi(c.Param("lineId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid line ID", err.Error()))
+		return
+	}
+
+	invoice, err := h.billingService.DeleteLine(actorFromContext(c), id, lineID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to delete invoice line", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Invoice line deleted successfully", invoice))
+}
+
+func (h *BillingHandler) IssueInvoice(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid invoice ID", err.Error()))
+		return
+	}
+
+	var req domain.IssueInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	invoice, err := h.billingService.IssueInvoice(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to issue invoice", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Invoice issued successfully", invoice))
+}
+
+func (h *BillingHandler) VoidInvoice(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid invoice ID", err.Error()))
+		return
+	}
+
+	var req domain.VoidInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	invoice, err := h.billingService.VoidInvoice(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to void invoice", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Invoice voided successfully", invoice))
+}
+
+func (h *BillingHandler) RecordPayment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid invoice ID", err.Error()))
+		return
+	}
+
+	var req domain.RecordPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	invoice, err := h.billingService.RecordPayment(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to record payment", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Payment recorded successfully", invoice))
+}
+
+func (h *BillingHandler) RefundPayment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid invoice ID", err.Error()))
+		return
+	}
+	paymentID, err := strconv.Atoi(c.Param("paymentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid payment ID", err.Error()))
+		return
+	}
+
+	var req domain.RefundPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	invoice, err := h.billingService.RefundPayment(actorFromContext(c), id, paymentID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to refund payment", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Payment refunded successfully", invoice))
+}
+
+func (h *BillingHandler) PatientBalance(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	balance, err := h.billingService.PatientBalance(actorFromContext(c), patientID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get patient balance", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Patient balance retrieved successfully", balance))
+}
+
+// OutstandingBalances lists the patients who owe at least ?min_balance=
+// minor units, largest balance first.
+func (h *BillingHandler) OutstandingBalances(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	minBalance, err := strconv.ParseInt(c.DefaultQuery("min_balance", "1"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid min_balance", err.Error()))
+		return
+	}
+
+	balances, err := h.billingService.OutstandingBalances(actorFromContext(c), minBalance, int32(limit), int32(offset))
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get outstanding balances", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Outstanding balances retrieved successfully", balances))
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+type CalendarHandler struct {
+	calendarService *services.CalendarService
+}
+
+func NewCalendarHandler(calendarService *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+func (h *CalendarHandler) CreateToken(c *gin.Context) {
+	token, err := h.calendarService.CreateToken(actorFromContext(c))
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create calendar token", err.Error()))
+		return
+	}
+
+	// The feed route sits next to this one: .../calendar/tokens becomes
+	// .../calendar/{token}.ics.
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	path := strings.TrimSuffix(c.FullPath(), "tokens") + token.Token + ".ics"
+	token.URL = fmt.Sprintf("%s://%s%s", scheme, c.Request.Host, path)
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Calendar token created", token))
+}
+
+func (h *CalendarHandler) ListTokens(c *gin.Context) {
+	tokens, err := h.calendarService.ListTokens(actorFromContext(c))
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get calendar tokens", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Calendar tokens retrieved successfully", tokens))
+}
+
+func (h *CalendarHandler) RevokeToken(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid calendar token ID", err.Error()))
+		return
+	}
+
+	if err := h.calendarService.RevokeToken(actorFromContext(c), id); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to revoke calendar token", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Calendar token revoked", nil))
+}
+
+// Feed serves /calendar/{token}.ics. It is not behind the auth middleware:
+// the token is the credential.
+func (h *CalendarHandler) Feed(c *gin.Context) {
+	token, ok := strings.CutSuffix(c.Param("file"), ".ics")
+	if !ok || token == "" {
+		c.JSON(http.StatusNotFound, utils.ErrorResponse("Calendar not found", ""))
+		return
+	}
+
+	body, err := h.calendarService.Feed(actorFromContext(c), token)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get calendar", err.Error()))
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, calendarContentType, body)
+}
+
+func (h *CalendarHandler) DownloadAppointment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid appointment ID", err.Error()))
+		return
+	}
+
+	body, err := h.calendarService.AppointmentCalendar(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.ErrorResponse("Appointment not found", err.Error()))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="appointment-%d.ics"`, id))
+	c.Data(http.StatusOK, calendarContentType, body)
+}
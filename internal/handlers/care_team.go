@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+type CareTeamHandler struct {
+	careTeamService *services.CareTeamService
+}
+
+func NewCareTeamHandler(careTeamService *services.CareTeamService) *CareTeamHandler {
+	return &CareTeamHandler{careTeamService: careTeamService}
+}
+
+func (h *CareTeamHandler) GetCareTeam(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	members, err := h.careTeamService.GetCareTeam(actorFromContext(c), patientID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get care team", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Care team retrieved successfully", members))
+}
+
+func (h *CareTeamHandler) AddMember(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	var req domain.AddCareTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	member, err := h.careTeamService.AddMember(actorFromContext(c), patientID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to add care team member", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Care team member added", member))
+}
+
+func (h *CareTeamHandler) RemoveMember(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	doctorID, err := strconv.Atoi(c.Param("doctorId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid doctor ID", err.Error()))
+		return
+	}
+
+	source := c.DefaultQuery("source", domain.CareTeamSourceAssignment)
+	if err := h.careTeamService.RemoveMember(actorFromContext(c), patientID, doctorID, source); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to remove care team member", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Care team member removed", nil))
+}
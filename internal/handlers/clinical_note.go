@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+type ClinicalNoteHandler struct {
+	noteService *services.ClinicalNoteService
+}
+
+func NewClinicalNoteHandler(noteService *services.ClinicalNoteService) *ClinicalNoteHandler {
+	return &ClinicalNoteHandler{noteService: noteService}
+}
+
+func (h *ClinicalNoteHandler) CreateNote(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	var req domain.CreateClinicalNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	note, err := h.noteService.CreateNote(actorFromContext(c), patientID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create clinical note", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Clinical note created successfully", note))
+}
+
+func (h *ClinicalNoteHandler) ListNotes(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	notes, err := h.noteService.ListNotes(actorFromContext(c), patientID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get clinical notes", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Clinical notes retrieved successfully", notes))
+}
+
+func (h *ClinicalNoteHandler) GetNote(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid note ID", err.Error()))
+		return
+	}
+
+	note, err := h.noteService.GetNote(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get clinical note", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Clinical note retrieved successfully", note))
+}
+
+func (h *ClinicalNoteHandler) GetAppointmentNote(c *gin.Context) {
+	appointmentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid appointment ID", err.Error()))
+		return
+	}
+
+	note, err := h.noteService.GetAppointmentNote(actorFromContext(c), appointmentID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get clinical note", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Clinical note retrieved successfully", note))
+}
+
+func (h *ClinicalNoteHandler) ListVersions(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid note ID", err.Error()))
+		return
+	}
+
+	versions, err := h.noteService.ListVersions(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get clinical note versions", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Clinical note versions retrieved successfully", versions))
+}
+
+func (h *ClinicalNoteHandler) UpdateNote(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid note ID", err.Error()))
+		return
+	}
+
+	var req domain.UpdateClinicalNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	note, err := h.noteService.UpdateNote(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to update clinical note", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Clinical note updated successfully", note))
+}
+
+func (h *ClinicalNoteHandler) SignNote(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid note ID", err.Error()))
+		return
+	}
+
+	var req domain.SignClinicalNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	note, err := h.noteService.SignNote(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to sign clinical note", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Clinical note signed successfully", note))
+}
+
+func (h *ClinicalNoteHandler) AddAddendum(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid note ID", err.Error()))
+		return
+	}
+
+	var req domain.CreateAddendumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	addendum, err := h.noteService.AddAddendum(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to add addendum", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Addendum added successfully", addendum))
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+type ConsentHandler struct {
+	consentService *services.ConsentService
+}
+
+func NewConsentHandler(consentService *services.ConsentService) *ConsentHandler {
+	return &ConsentHandler{consentService: consentService}
+}
+
+// ListTemplates lists every template version, filtered by ?purpose=.
+func (h *ConsentHandler) ListTemplates(c *gin.Context) {
+	var purpose *string
+	if v := c.Query("purpose"); v != "" {
+		purpose = &v
+	}
+
+	templates, err := h.consentService.ListTemplates(purpose)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get consent templates", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Consent templates retrieved successfully", templates))
+}
+
+func (h *ConsentHandler) GetTemplate(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid consent template ID", err.Error()))
+		return
+	}
+
+	template, err := h.consentService.GetTemplate(id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get consent template", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Consent template retrieved successfully", template))
+}
+
+func (h *ConsentHandler) CreateTemplate(c *gin.Context) {
+	var req domain.CreateConsentTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	template, err := h.consentService.CreateTemplate(actorFromContext(c), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create consent template", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Consent template created successfully", template))
+}
+
+func (h *ConsentHandler) ListConsents(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	consents, err := h.consentService.ListConsents(actorFromContext(c), patientID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get consents", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Consents retrieved successfully", consents))
+}
+
+func (h *ConsentHandler) RecordConsent(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+	var req domain.RecordConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	consent, err := h.consentService.RecordConsent(actorFromContext(c), patientID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to record consent", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Consent recorded successfully", consent))
+}
+
+// CheckConsent answers whether the patient has consent in force for
+// ?purpose=.
+func (h *ConsentHandler) CheckConsent(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	check, err := h.consentService.CheckConsent(actorFromContext(c), patientID, c.Query("purpose"))
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to check consent", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Consent checked successfully", check))
+}
+
+func (h *ConsentHandler) GetConsent(c *gin.Context) {
+	patientID, id, ok := consentParams(c)
+	if !ok {
+		return
+	}
+
+	consent, err := h.consentService.GetConsent(actorFromContext(c), patientID, id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get consent", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Consent retrieved successfully", consent))
+}
+
+func (h *ConsentHandler) RevokeConsent(c *gin.Context) {
+	patientID, id, ok := consentParams(c)
+	if !ok {
+		return
+	}
+	var req domain.RevokeConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	consent, err := h.consentService.RevokeConsent(actorFromContext(c), patientID, id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to revoke consent", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Consent revoked successfully", consent))
+}
+
+func consentParams(c *gin.Context) (int, int, bool) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return 0, 0, false
+	}
+	id, err := strconv.Atoi(c.Param("consentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid consent ID", err.Error()))
+		return 0, 0, false
+	}
+	return patientID, id, true
+}
@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+type DepartmentHandler struct {
+	departmentService *services.DepartmentService
+}
+
+func NewDepartmentHandler(departmentService *services.DepartmentService) *DepartmentHandler {
+	return &DepartmentHandler{departmentService: departmentService}
+}
+
+func (h *DepartmentHandler) ListDepartments(c *gin.Context) {
+	departments, err := h.departmentService.ListDepartments()
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get departments", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Departments retrieved successfully", departments))
+}
+
+func (h *DepartmentHandler) GetDepartment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid department ID", err.Error()))
+		return
+	}
+
+	department, err := h.departmentService.GetDepartment(id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get department", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Department retrieved successfully", department))
+}
+
+func (h *DepartmentHandler) CreateDepartment(c *gin.Context) {
+	var req domain.SaveDepartmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	department, err := h.departmentService.CreateDepartment(&req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create department", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Department created successfully", department))
+}
+
+func (h *DepartmentHandler) UpdateDepartment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid department ID", err.Error()))
+		return
+	}
+
+	var req domain.SaveDepartmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	department, err := h.departmentService.UpdateDepartment(id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to update department", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Department updated successfully", department))
+}
+
+func (h *DepartmentHandler) DeleteDepartment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid department ID", err.Error()))
+		return
+	}
+
+	if err := h.departmentService.DeleteDepartment(id); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to delete department", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Department deleted successfully", nil))
+}
+
+func (h *DepartmentHandler) AddDoctor(c *gin.Context) {
+	departmentID, doctorID, ok := departmentDoctorParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.departmentService.AddDoctor(departmentID, doctorID); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to add doctor to department", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Doctor added to department", nil))
+}
+
+func (h *DepartmentHandler) RemoveDoctor(c *gin.Context) {
+	departmentID, doctorID, ok := departmentDoctorParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.departmentService.RemoveDoctor(departmentID, doctorID); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to remove doctor from department", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Doctor removed from department", nil))
+}
+
+// ListDoctors lists doctors, optionally limited to ?specialty= and
+// ?department_id=.
+func (h *DepartmentHandler) ListDoctors(c *gin.Context) {
+	departmentID, err := queryID(c, "department_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid department ID", err.Error()))
+		return
+	}
+	var specialty *string
+	if s := c.Query("specialty"); s != "" {
+		specialty = &s
+	}
+
+	doctors, err := h.departmentService.ListDoctors(specialty, departmentID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get doctors", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Doctors retrieved successfully", doctors))
+}
+
+// ListSpecialties lists specialties, optionally limited to
+// ?department_id=.
+func (h *DepartmentHandler) ListSpecialties(c *gin.Context) {
+	departmentID, err := queryID(c, "department_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid department ID", err.Error()))
+		return
+	}
+
+	specialties, err := h.departmentService.ListSpecialties(departmentID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get specialties", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Specialties retrieved successfully", specialties))
+}
+
+func (h *DepartmentHandler) CreateSpecialty(c *gin.Context) {
+	var req domain.CreateSpecialtyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	specialty, err := h.departmentService.CreateSpecialty(&req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create specialty", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Specialty created successfully", specialty))
+}
+
+func (h *DepartmentHandler) DeleteSpecialty(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid specialty ID", err.Error()))
+		return
+	}
+
+	if err := h.departmentService.DeleteSpecialty(id); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to delete specialty", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Specialty deleted successfully", nil))
+}
+
+func (h *DepartmentHandler) ListDoctorSpecialties(c *gin.Context) {
+	doctorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid doctor ID", err.Error()))
+		return
+	}
+
+	specialties, err := h.departmentService.ListDoctorSpecialties(doctorID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get specialties", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Specialties retrieved successfully", specialties))
+}
+
+func (h *DepartmentHandler) AddDoctorSpecialty(c *gin.Context) {
+	doctorID, specialtyID, ok := doctorSpecialtyParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.departmentService.AddDoctorSpecialty(doctorID, specialtyID); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to add specialty", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Specialty added to doctor", nil))
+}
+
+func (h *DepartmentHandler) RemoveDoctorSpecialty(c *gin.Context) {
+	doctorID, specialtyID, ok := doctorSpecialtyParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.departmentService.RemoveDoctorSpecialty(doctorID, specialtyID); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to remove specialty", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Specialty removed from doctor", nil))
+}
+
+func departmentDoctorParams(c *gin.Context) (departmentID, doctorID int, ok bool) {
+	departmentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid department ID", err.Error()))
+		return 0, 0, false
+	}
+	doctorID, err = strconv.Atoi(c.Param("doctorId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid doctor ID", err.Error()))
+		return 0, 0, false
+	}
+	return departmentID, doctorID, true
+}
+
+func doctorSpecialtyParams(c *gin.Context) (doctorID, specialtyID int, ok bool) {
+	doctorID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid doctor ID", err.Error()))
+		return 0, 0, false
+	}
+	specialtyID, err = strconv.Atoi(c.Param("specialtyId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid specialty ID", err.Error()))
+		return 0, 0, false
+	}
+	return doctorID, specialtyID, true
+}
+
+// queryID reads an optional numeric ID from the query string.
+func queryID(c *gin.Context, key string) (*int32, error) {
+	s := c.Query(key)
+	if s == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	v := int32(id)
+	return &v, nil
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+type DiagnosisHandler struct {
+	diagnosisService *services.DiagnosisService
+}
+
+func NewDiagnosisHandler(diagnosisService *services.DiagnosisService) *DiagnosisHandler {
+	return &DiagnosisHandler{diagnosisService: diagnosisService}
+}
+
+// SearchCodes serves ICD-10 autocomplete: ?q= is the start of a code or
+// words of a description, ?billable=true keeps only codes that can be
+// recorded, and ?limit= caps the results.
+func (h *DiagnosisHandler) SearchCodes(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	search := domain.Icd10Search{
+		Query: c.Query("q"),
+		Limit: limit,
+	}
+	if v := c.Query("billable"); v != "" {
+		billable, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid billable", err.Error()))
+			return
+		}
+		search.Billable = &billable
+	}
+
+	codes, err := h.diagnosisService.SearchCodes(search)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to search ICD-10 codes", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("ICD-10 codes retrieved successfully", codes))
+}
+
+func (h *DiagnosisHandler) GetCode(c *gin.Context) {
+	code, err := h.diagnosisService.GetCode(c.Param("code"))
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get ICD-10 code", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("ICD-10 code retrieved successfully", code))
+}
+
+func (h *DiagnosisHandler) ListDiagnoses(c *gin.Context) {
+	appointmentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid appointment ID", err.Error()))
+		return
+	}
+
+	diagnoses, err := h.diagnosisService.ListDiagnoses(actorFromContext(c), appointmentID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get diagnoses", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Diagnoses retrieved successfully", diagnoses))
+}
+
+func (h *DiagnosisHandler) AddDiagnosis(c *gin.Context) {
+	appointmentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid appointment ID", err.Error()))
+		return
+	}
+
+	var req domain.CreateDiagnosisRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	diagnosis, err := h.diagnosisService.AddDiagnosis(actorFromContext(c), appointmentID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to add diagnosis", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Diagnosis added successfully", diagnosis))
+}
+
+func (h *DiagnosisHandler) UpdateDiagnosis(c *gin.Context) {
+	appointmentID, id, ok := diagnosisParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateDiagnosisRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	diagnosis, err := h.diagnosisService.UpdateDiagnosis(actorFromContext(c), appointmentID, id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to update diagnosis", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Diagnosis updated successfully", diagnosis))
+}
+
+func (h *DiagnosisHandler) DeleteDiagnosis(c *gin.Context) {
+	appointmentID, id, ok := diagnosisParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.diagnosisService.DeleteDiagnosis(actorFromContext(c), appointmentID, id); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to delete diagnosis", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Diagnosis deleted successfully", nil))
+}
+
+// ListProblems returns the patient's problem list, optionally only the
+// problems in ?status=.
+func (h *DiagnosisHandler) ListProblems(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	var status *string
+	if s := c.Query("status"); s != "" {
+		status = &s
+	}
+
+	problems, err := h.diagnosisService.ListProblems(actorFromContext(c), patientID, status)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get problem list", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Problem list retrieved successfully", problems))
+}
+
+func diagnosisParams(c *gin.Context) (appointmentID, diagnosisID int, ok bool) {
+	appointmentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid appointment ID", err.Error()))
+		return 0, 0, false
+	}
+	diagnosisID, err = strconv.Atoi(c.Param("diagnosisId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid diagnosis ID", err.Error()))
+		return 0, 0, false
+	}
+	return appointmentID, diagnosisID, true
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+const pdfContentType = "application/pdf"
+
+type DocumentHandler struct {
+	documentService *services.DocumentService
+}
+
+func NewDocumentHandler(documentService *services.DocumentService) *DocumentHandler {
+	return &DocumentHandler{documentService: documentService}
+}
+
+func (h *DocumentHandler) VisitSummary(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid appointment ID", err.Error()))
+		return
+	}
+
+	body, err := h.documentService.VisitSummary(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to generate visit summary", err.Error()))
+		return
+	}
+
+	servePDF(c, fmt.Sprintf("visit-summary-%d.pdf", id), body)
+}
+
+func (h *DocumentHandler) Prescription(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid appointment ID", err.Error()))
+		return
+	}
+
+	var req domain.CreatePrescriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	body, err := h.documentService.Prescription(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to generate prescription", err.Error()))
+		return
+	}
+
+	servePDF(c, fmt.Sprintf("prescription-%d.pdf", id), body)
+}
+
+func (h *DocumentHandler) PatientCard(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	body, err := h.documentService.PatientCard(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to generate patient card", err.Error()))
+		return
+	}
+
+	servePDF(c, fmt.Sprintf("patient-card-%d.pdf", id), body)
+}
+
+// servePDF sends a generated document. It holds patient data, so it must
+// not be kept by shared caches.
+func servePDF(c *gin.Context, filename string, body []byte) {
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, filename))
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, pdfContentType, body)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+type EmergencyAccessHandler struct {
+	emergencyAccessService *services.EmergencyAccessService
+}
+
+func NewEmergencyAccessHandler(emergencyAccessService *services.EmergencyAccessService) *EmergencyAccessHandler {
+	return &EmergencyAccessHandler{emergencyAccessService: emergencyAccessService}
+}
+
+func (h *EmergencyAccessHandler) RequestAccess(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	var req domain.EmergencyAccessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	grant, err := h.emergencyAccessService.RequestAccess(actorFromContext(c), patientID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to grant emergency access", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Emergency access granted", grant))
+}
+
+func (h *EmergencyAccessHandler) GetReviewQueue(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	status := c.DefaultQuery("status", domain.ReviewPending)
+	if status == "all" {
+		status = ""
+	}
+
+	grants, err := h.emergencyAccessService.GetReviewQueue(status, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to get emergency access grants", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Emergency access grants retrieved successfully", grants))
+}
+
+func (h *EmergencyAccessHandler) ReviewGrant(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid grant ID", err.Error()))
+		return
+	}
+
+	var req domain.EmergencyAccessReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	grant, err := h.emergencyAccessService.Review(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to review emergency access", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Emergency access reviewed", grant))
+}
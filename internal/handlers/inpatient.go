@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+type InpatientHandler struct {
+	inpatientService *services.InpatientService
+}
+
+func NewInpatientHandler(inpatientService *services.InpatientService) *InpatientHandler {
+	return &InpatientHandler{inpatientService: inpatientService}
+}
+
+// ListWards lists wards, filtered by ?active=.
+func (h *InpatientHandler) ListWards(c *gin.Context) {
+	var active *bool
+	if v := c.Query("active"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid active", err.Error()))
+			return
+		}
+		active = &b
+	}
+
+	wards, err := h.inpatientService.ListWards(active)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get wards", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Wards retrieved successfully", wards))
+}
+
+func (h *InpatientHandler) CreateWard(c *gin.Context) {
+	var req domain.SaveWardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	ward, err := h.inpatientService.CreateWard(&req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create ward", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Ward created successfully", ward))
+}
+
+func (h *InpatientHandler) UpdateWard(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid ward ID", err.Error()))
+		return
+	}
+
+	var req domain.SaveWardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	ward, err := h.inpatientService.UpdateWard(id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to update ward", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Ward updated successfully", ward))
+}
+
+func (h *InpatientHandler) ListBeds(c *gin.Context) {
+	wardID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid ward ID", err.Error()))
+		return
+	}
+
+	beds, err := h.inpatientService.ListBeds(wardID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get beds", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Beds retrieved successfully", beds))
+}
+
+func (h *InpatientHandler) CreateBed(c *gin.Context) {
+	wardID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid ward ID", err.Error()))
+		return
+	}
+
+	var req domain.SaveBedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	bed, err := h.inpatientService.CreateBed(wardID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create bed", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Bed created successfully", bed))
+}
+
+func (h *InpatientHandler) RenameBed(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid bed ID", err.Error()))
+		return
+	}
+
+	var req domain.SaveBedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	bed, err := h.inpatientService.RenameBed(id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to update bed", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Bed updated successfully", bed))
+}
+
+func (h *InpatientHandler) SetBedStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid bed ID", err.Error()))
+		return
+	}
+
+	var req domain.SetBedStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	bed, err := h.inpatientService.SetBedStatus(id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to update bed status", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Bed status updated successfully", bed))
+}
+
+// Board returns the live bed board, optionally of one ward (?ward_id=).
+func (h *InpatientHandler) Board(c *gin.Context) {
+	wardID, err := queryID(c, "ward_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid ward ID", err.Error()))
+		return
+	}
+
+	boards, err := h.inpatientService.Board(actorFromContext(c), wardID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get bed board", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Bed board retrieved successfully", boards))
+}
+
+// Census returns the per-ward census for ?date= (YYYY-MM-DD, default
+// today).
+func (h *InpatientHandler) Census(c *gin.Context) {
+	census, err := h.inpatientService.Census(c.Query("date"))
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get ward census", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Ward census retrieved successfully", census))
+}
+
+func (h *InpatientHandler) ListAdmissions(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	filter, err := admissionFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid filter", err.Error()))
+		return
+	}
+	filter.Limit = int32(limit)
+	filter.Offset = int32(offset)
+
+	admissions, err := h.inpatientService.ListAdmissions(actorFromContext(c), filter)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get admissions", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Admissions retrieved successfully", admissions))
+}
+
+func (h *InpatientHandler) GetAdmission(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid admission ID", err.Error()))
+		return
+	}
+
+	admission, err := h.inpatientService.GetAdmission(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get admission", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Admission retrieved successfully", admission))
+}
+
+func (h *InpatientHandler) Admit(c *gin.Context) {
+	var req domain.AdmitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	admission, err := h.inpatientService.Admit(actorFromContext(c), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to admit patient", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Patient admitted successfully", admission))
+}
+
+func (h *InpatientHandler) Transfer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid admission ID", err.Error()))
+		return
+	}
+
+	var req domain.TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	admission, err := h.inpatientService.Transfer(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to transfer patient", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Patient transferred successfully", admission))
+}
+
+func (h *InpatientHandler) Discharge(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid admission ID", err.Error()))
+		return
+	}
+
+	var req domain.DischargeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	admission, err := h.inpatientService.Discharge(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to discharge patient", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Patient discharged successfully", admission))
+}
+
+func admissionFilter(c *gin.Context) (domain.AdmissionFilter, error) {
+	var filter domain.AdmissionFilter
+	var err error
+	if s := c.Query("status"); s != "" {
+		filter.Status = &s
+	}
+	if filter.WardID, err = queryID(c, "ward_id"); err != nil {
+		return filter, err
+	}
+	filter.PatientID, err = queryID(c, "patient_id")
+	return filter, err
+}
@@ -0,0 +1,404 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+const claimFileContentType = "text/csv; charset=utf-8"
+
+type InsuranceHandler struct {
+	insuranceService *services.InsuranceService
+}
+
+func NewInsuranceHandler(insuranceService *services.InsuranceService) *InsuranceHandler {
+	return &InsuranceHandler{insuranceService: insuranceService}
+}
+
+// ListPayers lists insurance payers, filtered by ?active=.
+func (h *InsuranceHandler) ListPayers(c *gin.Context) {
+	var active *bool
+	if v := c.Query("active"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid active", err.Error()))
+			return
+		}
+		active = &b
+	}
+
+	payers, err := h.insuranceService.ListPayers(active)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get insurance payers", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Insurance payers retrieved successfully", payers))
+}
+
+func (h *InsuranceHandler) GetPayer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid insurance payer ID", err.Error()))
+		return
+	}
+
+	payer, err := h.insuranceService.GetPayer(id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get insurance payer", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Insurance payer retrieved successfully", payer))
+}
+
+func (h *InsuranceHandler) CreatePayer(c *gin.Context) {
+	var req domain.SaveInsurancePayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	payer, err := h.insuranceService.CreatePayer(&req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create insurance payer", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Insurance payer created successfully", payer))
+}
+
+func (h *InsuranceHandler) UpdatePayer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid insurance payer ID", err.Error()))
+		return
+	}
+
+	var req domain.SaveInsurancePayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	payer, err := h.insuranceService.UpdatePayer(id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to update insurance payer", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Insurance payer updated successfully", payer))
+}
+
+func (h *InsuranceHandler) ListCoverages(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	coverages, err := h.insuranceService.ListCoverages(actorFromContext(c), patientID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get coverages", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Coverages retrieved successfully", coverages))
+}
+
+func (h *InsuranceHandler) CreateCoverage(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	var req domain.SaveCoverageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	coverage, err := h.insuranceService.CreateCoverage(actorFromContext(c), patientID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create coverage", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Coverage created successfully", coverage))
+}
+
+func (h *InsuranceHandler) UpdateCoverage(c *gin.Context) {
+	patientID, id, ok := coverageParams(c)
+	if !ok {
+		return
+	}
+
+	var req domain.SaveCoverageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	coverage, err := h.insuranceService.UpdateCoverage(actorFromContext(c), patientID, id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to update coverage", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Coverage updated successfully", coverage))
+}
+
+func (h *InsuranceHandler) DeleteCoverage(c *gin.Context) {
+	patientID, id, ok := coverageParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.insuranceService.DeleteCoverage(actorFromContext(c), patientID, id); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to delete coverage", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Coverage deleted successfully", nil))
+}
+
+// CreateClaim drafts an insurance claim of an issued invoice.
+func (h *InsuranceHandler) CreateClaim(c *gin.Context) {
+	invoiceID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid invoice ID", err.Error()))
+		return
+	}
+
+	var req domain.CreateClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	claim, err := h.insuranceService.CreateClaim(actorFromContext(c), invoiceID, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create claim", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Claim created successfully", claim))
+}
+
+// ListClaims lists claims, newest first, filtered by ?status=,
+// ?payer_id=, ?invoice_id=, ?patient_id= and ?batch_id=.
+func (h *InsuranceHandler) ListClaims(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	filter, err := claimFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid filter", err.Error()))
+		return
+	}
+	filter.Limit = int32(limit)
+	filter.Offset = int32(offset)
+
+	claims, err := h.insuranceService.ListClaims(actorFromContext(c), filter)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get claims", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Claims retrieved successfully", claims))
+}
+
+func (h *InsuranceHandler) GetClaim(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid claim ID", err.Error()))
+		return
+	}
+
+	claim, err := h.insuranceService.GetClaim(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get claim", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Claim retrieved successfully", claim))
+}
+
+func (h *InsuranceHandler) AdjudicateClaim(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid claim ID", err.Error()))
+		return
+	}
+
+	var req domain.AdjudicateClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	claim, err := h.insuranceService.AdjudicateClaim(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to adjudicate claim", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Claim adjudicated successfully", claim))
+}
+
+func (h *InsuranceHandler) CancelClaim(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid claim ID", err.Error()))
+		return
+	}
+
+	claim, err := h.insuranceService.CancelClaim(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to cancel claim", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Claim cancelled successfully", claim))
+}
+
+func (h *InsuranceHandler) RecordClaimPayment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid claim ID", err.Error()))
+		return
+	}
+
+	var req domain.ClaimPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	claim, err := h.insuranceService.RecordClaimPayment(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to record claim payment", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Claim payment recorded successfully", claim))
+}
+
+// SubmitBatch submits every draft claim to a payer as one batch.
+func (h *InsuranceHandler) SubmitBatch(c *gin.Context) {
+	var req domain.CreateClaimBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	batch, err := h.insuranceService.SubmitBatch(actorFromContext(c), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to submit claims", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Claims submitted successfully", batch))
+}
+
+// ListBatches lists claim batches, newest first, filtered by ?payer_id=.
+func (h *InsuranceHandler) ListBatches(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	payerID, err := queryID(c, "payer_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid payer_id", err.Error()))
+		return
+	}
+
+	batches, err := h.insuranceService.ListBatches(payerID, int32(limit), int32(offset))
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get claim batches", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Claim batches retrieved successfully", batches))
+}
+
+func (h *InsuranceHandler) GetBatch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid claim batch ID", err.Error()))
+		return
+	}
+
+	batch, err := h.insuranceService.GetBatch(id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get claim batch", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Claim batch retrieved successfully", batch))
+}
+
+// BatchFile downloads the batch's claim file for the clearinghouse.
+func (h *InsuranceHandler) BatchFile(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid claim batch ID", err.Error()))
+		return
+	}
+
+	file, err := h.insuranceService.BatchFile(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to generate claim file", err.Error()))
+		return
+	}
+	var buf bytes.Buffer
+	if err := file.Write(&buf); err != nil {
+		c.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to generate claim file", err.Error()))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="claims-%s-%d.csv"`, file.PayerCode, file.ID))
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, claimFileContentType, buf.Bytes())
+}
+
+func claimFilter(c *gin.Context) (domain.ClaimFilter, error) {
+	var filter domain.ClaimFilter
+	var err error
+	if s := c.Query("status"); s != "" {
+		filter.Status = &s
+	}
+	if filter.PayerID, err = queryID(c, "payer_id"); err != nil {
+		return filter, err
+	}
+	if filter.InvoiceID, err = queryID(c, "invoice_id"); err != nil {
+		return filter, err
+	}
+	if filter.PatientID, err = queryID(c, "patient_id"); err != nil {
+		return filter, err
+	}
+	filter.BatchID, err = queryID(c, "batch_id")
+	return filter, err
+}
+
+func coverageParams(c *gin.Context) (int, int, bool) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return 0, 0, false
+	}
+	id, err := strconv.Atoi(c.Param("coverageId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid coverage ID", err.Error()))
+		return 0, 0, false
+	}
+	return patientID, id, true
+}
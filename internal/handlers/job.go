@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+const maxJobRuns = 500
+
+type JobHandler struct {
+	jobScheduler *services.JobScheduler
+}
+
+func NewJobHandler(jobScheduler *services.JobScheduler) *JobHandler {
+	return &JobHandler{jobScheduler: jobScheduler}
+}
+
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	jobs, err := h.jobScheduler.ListJobs()
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get jobs", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Jobs retrieved successfully", jobs))
+}
+
+func (h *JobHandler) ListRuns(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > maxJobRuns {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid limit", "limit must be between 1 and 500"))
+		return
+	}
+
+	filter := domain.JobRunFilter{Limit: int32(limit)}
+	if s := c.Query("job"); s != "" {
+		filter.JobName = &s
+	}
+	if s := c.Query("status"); s != "" {
+		filter.Status = &s
+	}
+
+	runs, err := h.jobScheduler.ListRuns(filter)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get job runs", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Job runs retrieved successfully", runs))
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -8,26 +9,35 @@ import (
 	"github.com/prem0x01/hospital/internal/domain"
 	"github.com/prem0x01/hospital/internal/services"
 	"github.com/prem0x01/hospital/internal/utils"
+	"github.com/prem0x01/hospital/internal/visibility"
 )
 
 type PatientHandler struct {
 	patientService *services.PatientService
+	fieldPolicy    visibility.Policy
 }
 
-func NewPatientHandler(patientService *services.PatientService) *PatientHandler {
-	return &PatientHandler{patientService: patientService}
+func NewPatientHandler(patientService *services.PatientService, fieldPolicy visibility.Policy) *PatientHandler {
+	return &PatientHandler{patientService: patientService, fieldPolicy: fieldPolicy}
 }
 
 func (h *PatientHandler) GetPatients(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-	patients, err := h.patientService.GetPatients(limit, offset)
+	var err error
+	var patients []domain.Patient
+	if search := c.Query("search"); search != "" {
+		patients, err = h.patientService.SearchPatients(actorFromContext(c), search, limit, offset)
+	} else {
+		patients, err = h.patientService.GetPatients(actorFromContext(c), limit, offset)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to get patients", err.Error()))
 		return
 	}
 
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourcePatient, patients)
 	c.JSON(http.StatusOK, utils.SuccessResponse("Patients retrieved successfully", patients))
 }
 
@@ -38,12 +48,17 @@ func (h *PatientHandler) GetPatient(c *gin.Context) {
 		return
 	}
 
-	patient, err := h.patientService.GetPatient(id)
+	patient, err := h.patientService.GetPatient(actorFromContext(c), id)
+	if errors.Is(err, domain.ErrForbidden) {
+		c.JSON(http.StatusForbidden, utils.ErrorResponse("Access denied", err.Error()))
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusNotFound, utils.ErrorResponse("Patient not found", err.Error()))
 		return
 	}
 
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourcePatient, patient)
 	c.JSON(http.StatusOK, utils.SuccessResponse("Patient retrieved successfully", patient))
 }
 
@@ -54,13 +69,13 @@ func (h *PatientHandler) CreatePatient(c *gin.Context) {
 		return
 	}
 
-	userID := c.GetInt("user_id")
-	patient, err := h.patientService.CreatePatient(&req, userID)
+	patient, err := h.patientService.CreatePatient(actorFromContext(c), &req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to create patient", err.Error()))
 		return
 	}
 
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourcePatient, patient)
 	c.JSON(http.StatusCreated, utils.SuccessResponse("Patient created successfully", patient))
 }
 
@@ -77,8 +92,8 @@ func (h *PatientHandler) UpdatePatient(c *gin.Context) {
 		return
 	}
 
-	if _, err := h.patientService.UpdatePatient(id, &req); err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to update patient", err.Error()))
+	if _, err := h.patientService.UpdatePatient(actorFromContext(c), id, &req); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to update patient", err.Error()))
 		return
 	}
 
@@ -99,8 +114,8 @@ func (h *PatientHandler) DeletePatient(c *gin.Context) {
 		return
 	}
 
-	if err := h.patientService.DeletePatient(id); err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ErrorResponse("Failed to delete patient", err.Error()))
+	if err := h.patientService.DeletePatient(actorFromContext(c), id); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to delete patient", err.Error()))
 		return
 	}
 
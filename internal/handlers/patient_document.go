@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+// multipartOverhead is allowed on top of the file size for the form
+// fields and part headers of an upload.
+const multipartOverhead = 1 << 20
+
+type PatientDocumentHandler struct {
+	documentService *services.PatientDocumentService
+}
+
+func NewPatientDocumentHandler(documentService *services.PatientDocumentService) *PatientDocumentHandler {
+	return &PatientDocumentHandler{documentService: documentService}
+}
+
+// Upload takes a multipart form with the file in "file" and the category
+// and optional description as fields.
+func (h *PatientDocumentHandler) Upload(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+
+	maxBytes := h.documentService.MaxBytes()
+	tooLarge := fmt.Sprintf("files can be at most %d bytes", maxBytes)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes+multipartOverhead)
+
+	var req domain.UploadDocumentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, utils.ErrorResponse("File too large", tooLarge))
+			return
+		}
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", "file is required"))
+		return
+	}
+	defer file.Close()
+	if header.Size > maxBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, utils.ErrorResponse("File too large", tooLarge))
+		return
+	}
+
+	doc, err := h.documentService.Upload(actorFromContext(c), patientID, &req, header.Filename, file)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to upload document", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Document uploaded successfully", doc))
+}
+
+// ListDocuments lists the patient's documents, filtered by ?category=.
+func (h *PatientDocumentHandler) ListDocuments(c *gin.Context) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return
+	}
+	var category *string
+	if v := c.Query("category"); v != "" {
+		category = &v
+	}
+
+	docs, err := h.documentService.ListDocuments(actorFromContext(c), patientID, category)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get documents", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Documents retrieved successfully", docs))
+}
+
+func (h *PatientDocumentHandler) GetDocument(c *gin.Context) {
+	patientID, id, ok := documentParams(c)
+	if !ok {
+		return
+	}
+
+	doc, err := h.documentService.GetDocument(actorFromContext(c), patientID, id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get document", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Document retrieved successfully", doc))
+}
+
+// Download streams the document's contents with the sniffed content type.
+func (h *PatientDocumentHandler) Download(c *gin.Context) {
+	patientID, id, ok := documentParams(c)
+	if !ok {
+		return
+	}
+
+	doc, content, err := h.documentService.OpenDocument(actorFromContext(c), patientID, id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to download document", err.Error()))
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, doc.SizeBytes, doc.ContentType, content, map[string]string{
+		"Content-Disposition":    fmt.Sprintf(`attachment; filename="%s"`, doc.Filename),
+		"Cache-Control":          "private, no-store",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h *PatientDocumentHandler) DeleteDocument(c *gin.Context) {
+	patientID, id, ok := documentParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.documentService.DeleteDocument(actorFromContext(c), patientID, id); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to delete document", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Document deleted successfully", nil))
+}
+
+func documentParams(c *gin.Context) (int, int, bool) {
+	patientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient ID", err.Error()))
+		return 0, 0, false
+	}
+	id, err := strconv.Atoi(c.Param("documentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid document ID", err.Error()))
+		return 0, 0, false
+	}
+	return patientID, id, true
+}
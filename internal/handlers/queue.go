@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+// queueHeartbeat keeps idle streams from being closed by proxies.
+const queueHeartbeat = 30 * time.Second
+
+type QueueHandler struct {
+	queueService *services.QueueService
+}
+
+func NewQueueHandler(queueService *services.QueueService) *QueueHandler {
+	return &QueueHandler{queueService: queueService}
+}
+
+func (h *QueueHandler) GetQueue(c *gin.Context) {
+	filter, err := queueFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid doctor ID", err.Error()))
+		return
+	}
+
+	queues, err := h.queueService.GetQueue(actorFromContext(c), filter)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get queue", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Queue retrieved successfully", queues))
+}
+
+// Stream sends the queue as a "queue" Server-Sent Event on connect and
+// again whenever it changes.
+func (h *QueueHandler) Stream(c *gin.Context) {
+	filter, err := queueFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid doctor ID", err.Error()))
+		return
+	}
+
+	updates, err := h.queueService.Watch(c.Request.Context(), actorFromContext(c), filter)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get queue", err.Error()))
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(queueHeartbeat)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case queues, ok := <-updates:
+			if !ok {
+				return false
+			}
+			c.SSEvent("queue", queues)
+		case <-heartbeat.C:
+			// A comment line, ignored by clients.
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func queueFilter(c *gin.Context) (domain.QueueFilter, error) {
+	var filter domain.QueueFilter
+	var err error
+	if filter.DoctorID, err = queryID(c, "doctor_id"); err != nil {
+		return filter, err
+	}
+	if s := c.Query("specialty"); s != "" {
+		filter.Specialty = &s
+	}
+	filter.DepartmentID, err = queryID(c, "department_id")
+	return filter, err
+}
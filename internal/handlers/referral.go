@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+	"github.com/prem0x01/hospital/internal/visibility"
+)
+
+type ReferralHandler struct {
+	referralService *services.ReferralService
+	fieldPolicy     visibility.Policy
+}
+
+func NewReferralHandler(referralService *services.ReferralService, fieldPolicy visibility.Policy) *ReferralHandler {
+	return &ReferralHandler{referralService: referralService, fieldPolicy: fieldPolicy}
+}
+
+func (h *ReferralHandler) CreateReferral(c *gin.Context) {
+	var req domain.CreateReferralRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	referral, err := h.referralService.CreateReferral(actorFromContext(c), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create referral", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Referral created successfully", referral))
+}
+
+// ListReferrals lists referrals filtered by ?status=&patient_id=. Doctors
+// see those they can act on, or with ?box=sent those they sent.
+func (h *ReferralHandler) ListReferrals(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	filter := domain.ReferralFilter{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+	if s := c.Query("status"); s != "" {
+		filter.Status = &s
+	}
+	patientID, err := queryID(c, "patient_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid patient_id", err.Error()))
+		return
+	}
+	filter.PatientID = patientID
+
+	referrals, err := h.referralService.ListReferrals(actorFromContext(c), c.Query("box"), filter)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get referrals", err.Error()))
+		return
+	}
+
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourceReferral, referrals)
+	c.JSON(http.StatusOK, utils.SuccessResponse("Referrals retrieved successfully", referrals))
+}
+
+func (h *ReferralHandler) GetReferral(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid referral ID", err.Error()))
+		return
+	}
+
+	referral, err := h.referralService.GetReferral(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get referral", err.Error()))
+		return
+	}
+
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourceReferral, referral)
+	c.JSON(http.StatusOK, utils.SuccessResponse("Referral retrieved successfully", referral))
+}
+
+func (h *ReferralHandler) AcceptReferral(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid referral ID", err.Error()))
+		return
+	}
+
+	referral, err := h.referralService.AcceptReferral(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to accept referral", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Referral accepted successfully", referral))
+}
+
+func (h *ReferralHandler) DeclineReferral(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid referral ID", err.Error()))
+		return
+	}
+
+	var req domain.DeclineReferralRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	referral, err := h.referralService.DeclineReferral(actorFromContext(c), id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to decline referral", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Referral declined successfully", referral))
+}
+
+func (h *ReferralHandler) BookAppointment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid referral ID", err.Error()))
+		return
+	}
+
+	var req domain.BookReferralRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	referral, err := h.referralService.BookAppointment(actorFromContext(c), id, &req)
+	if err != nil {
+		appointmentError(c, "Failed to book referral appointment", err)
+		return
+	}
+
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourceReferral, referral)
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Referral appointment booked successfully", referral))
+}
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/localtime"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+type ResourceHandler struct {
+	resourceService *services.ResourceService
+	loc             *time.Location
+}
+
+func NewResourceHandler(resourceService *services.ResourceService, loc *time.Location) *ResourceHandler {
+	return &ResourceHandler{resourceService: resourceService, loc: loc}
+}
+
+// ListRooms lists rooms, optionally limited to ?department_id=.
+func (h *ResourceHandler) ListRooms(c *gin.Context) {
+	departmentID, err := queryID(c, "department_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid department ID", err.Error()))
+		return
+	}
+
+	rooms, err := h.resourceService.ListRooms(departmentID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get rooms", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Rooms retrieved successfully", rooms))
+}
+
+func (h *ResourceHandler) GetRoom(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid room ID", err.Error()))
+		return
+	}
+
+	room, err := h.resourceService.GetRoom(id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get room", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Room retrieved successfully", room))
+}
+
+func (h *ResourceHandler) CreateRoom(c *gin.Context) {
+	var req domain.SaveResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	room, err := h.resourceService.CreateRoom(&req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create room", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Room created successfully", room))
+}
+
+func (h *ResourceHandler) UpdateRoom(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid room ID", err.Error()))
+		return
+	}
+
+	var req domain.SaveResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	room, err := h.resourceService.UpdateRoom(id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to update room", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Room updated successfully", room))
+}
+
+// GetRoomSchedule lists the room's appointments between ?from= and ?to=,
+// which default to today and a week later.
+func (h *ResourceHandler) GetRoomSchedule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid room ID", err.Error()))
+		return
+	}
+	from, to, ok := h.bookingRange(c)
+	if !ok {
+		return
+	}
+
+	bookings, err := h.resourceService.GetRoomSchedule(id, from, to)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get room schedule", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Room schedule retrieved successfully", bookings))
+}
+
+// ListEquipment lists equipment, optionally limited to ?department_id=.
+func (h *ResourceHandler) ListEquipment(c *gin.Context) {
+	departmentID, err := queryID(c, "department_id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid department ID", err.Error()))
+		return
+	}
+
+	equipment, err := h.resourceService.ListEquipment(departmentID)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get equipment", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Equipment retrieved successfully", equipment))
+}
+
+func (h *ResourceHandler) GetEquipment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid equipment ID", err.Error()))
+		return
+	}
+
+	equipment, err := h.resourceService.GetEquipment(id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get equipment", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Equipment retrieved successfully", equipment))
+}
+
+func (h *ResourceHandler) CreateEquipment(c *gin.Context) {
+	var req domain.SaveResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	equipment, err := h.resourceService.CreateEquipment(&req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to create equipment", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Equipment created successfully", equipment))
+}
+
+func (h *ResourceHandler) UpdateEquipment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid equipment ID", err.Error()))
+		return
+	}
+
+	var req domain.SaveResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	equipment, err := h.resourceService.UpdateEquipment(id, &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to update equipment", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Equipment updated successfully", equipment))
+}
+
+// ListEquipmentBookings lists the equipment's bookings between ?from= and
+// ?to=, which default to today and a week later.
+func (h *ResourceHandler) ListEquipmentBookings(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid equipment ID", err.Error()))
+		return
+	}
+	from, to, ok := h.bookingRange(c)
+	if !ok {
+		return
+	}
+
+	bookings, err := h.resourceService.ListEquipmentBookings(id, from, to)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get equipment bookings", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Equipment bookings retrieved successfully", bookings))
+}
+
+func (h *ResourceHandler) BookEquipment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid equipment ID", err.Error()))
+		return
+	}
+
+	var req domain.CreateEquipmentBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	booking, err := h.resourceService.BookEquipment(actorFromContext(c), id, &req)
+	if err != nil {
+		resp := utils.ErrorResponse("Failed to book equipment", err.Error())
+		var conflict *domain.ResourceConflictError
+		if errors.As(err, &conflict) {
+			resp.Data = gin.H{"conflicts": conflict.Conflicts}
+		}
+		c.JSON(errorStatus(err), resp)
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Equipment booked successfully", booking))
+}
+
+func (h *ResourceHandler) CancelEquipmentBooking(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid booking ID", err.Error()))
+		return
+	}
+
+	if err := h.resourceService.CancelEquipmentBooking(id); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to cancel equipment booking", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Equipment booking cancelled", nil))
+}
+
+// bookingRange reads ?from= and ?to= like the slot endpoints do.
+func (h *ResourceHandler) bookingRange(c *gin.Context) (from, to time.Time, ok bool) {
+	today := localtime.StartOfDay(time.Now().In(h.loc), h.loc)
+	from, to = today, localtime.StartOfDay(today.AddDate(0, 0, defaultSlotDays), h.loc)
+	var err error
+	if v := c.Query("from"); v != "" {
+		if from, err = parseSlotTime(v, false, h.loc); err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid from", err.Error()))
+			return from, to, false
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if to, err = parseSlotTime(v, true, h.loc); err != nil {
+			c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid to", err.Error()))
+			return from, to, false
+		}
+	}
+	return from, to, true
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/services"
+	"github.com/prem0x01/hospital/internal/utils"
+	"github.com/prem0x01/hospital/internal/visibility"
+)
+
+type WaitlistHandler struct {
+	waitlistService services.IWaitlistService
+	fieldPolicy     visibility.Policy
+}
+
+func NewWaitlistHandler(waitlistService services.IWaitlistService, fieldPolicy visibility.Policy) *WaitlistHandler {
+	return &WaitlistHandler{waitlistService: waitlistService, fieldPolicy: fieldPolicy}
+}
+
+func (h *WaitlistHandler) CreateEntry(c *gin.Context) {
+	var req domain.CreateWaitlistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid request", err.Error()))
+		return
+	}
+
+	entry, err := h.waitlistService.CreateEntry(actorFromContext(c), &req)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to add to waitlist", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Added to waitlist", entry))
+}
+
+func (h *WaitlistHandler) ListEntries(c *gin.Context) {
+	var status *string
+	if s := c.Query("status"); s != "" {
+		status = &s
+	}
+
+	entries, err := h.waitlistService.ListEntries(actorFromContext(c), status)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get waitlist", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Waitlist retrieved successfully", entries))
+}
+
+func (h *WaitlistHandler) GetEntry(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid waitlist entry ID", err.Error()))
+		return
+	}
+
+	entry, err := h.waitlistService.GetEntry(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to get waitlist entry", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Waitlist entry retrieved successfully", entry))
+}
+
+func (h *WaitlistHandler) CancelEntry(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid waitlist entry ID", err.Error()))
+		return
+	}
+
+	if err := h.waitlistService.CancelEntry(actorFromContext(c), id); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to remove from waitlist", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Removed from waitlist", nil))
+}
+
+func (h *WaitlistHandler) ConfirmHold(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid hold ID", err.Error()))
+		return
+	}
+
+	appointment, err := h.waitlistService.ConfirmHold(actorFromContext(c), id)
+	if err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to confirm hold", err.Error()))
+		return
+	}
+
+	h.fieldPolicy.Apply(c.GetString("user_role"), domain.ResourceAppointment, appointment)
+	c.JSON(http.StatusCreated, utils.SuccessResponse("Appointment booked from waitlist", appointment))
+}
+
+func (h *WaitlistHandler) DeclineHold(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.ErrorResponse("Invalid hold ID", err.Error()))
+		return
+	}
+
+	if err := h.waitlistService.DeclineHold(actorFromContext(c), id); err != nil {
+		c.JSON(errorStatus(err), utils.ErrorResponse("Failed to decline hold", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.SuccessResponse("Hold declined", nil))
+}
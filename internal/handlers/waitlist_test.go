@@ -0,0 +1,89 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/handlers"
+	"github.com/prem0x01/hospital/internal/visibility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockWaitlistService struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistService) CreateEntry(actor domain.Actor, req *domain.CreateWaitlistEntryRequest) (*domain.WaitlistEntry, error) {
+	args := m.Called(actor, req)
+	entry, _ := args.Get(0).(*domain.WaitlistEntry)
+	return entry, args.Error(1)
+}
+
+func (m *MockWaitlistService) ListEntries(actor domain.Actor, status *string) ([]domain.WaitlistEntry, error) {
+	args := m.Called(actor, status)
+	entries, _ := args.Get(0).([]domain.WaitlistEntry)
+	return entries, args.Error(1)
+}
+
+func (m *MockWaitlistService) GetEntry(actor domain.Actor, id int) (*domain.WaitlistEntry, error) {
+	args := m.Called(actor, id)
+	entry, _ := args.Get(0).(*domain.WaitlistEntry)
+	return entry, args.Error(1)
+}
+
+func (m *MockWaitlistService) CancelEntry(actor domain.Actor, id int) error {
+	return m.Called(actor, id).Error(0)
+}
+
+func (m *MockWaitlistService) ConfirmHold(actor domain.Actor, id int) (*domain.Appointment, error) {
+	args := m.Called(actor, id)
+	appointment, _ := args.Get(0).(*domain.Appointment)
+	return appointment, args.Error(1)
+}
+
+func (m *MockWaitlistService) DeclineHold(actor domain.Actor, id int) error {
+	return m.Called(actor, id).Error(0)
+}
+
+func TestConfirmHold_HidesClinicalFieldsFromReceptionist(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockWaitlistService)
+	handler := handlers.NewWaitlistHandler(mockService, visibility.DefaultPolicy())
+
+	router := gin.Default()
+	router.POST("/waitlist/holds/:id/confirm", func(c *gin.Context) {
+		c.Set("user_id", 7)
+		c.Set("user_role", domain.RoleReceptionist)
+		handler.ConfirmHold(c)
+	})
+
+	diagnosis := "Suspected appendicitis"
+	plan := "Surgical consult"
+	mockService.On("ConfirmHold", mock.Anything, 3).Return(&domain.Appointment{
+		ID:            12,
+		Diagnosis:     &diagnosis,
+		TreatmentPlan: &plan,
+	}, nil)
+
+	req, _ := http.NewRequest("POST", "/waitlist/holds/3/confirm", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	require.Equal(t, http.StatusCreated, resp.Code)
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.EqualValues(t, 12, body.Data["id"])
+	assert.Nil(t, body.Data["diagnosis"])
+	assert.Nil(t, body.Data["treatment_plan"])
+	assert.NotContains(t, resp.Body.String(), diagnosis)
+	assert.NotContains(t, resp.Body.String(), plan)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,125 @@
+// Package ical writes iCalendar (RFC 5545) files.
+//
+// It supports just what appointment feeds need: a calendar of VEVENTs with
+// a start, an end, a summary and a status.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Event statuses.
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+const (
+	utcFormat = "20060102T150405Z"
+	// maxLineOctets is the longest a content line may be before folding.
+	maxLineOctets = 75
+)
+
+type Event struct {
+	// UID identifies the event across feed refreshes, so it must not
+	// change when the event does.
+	UID          string
+	Start        time.Time
+	End          time.Time
+	Summary      string
+	Description  string
+	Status       string
+	LastModified time.Time
+}
+
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Write writes c to w. All times are written in UTC, which calendar apps
+// show in the viewer's own zone.
+func (c *Calendar) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	l := lineWriter{w: bw}
+	l.line("BEGIN", "VCALENDAR")
+	l.line("VERSION", "2.0")
+	l.line("PRODID", "-//prem0x01//hospital//EN")
+	l.line("CALSCALE", "GREGORIAN")
+	l.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		l.line("X-WR-CALNAME", escape(c.Name))
+	}
+	for _, e := range c.Events {
+		l.line("BEGIN", "VEVENT")
+		l.line("UID", escape(e.UID))
+		l.line("DTSTAMP", e.LastModified.UTC().Format(utcFormat))
+		l.line("DTSTART", e.Start.UTC().Format(utcFormat))
+		l.line("DTEND", e.End.UTC().Format(utcFormat))
+		l.line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			l.line("DESCRIPTION", escape(e.Description))
+		}
+		if e.Status != "" {
+			l.line("STATUS", e.Status)
+		}
+		l.line("LAST-MODIFIED", e.LastModified.UTC().Format(utcFormat))
+		l.line("END", "VEVENT")
+	}
+	l.line("END", "VCALENDAR")
+	if l.err != nil {
+		return l.err
+	}
+	return bw.Flush()
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return textEscaper.Replace(s)
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// lineWriter writes content lines, folding them at maxLineOctets without
+// splitting a UTF-8 sequence. It keeps the first error and ignores later
+// writes.
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (l *lineWriter) line(name, value string) {
+	if l.err != nil {
+		return
+	}
+	s := name + ":" + value
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		l.write(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts.
+		limit = maxLineOctets - 1
+	}
+	l.write(s + "\r\n")
+}
+
+func (l *lineWriter) write(s string) {
+	if l.err == nil {
+		_, l.err = l.w.WriteString(s)
+	}
+}
@@ -0,0 +1,77 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prem0x01/hospital/internal/ical"
+)
+
+func write(t *testing.T, c *ical.Calendar) string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, c.Write(&buf))
+	return buf.String()
+}
+
+func TestWrite(t *testing.T) {
+	modified := time.Date(2025, 3, 1, 8, 0, 0, 0, time.FixedZone("", 3600))
+	out := write(t, &ical.Calendar{
+		Name: "Clinic",
+		Events: []ical.Event{{
+			UID:          "appointment-7@hospital",
+			Start:        time.Date(2025, 3, 3, 9, 30, 0, 0, time.FixedZone("", 3600)),
+			End:          time.Date(2025, 3, 3, 10, 0, 0, 0, time.FixedZone("", 3600)),
+			Summary:      "Follow-up; room 2, east wing",
+			Status:       ical.StatusCancelled,
+			LastModified: modified,
+		}},
+	})
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//prem0x01//hospital//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Clinic",
+		"BEGIN:VEVENT",
+		"UID:appointment-7@hospital",
+		"DTSTAMP:20250301T070000Z",
+		"DTSTART:20250303T083000Z",
+		"DTEND:20250303T090000Z",
+		`SUMMARY:Follow-up\; room 2\, east wing`,
+		"STATUS:CANCELLED",
+		"LAST-MODIFIED:20250301T070000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	assert.Equal(t, want, out)
+}
+
+func TestWriteFoldsLongLines(t *testing.T) {
+	summary := strings.Repeat("é", 100)
+	out := write(t, &ical.Calendar{Events: []ical.Event{{UID: "x", Summary: summary}}})
+
+	var unfolded []string
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+		if strings.HasPrefix(line, " ") {
+			unfolded[len(unfolded)-1] += line[1:]
+			continue
+		}
+		unfolded = append(unfolded, line)
+	}
+	assert.Contains(t, unfolded, "SUMMARY:"+summary)
+}
+
+func TestWriteEscapesNewlines(t *testing.T) {
+	out := write(t, &ical.Calendar{Events: []ical.Event{{UID: "x", Description: "a\nb\\c"}}})
+	assert.Contains(t, out, "DESCRIPTION:a\\nb\\\\c\r\n")
+}
@@ -0,0 +1,252 @@
+// Package icd10 reads the ICD-10-CM code files published by CMS and
+// normalises codes for storage and search.
+//
+// Three release files are understood:
+//
+//   - the tabular list, icd10cm_tabular_YYYY.xml, in which seventh
+//     character extensions are expanded into billable codes;
+//   - the order file, icd10cm_order_YYYY.txt, a fixed-width list of every
+//     code with a flag for whether it is billable;
+//   - the codes file, icd10cm_codes_YYYY.txt, listing billable codes only.
+package icd10
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Code is one entry of the catalog. Billable codes are valid on claims;
+// the others are categories that group them.
+type Code struct {
+	Code        string
+	Description string
+	Billable    bool
+}
+
+var (
+	codePattern   = regexp.MustCompile(`^[A-Z][0-9][0-9A-Z](\.[0-9A-Z]{1,4})?$`)
+	prefixPattern = regexp.MustCompile(`^[A-Z][0-9][0-9A-Z]?(\.?[0-9A-Z]{0,4})?$`)
+)
+
+// Normalize upper-cases code, drops spaces and dots, and puts the dot back
+// after the category: "e119" and "E11.9" both become "E11.9".
+func Normalize(code string) string {
+	code = strings.ToUpper(strings.Map(func(r rune) rune {
+		if r == '.' || unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, code))
+	if len(code) > 3 {
+		code = code[:3] + "." + code[3:]
+	}
+	return code
+}
+
+// Valid reports whether code, once normalised, has the shape of an
+// ICD-10-CM code. It does not check that the code exists.
+func Valid(code string) bool {
+	return codePattern.MatchString(Normalize(code))
+}
+
+// IsCodePrefix reports whether q looks like the start of a code rather
+// than words of a description.
+func IsCodePrefix(q string) bool {
+	q = strings.ToUpper(strings.TrimSpace(q))
+	return prefixPattern.MatchString(q)
+}
+
+// PrefixQuery turns free text into a PostgreSQL tsquery matching
+// descriptions that contain every word, the last ones possibly
+// unfinished, as when typing into an autocomplete box. It returns "" if q
+// has no words.
+func PrefixQuery(q string) string {
+	words := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// Read parses a release file, detecting which one it is, and calls emit
+// for every code in file order.
+func Read(r io.Reader, emit func(Code) error) error {
+	br := bufio.NewReader(r)
+	if bom, _ := br.Peek(3); string(bom) == "\xEF\xBB\xBF" {
+		br.Discard(3)
+	}
+	for {
+		b, err := br.Peek(1)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !unicode.IsSpace(rune(b[0])) {
+			break
+		}
+		br.Discard(1)
+	}
+
+	b, _ := br.Peek(1)
+	switch {
+	case b[0] == '<':
+		return readTabular(br, emit)
+	case b[0] >= '0' && b[0] <= '9':
+		return readLines(br, parseOrderLine, emit)
+	default:
+		return readLines(br, parseCodesLine, emit)
+	}
+}
+
+func readLines(r io.Reader, parse func(string) (Code, error), emit func(Code) error) error {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), " \r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		c, err := parse(text)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := emit(c); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// parseOrderLine reads one line of the order file: a five digit order
+// number, the code in columns 7-13, the billable flag in column 15, the
+// short description in columns 17-76 and the long description from
+// column 78.
+func parseOrderLine(line string) (Code, error) {
+	if len(line) < 17 {
+		return Code{}, fmt.Errorf("too short for the order file format")
+	}
+	code := Normalize(line[6:13])
+	if !codePattern.MatchString(code) {
+		return Code{}, fmt.Errorf("invalid code %q", strings.TrimSpace(line[6:13]))
+	}
+
+	var billable bool
+	switch line[14] {
+	case '0':
+	case '1':
+		billable = true
+	default:
+		return Code{}, fmt.Errorf("invalid billable flag %q", line[14])
+	}
+
+	desc := strings.TrimSpace(line[16:])
+	if len(line) > 77 {
+		desc = strings.TrimSpace(line[77:])
+	}
+	return Code{Code: code, Description: desc, Billable: billable}, nil
+}
+
+// parseCodesLine reads one line of the codes file: the code, blank space
+// and the description.
+func parseCodesLine(line string) (Code, error) {
+	fields := strings.SplitN(line, " ", 2)
+	code := Normalize(fields[0])
+	if !codePattern.MatchString(code) {
+		return Code{}, fmt.Errorf("invalid code %q", fields[0])
+	}
+	if len(fields) < 2 || strings.TrimSpace(fields[1]) == "" {
+		return Code{}, fmt.Errorf("code %s has no description", code)
+	}
+	return Code{Code: code, Description: strings.TrimSpace(fields[1]), Billable: true}, nil
+}
+
+type tabular struct {
+	Chapters []struct {
+		Sections []struct {
+			Diags []diag `xml:"diag"`
+		} `xml:"section"`
+	} `xml:"chapter"`
+}
+
+type diag struct {
+	Name       string      `xml:"name"`
+	Desc       string      `xml:"desc"`
+	Extensions []extension `xml:"sevenChrDef>extension"`
+	Diags      []diag      `xml:"diag"`
+}
+
+type extension struct {
+	Char string `xml:"char,attr"`
+	Desc string `xml:",chardata"`
+}
+
+func readTabular(r io.Reader, emit func(Code) error) error {
+	var t tabular
+	if err := xml.NewDecoder(r).Decode(&t); err != nil {
+		return fmt.Errorf("invalid tabular file: %w", err)
+	}
+	for _, chapter := range t.Chapters {
+		for _, section := range chapter.Sections {
+			for _, d := range section.Diags {
+				if err := walkDiag(d, nil, emit); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// walkDiag emits d and its subcodes. A seventh character definition
+// applies to every code below the one it is given on; the codes at the
+// bottom of such a tree are only billable with the seventh character,
+// with "X" placeholders filling any gap before it.
+func walkDiag(d diag, extensions []extension, emit func(Code) error) error {
+	code := Normalize(d.Name)
+	if !codePattern.MatchString(code) {
+		return fmt.Errorf("invalid code %q in tabular file", d.Name)
+	}
+	if len(d.Extensions) > 0 {
+		extensions = d.Extensions
+	}
+	desc := strings.TrimSpace(d.Desc)
+
+	leaf := len(d.Diags) == 0
+	if err := emit(Code{Code: code, Description: desc, Billable: leaf && len(extensions) == 0}); err != nil {
+		return err
+	}
+	if leaf {
+		for _, ext := range extensions {
+			full := strings.ReplaceAll(code, ".", "")
+			for len(full) < 6 {
+				full += "X"
+			}
+			full += strings.ToUpper(ext.Char)
+			err := emit(Code{
+				Code:        Normalize(full),
+				Description: desc + ", " + strings.TrimSpace(ext.Desc),
+				Billable:    true,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, child := range d.Diags {
+		if err := walkDiag(child, extensions, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package icd10
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, input string) []Code {
+	t.Helper()
+	var codes []Code
+	err := Read(strings.NewReader(input), func(c Code) error {
+		codes = append(codes, c)
+		return nil
+	})
+	require.NoError(t, err)
+	return codes
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "E11.9", Normalize("e119"))
+	assert.Equal(t, "E11.9", Normalize(" E11.9 "))
+	assert.Equal(t, "S72.001A", Normalize("S72001A"))
+	assert.Equal(t, "I10", Normalize("i10"))
+	assert.Equal(t, "E1", Normalize("e1"))
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid("J45.909"))
+	assert.True(t, Valid("i10"))
+	assert.False(t, Valid("J4"))
+	assert.False(t, Valid("U"))
+	assert.False(t, Valid("45.909"))
+	assert.False(t, Valid("J45.90901"))
+}
+
+func TestIsCodePrefix(t *testing.T) {
+	assert.True(t, IsCodePrefix("E1"))
+	assert.True(t, IsCodePrefix("e11.6"))
+	assert.True(t, IsCodePrefix("E116"))
+	assert.False(t, IsCodePrefix("asthma"))
+	assert.False(t, IsCodePrefix("type 2 diabetes"))
+	assert.False(t, IsCodePrefix(""))
+}
+
+func TestPrefixQuery(t *testing.T) {
+	assert.Equal(t, "type:* & 2:* & diab:*", PrefixQuery("Type 2 diab"))
+	assert.Equal(t, "crohn:* & s:*", PrefixQuery("crohn's"))
+	assert.Equal(t, "", PrefixQuery(" -- "))
+}
+
+func TestReadOrderFile(t *testing.T) {
+	input := "\xEF\xBB\xBF" +
+		"00001 A00     0 Cholera                                                      Cholera\n" +
+		"00002 A000    1 Cholera due to Vibrio cholerae 01, biovar cholerae           Cholera due to Vibrio cholerae 01, biovar cholerae\r\n" +
+		"\n" +
+		"00003 A001    1 Cholera due to Vibrio cholerae 01, biovar eltor\n"
+
+	assert.Equal(t, []Code{
+		{Code: "A00", Description: "Cholera", Billable: false},
+		{Code: "A00.0", Description: "Cholera due to Vibrio cholerae 01, biovar cholerae", Billable: true},
+		{Code: "A00.1", Description: "Cholera due to Vibrio cholerae 01, biovar eltor", Billable: true},
+	}, readAll(t, input))
+}
+
+func TestReadCodesFile(t *testing.T) {
+	input := "A000    Cholera due to Vibrio cholerae 01, biovar cholerae\n" +
+		"I10     Essential (primary) hypertension\n"
+
+	assert.Equal(t, []Code{
+		{Code: "A00.0", Description: "Cholera due to Vibrio cholerae 01, biovar cholerae", Billable: true},
+		{Code: "I10", Description: "Essential (primary) hypertension", Billable: true},
+	}, readAll(t, input))
+}
+
+func TestReadRejectsMalformedLines(t *testing.T) {
+	err := Read(strings.NewReader("00001 A00     7 Cholera\n"), func(Code) error { return nil })
+	assert.ErrorContains(t, err, "line 1")
+
+	err = Read(strings.NewReader("I10\n"), func(Code) error { return nil })
+	assert.ErrorContains(t, err, "no description")
+}
+
+func TestReadTabularExpandsSeventhCharacters(t *testing.T) {
+	input := `<?xml version="1.0" encoding="utf-8"?>
+<ICD10CM.tabular>
+  <version>2025</version>
+  <chapter>
+    <name>19</name>
+    <desc>Injury, poisoning and certain other consequences of external causes (S00-T88)</desc>
+    <section id="S70-S79">
+      <desc>Injuries to the hip and thigh (S70-S79)</desc>
+      <diag>
+        <name>S72</name>
+        <desc>Fracture of femur</desc>
+        <sevenChrDef>
+          <extension char="A">initial encounter for closed fracture</extension>
+          <extension char="D">subsequent encounter for closed fracture with routine healing</extension>
+        </sevenChrDef>
+        <diag>
+          <name>S72.0</name>
+          <desc>Fracture of head and neck of femur</desc>
+          <diag>
+            <name>S72.00</name>
+            <desc>Fracture of unspecified part of neck of femur</desc>
+            <diag>
+              <name>S72.001</name>
+              <desc>Fracture of unspecified part of neck of right femur</desc>
+            </diag>
+          </diag>
+        </diag>
+        <diag>
+          <name>S72.8</name>
+          <desc>Other fracture of femur</desc>
+        </diag>
+      </diag>
+    </section>
+  </chapter>
+  <chapter>
+    <name>9</name>
+    <section id="I10-I1A">
+      <diag>
+        <name>I10</name>
+        <desc>Essential (primary) hypertension</desc>
+      </diag>
+    </section>
+  </chapter>
+</ICD10CM.tabular>`
+
+	assert.Equal(t, []Code{
+		{Code: "S72", Description: "Fracture of femur"},
+		{Code: "S72.0", Description: "Fracture of head and neck of femur"},
+		{Code: "S72.00", Description: "Fracture of unspecified part of neck of femur"},
+		{Code: "S72.001", Description: "Fracture of unspecified part of neck of right femur"},
+		{Code: "S72.001A", Description: "Fracture of unspecified part of neck of right femur, initial encounter for closed fracture", Billable: true},
+		{Code: "S72.001D", Description: "Fracture of unspecified part of neck of right femur, subsequent encounter for closed fracture with routine healing", Billable: true},
+		{Code: "S72.8", Description: "Other fracture of femur"},
+		{Code: "S72.8XXA", Description: "Other fracture of femur, initial encounter for closed fracture", Billable: true},
+		{Code: "S72.8XXD", Description: "Other fracture of femur, subsequent encounter for closed fracture with routine healing", Billable: true},
+		{Code: "I10", Description: "Essential (primary) hypertension", Billable: true},
+	}, readAll(t, input))
+}
@@ -0,0 +1,108 @@
+// Package localtime converts between instants and wall-clock times in the
+// hospital's time zone.
+//
+// A wall-clock time is ambiguous on the day the clocks go back, when it
+// happens twice, and does not exist on the day they go forward. Rather
+// than guess, Resolve reports both cases as errors so callers can ask for
+// a UTC offset instead.
+package localtime
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrNonexistent = errors.New("does not exist")
+	ErrAmbiguous   = errors.New("is ambiguous")
+)
+
+// wallFormats are the accepted inputs without a UTC offset.
+var wallFormats = []string{"2006-01-02T15:04:05", "2006-01-02T15:04"}
+
+// Parse reads an RFC 3339 time, such as "2025-03-03T09:30:00+01:00", or a
+// wall-clock time in loc without an offset, such as "2025-03-03T09:30".
+// The result is in loc.
+func Parse(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.In(loc), nil
+	}
+	for _, layout := range wallFormats {
+		if wall, err := time.Parse(layout, s); err == nil {
+			return Resolve(wall, loc)
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, want RFC 3339 such as 2025-03-03T09:30:00+01:00", s)
+}
+
+// ParseDate reads "2006-01-02" and returns the first instant of that day
+// in loc.
+func ParseDate(s string, loc *time.Location) (time.Time, error) {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, want YYYY-MM-DD", s)
+	}
+	return StartOfDay(d, loc), nil
+}
+
+// StartOfDay returns the first instant in loc of the calendar day of t.
+// The date is read from t's own fields, whatever its location.
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	// time.Date moves a midnight that falls in a gap forward to the end of
+	// the gap, which is the first instant of the day.
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// Resolve returns the instant in loc whose wall clock reads the date and
+// time of wall, ignoring wall's location. It fails with ErrNonexistent or
+// ErrAmbiguous if the clocks change over that time.
+func Resolve(wall time.Time, loc *time.Location) (time.Time, error) {
+	naive := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), time.UTC)
+
+	// Any offset that applies to this wall-clock time is in force within a
+	// day either side of it.
+	var found []time.Time
+	for _, probe := range []time.Time{naive.Add(-24 * time.Hour), naive, naive.Add(24 * time.Hour)} {
+		_, offset := probe.In(loc).Zone()
+		t := naive.Add(-time.Duration(offset) * time.Second).In(loc)
+		if !sameWall(t, naive) {
+			continue
+		}
+		if len(found) == 0 || !found[0].Equal(t) {
+			found = append(found, t)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return time.Time{}, fmt.Errorf("%s %w in %s", naive.Format("2006-01-02T15:04"), ErrNonexistent, loc)
+	case 1:
+		return found[0], nil
+	default:
+		return time.Time{}, fmt.Errorf("%s %w in %s; give a UTC offset", naive.Format("2006-01-02T15:04"), ErrAmbiguous, loc)
+	}
+}
+
+// Shift moves t by the calendar days and wall-clock time between from and
+// to, all read in loc. Moving a weekly 09:00 appointment to 10:00 keeps
+// every occurrence at 10:00, even across a daylight saving change.
+func Shift(t, from, to time.Time, loc *time.Location) (time.Time, error) {
+	t, from, to = t.In(loc), from.In(loc), to.In(loc)
+	days := int(StartOfDay(to, time.UTC).Sub(StartOfDay(from, time.UTC)) / (24 * time.Hour))
+	clock := clockOf(to) - clockOf(from)
+
+	wall := time.Date(t.Year(), t.Month(), t.Day()+days, 0, 0, 0, 0, time.UTC).Add(clockOf(t) + clock)
+	return Resolve(wall, loc)
+}
+
+func clockOf(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
+
+func sameWall(t, naive time.Time) bool {
+	y, mo, d := t.Date()
+	ny, nmo, nd := naive.Date()
+	return y == ny && mo == nmo && d == nd && clockOf(t) == clockOf(naive)
+}
@@ -0,0 +1,77 @@
+package localtime_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prem0x01/hospital/internal/localtime"
+)
+
+func london(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+	return loc
+}
+
+func TestParse(t *testing.T) {
+	loc := london(t)
+
+	got, err := localtime.Parse("2025-07-01T09:30", loc)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-07-01T09:30:00+01:00", got.Format(time.RFC3339))
+
+	got, err = localtime.Parse("2025-07-01T09:30:00Z", loc)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-07-01T10:30:00+01:00", got.Format(time.RFC3339))
+	assert.Equal(t, loc, got.Location())
+
+	_, err = localtime.Parse("01/07/2025 09:30", loc)
+	assert.Error(t, err)
+}
+
+func TestResolveAroundClockChanges(t *testing.T) {
+	loc := london(t)
+
+	// Clocks went forward from 01:00 to 02:00 on 2025-03-30.
+	_, err := localtime.Parse("2025-03-30T01:30", loc)
+	assert.True(t, errors.Is(err, localtime.ErrNonexistent), "got %v", err)
+
+	// Clocks went back from 02:00 to 01:00 on 2025-10-26.
+	_, err = localtime.Parse("2025-10-26T01:30", loc)
+	assert.True(t, errors.Is(err, localtime.ErrAmbiguous), "got %v", err)
+
+	// An offset picks one of the two.
+	got, err := localtime.Parse("2025-10-26T01:30:00+00:00", loc)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-10-26T01:30:00Z", got.UTC().Format(time.RFC3339))
+
+	got, err = localtime.Parse("2025-10-26T02:30", loc)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-10-26T02:30:00Z", got.Format(time.RFC3339))
+}
+
+func TestShiftKeepsWallClock(t *testing.T) {
+	loc := london(t)
+	from := time.Date(2025, 3, 24, 9, 0, 0, 0, loc)
+	to := time.Date(2025, 3, 25, 10, 0, 0, 0, loc)
+
+	// A week later the clocks have gone forward, but the visit still moves
+	// to 10:00 the next day.
+	got, err := localtime.Shift(time.Date(2025, 3, 31, 9, 0, 0, 0, loc), from, to, loc)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-04-01T10:00:00+01:00", got.Format(time.RFC3339))
+
+	_, err = localtime.Shift(time.Date(2025, 3, 29, 0, 30, 0, 0, loc), from, to, loc)
+	assert.True(t, errors.Is(err, localtime.ErrNonexistent), "got %v", err)
+}
+
+func TestParseDate(t *testing.T) {
+	got, err := localtime.ParseDate("2025-03-30", london(t))
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-30T00:00:00Z", got.Format(time.RFC3339))
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+// RequireRole rejects requests whose authenticated role is not one of roles.
+// It must run after AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userRole := c.GetString("user_role")
+		for _, role := range roles {
+			if userRole == role {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, utils.ErrorResponse("Access denied", "Insufficient role for this resource"))
+		c.Abort()
+	}
+}
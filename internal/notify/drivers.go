@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// NewDriver returns the driver called name: "log", or "file" writing to
+// dir.
+func NewDriver(name, dir string) (Driver, error) {
+	switch name {
+	case "", "log":
+		return LogDriver{}, nil
+	case "file":
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, err
+		}
+		return FileDriver{Dir: dir}, nil
+	default:
+		return nil, fmt.Errorf("unknown notification driver %q", name)
+	}
+}
+
+// LogDriver writes messages to the standard logger. Messages contain PHI,
+// so it must not be used in production.
+type LogDriver struct{}
+
+func (LogDriver) SendEmail(_ context.Context, m Message) error {
+	log.Printf("notify: email %d to %s: %s\n%s", m.ID, m.To, m.Subject, m.Body)
+	return nil
+}
+
+func (LogDriver) SendSMS(_ context.Context, m Message) error {
+	log.Printf("notify: sms %d to %s: %s", m.ID, m.To, m.Body)
+	return nil
+}
+
+// FileDriver writes each message to its own file in Dir, named after the
+// message ID, so a retried message replaces the earlier copy.
+type FileDriver struct {
+	Dir string
+}
+
+func (d FileDriver) SendEmail(_ context.Context, m Message) error {
+	return d.write(m, fmt.Sprintf("To: %s\nSubject: %s\n\n%s\n", m.To, m.Subject, m.Body))
+}
+
+func (d FileDriver) SendSMS(_ context.Context, m Message) error {
+	return d.write(m, fmt.Sprintf("To: %s\n\n%s\n", m.To, m.Body))
+}
+
+func (d FileDriver) write(m Message, content string) error {
+	name := filepath.Join(d.Dir, fmt.Sprintf("%d-%s.txt", m.ID, m.Channel))
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
@@ -0,0 +1,103 @@
+// Package notify sends messages to patients by email and SMS. The drivers
+// in this package are stand-ins for development: they log messages or
+// write them to disk instead of delivering them.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+)
+
+// Channels lists every channel in the order messages are queued.
+var Channels = []string{ChannelEmail, ChannelSMS}
+
+// Message is one notification. ID stays the same when a message is
+// retried, so drivers can use it to drop duplicates.
+type Message struct {
+	ID      int64
+	Channel string
+	To      string
+	Subject string
+	Body    string
+}
+
+type EmailSender interface {
+	SendEmail(ctx context.Context, m Message) error
+}
+
+type SMSSender interface {
+	SendSMS(ctx context.Context, m Message) error
+}
+
+// Driver delivers on both channels.
+type Driver interface {
+	EmailSender
+	SMSSender
+}
+
+// Template holds text/template sources for a message. Subject is not
+// used for SMS.
+type Template struct {
+	Subject string
+	Body    string
+}
+
+// Render executes the template with data. Missing keys are errors rather
+// than "<no value>" in a message to a patient.
+func (t Template) Render(data interface{}) (subject, body string, err error) {
+	if subject, err = execute("subject", t.Subject, data); err != nil {
+		return "", "", err
+	}
+	if body, err = execute("body", t.Body, data); err != nil {
+		return "", "", err
+	}
+	return subject, body, nil
+}
+
+func execute(name, text string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// ParseOffsets reads a comma-separated list of durations before an
+// appointment, such as "24h,2h". The result is sorted longest first.
+func ParseOffsets(s string) ([]time.Duration, error) {
+	seen := make(map[time.Duration]bool)
+	var offsets []time.Duration
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		d, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reminder offset %q: %w", part, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("reminder offset %q must be positive", part)
+		}
+		if !seen[d] {
+			seen[d] = true
+			offsets = append(offsets, d)
+		}
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] > offsets[j] })
+	return offsets, nil
+}
@@ -0,0 +1,63 @@
+package notify_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOffsets(t *testing.T) {
+	offsets, err := notify.ParseOffsets("2h, 24h,2h,")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{24 * time.Hour, 2 * time.Hour}, offsets)
+
+	_, err = notify.ParseOffsets("24h,-1h")
+	assert.Error(t, err)
+	_, err = notify.ParseOffsets("tomorrow")
+	assert.Error(t, err)
+}
+
+func TestTemplateRender(t *testing.T) {
+	tmpl := notify.Template{
+		Subject: "Appointment with {{.DoctorName}}",
+		Body:    "Hello {{.FirstName}}, see you {{.When}}.",
+	}
+	data := map[string]string{"DoctorName": "Dr Rao", "FirstName": "Asha", "When": "Monday 3 March at 09:30"}
+
+	subject, body, err := tmpl.Render(data)
+	require.NoError(t, err)
+	assert.Equal(t, "Appointment with Dr Rao", subject)
+	assert.Equal(t, "Hello Asha, see you Monday 3 March at 09:30.", body)
+
+	_, _, err = notify.Template{Body: "{{.Missing}}"}.Render(data)
+	assert.Error(t, err)
+}
+
+func TestFileDriverReplacesRetries(t *testing.T) {
+	dir := t.TempDir()
+	driver, err := notify.NewDriver("file", dir)
+	require.NoError(t, err)
+
+	m := notify.Message{ID: 7, Channel: notify.ChannelSMS, To: "+15550100", Body: "first"}
+	require.NoError(t, driver.SendSMS(context.Background(), m))
+	m.Body = "second"
+	require.NoError(t, driver.SendSMS(context.Background(), m))
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	content, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "second")
+}
+
+func TestUnknownDriver(t *testing.T) {
+	_, err := notify.NewDriver("carrier-pigeon", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package pdf
+
+import (
+	"errors"
+	"strings"
+)
+
+// code128 holds the bar and space widths, in modules, of every Code 128
+// symbol value. Values 103-105 are the start codes for sets A, B and C and
+// 106 is the stop code, which ends with an extra bar.
+var code128 = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = 106
+)
+
+// ErrBarcodeData is returned for data that Code 128 set B cannot encode.
+var ErrBarcodeData = errors.New("pdf: barcode data must be printable ASCII")
+
+// Code128 encodes data as a Code 128 barcode and returns the widths of
+// its bars and spaces in modules, starting with a bar. An even number of
+// digits uses the denser set C; anything else uses set B.
+func Code128(data string) ([]int, error) {
+	if data == "" {
+		return nil, ErrBarcodeData
+	}
+
+	var values []int
+	if len(data)%2 == 0 && strings.Trim(data, "0123456789") == "" {
+		values = append(values, code128StartC)
+		for i := 0; i < len(data); i += 2 {
+			values = append(values, int(data[i]-'0')*10+int(data[i+1]-'0'))
+		}
+	} else {
+		values = append(values, code128StartB)
+		for i := 0; i < len(data); i++ {
+			c := data[i]
+			if c < 32 || c > 126 {
+				return nil, ErrBarcodeData
+			}
+			values = append(values, int(c)-32)
+		}
+	}
+
+	check := values[0]
+	for i, v := range values[1:] {
+		check += (i + 1) * v
+	}
+	values = append(values, check%103, code128Stop)
+
+	var widths []int
+	for _, v := range values {
+		for _, w := range code128[v] {
+			widths = append(widths, int(w-'0'))
+		}
+	}
+	return widths, nil
+}
+
+// Barcode draws the bars of a barcode whose widths come from Code128,
+// starting at (x, y), with each module the given number of points wide.
+// It returns the total width drawn. Callers leave a quiet zone of at least
+// ten modules on either side.
+func (p *Page) Barcode(widths []int, x, y, module, height float64) float64 {
+	pos := x
+	for i, w := range widths {
+		if i%2 == 0 {
+			p.FillRect(pos, y, float64(w)*module, height)
+		}
+		pos += float64(w) * module
+	}
+	return pos - x
+}
@@ -0,0 +1,61 @@
+package pdf_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prem0x01/hospital/internal/pdf"
+)
+
+func sum(widths []int) int {
+	n := 0
+	for _, w := range widths {
+		n += w
+	}
+	return n
+}
+
+func TestCode128(t *testing.T) {
+	// Set C: start, four digit pairs, check symbol and stop.
+	// (105 + 1*12 + 2*34 + 3*56 + 4*78) % 103 = 47.
+	widths, err := pdf.Code128("12345678")
+	require.NoError(t, err)
+	assert.Len(t, widths, 6*6+7)
+	assert.Equal(t, 6*11+13, sum(widths))
+	assert.Equal(t, []int{2, 1, 1, 2, 3, 2}, widths[:6])
+	assert.Equal(t, []int{1, 3, 3, 1, 2, 1}, widths[30:36])
+	assert.Equal(t, []int{2, 3, 3, 1, 1, 1, 2}, widths[36:])
+
+	// Set B for odd-length or non-numeric data.
+	widths, err = pdf.Code128("A1b")
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 1, 1, 2, 1, 4}, widths[:6])
+	assert.Equal(t, 5*11+13, sum(widths))
+
+	for _, bad := range []string{"", "tab\t", "é"} {
+		_, err := pdf.Code128(bad)
+		assert.ErrorIs(t, err, pdf.ErrBarcodeData, bad)
+	}
+}
+
+func TestCode128SymbolsAreElevenModules(t *testing.T) {
+	// Every symbol, checked through single characters of set B.
+	for c := byte(32); c <= 126; c++ {
+		widths, err := pdf.Code128(string(c))
+		require.NoError(t, err)
+		assert.Equal(t, 3*11+13, sum(widths), "%q", c)
+		for i := 0; i < 3*6; i += 6 {
+			assert.Equal(t, 11, sum(widths[i:i+6]), "%q symbol %d", c, i/6)
+		}
+	}
+}
+
+func TestBarcodeDrawsBars(t *testing.T) {
+	widths, err := pdf.Code128("12")
+	require.NoError(t, err)
+	doc := pdf.New("")
+	drawn := doc.AddPage(100, 100).Barcode(widths, 10, 10, 0.5, 20)
+	assert.InDelta(t, float64(sum(widths))*0.5, drawn, 0.001)
+}
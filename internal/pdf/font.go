@@ -0,0 +1,104 @@
+package pdf
+
+import "fmt"
+
+// Font is one of the standard fonts every PDF reader provides, so nothing
+// has to be embedded.
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+type fontMetrics struct {
+	name string
+	// ascii holds the advance widths of characters 32-126 in 1/1000 em,
+	// from the Adobe font metrics.
+	ascii [95]int
+	// other is used for characters above 126, most of which are accented
+	// letters of about the same width.
+	other int
+}
+
+var fonts = [...]fontMetrics{
+	Helvetica: {
+		name: "Helvetica",
+		ascii: [95]int{
+			278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+			556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+			1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+			667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+			333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+			556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+		},
+		other: 556,
+	},
+	HelveticaBold: {
+		name: "Helvetica-Bold",
+		ascii: [95]int{
+			278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+			556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+			975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+			667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+			333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+			611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+		},
+		other: 611,
+	},
+}
+
+func (f Font) resource() string {
+	return fmt.Sprintf("F%d", int(f)+1)
+}
+
+// Width returns the width of s in points when drawn at the given size.
+func (f Font) Width(s string, size float64) float64 {
+	m := fonts[f]
+	total := 0
+	for _, c := range encode(s) {
+		switch {
+		case c >= 32 && c <= 126:
+			total += m.ascii[c-32]
+		case c == 0x95:
+			total += 350
+		case c == 0x97:
+			total += 1000
+		case c == 0xA0:
+			total += 278
+		default:
+			total += m.other
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// winAnsi maps the characters of Windows-1252 that differ from Latin-1.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// encode converts s to the single-byte encoding used by the standard
+// fonts. Tabs become spaces; anything else that cannot be shown becomes
+// "?".
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			out = append(out, ' ')
+		case r >= 32 && r <= 126, r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		default:
+			if b, ok := winAnsi[r]; ok {
+				out = append(out, b)
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
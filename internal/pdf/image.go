@@ -0,0 +1,94 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+)
+
+// Image is a raster image. It is read-only once loaded and can be shared
+// between documents.
+type Image struct {
+	Width, Height int
+
+	colorSpace string
+	filter     string
+	data       []byte
+}
+
+// ErrUnsupportedImage is returned for image data that is neither JPEG
+// nor PNG.
+var ErrUnsupportedImage = errors.New("pdf: unsupported image format")
+
+// LoadImage reads a JPEG or PNG image. JPEG data is embedded as is; PNG
+// images are flattened onto a white background, since the output has no
+// transparency, and recompressed.
+func LoadImage(data []byte) (*Image, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	switch format {
+	case "jpeg":
+		img := &Image{Width: cfg.Width, Height: cfg.Height, filter: "DCTDecode", data: data}
+		switch cfg.ColorModel {
+		case color.GrayModel:
+			img.colorSpace = "DeviceGray"
+		case color.CMYKModel:
+			img.colorSpace = "DeviceCMYK"
+		default:
+			img.colorSpace = "DeviceRGB"
+		}
+		// Make sure the data actually decodes before embedding it.
+		if _, err := jpeg.Decode(bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("pdf: invalid JPEG: %w", err)
+		}
+		return img, nil
+	case "png":
+		src, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("pdf: invalid PNG: %w", err)
+		}
+		return flatten(src), nil
+	default:
+		return nil, ErrUnsupportedImage
+	}
+}
+
+func flatten(src image.Image) *Image {
+	b := src.Bounds()
+	raw := make([]byte, 0, b.Dx()*b.Dy()*3)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			// Colors are alpha-premultiplied, so blending onto white is
+			// adding the uncovered part.
+			r, g, bl, a := src.At(x, y).RGBA()
+			white := 0xffff - a
+			raw = append(raw, byte((r+white)>>8), byte((g+white)>>8), byte((bl+white)>>8))
+		}
+	}
+
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write(raw)
+	zw.Close()
+
+	return &Image{
+		Width:      b.Dx(),
+		Height:     b.Dy(),
+		colorSpace: "DeviceRGB",
+		filter:     "FlateDecode",
+		data:       buf.Bytes(),
+	}
+}
+
+func (img *Image) dict() string {
+	return fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s ",
+		img.Width, img.Height, img.colorSpace, img.filter)
+}
@@ -0,0 +1,246 @@
+// Package pdf writes simple PDF documents: text in the standard Helvetica
+// fonts, lines, filled rectangles, JPEG and PNG images and Code 128
+// barcodes. Output depends only on what is drawn, with no timestamps or
+// random IDs, so the same document always produces the same bytes.
+//
+// Coordinates are in points (1/72 inch) from the top-left corner of the
+// page; text is positioned by its baseline.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Page sizes in points.
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+	// ID-1 is the size of a credit card.
+	ID1Width  = 242.65
+	ID1Height = 153.07
+)
+
+// Document is a PDF being built page by page.
+type Document struct {
+	title  string
+	pages  []*Page
+	images []*Image
+}
+
+// New starts an empty document. The title is stored in the document
+// information dictionary.
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// AddPage appends a page of the given size and returns it for drawing.
+func (d *Document) AddPage(width, height float64) *Page {
+	p := &Page{doc: d, width: width, height: height}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Page is one page of a Document. Drawing operations are appended to its
+// content stream in call order.
+type Page struct {
+	doc           *Document
+	width, height float64
+	content       bytes.Buffer
+	images        []*Image
+}
+
+func (p *Page) Width() float64  { return p.width }
+func (p *Page) Height() float64 { return p.height }
+
+// SetFillColor sets the color of text and filled shapes drawn afterwards.
+func (p *Page) SetFillColor(r, g, b uint8) {
+	fmt.Fprintf(&p.content, "%s %s %s rg\n", component(r), component(g), component(b))
+}
+
+// SetStrokeColor sets the color of lines drawn afterwards.
+func (p *Page) SetStrokeColor(r, g, b uint8) {
+	fmt.Fprintf(&p.content, "%s %s %s RG\n", component(r), component(g), component(b))
+}
+
+// Text draws s with its baseline starting at (x, y). Characters outside
+// the Windows-1252 character set are drawn as "?".
+func (p *Page) Text(x, y float64, f Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		f.resource(), num(size), num(x), num(p.height-y), escape(encode(s)))
+}
+
+// Line draws a straight line of the given width.
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		num(width), num(x1), num(p.height-y1), num(x2), num(p.height-y2))
+}
+
+// FillRect fills the rectangle whose top-left corner is (x, y).
+func (p *Page) FillRect(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n", num(x), num(p.height-y-h), num(w), num(h))
+}
+
+// Image draws img scaled to fill the box whose top-left corner is (x, y).
+// The same image can be drawn any number of times, on any document, and
+// is embedded once per document.
+func (p *Page) Image(img *Image, x, y, w, h float64) {
+	index := indexOf(p.doc.images, img)
+	if index < 0 {
+		index = len(p.doc.images)
+		p.doc.images = append(p.doc.images, img)
+	}
+	if indexOf(p.images, img) < 0 {
+		p.images = append(p.images, img)
+	}
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /Im%d Do Q\n",
+		num(w), num(h), num(x), num(p.height-y-h), index+1)
+}
+
+// WriteTo writes the document as a PDF file.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var out bytes.Buffer
+	var offsets []int
+
+	// Objects are numbered in a fixed order: catalog, page tree, info,
+	// fonts, images, then each page followed by its content stream.
+	begin := func() int {
+		offsets = append(offsets, out.Len())
+		n := len(offsets)
+		fmt.Fprintf(&out, "%d 0 obj\n", n)
+		return n
+	}
+	end := func() { out.WriteString("endobj\n") }
+	stream := func(dict string, data []byte) {
+		fmt.Fprintf(&out, "<< %s/Length %d >>\nstream\n", dict, len(data))
+		out.Write(data)
+		out.WriteString("\nendstream\n")
+	}
+
+	const (
+		catalogObj = 1
+		pagesObj   = 2
+		infoObj    = 3
+		fontObj    = 4
+	)
+	imageObj := fontObj + len(fonts)
+	pageObj := func(i int) int { return imageObj + len(d.images) + 2*i }
+
+	out.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	begin()
+	fmt.Fprintf(&out, "<< /Type /Catalog /Pages %d 0 R >>\n", pagesObj)
+	end()
+
+	begin()
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageObj(i))
+	}
+	fmt.Fprintf(&out, "<< /Type /Pages /Kids [%s] /Count %d >>\n", strings.Join(kids, " "), len(d.pages))
+	end()
+
+	begin()
+	fmt.Fprintf(&out, "<< /Title (%s) /Producer (hospital) >>\n", escape(encode(d.title)))
+	end()
+
+	for _, f := range fonts {
+		begin()
+		fmt.Fprintf(&out, "<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>\n", f.name)
+		end()
+	}
+
+	for _, img := range d.images {
+		begin()
+		stream(img.dict(), img.data)
+		end()
+	}
+
+	var fontRefs strings.Builder
+	for i := range fonts {
+		fmt.Fprintf(&fontRefs, "/%s %d 0 R ", Font(i).resource(), fontObj+i)
+	}
+	for i, p := range d.pages {
+		var xobjects string
+		if len(p.images) > 0 {
+			refs := make([]string, len(p.images))
+			for j, img := range p.images {
+				index := indexOf(d.images, img)
+				refs[j] = fmt.Sprintf("/Im%d %d 0 R", index+1, imageObj+index)
+			}
+			xobjects = fmt.Sprintf(" /XObject << %s >>", strings.Join(refs, " "))
+		}
+
+		begin()
+		fmt.Fprintf(&out, "<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s>>%s >> /Contents %d 0 R >>\n",
+			pagesObj, num(p.width), num(p.height), fontRefs.String(), xobjects, pageObj(i)+1)
+		end()
+
+		begin()
+		stream("", p.content.Bytes())
+		end()
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, catalogObj, infoObj, xref)
+
+	n, err := w.Write(out.Bytes())
+	return int64(n), err
+}
+
+// Bytes returns the document as a PDF file.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	d.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+func indexOf(images []*Image, img *Image) int {
+	for i, other := range images {
+		if other == img {
+			return i
+		}
+	}
+	return -1
+}
+
+// num formats a coordinate with at most two decimals, the precision of
+// every number in the output.
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+func component(c uint8) string {
+	return num(float64(c) / 255)
+}
+
+// escape quotes a byte string for a PDF literal string.
+func escape(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c < 32 || c > 126:
+			fmt.Fprintf(&sb, "\\%03o", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,98 @@
+package pdf_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prem0x01/hospital/internal/pdf"
+)
+
+func sample(t *testing.T) []byte {
+	t.Helper()
+	doc := pdf.New("Visit (summary)")
+	page := doc.AddPage(pdf.A4Width, pdf.A4Height)
+	page.Text(50, 60, pdf.HelveticaBold, 14, "Café (tea) \\ 100%")
+	page.Line(50, 70, 545, 70, 0.5)
+	page.SetFillColor(255, 0, 0)
+	page.FillRect(50, 80, 10.504, 20)
+	doc.AddPage(pdf.ID1Width, pdf.ID1Height).Text(10, 20, pdf.Helvetica, 8, "second")
+	return doc.Bytes()
+}
+
+func TestDocumentIsDeterministic(t *testing.T) {
+	assert.Equal(t, sample(t), sample(t))
+}
+
+func TestDocumentStructure(t *testing.T) {
+	out := sample(t)
+	s := string(out)
+
+	require.True(t, strings.HasPrefix(s, "%PDF-1.4\n"))
+	require.True(t, strings.HasSuffix(s, "%%EOF\n"))
+	assert.Contains(t, s, "/Title (Visit \\(summary\\))")
+	assert.Contains(t, s, "/Count 2")
+	assert.Contains(t, s, "/MediaBox [0 0 595.28 841.89]")
+	assert.Contains(t, s, "BT /F2 14 Tf 50 781.89 Td (Caf\\351 \\(tea\\) \\\\ 100%) Tj ET")
+	assert.Contains(t, s, "1 0 0 rg\n50 741.89 10.5 20 re f\n")
+
+	// startxref points at the table, and every entry at its object.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(s)
+	require.NotNil(t, m)
+	xref, _ := strconv.Atoi(m[1])
+	require.True(t, strings.HasPrefix(s[xref:], "xref\n0 "))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(s[xref:], -1)
+	require.NotEmpty(t, entries)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(e[1])
+		assert.True(t, strings.HasPrefix(s[off:], fmt.Sprintf("%d 0 obj\n", i+1)), "object %d", i+1)
+	}
+	assert.Contains(t, s, fmt.Sprintf("/Size %d ", len(entries)+1))
+}
+
+func TestFontWidth(t *testing.T) {
+	assert.InDelta(t, 27.8, pdf.Helvetica.Width("  ", 50), 0.001)
+	assert.InDelta(t, 0.722+0.556, pdf.Helvetica.Width("Hé", 1), 0.001)
+	assert.InDelta(t, 0.611, pdf.HelveticaBold.Width("n", 1), 0.001)
+	// Unencodable characters are measured as the "?" they are drawn as.
+	assert.Equal(t, pdf.Helvetica.Width("?", 10), pdf.Helvetica.Width("字", 10))
+}
+
+func TestLoadImage(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	src.Set(1, 0, color.NRGBA{})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, src))
+
+	img, err := pdf.LoadImage(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, 2, img.Width)
+	assert.Equal(t, 1, img.Height)
+
+	// The image is embedded once per document however often it is drawn,
+	// and can be reused by another document.
+	for range 2 {
+		doc := pdf.New("logo")
+		doc.AddPage(100, 100).Image(img, 0, 0, 20, 10)
+		page := doc.AddPage(100, 100)
+		page.Image(img, 0, 0, 20, 10)
+		page.Image(img, 50, 50, 20, 10)
+		s := string(doc.Bytes())
+		assert.Equal(t, 1, strings.Count(s, "/Subtype /Image"))
+		assert.Equal(t, 3, strings.Count(s, "/Im1 Do"))
+	}
+
+	_, err = pdf.LoadImage([]byte("GIF89a"))
+	assert.ErrorIs(t, err, pdf.ErrUnsupportedImage)
+}
@@ -0,0 +1,42 @@
+package queue
+
+import "sync"
+
+// Broker tells subscribers when the queue has changed. Each subscriber
+// has room for one pending notice, so a slow subscriber skips
+// intermediate changes rather than holding up the others.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[chan struct{}]struct{})}
+}
+
+// Subscribe returns a channel that receives a value after each change,
+// and a function that unsubscribes it.
+func (b *Broker) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subs, ch)
+		b.mu.Unlock()
+	}
+}
+
+// Publish notifies every subscriber of a change.
+func (b *Broker) Publish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Package queue orders the patients waiting to see a doctor and estimates
+// how long each of them will wait.
+package queue
+
+import (
+	"sort"
+	"time"
+)
+
+// Visit is one patient in a doctor's queue.
+type Visit struct {
+	ID        int32
+	Scheduled time.Time
+	Duration  time.Duration
+	Arrived   time.Time
+	// Rank orders visits by priority; higher ranks are seen first.
+	Rank int
+	// Started is set while the patient is with the doctor.
+	Started bool
+}
+
+// Order sorts visits into the order the doctor will see them: patients
+// already being seen, then by rank, scheduled time and arrival time.
+func Order(visits []Visit) {
+	sort.SliceStable(visits, func(i, j int) bool {
+		a, b := visits[i], visits[j]
+		if a.Started != b.Started {
+			return a.Started
+		}
+		if a.Rank != b.Rank {
+			return a.Rank > b.Rank
+		}
+		if !a.Scheduled.Equal(b.Scheduled) {
+			return a.Scheduled.Before(b.Scheduled)
+		}
+		return a.Arrived.Before(b.Arrived)
+	})
+}
+
+// EstimateWaits returns how long from now each visit of an ordered queue
+// is expected to wait before the doctor sees the patient. Patients being
+// seen are expected to take until their scheduled end, or to be nearly
+// done if they have overrun; each waiting patient takes the length of
+// their appointment. Routine (rank 0) patients are not seen before their
+// scheduled time, while higher ranks are seen as soon as the doctor is
+// free.
+func EstimateWaits(visits []Visit, now time.Time) []time.Duration {
+	waits := make([]time.Duration, len(visits))
+	free := now
+	for i, v := range visits {
+		if v.Started {
+			if end := v.Scheduled.Add(v.Duration); end.After(free) {
+				free = end
+			}
+			continue
+		}
+		start := free
+		if v.Rank == 0 && v.Scheduled.After(start) {
+			start = v.Scheduled
+		}
+		waits[i] = start.Sub(now)
+		free = start.Add(v.Duration)
+	}
+	return waits
+}
@@ -0,0 +1,73 @@
+package queue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/queue"
+	"github.com/stretchr/testify/assert"
+)
+
+var nine = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+func ids(visits []queue.Visit) []int32 {
+	out := make([]int32, 0, len(visits))
+	for _, v := range visits {
+		out = append(out, v.ID)
+	}
+	return out
+}
+
+func TestOrder(t *testing.T) {
+	visits := []queue.Visit{
+		{ID: 1, Scheduled: nine.Add(30 * time.Minute), Arrived: nine},
+		{ID: 2, Scheduled: nine, Arrived: nine.Add(5 * time.Minute)},
+		{ID: 3, Scheduled: nine.Add(time.Hour), Arrived: nine.Add(10 * time.Minute), Rank: 1},
+		{ID: 4, Scheduled: nine.Add(-30 * time.Minute), Started: true},
+		{ID: 5, Scheduled: nine.Add(30 * time.Minute), Arrived: nine.Add(-time.Minute)},
+	}
+	queue.Order(visits)
+	assert.Equal(t, []int32{4, 3, 2, 5, 1}, ids(visits))
+}
+
+func TestEstimateWaits(t *testing.T) {
+	now := nine.Add(10 * time.Minute)
+	visits := []queue.Visit{
+		// Being seen until 09:20.
+		{ID: 1, Scheduled: nine, Duration: 20 * time.Minute, Started: true},
+		// Urgent: seen as soon as the doctor is free.
+		{ID: 2, Scheduled: nine.Add(2 * time.Hour), Duration: 15 * time.Minute, Rank: 1},
+		// Routine, due at 09:30: the doctor is free at 09:35.
+		{ID: 3, Scheduled: nine.Add(30 * time.Minute), Duration: 30 * time.Minute},
+		// Routine, due at 11:00: waits for its slot.
+		{ID: 4, Scheduled: nine.Add(2 * time.Hour), Duration: 30 * time.Minute},
+	}
+	waits := queue.EstimateWaits(visits, now)
+	assert.Equal(t, []time.Duration{0, 10 * time.Minute, 25 * time.Minute, 110 * time.Minute}, waits)
+}
+
+func TestEstimateWaitsOverrun(t *testing.T) {
+	now := nine.Add(45 * time.Minute)
+	visits := []queue.Visit{
+		{ID: 1, Scheduled: nine, Duration: 20 * time.Minute, Started: true},
+		{ID: 2, Scheduled: nine.Add(20 * time.Minute), Duration: 20 * time.Minute},
+	}
+	assert.Equal(t, []time.Duration{0, 0}, queue.EstimateWaits(visits, now))
+}
+
+func TestBroker(t *testing.T) {
+	b := queue.NewBroker()
+	first, cancelFirst := b.Subscribe()
+	second, cancelSecond := b.Subscribe()
+	defer cancelSecond()
+
+	b.Publish()
+	b.Publish()
+	cancelFirst()
+	b.Publish()
+
+	assert.Len(t, first, 1, "pending notices collapse into one")
+	<-second
+	b.Publish()
+	assert.Len(t, second, 1)
+}
@@ -0,0 +1,228 @@
+// Package recurrence expands the subset of RFC 5545 recurrence rules
+// used for appointment series: FREQ=DAILY, WEEKLY or MONTHLY with
+// INTERVAL, COUNT, UNTIL, BYDAY (weekly only) and BYMONTHDAY (monthly
+// only). Every rule must be bounded by COUNT or UNTIL.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// ErrTooMany is returned by Expand when a rule yields more occurrences
+// than the caller allows.
+var ErrTooMany = errors.New("recurrence yields too many occurrences")
+
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []time.Weekday
+	ByMonthDay []int
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=12". An
+// "RRULE:" prefix is accepted. UNTIL is read as a wall-clock time in loc.
+func Parse(s string, loc *time.Location) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, errors.New("empty recurrence rule")
+	}
+
+	r := &Rule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("malformed rule part %q", part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+			if r.Freq != Daily && r.Freq != Weekly && r.Freq != Monthly {
+				return nil, fmt.Errorf("unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = positive(key, value)
+		case "COUNT":
+			r.Count, err = positive(key, value)
+		case "UNTIL":
+			r.Until, err = parseUntil(value, loc)
+		case "BYDAY":
+			for _, d := range strings.Split(strings.ToUpper(value), ",") {
+				wd, ok := weekdays[d]
+				if !ok {
+					return nil, fmt.Errorf("unsupported BYDAY value %q", d)
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(value, ",") {
+				n, convErr := strconv.Atoi(d)
+				if convErr != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY value %q", d)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, n)
+			}
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				return nil, fmt.Errorf("only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case r.Freq == "":
+		return nil, errors.New("FREQ is required")
+	case r.Count == 0 && r.Until.IsZero():
+		return nil, errors.New("COUNT or UNTIL is required")
+	case r.Count > 0 && !r.Until.IsZero():
+		return nil, errors.New("COUNT and UNTIL cannot both be set")
+	case len(r.ByDay) > 0 && r.Freq != Weekly:
+		return nil, errors.New("BYDAY is only supported with FREQ=WEEKLY")
+	case len(r.ByMonthDay) > 0 && r.Freq != Monthly:
+		return nil, errors.New("BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+	return r, nil
+}
+
+// Expand returns the occurrences of the rule starting at start, which is
+// always the first one. It fails with ErrTooMany rather than return more
+// than limit occurrences.
+func (r *Rule) Expand(start time.Time, limit int) ([]time.Time, error) {
+	var out []time.Time
+	var tooMany bool
+	// emit adds t and reports whether the expansion is finished.
+	emit := func(t time.Time) bool {
+		if t.Before(start) {
+			return false
+		}
+		if !r.Until.IsZero() && t.After(r.Until) {
+			return true
+		}
+		if len(out) == limit {
+			tooMany = true
+			return true
+		}
+		out = append(out, t)
+		return r.Count > 0 && len(out) == r.Count
+	}
+
+	h, m, sec := start.Clock()
+	at := func(y int, mo time.Month, d int) time.Time {
+		return time.Date(y, mo, d, h, m, sec, 0, start.Location())
+	}
+
+	switch r.Freq {
+	case Daily:
+		for i := 0; ; i++ {
+			d := start.AddDate(0, 0, i*r.Interval)
+			if emit(at(d.Year(), d.Month(), d.Day())) {
+				break
+			}
+		}
+
+	case Weekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		offsets := make([]int, 0, len(days))
+		for _, d := range days {
+			offsets = append(offsets, (int(d)+6)%7) // days after Monday
+		}
+		sort.Ints(offsets)
+
+		monday := start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	weeks:
+		for w := 0; ; w++ {
+			for _, off := range offsets {
+				d := monday.AddDate(0, 0, w*7*r.Interval+off)
+				if emit(at(d.Year(), d.Month(), d.Day())) {
+					break weeks
+				}
+			}
+		}
+
+	case Monthly:
+		days := r.ByMonthDay
+		if len(days) == 0 {
+			days = []int{start.Day()}
+		}
+	months:
+		for i := 0; ; i++ {
+			first := time.Date(start.Year(), start.Month()+time.Month(i*r.Interval), 1, 0, 0, 0, 0, start.Location())
+			n := daysIn(first)
+			var resolved []int
+			for _, d := range days {
+				if d < 0 {
+					d = n + d + 1
+				}
+				// Days that do not exist in this month are skipped, as in RFC 5545.
+				if d >= 1 && d <= n {
+					resolved = append(resolved, d)
+				}
+			}
+			sort.Ints(resolved)
+			for _, d := range resolved {
+				if emit(at(first.Year(), first.Month(), d)) {
+					break months
+				}
+			}
+		}
+	}
+
+	if tooMany {
+		return nil, fmt.Errorf("%w: more than %d", ErrTooMany, limit)
+	}
+	return out, nil
+}
+
+func daysIn(first time.Time) int {
+	return first.AddDate(0, 1, -1).Day()
+}
+
+func positive(key, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer, got %q", key, value)
+	}
+	return n, nil
+}
+
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSuffix(value, "Z")
+	if t, err := time.ParseInLocation("20060102T150405", value, loc); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("20060102", value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid UNTIL %q", value)
+	}
+	// A date-only UNTIL includes that whole day.
+	return t.AddDate(0, 0, 1).Add(-time.Second), nil
+}
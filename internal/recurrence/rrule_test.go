@@ -0,0 +1,92 @@
+package recurrence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/recurrence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02T15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func expand(t *testing.T, rule string, start string, limit int) []string {
+	t.Helper()
+	r, err := recurrence.Parse(rule, time.UTC)
+	require.NoError(t, err)
+	occurrences, err := r.Expand(at(start), limit)
+	require.NoError(t, err)
+
+	out := make([]string, 0, len(occurrences))
+	for _, o := range occurrences {
+		out = append(out, o.Format("Mon 2006-01-02T15:04"))
+	}
+	return out
+}
+
+func TestWeeklyByDay(t *testing.T) {
+	// 2025-03-05 is a Wednesday; the Monday of that week is skipped.
+	got := expand(t, "RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4", "2025-03-05T09:30", 50)
+
+	assert.Equal(t, []string{
+		"Wed 2025-03-05T09:30",
+		"Fri 2025-03-07T09:30",
+		"Mon 2025-03-10T09:30",
+		"Wed 2025-03-12T09:30",
+	}, got)
+}
+
+func TestDailyIntervalUntil(t *testing.T) {
+	got := expand(t, "FREQ=DAILY;INTERVAL=2;UNTIL=20250307", "2025-03-03T08:00", 50)
+
+	assert.Equal(t, []string{
+		"Mon 2025-03-03T08:00",
+		"Wed 2025-03-05T08:00",
+		"Fri 2025-03-07T08:00",
+	}, got)
+}
+
+func TestMonthlySkipsMissingDays(t *testing.T) {
+	got := expand(t, "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3", "2025-01-31T10:00", 50)
+
+	assert.Equal(t, []string{
+		"Fri 2025-01-31T10:00",
+		"Mon 2025-03-31T10:00",
+		"Sat 2025-05-31T10:00",
+	}, got)
+}
+
+func TestMonthlyLastDay(t *testing.T) {
+	got := expand(t, "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=2", "2025-02-28T10:00", 50)
+
+	assert.Equal(t, []string{"Fri 2025-02-28T10:00", "Mon 2025-03-31T10:00"}, got)
+}
+
+func TestExpandLimit(t *testing.T) {
+	r, err := recurrence.Parse("FREQ=DAILY;COUNT=10", time.UTC)
+	require.NoError(t, err)
+
+	_, err = r.Expand(at("2025-03-03T08:00"), 5)
+	assert.ErrorIs(t, err, recurrence.ErrTooMany)
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"FREQ=WEEKLY",                     // unbounded
+		"FREQ=YEARLY;COUNT=2",             // unsupported frequency
+		"FREQ=DAILY;BYDAY=MO;COUNT=2",     // BYDAY needs WEEKLY
+		"FREQ=WEEKLY;BYDAY=1MO;COUNT=2",   // ordinal weekdays
+		"FREQ=DAILY;COUNT=2;UNTIL=202503", // both bounds, bad date
+	} {
+		_, err := recurrence.Parse(rule, time.UTC)
+		assert.Error(t, err, rule)
+	}
+}
@@ -2,29 +2,44 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
 	"github.com/prem0x01/hospital/internal/database/queries"
 	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/notify"
+	"github.com/prem0x01/hospital/internal/utils"
 )
 
+// exclusionViolation is the SQLSTATE raised by the overlap constraints.
+const exclusionViolation = "23P01"
+
 type AppointmentRepository struct {
 	q      *queries.Queries
-	dbConn queries.DBTX
+	dbConn *pgxpool.Pool
+	// reminderOffsets are how long before each appointment reminders are
+	// queued in the notification outbox.
+	reminderOffsets []time.Duration
 }
 
-func NewAppointmentRepository(q *queries.Queries, dbConn queries.DBTX) *AppointmentRepository {
-	return &AppointmentRepository{q: q, dbConn: dbConn}
+func NewAppointmentRepository(q *queries.Queries, dbConn *pgxpool.Pool, reminderOffsets []time.Duration) *AppointmentRepository {
+	return &AppointmentRepository{q: q, dbConn: dbConn, reminderOffsets: reminderOffsets}
 }
 
-func (r *AppointmentRepository) GetAll(ctx context.Context, limit, offset int32, userRole string, userID int32) ([]domain.Appointment, error) {
+func (r *AppointmentRepository) GetAll(ctx context.Context, limit, offset int32, userRole string, userID int32, filter domain.AppointmentFilter) ([]domain.Appointment, error) {
 	if userRole == "doctor" {
 		doctorAppointments, err := r.q.GetAppointmentsByDoctor(ctx, queries.GetAppointmentsByDoctorParams{
-			DoctorID: &userID,
-			Limit:    limit,
-			Offset:   offset,
+			DoctorID:     &userID,
+			DepartmentID: filter.DepartmentID,
+			Limit:        limit,
+			Offset:       offset,
 		})
 		if err != nil {
 			return nil, err
@@ -39,8 +54,9 @@ func (r *AppointmentRepository) GetAll(ctx context.Context, limit, offset int32,
 	}
 
 	appointments, err := r.q.GetAppointments(ctx, queries.GetAppointmentsParams{
-		Limit:  limit,
-		Offset: offset,
+		DepartmentID: filter.DepartmentID,
+		Limit:        limit,
+		Offset:       offset,
 	})
 	if err != nil {
 		return nil, err
@@ -54,6 +70,39 @@ func (r *AppointmentRepository) GetAll(ctx context.Context, limit, offset int32,
 	return result, nil
 }
 
+// GetByDoctor returns up to limit of doctorID's appointments, latest
+// first.
+func (r *AppointmentRepository) GetByDoctor(ctx context.Context, doctorID, limit int32) ([]domain.Appointment, error) {
+	rows, err := r.q.GetAppointmentsByDoctor(ctx, queries.GetAppointmentsByDoctorParams{
+		DoctorID: &doctorID,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Appointment, 0, len(rows))
+	for _, a := range rows {
+		result = append(result, *toDomainAppointmentFromRow(queries.GetAppointmentsRow(*a)))
+	}
+	return result, nil
+}
+
+// GetToday returns every appointment on today's date in the hospital's
+// time zone, earliest first.
+func (r *AppointmentRepository) GetToday(ctx context.Context) ([]domain.Appointment, error) {
+	rows, err := r.q.GetTodaysAppointments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Appointment, 0, len(rows))
+	for _, a := range rows {
+		result = append(result, *toDomainAppointmentFromRow(queries.GetAppointmentsRow(*a)))
+	}
+	return result, nil
+}
+
 func (r *AppointmentRepository) GetByID(ctx context.Context, id int32) (*domain.Appointment, error) {
 	a, err := r.q.GetAppointmentByID(ctx, id)
 	if err != nil {
@@ -71,6 +120,10 @@ func convertGetAppointmentByIDRowToGetAppointmentsRow(a *queries.GetAppointmentB
 		PatientID:       a.PatientID,
 		DoctorID:        a.DoctorID,
 		AppointmentDate: a.AppointmentDate,
+		EndTime:         a.EndTime,
+		SeriesID:        a.SeriesID,
+		DepartmentID:    a.DepartmentID,
+		RoomID:          a.RoomID,
 		Status:          a.Status,
 		Notes:           a.Notes,
 		Diagnosis:       a.Diagnosis,
@@ -83,13 +136,47 @@ func convertGetAppointmentByIDRowToGetAppointmentsRow(a *queries.GetAppointmentB
 	}
 }
 
+// Create books the appointment, opens its status history and queues its
+// reminders.
 func (r *AppointmentRepository) Create(ctx context.Context, a *domain.Appointment) error {
-	result, err := r.q.CreateAppointment(ctx, queries.CreateAppointmentParams{
+	tx, err := r.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.insert(ctx, r.q.WithTx(tx), a); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// insert saves a, its first status history entry and its reminders with
+// qtx, which must be bound to a transaction.
+func (r *AppointmentRepository) insert(ctx context.Context, qtx *queries.Queries, a *domain.Appointment) error {
+	result, err := qtx.CreateAppointment(ctx, queries.CreateAppointmentParams{
 		PatientID:       a.PatientID,
 		DoctorID:        a.DoctorID,
 		AppointmentDate: a.AppointmentDate,
+		EndTime:         a.EndTime,
 		Notes:           a.Notes,
 		CreatedBy:       a.CreatedBy,
+		SeriesID:        a.SeriesID,
+		DepartmentID:    a.DepartmentID,
+		RoomID:          a.RoomID,
+	})
+	if err != nil {
+		return translateOverlap(err)
+	}
+
+	status := domain.AppointmentScheduled
+	if result.Status != nil {
+		status = *result.Status
+	}
+	_, err = qtx.CreateAppointmentStatusHistory(ctx, queries.CreateAppointmentStatusHistoryParams{
+		AppointmentID: result.ID,
+		ToStatus:      status,
+		ChangedBy:     a.CreatedBy,
 	})
 	if err != nil {
 		return err
@@ -99,23 +186,227 @@ func (r *AppointmentRepository) Create(ctx context.Context, a *domain.Appointmen
 	a.Status = result.Status
 	a.CreatedAt = result.CreatedAt
 	a.UpdatedAt = result.UpdatedAt
+	return r.queueReminders(ctx, qtx, a.ID, a.AppointmentDate.Time)
+}
+
+// queueReminders adds the appointment's reminders to the outbox. Those
+// already due are skipped by the query.
+func (r *AppointmentRepository) queueReminders(ctx context.Context, qtx *queries.Queries, id int32, start time.Time) error {
+	for _, offset := range r.reminderOffsets {
+		for _, channel := range notify.Channels {
+			err := qtx.EnqueueNotification(ctx, queries.EnqueueNotificationParams{
+				AppointmentID: id,
+				Template:      domain.TemplateAppointmentReminder,
+				Channel:       channel,
+				SendAt:        utils.TimeToTimestamp(start.Add(-offset)),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
+// Transition moves the appointment from one status to another and records
+// the change. It returns domain.ErrConflict if the appointment is no
+// longer in the from status, e.g. because of a concurrent change.
+// Moving an appointment to checked_in records the patient's arrival with
+// routine priority.
+func (r *AppointmentRepository) Transition(ctx context.Context, id int32, from, to string, reason *string, changedBy int32) error {
+	tx, err := r.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.q.WithTx(tx)
+	if err := r.transition(ctx, qtx, id, from, to, reason, &changedBy); err != nil {
+		return err
+	}
+	if to == domain.AppointmentCheckedIn {
+		if err := createCheckIn(ctx, qtx, id, domain.PriorityRoutine, changedBy); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// CheckIn moves a scheduled appointment to checked_in and records the
+// patient's arrival with the given priority.
+func (r *AppointmentRepository) CheckIn(ctx context.Context, id int32, priority string, changedBy int32) error {
+	tx, err := r.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.q.WithTx(tx)
+	if err := r.transition(ctx, qtx, id, domain.AppointmentScheduled, domain.AppointmentCheckedIn, nil, &changedBy); err != nil {
+		return err
+	}
+	if err := createCheckIn(ctx, qtx, id, priority, changedBy); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func createCheckIn(ctx context.Context, qtx *queries.Queries, id int32, priority string, changedBy int32) error {
+	_, err := qtx.CreateCheckIn(ctx, queries.CreateCheckInParams{
+		AppointmentID: id,
+		Priority:      priority,
+		CheckedInBy:   &changedBy,
+	})
+	return err
+}
+
+// MarkNoShow moves a scheduled appointment to no_show on behalf of the
+// system rather than a user.
+func (r *AppointmentRepository) MarkNoShow(ctx context.Context, id int32, reason string) error {
+	tx, err := r.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.transition(ctx, r.q.WithTx(tx), id, domain.AppointmentScheduled, domain.AppointmentNoShow, &reason, nil); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListOverdue returns up to limit scheduled appointments that started
+// before the given time, earliest first.
+func (r *AppointmentRepository) ListOverdue(ctx context.Context, before time.Time, limit int32) ([]int32, error) {
+	return r.q.ListOverdueAppointments(ctx, queries.ListOverdueAppointmentsParams{
+		Before:    utils.TimeToTimestamp(before),
+		BatchSize: limit,
+	})
+}
+
+func (r *AppointmentRepository) transition(ctx context.Context, qtx *queries.Queries, id int32, from, to string, reason *string, changedBy *int32) error {
+	n, err := qtx.SetAppointmentStatus(ctx, queries.SetAppointmentStatusParams{
+		ToStatus:   &to,
+		ID:         id,
+		FromStatus: from,
+	})
+	if err != nil {
+		return translateOverlap(err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: appointment %d is no longer %s", domain.ErrConflict, id, from)
+	}
+	// Reminders are only for appointments that are still to come.
+	if to != domain.AppointmentScheduled {
+		if err := qtx.CancelPendingNotifications(ctx, id); err != nil {
+			return err
+		}
+	}
+	// An appointment that will not take place releases its equipment, and
+	// a referral it was booked for can be booked again.
+	if to == domain.AppointmentCancelled || to == domain.AppointmentNoShow {
+		if err := qtx.DeleteAppointmentEquipmentBookings(ctx, &id); err != nil {
+			return err
+		}
+		if err := qtx.ReopenAppointmentReferral(ctx, &id); err != nil {
+			return err
+		}
+	}
+	if to == domain.AppointmentCompleted {
+		if err := qtx.CompleteAppointmentReferral(ctx, &id); err != nil {
+			return err
+		}
+	}
+
+	_, err = qtx.CreateAppointmentStatusHistory(ctx, queries.CreateAppointmentStatusHistoryParams{
+		AppointmentID: id,
+		FromStatus:    &from,
+		ToStatus:      to,
+		Reason:        reason,
+		ChangedBy:     changedBy,
+	})
+	return err
+}
+
+func (r *AppointmentRepository) ListStatusHistory(ctx context.Context, id int32) ([]domain.AppointmentStatusChange, error) {
+	rows, err := r.q.ListAppointmentStatusHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.AppointmentStatusChange, 0, len(rows))
+	for _, row := range rows {
+		change := domain.AppointmentStatusChange{
+			ID:            row.ID,
+			AppointmentID: row.AppointmentID,
+			FromStatus:    row.FromStatus,
+			ToStatus:      row.ToStatus,
+			Reason:        row.Reason,
+			ChangedBy:     row.ChangedBy,
+			ChangedAt:     row.ChangedAt.Time,
+		}
+		change.ChangedByName, _ = row.ChangedByName.(string)
+		result = append(result, change)
+	}
+	return result, nil
+}
+
+// Delete removes the appointment. A referral it was booked for can be
+// booked again.
 func (r *AppointmentRepository) Delete(ctx context.Context, id int32) error {
-	return r.q.DeleteAppointment(ctx, id)
+	tx, err := r.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.q.WithTx(tx)
+	if err := qtx.ReopenAppointmentReferral(ctx, &id); err != nil {
+		return err
+	}
+	if err := qtx.DeleteAppointment(ctx, id); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 func (r *AppointmentRepository) Count(ctx context.Context) (int64, error) {
 	return r.q.CountAppointments(ctx)
 }
 
+// CountRecentByStatus counts the appointments of the last days days,
+// including today, by status. The counts are as of the last statistics
+// refresh.
+func (r *AppointmentRepository) CountRecentByStatus(ctx context.Context, days int32) (map[string]int64, error) {
+	rows, err := r.q.CountRecentAppointmentsByStatus(ctx, days)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		result[row.Status] = row.Appointments
+	}
+	return result, nil
+}
+
+// RefreshStatistics recomputes the appointment statistics views.
+func (r *AppointmentRepository) RefreshStatistics(ctx context.Context) error {
+	return r.q.RefreshAppointmentDailyStats(ctx)
+}
+
 func toDomainAppointmentFromRow(a queries.GetAppointmentsRow) *domain.Appointment {
 	return &domain.Appointment{
 		ID:              a.ID,
 		PatientID:       a.PatientID,
 		DoctorID:        a.DoctorID,
 		AppointmentDate: a.AppointmentDate,
+		EndTime:         a.EndTime,
+		SeriesID:        a.SeriesID,
+		DepartmentID:    a.DepartmentID,
+		RoomID:          a.RoomID,
 		Status:          a.Status,
 		Notes:           a.Notes,
 		Diagnosis:       a.Diagnosis,
@@ -133,6 +424,63 @@ func (r *AppointmentRepository) Update(ctx context.Context, id int32, updates ma
 		return fmt.Errorf("no updates provided")
 	}
 
+	tx, err := r.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.update(ctx, tx, id, updates); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Change applies updates to appointment id, then moves it through status,
+// if given, and saves note, if given, as the appointment's clinical note:
+// its first version if note.ID is 0, otherwise the version after
+// note.CurrentVersion, which is updated. It all happens in one
+// transaction, so a change that is rejected part way leaves nothing
+// behind.
+func (r *AppointmentRepository) Change(ctx context.Context, id int32, updates map[string]interface{}, status *domain.AppointmentStatusChange, note *domain.ClinicalNote) error {
+	tx, err := r.dbConn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	if len(updates) > 0 {
+		if err := r.update(ctx, tx, id, updates); err != nil {
+			return err
+		}
+	}
+	if status != nil {
+		if err := r.transition(ctx, qtx, id, *status.FromStatus, status.ToStatus, status.Reason, status.ChangedBy); err != nil {
+			return err
+		}
+		if status.ToStatus == domain.AppointmentCheckedIn {
+			if err := createCheckIn(ctx, qtx, id, domain.PriorityRoutine, *status.ChangedBy); err != nil {
+				return err
+			}
+		}
+	}
+	if note != nil && note.ID == 0 {
+		if err := createNote(ctx, qtx, note); err != nil {
+			return err
+		}
+	} else if note != nil {
+		if err := addNoteVersion(ctx, qtx, note.ID, note.CurrentVersion, note.SOAP, *note.AuthorID); err != nil {
+			return err
+		}
+		note.CurrentVersion++
+	}
+	return tx.Commit(ctx)
+}
+
+// update writes updates to appointment id within tx, moving its reminders
+// and equipment bookings with it.
+func (r *AppointmentRepository) update(ctx context.Context, tx pgx.Tx, id int32, updates map[string]interface{}) error {
 	setParts := make([]string, 0, len(updates))
 	args := make([]interface{}, 0, len(updates)+1)
 	argIndex := 1
@@ -153,7 +501,75 @@ func (r *AppointmentRepository) Update(ctx context.Context, id int32, updates ma
 		UPDATE appointments
 		SET %s
 		WHERE id = $%d`, strings.Join(setParts, ", "), argIndex)
-	_, err := r.dbConn.Exec(ctx, query, args...)
-	_, err = r.dbConn.Exec(ctx, query, args...)
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return translateOverlap(err)
+	}
+	qtx := r.q.WithTx(tx)
+	// A moved appointment gets its reminders at the new time.
+	if start, ok := updates["appointment_date"].(time.Time); ok {
+		if err := qtx.CancelPendingNotifications(ctx, id); err != nil {
+			return err
+		}
+		if err := r.queueReminders(ctx, qtx, id, start); err != nil {
+			return err
+		}
+	}
+	// Its equipment bookings move with it.
+	_, moved := updates["appointment_date"]
+	_, resized := updates["end_time"]
+	if moved || resized {
+		a, err := qtx.GetAppointmentByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		err = qtx.MoveAppointmentEquipmentBookings(ctx, queries.MoveAppointmentEquipmentBookingsParams{
+			AppointmentID: &id,
+			StartsAt:      a.AppointmentDate,
+			EndsAt:        a.EndTime,
+		})
+		if err != nil {
+			return translateOverlap(err)
+		}
+	}
+	return nil
+}
+
+// ListOverlapping returns the active appointments of the doctor, the
+// patient or the room that intersect [start, end), excluding excludeID.
+func (r *AppointmentRepository) ListOverlapping(ctx context.Context, excludeID int32, doctorID, patientID, roomID *int32, start, end pgtype.Timestamptz) ([]domain.AppointmentConflict, error) {
+	rows, err := r.q.ListOverlappingAppointments(ctx, queries.ListOverlappingAppointmentsParams{
+		ExcludeID: excludeID,
+		DoctorID:  doctorID,
+		PatientID: patientID,
+		RoomID:    roomID,
+		EndTime:   end,
+		StartTime: start,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.AppointmentConflict, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.AppointmentConflict{
+			ID:              row.ID,
+			PatientID:       row.PatientID,
+			DoctorID:        row.DoctorID,
+			RoomID:          row.RoomID,
+			AppointmentDate: row.AppointmentDate,
+			EndTime:         row.EndTime,
+		})
+	}
+	return result, nil
+}
+
+// translateOverlap turns a violation of the doctor, patient, room or
+// equipment exclusion constraint into domain.ErrConflict.
+func translateOverlap(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == exclusionViolation {
+		return fmt.Errorf("%w: %s", domain.ErrConflict, pgErr.ConstraintName)
+	}
 	return err
 }
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+func (r *AppointmentRepository) CreateSeries(ctx context.Context, s *domain.AppointmentSeries) error {
+	result, err := r.q.CreateAppointmentSeries(ctx, queries.CreateAppointmentSeriesParams{
+		PatientID:       s.PatientID,
+		DoctorID:        s.DoctorID,
+		Rrule:           s.RRule,
+		StartsAt:        utils.TimeToTimestamp(s.StartsAt),
+		DurationMinutes: s.DurationMinutes,
+		Notes:           s.Notes,
+		CreatedBy:       s.CreatedBy,
+	})
+	if err != nil {
+		return err
+	}
+	*s = *toDomainAppointmentSeries(result)
+	return nil
+}
+
+// GetSeries returns the series and its appointments in date order.
+func (r *AppointmentRepository) GetSeries(ctx context.Context, id int32) (*domain.AppointmentSeries, error) {
+	s, err := r.q.GetAppointmentSeries(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.q.ListSeriesAppointments(ctx, &id)
+	if err != nil {
+		return nil, err
+	}
+
+	series := toDomainAppointmentSeries(s)
+	series.Appointments = make([]domain.Appointment, 0, len(rows))
+	for _, a := range rows {
+		series.Appointments = append(series.Appointments, domain.Appointment{
+			ID:              a.ID,
+			PatientID:       a.PatientID,
+			DoctorID:        a.DoctorID,
+			AppointmentDate: a.AppointmentDate,
+			EndTime:         a.EndTime,
+			SeriesID:        a.SeriesID,
+			Status:          a.Status,
+			Notes:           a.Notes,
+			Diagnosis:       a.Diagnosis,
+			TreatmentPlan:   a.TreatmentPlan,
+			CreatedBy:       a.CreatedBy,
+			CreatedAt:       a.CreatedAt,
+			UpdatedAt:       a.UpdatedAt,
+		})
+	}
+	return series, nil
+}
+
+// UpdateSeries changes the template that future edits of the whole series
+// start from. Nil fields are left as they are.
+func (r *AppointmentRepository) UpdateSeries(ctx context.Context, id int32, doctorID *int32, startsAt *time.Time, durationMinutes *int32, notes *string) error {
+	arg := queries.UpdateAppointmentSeriesParams{
+		ID:              id,
+		DoctorID:        doctorID,
+		DurationMinutes: durationMinutes,
+		Notes:           notes,
+	}
+	if startsAt != nil {
+		arg.StartsAt = utils.TimeToTimestamp(*startsAt)
+	}
+	_, err := r.q.UpdateAppointmentSeries(ctx, arg)
+	return err
+}
+
+// CancelSeries marks the series cancelled. It returns domain.ErrConflict
+// if it already was.
+func (r *AppointmentRepository) CancelSeries(ctx context.Context, id int32) error {
+	n, err := r.q.CancelAppointmentSeries(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+func (r *AppointmentRepository) DeleteSeries(ctx context.Context, id int32) error {
+	return r.q.DeleteAppointmentSeries(ctx, id)
+}
+
+func toDomainAppointmentSeries(s *queries.AppointmentSeries) *domain.AppointmentSeries {
+	series := &domain.AppointmentSeries{
+		ID:              s.ID,
+		PatientID:       s.PatientID,
+		DoctorID:        s.DoctorID,
+		RRule:           s.Rrule,
+		StartsAt:        s.StartsAt.Time,
+		DurationMinutes: s.DurationMinutes,
+		Notes:           s.Notes,
+		CreatedBy:       s.CreatedBy,
+		CreatedAt:       s.CreatedAt.Time,
+	}
+	if s.CancelledAt.Valid {
+		t := s.CancelledAt.Time
+		series.CancelledAt = &t
+	}
+	return series
+}
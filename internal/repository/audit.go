@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prem0x01/hospital/internal/audit"
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+// auditChainLockKey serialises appends to the hash chain across connections
+// and server replicas; every writer must read the latest hash and insert the
+// next entry while holding it.
+const auditChainLockKey = 7261001
+
+type AuditRepository struct {
+	db      *pgxpool.Pool
+	queries *queries.Queries
+}
+
+func NewAuditRepository(pool *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{
+		db:      pool,
+		queries: queries.New(pool),
+	}
+}
+
+// Append seals the entries onto the end of the chain and stores them in a
+// single transaction. The entries are updated in place with their id and hashes.
+func (r *AuditRepository) Append(ctx context.Context, entries ...*domain.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := appendAudit(ctx, tx, r.queries.WithTx(tx), entries...); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// appendAudit seals entries onto the chain within tx, so they are stored
+// only if the rest of tx is. It holds the chain lock until tx ends.
+func appendAudit(ctx context.Context, tx pgx.Tx, qtx *queries.Queries, entries ...*domain.AuditEntry) error {
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", auditChainLockKey); err != nil {
+		return fmt.Errorf("failed to lock audit chain: %w", err)
+	}
+
+	prevHash, err := qtx.GetLastAuditHash(ctx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		prevHash = audit.GenesisHash
+	} else if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if err := audit.Seal(e, prevHash); err != nil {
+			return err
+		}
+
+		var changes []byte
+		if len(e.Changes) > 0 {
+			changes, err = json.Marshal(e.Changes)
+			if err != nil {
+				return fmt.Errorf("failed to encode audit changes: %w", err)
+			}
+		}
+
+		row, err := qtx.CreateAuditEntry(ctx, queries.CreateAuditEntryParams{
+			OccurredAt:   pgtype.Timestamptz{Time: e.OccurredAt, Valid: true},
+			ActorID:      e.ActorID,
+			ActorRole:    e.ActorRole,
+			Action:       e.Action,
+			ResourceType: e.ResourceType,
+			ResourceID:   e.ResourceID,
+			PatientID:    e.PatientID,
+			Changes:      changes,
+			IpAddress:    e.IPAddress,
+			RequestID:    e.RequestID,
+			PrevHash:     e.PrevHash,
+			Hash:         e.Hash,
+		})
+		if err != nil {
+			return err
+		}
+
+		e.ID = row.ID
+		prevHash = e.Hash
+	}
+	return nil
+}
+
+func (r *AuditRepository) List(ctx context.Context, f domain.AuditFilter) ([]domain.AuditEntry, error) {
+	arg := queries.ListAuditEntriesParams{
+		PatientID: f.PatientID,
+		ActorID:   f.ActorID,
+		Limit:     f.Limit,
+		Offset:    f.Offset,
+	}
+	if f.From != nil {
+		arg.FromTime = pgtype.Timestamptz{Time: *f.From, Valid: true}
+	}
+	if f.To != nil {
+		arg.ToTime = pgtype.Timestamptz{Time: *f.To, Valid: true}
+	}
+
+	rows, err := r.queries.ListAuditEntries(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		e, err := toDomainAuditEntry(row)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *e)
+	}
+	return result, nil
+}
+
+// Walk calls fn for every entry of the log in chain order, reading in
+// batches so the whole log never has to be held in memory.
+func (r *AuditRepository) Walk(ctx context.Context, batchSize int32, fn func(*domain.AuditEntry) error) error {
+	var lastID int64
+	for {
+		rows, err := r.queries.ListAuditEntriesAfter(ctx, queries.ListAuditEntriesAfterParams{
+			ID:    lastID,
+			Limit: batchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			e, err := toDomainAuditEntry(row)
+			if err != nil {
+				return err
+			}
+			if err := fn(e); err != nil {
+				return err
+			}
+			lastID = row.ID
+		}
+
+		if int32(len(rows)) < batchSize {
+			return nil
+		}
+	}
+}
+
+func toDomainAuditEntry(a *queries.AuditLog) (*domain.AuditEntry, error) {
+	e := &domain.AuditEntry{
+		ID:           a.ID,
+		OccurredAt:   a.OccurredAt.Time.In(time.UTC),
+		ActorID:      a.ActorID,
+		ActorRole:    a.ActorRole,
+		Action:       a.Action,
+		ResourceType: a.ResourceType,
+		ResourceID:   a.ResourceID,
+		PatientID:    a.PatientID,
+		IPAddress:    a.IpAddress,
+		RequestID:    a.RequestID,
+		PrevHash:     a.PrevHash,
+		Hash:         a.Hash,
+	}
+
+	if len(a.Changes) > 0 {
+		if err := json.Unmarshal(a.Changes, &e.Changes); err != nil {
+			return nil, fmt.Errorf("entry %d: invalid changes: %w", a.ID, err)
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/scheduling"
+)
+
+type AvailabilityRepository struct {
+	db *pgxpool.Pool
+	q  *queries.Queries
+}
+
+func NewAvailabilityRepository(pool *pgxpool.Pool) *AvailabilityRepository {
+	return &AvailabilityRepository{db: pool, q: queries.New(pool)}
+}
+
+// GetSpecialty returns the doctor's specialty, or nil if none was set.
+func (r *AvailabilityRepository) GetSpecialty(ctx context.Context, doctorID int32) (*string, error) {
+	p, err := r.q.GetDoctorProfile(ctx, doctorID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p.Specialty, nil
+}
+
+// ListDoctors returns every doctor, narrowed to those with the given
+// specialty (case-insensitive) and those in the given department when
+// either is not nil.
+func (r *AvailabilityRepository) ListDoctors(ctx context.Context, specialty *string, departmentID *int32) ([]domain.DoctorProfile, error) {
+	rows, err := r.q.ListDoctorsWithSpecialty(ctx, queries.ListDoctorsWithSpecialtyParams{
+		Specialty:    specialty,
+		DepartmentID: departmentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.DoctorProfile, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.DoctorProfile{
+			DoctorID:  row.ID,
+			Name:      row.FirstName + " " + row.LastName,
+			Specialty: row.Specialty,
+		})
+	}
+	return result, nil
+}
+
+// ReplaceTemplates swaps the doctor's whole weekly template, and specialty,
+// in one transaction. The templates must already be validated.
+func (r *AvailabilityRepository) ReplaceTemplates(ctx context.Context, doctorID int32, specialty *string, templates []domain.AvailabilityTemplate) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.q.WithTx(tx)
+	if err := qtx.UpsertDoctorProfile(ctx, queries.UpsertDoctorProfileParams{DoctorID: doctorID, Specialty: specialty}); err != nil {
+		return err
+	}
+	// The profile's specialty is also one of the doctor's specialties, so
+	// that searches by specialty find them.
+	if specialty != nil {
+		s, err := qtx.UpsertSpecialty(ctx, *specialty)
+		if err != nil {
+			return err
+		}
+		err = qtx.AddDoctorSpecialty(ctx, queries.AddDoctorSpecialtyParams{DoctorID: doctorID, SpecialtyID: s.ID})
+		if err != nil {
+			return err
+		}
+	}
+	if err := qtx.DeleteAvailabilityTemplates(ctx, doctorID); err != nil {
+		return err
+	}
+
+	for _, t := range templates {
+		created, err := qtx.CreateAvailabilityTemplate(ctx, queries.CreateAvailabilityTemplateParams{
+			DoctorID:    doctorID,
+			Weekday:     int16(t.Weekday),
+			StartTime:   clockToTime(t.StartTime),
+			EndTime:     clockToTime(t.EndTime),
+			SlotMinutes: int32(t.SlotMinutes),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, b := range t.Breaks {
+			err := qtx.CreateAvailabilityBreak(ctx, queries.CreateAvailabilityBreakParams{
+				TemplateID: created.ID,
+				StartTime:  clockToTime(b.StartTime),
+				EndTime:    clockToTime(b.EndTime),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *AvailabilityRepository) ListTemplates(ctx context.Context, doctorID int32) ([]domain.AvailabilityTemplate, error) {
+	templates, err := r.q.ListAvailabilityTemplates(ctx, doctorID)
+	if err != nil {
+		return nil, err
+	}
+	breaks, err := r.q.ListAvailabilityBreaks(ctx, doctorID)
+	if err != nil {
+		return nil, err
+	}
+
+	byTemplate := make(map[int32][]domain.AvailabilityBreak)
+	for _, b := range breaks {
+		byTemplate[b.TemplateID] = append(byTemplate[b.TemplateID], domain.AvailabilityBreak{
+			StartTime: timeToClock(b.StartTime),
+			EndTime:   timeToClock(b.EndTime),
+		})
+	}
+
+	result := make([]domain.AvailabilityTemplate, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, domain.AvailabilityTemplate{
+			ID:          t.ID,
+			Weekday:     int(t.Weekday),
+			StartTime:   timeToClock(t.StartTime),
+			EndTime:     timeToClock(t.EndTime),
+			SlotMinutes: int(t.SlotMinutes),
+			Breaks:      byTemplate[t.ID],
+		})
+	}
+	return result, nil
+}
+
+func (r *AvailabilityRepository) CreateException(ctx context.Context, e *domain.AvailabilityException) error {
+	created, err := r.q.CreateAvailabilityException(ctx, queries.CreateAvailabilityExceptionParams{
+		DoctorID:  e.DoctorID,
+		StartsAt:  pgtype.Timestamptz{Time: e.StartsAt, Valid: true},
+		EndsAt:    pgtype.Timestamptz{Time: e.EndsAt, Valid: true},
+		Kind:      e.Kind,
+		Reason:    e.Reason,
+		CreatedBy: e.CreatedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	*e = *toDomainAvailabilityException(created)
+	return nil
+}
+
+// DeleteException returns domain.ErrNotFound if the doctor has no such
+// exception.
+func (r *AvailabilityRepository) DeleteException(ctx context.Context, doctorID, id int32) error {
+	n, err := r.q.DeleteAvailabilityException(ctx, queries.DeleteAvailabilityExceptionParams{ID: id, DoctorID: doctorID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// ListExceptions returns the exceptions that intersect [from, to).
+func (r *AvailabilityRepository) ListExceptions(ctx context.Context, doctorID int32, from, to time.Time) ([]domain.AvailabilityException, error) {
+	rows, err := r.q.ListAvailabilityExceptions(ctx, queries.ListAvailabilityExceptionsParams{
+		DoctorID: doctorID,
+		EndsAt:   pgtype.Timestamptz{Time: from, Valid: true},
+		StartsAt: pgtype.Timestamptz{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.AvailabilityException, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainAvailabilityException(row))
+	}
+	return result, nil
+}
+
+// ListBusy returns the doctor's active appointments that intersect [from, to).
+func (r *AvailabilityRepository) ListBusy(ctx context.Context, doctorID int32, from, to time.Time) ([]scheduling.Interval, error) {
+	rows, err := r.q.ListDoctorBusyTimes(ctx, queries.ListDoctorBusyTimesParams{
+		DoctorID:        &doctorID,
+		EndTime:         pgtype.Timestamptz{Time: from, Valid: true},
+		AppointmentDate: pgtype.Timestamptz{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]scheduling.Interval, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, scheduling.Interval{Start: row.AppointmentDate.Time, End: row.EndTime.Time})
+	}
+	return result, nil
+}
+
+func toDomainAvailabilityException(e *queries.AvailabilityException) *domain.AvailabilityException {
+	return &domain.AvailabilityException{
+		ID:        e.ID,
+		DoctorID:  e.DoctorID,
+		StartsAt:  e.StartsAt.Time,
+		EndsAt:    e.EndsAt.Time,
+		Kind:      e.Kind,
+		Reason:    e.Reason,
+		CreatedBy: e.CreatedBy,
+	}
+}
+
+// clockToTime converts an "HH:MM" string, already validated with
+// scheduling.ParseClock, to a TIME value.
+func clockToTime(s string) pgtype.Time {
+	c, _ := scheduling.ParseClock(s)
+	return pgtype.Time{Microseconds: int64(c) * int64(time.Minute/time.Microsecond), Valid: true}
+}
+
+func timeToClock(t pgtype.Time) string {
+	return scheduling.Clock(t.Microseconds / int64(time.Minute/time.Microsecond)).String()
+}
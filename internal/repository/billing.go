@@ -0,0 +1,588 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+// BillingRepository keeps the price catalog, invoices and payments. Every
+// change to an invoice locks it first, so lines and payments are applied
+// one at a time and its totals always match.
+type BillingRepository struct {
+	q    *queries.Queries
+	pool *pgxpool.Pool
+}
+
+func NewBillingRepository(q *queries.Queries, pool *pgxpool.Pool) *BillingRepository {
+	return &BillingRepository{q: q, pool: pool}
+}
+
+func (r *BillingRepository) CreateService(ctx context.Context, s *domain.BillableService) error {
+	row, err := r.q.CreateBillableService(ctx, queries.CreateBillableServiceParams{
+		Code:         s.Code,
+		Name:         s.Name,
+		Description:  s.Description,
+		Category:     s.Category,
+		DepartmentID: s.DepartmentID,
+		PriceMinor:   s.PriceMinor,
+		TaxRateBp:    s.TaxRateBP,
+		Active:       s.Active,
+	})
+	if err != nil {
+		return translateServiceCode(err, s.Code)
+	}
+	*s = *toDomainBillableService(row)
+	return nil
+}
+
+func (r *BillingRepository) UpdateService(ctx context.Context, s *domain.BillableService) error {
+	row, err := r.q.UpdateBillableService(ctx, queries.UpdateBillableServiceParams{
+		ID:           s.ID,
+		Code:         s.Code,
+		Name:         s.Name,
+		Description:  s.Description,
+		Category:     s.Category,
+		DepartmentID: s.DepartmentID,
+		PriceMinor:   s.PriceMinor,
+		TaxRateBp:    s.TaxRateBP,
+		Active:       s.Active,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: billable service %d", domain.ErrNotFound, s.ID)
+	}
+	if err != nil {
+		return translateServiceCode(err, s.Code)
+	}
+	*s = *toDomainBillableService(row)
+	return nil
+}
+
+func (r *BillingRepository) GetService(ctx context.Context, id int32) (*domain.BillableService, error) {
+	row, err := r.q.GetBillableService(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: billable service %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainBillableService(row), nil
+}
+
+// ListServices returns the catalog by category and name. query matches
+// the start of a code or any part of a name.
+func (r *BillingRepository) ListServices(ctx context.Context, category *string, active *bool, query *string) ([]domain.BillableService, error) {
+	rows, err := r.q.ListBillableServices(ctx, queries.ListBillableServicesParams{
+		Category: category,
+		Active:   active,
+		Query:    query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.BillableService, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainBillableService(row))
+	}
+	return result, nil
+}
+
+// DefaultConsultation returns the active consultation of the department,
+// or failing that the general one.
+func (r *BillingRepository) DefaultConsultation(ctx context.Context, departmentID *int32) (*domain.BillableService, error) {
+	row, err := r.q.GetDefaultConsultation(ctx, departmentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: the catalog has no active consultation to charge", domain.ErrInvalid)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainBillableService(row), nil
+}
+
+// CreateInvoice saves inv as a draft with lines and fills in its totals.
+func (r *BillingRepository) CreateInvoice(ctx context.Context, inv *domain.Invoice, lines []domain.InvoiceLine) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	row, err := qtx.CreateInvoice(ctx, queries.CreateInvoiceParams{
+		PatientID:     inv.PatientID,
+		AppointmentID: inv.AppointmentID,
+		Currency:      inv.Currency,
+		Notes:         inv.Notes,
+		CreatedBy:     inv.CreatedBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && inv.AppointmentID != nil {
+			return fmt.Errorf("%w: appointment %d already has an invoice", domain.ErrConflict, *inv.AppointmentID)
+		}
+		return translateConstraint(err, "invoice")
+	}
+	for i := range lines {
+		lines[i].InvoiceID = row.ID
+		if err := addLine(ctx, qtx, &lines[i]); err != nil {
+			return err
+		}
+	}
+	row, err = qtx.UpdateInvoiceTotals(ctx, row.ID)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	*inv = *toDomainInvoice(row)
+	inv.Lines = lines
+	return nil
+}
+
+func (r *BillingRepository) GetInvoice(ctx context.Context, id int32) (*domain.Invoice, error) {
+	row, err := r.q.GetInvoice(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: invoice %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainInvoice(row), nil
+}
+
+// GetInvoiceDetail returns the invoice with its lines and payments.
+func (r *BillingRepository) GetInvoiceDetail(ctx context.Context, id int32) (*domain.Invoice, error) {
+	inv, err := r.GetInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := r.q.ListInvoiceLines(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	inv.Lines = make([]domain.InvoiceLine, 0, len(lines))
+	for _, l := range lines {
+		inv.Lines = append(inv.Lines, *toDomainInvoiceLine(l))
+	}
+
+	payments, err := r.q.ListPayments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	inv.Payments = make([]domain.Payment, 0, len(payments))
+	for _, p := range payments {
+		inv.Payments = append(inv.Payments, *toDomainPayment(p))
+	}
+	return inv, nil
+}
+
+// ListInvoices returns the invoices matching filter, newest first.
+func (r *BillingRepository) ListInvoices(ctx context.Context, filter domain.InvoiceFilter) ([]domain.Invoice, error) {
+	rows, err := r.q.ListInvoices(ctx, queries.ListInvoicesParams{
+		PatientID: filter.PatientID,
+		Status:    filter.Status,
+		Limit:     filter.Limit,
+		Offset:    filter.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDomainInvoices(rows), nil
+}
+
+// AddLine adds line to the draft invoiceID, which may have at most
+// maxLines lines, and returns the invoice with its new totals.
+func (r *BillingRepository) AddLine(ctx context.Context, invoiceID int32, line *domain.InvoiceLine, maxLines int64) (*domain.Invoice, error) {
+	var updated *domain.Invoice
+	err := r.withDraft(ctx, invoiceID, func(qtx *queries.Queries) error {
+		n, err := qtx.CountInvoiceLines(ctx, invoiceID)
+		if err != nil {
+			return err
+		}
+		if n >= maxLines {
+			return fmt.Errorf("%w: an invoice can have at most %d lines", domain.ErrInvalid, maxLines)
+		}
+		line.InvoiceID = invoiceID
+		if err := addLine(ctx, qtx, line); err != nil {
+			return err
+		}
+		row, err := qtx.UpdateInvoiceTotals(ctx, invoiceID)
+		updated = toDomainInvoice(row)
+		return err
+	})
+	return updated, err
+}
+
+// DeleteLine removes a line from the draft invoiceID and returns the
+// invoice with its new totals.
+func (r *BillingRepository) DeleteLine(ctx context.Context, invoiceID, lineID int32) (*domain.Invoice, error) {
+	var updated *domain.Invoice
+	err := r.withDraft(ctx, invoiceID, func(qtx *queries.Queries) error {
+		n, err := qtx.DeleteInvoiceLine(ctx, queries.DeleteInvoiceLineParams{ID: lineID, InvoiceID: invoiceID})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return fmt.Errorf("%w: line %d of invoice %d", domain.ErrNotFound, lineID, invoiceID)
+		}
+		row, err := qtx.UpdateInvoiceTotals(ctx, invoiceID)
+		updated = toDomainInvoice(row)
+		return err
+	})
+	return updated, err
+}
+
+// Issue finalises the draft invoice, which must have a line, payable by
+// dueDate.
+func (r *BillingRepository) Issue(ctx context.Context, id int32, dueDate time.Time) (*domain.Invoice, error) {
+	var issued *domain.Invoice
+	err := r.withDraft(ctx, id, func(qtx *queries.Queries) error {
+		n, err := qtx.CountInvoiceLines(ctx, id)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return fmt.Errorf("%w: invoice %d has no lines", domain.ErrInvalid, id)
+		}
+		row, err := qtx.IssueInvoice(ctx, queries.IssueInvoiceParams{
+			ID:      id,
+			DueDate: pgtype.Date{Time: dueDate, Valid: true},
+		})
+		issued = toDomainInvoice(row)
+		return err
+	})
+	return issued, err
+}
+
+// Void cancels a draft, or an issued invoice with nothing paid on it.
+func (r *BillingRepository) Void(ctx context.Context, id int32, reason string) (*domain.Invoice, error) {
+	row, err := r.q.VoidInvoice(ctx, queries.VoidInvoiceParams{ID: id, VoidReason: &reason})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: invoice %d can only be voided before anything is paid on it", domain.ErrConflict, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainInvoice(row), nil
+}
+
+// RecordPayment saves a payment of p.AmountMinor against its invoice,
+// which may not be more than the balance, and returns the invoice.
+func (r *BillingRepository) RecordPayment(ctx context.Context, p *domain.Payment) (*domain.Invoice, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	inv, err := lockInvoice(ctx, qtx, p.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status != domain.InvoiceIssued && inv.Status != domain.InvoicePartiallyPaid {
+		return nil, fmt.Errorf("%w: invoice %d is %s", domain.ErrConflict, inv.ID, inv.Status)
+	}
+	if balance := inv.TotalMinor - inv.PaidMinor; p.AmountMinor > balance {
+		return nil, fmt.Errorf("%w: invoice %d has %d left to pay", domain.ErrInvalid, inv.ID, balance)
+	}
+
+	p.Kind = domain.PaymentKindPayment
+	updated, err := applyPayment(ctx, qtx, p, p.AmountMinor)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Refund gives back r.AmountMinor of the payment r.RefundOf, by the same
+// method, and returns the invoice. Refunds of a payment cannot add up to
+// more than it.
+func (r *BillingRepository) Refund(ctx context.Context, refund *domain.Payment) (*domain.Invoice, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	inv, err := lockInvoice(ctx, qtx, refund.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	original, err := qtx.GetPayment(ctx, *refund.RefundOf)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && original.InvoiceID != inv.ID) {
+		return nil, fmt.Errorf("%w: payment %d of invoice %d", domain.ErrNotFound, *refund.RefundOf, inv.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if original.Kind != domain.PaymentKindPayment {
+		return nil, fmt.Errorf("%w: payment %d is itself a refund", domain.ErrInvalid, original.ID)
+	}
+	refunded, err := qtx.GetRefundedAmount(ctx, &original.ID)
+	if err != nil {
+		return nil, err
+	}
+	if left := original.AmountMinor - refunded; refund.AmountMinor > left {
+		return nil, fmt.Errorf("%w: payment %d has %d left to refund", domain.ErrInvalid, original.ID, left)
+	}
+
+	refund.Kind = domain.PaymentKindRefund
+	refund.Method = original.Method
+	updated, err := applyPayment(ctx, qtx, refund, -refund.AmountMinor)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// PatientBalance returns what the patient owes in currency on issued
+// invoices, with those invoices. Invoices due before today are overdue.
+func (r *BillingRepository) PatientBalance(ctx context.Context, patientID int32, currency string, today time.Time) (*domain.PatientBalance, error) {
+	row, err := r.q.GetPatientBalance(ctx, queries.GetPatientBalanceParams{
+		Today:     pgtype.Date{Time: today, Valid: true},
+		PatientID: patientID,
+		Currency:  currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	invoices, err := r.q.ListOpenInvoices(ctx, queries.ListOpenInvoicesParams{PatientID: patientID, Currency: currency})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PatientBalance{
+		PatientID:        patientID,
+		Currency:         currency,
+		OutstandingMinor: row.OutstandingMinor,
+		OverdueMinor:     row.OverdueMinor,
+		OpenInvoices:     row.OpenInvoices,
+		Invoices:         toDomainInvoices(invoices),
+	}, nil
+}
+
+// OutstandingBalances lists the patients owing at least minBalance in
+// currency, largest balance first.
+func (r *BillingRepository) OutstandingBalances(ctx context.Context, currency string, today time.Time, minBalance int64, limit, offset int32) ([]domain.PatientBalance, error) {
+	rows, err := r.q.ListOutstandingBalances(ctx, queries.ListOutstandingBalancesParams{
+		Today:      pgtype.Date{Time: today, Valid: true},
+		Currency:   currency,
+		MinBalance: minBalance,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.PatientBalance, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.PatientBalance{
+			PatientID:        row.PatientID,
+			Currency:         currency,
+			OutstandingMinor: row.OutstandingMinor,
+			OverdueMinor:     row.OverdueMinor,
+			OpenInvoices:     row.OpenInvoices,
+		})
+	}
+	return result, nil
+}
+
+// withDraft runs fn in a transaction holding the lock on the invoice,
+// which must be a draft.
+func (r *BillingRepository) withDraft(ctx context.Context, id int32, fn func(qtx *queries.Queries) error) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	inv, err := lockInvoice(ctx, qtx, id)
+	if err != nil {
+		return err
+	}
+	if inv.Status != domain.InvoiceDraft {
+		return fmt.Errorf("%w: invoice %d is %s and can no longer be changed", domain.ErrConflict, id, inv.Status)
+	}
+	if err := fn(qtx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func lockInvoice(ctx context.Context, qtx *queries.Queries, id int32) (*queries.Invoice, error) {
+	inv, err := qtx.LockInvoice(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: invoice %d", domain.ErrNotFound, id)
+	}
+	return inv, err
+}
+
+func addLine(ctx context.Context, qtx *queries.Queries, l *domain.InvoiceLine) error {
+	row, err := qtx.AddInvoiceLine(ctx, queries.AddInvoiceLineParams{
+		InvoiceID:      l.InvoiceID,
+		ServiceID:      l.ServiceID,
+		Description:    l.Description,
+		Quantity:       l.Quantity,
+		UnitPriceMinor: l.UnitPriceMinor,
+		TaxRateBp:      l.TaxRateBP,
+		SubtotalMinor:  l.SubtotalMinor,
+		DiscountMinor:  l.DiscountMinor,
+		TaxMinor:       l.TaxMinor,
+		TotalMinor:     l.TotalMinor,
+	})
+	if err != nil {
+		return err
+	}
+	l.ID = row.ID
+	return nil
+}
+
+// applyPayment saves p and adds amount to what was paid on its invoice.
+func applyPayment(ctx context.Context, qtx *queries.Queries, p *domain.Payment, amount int64) (*domain.Invoice, error) {
+	row, err := qtx.CreatePayment(ctx, queries.CreatePaymentParams{
+		InvoiceID:   p.InvoiceID,
+		Kind:        p.Kind,
+		Method:      p.Method,
+		AmountMinor: p.AmountMinor,
+		Reference:   p.Reference,
+		RefundOf:    p.RefundOf,
+		Reason:      p.Reason,
+		ReceivedBy:  p.ReceivedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	*p = *toDomainPayment(row)
+
+	inv, err := qtx.ApplyInvoicePayment(ctx, queries.ApplyInvoicePaymentParams{Amount: amount, ID: p.InvoiceID})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: the payment does not fit invoice %d", domain.ErrConflict, p.InvoiceID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainInvoice(inv), nil
+}
+
+func translateServiceCode(err error, code string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return fmt.Errorf("%w: a billable service with code %s already exists", domain.ErrConflict, code)
+	}
+	return translateConstraint(err, "billable service")
+}
+
+func toDomainBillableService(row *queries.BillableService) *domain.BillableService {
+	return &domain.BillableService{
+		ID:           row.ID,
+		Code:         row.Code,
+		Name:         row.Name,
+		Description:  row.Description,
+		Category:     row.Category,
+		DepartmentID: row.DepartmentID,
+		PriceMinor:   row.PriceMinor,
+		TaxRateBP:    row.TaxRateBp,
+		Active:       row.Active,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+	}
+}
+
+func toDomainInvoices(rows []*queries.Invoice) []domain.Invoice {
+	result := make([]domain.Invoice, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainInvoice(row))
+	}
+	return result
+}
+
+func toDomainInvoice(row *queries.Invoice) *domain.Invoice {
+	if row == nil {
+		return nil
+	}
+	inv := &domain.Invoice{
+		ID:            row.ID,
+		PatientID:     row.PatientID,
+		AppointmentID: row.AppointmentID,
+		Currency:      row.Currency,
+		Status:        row.Status,
+		SubtotalMinor: row.SubtotalMinor,
+		DiscountMinor: row.DiscountMinor,
+		TaxMinor:      row.TaxMinor,
+		TotalMinor:    row.TotalMinor,
+		PaidMinor:     row.PaidMinor,
+		Notes:         row.Notes,
+		DueDate:       row.DueDate,
+		VoidReason:    row.VoidReason,
+		CreatedBy:     row.CreatedBy,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+	if inv.Status != domain.InvoiceVoid {
+		inv.BalanceMinor = inv.TotalMinor - inv.PaidMinor
+	}
+	if row.IssuedAt.Valid {
+		issued := row.IssuedAt.Time
+		inv.IssuedAt = &issued
+	}
+	if row.VoidedAt.Valid {
+		voided := row.VoidedAt.Time
+		inv.VoidedAt = &voided
+	}
+	return inv
+}
+
+func toDomainInvoiceLine(row *queries.InvoiceLine) *domain.InvoiceLine {
+	return &domain.InvoiceLine{
+		ID:             row.ID,
+		InvoiceID:      row.InvoiceID,
+		ServiceID:      row.ServiceID,
+		Description:    row.Description,
+		Quantity:       row.Quantity,
+		UnitPriceMinor: row.UnitPriceMinor,
+		TaxRateBP:      row.TaxRateBp,
+		SubtotalMinor:  row.SubtotalMinor,
+		DiscountMinor:  row.DiscountMinor,
+		TaxMinor:       row.TaxMinor,
+		TotalMinor:     row.TotalMinor,
+	}
+}
+
+func toDomainPayment(row *queries.Payment) *domain.Payment {
+	return &domain.Payment{
+		ID:          row.ID,
+		InvoiceID:   row.InvoiceID,
+		Kind:        row.Kind,
+		Method:      row.Method,
+		AmountMinor: row.AmountMinor,
+		Reference:   row.Reference,
+		RefundOf:    row.RefundOf,
+		Reason:      row.Reason,
+		ReceivedBy:  row.ReceivedBy,
+		CreatedAt:   row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+type CalendarRepository struct {
+	q *queries.Queries
+}
+
+func NewCalendarRepository(q *queries.Queries) *CalendarRepository {
+	return &CalendarRepository{q: q}
+}
+
+func (r *CalendarRepository) CreateToken(ctx context.Context, doctorID int32, tokenHash []byte) (*domain.CalendarToken, error) {
+	t, err := r.q.CreateCalendarToken(ctx, queries.CreateCalendarTokenParams{
+		DoctorID:  doctorID,
+		TokenHash: tokenHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDomainCalendarToken(t), nil
+}
+
+// GetToken returns the unrevoked token with tokenHash and records that it
+// was used.
+func (r *CalendarRepository) GetToken(ctx context.Context, tokenHash []byte) (*domain.CalendarToken, error) {
+	t, err := r.q.GetCalendarTokenByHash(ctx, tokenHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: calendar token", domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.q.TouchCalendarToken(ctx, t.ID); err != nil {
+		return nil, err
+	}
+	return toDomainCalendarToken(t), nil
+}
+
+func (r *CalendarRepository) ListTokens(ctx context.Context, doctorID int32) ([]domain.CalendarToken, error) {
+	rows, err := r.q.ListCalendarTokens(ctx, doctorID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.CalendarToken, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainCalendarToken(row))
+	}
+	return result, nil
+}
+
+func (r *CalendarRepository) RevokeToken(ctx context.Context, id, doctorID int32) error {
+	n, err := r.q.RevokeCalendarToken(ctx, queries.RevokeCalendarTokenParams{ID: id, DoctorID: doctorID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: calendar token %d", domain.ErrNotFound, id)
+	}
+	return nil
+}
+
+func toDomainCalendarToken(t *queries.CalendarToken) *domain.CalendarToken {
+	token := &domain.CalendarToken{
+		ID:        t.ID,
+		DoctorID:  t.DoctorID,
+		CreatedAt: t.CreatedAt.Time,
+	}
+	if t.LastUsedAt.Valid {
+		used := t.LastUsedAt.Time
+		token.LastUsedAt = &used
+	}
+	if t.RevokedAt.Valid {
+		revoked := t.RevokedAt.Time
+		token.RevokedAt = &revoked
+	}
+	return token
+}
+
+// PurgeRevoked deletes tokens revoked before the given time and returns
+// how many were deleted.
+func (r *CalendarRepository) PurgeRevoked(ctx context.Context, before time.Time) (int64, error) {
+	return r.q.DeleteRevokedCalendarTokens(ctx, utils.TimeToTimestamp(before))
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+type CareTeamRepository struct {
+	q *queries.Queries
+}
+
+func NewCareTeamRepository(q *queries.Queries) *CareTeamRepository {
+	return &CareTeamRepository{q: q}
+}
+
+// Add stores an explicit membership. Adding the same doctor twice with the
+// same source is a no-op that returns the existing row.
+func (r *CareTeamRepository) Add(ctx context.Context, patientID, doctorID int32, source string, addedBy *int32) (*domain.CareTeamMember, error) {
+	m, err := r.q.AddCareTeamMember(ctx, queries.AddCareTeamMemberParams{
+		PatientID: patientID,
+		DoctorID:  doctorID,
+		Source:    source,
+		AddedBy:   addedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CareTeamMember{
+		PatientID: m.PatientID,
+		DoctorID:  m.DoctorID,
+		Source:    m.Source,
+		AddedBy:   m.AddedBy,
+		Since:     m.CreatedAt.Time,
+	}, nil
+}
+
+// Remove deletes an explicit membership. It returns domain.ErrNotFound if
+// there was none.
+func (r *CareTeamRepository) Remove(ctx context.Context, patientID, doctorID int32, source string) error {
+	n, err := r.q.RemoveCareTeamMember(ctx, queries.RemoveCareTeamMemberParams{
+		PatientID: patientID,
+		DoctorID:  doctorID,
+		Source:    source,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// List returns every membership of the patient, including the ones implied
+// by appointments. A doctor may appear once per source.
+func (r *CareTeamRepository) List(ctx context.Context, patientID int32) ([]domain.CareTeamMember, error) {
+	rows, err := r.q.ListCareTeam(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.CareTeamMember, 0, len(rows))
+	for _, row := range rows {
+		m := domain.CareTeamMember{
+			PatientID: patientID,
+			DoctorID:  row.DoctorID,
+			Source:    row.Source,
+			Since:     row.Since.Time,
+		}
+		m.DoctorName, _ = row.DoctorName.(string)
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+func (r *CareTeamRepository) HasAccess(ctx context.Context, doctorID, patientID int32) (bool, error) {
+	return r.q.DoctorHasPatientAccess(ctx, queries.DoctorHasPatientAccessParams{
+		DoctorID:  doctorID,
+		PatientID: patientID,
+	})
+}
@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+// integrityViolation is the SQLSTATE raised by the triggers that keep
+// clinical notes append-only.
+const integrityViolation = "23000"
+
+type ClinicalNoteRepository struct {
+	q    *queries.Queries
+	pool *pgxpool.Pool
+}
+
+func NewClinicalNoteRepository(q *queries.Queries, pool *pgxpool.Pool) *ClinicalNoteRepository {
+	return &ClinicalNoteRepository{q: q, pool: pool}
+}
+
+// Create saves n as a draft with its sections as version 1. An
+// appointment has at most one note.
+func (r *ClinicalNoteRepository) Create(ctx context.Context, n *domain.ClinicalNote) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := createNote(ctx, r.q.WithTx(tx), n); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// createNote saves n and its first version with qtx, which must be bound
+// to a transaction.
+func createNote(ctx context.Context, qtx *queries.Queries, n *domain.ClinicalNote) error {
+	note, err := qtx.CreateClinicalNote(ctx, queries.CreateClinicalNoteParams{
+		PatientID:     n.PatientID,
+		AppointmentID: n.AppointmentID,
+		AuthorID:      n.AuthorID,
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return fmt.Errorf("%w: appointment %d already has a clinical note", domain.ErrConflict, *n.AppointmentID)
+	}
+	if err != nil {
+		return translateConstraint(err, "clinical note")
+	}
+	if err := addVersion(ctx, qtx, note, 1, n.SOAP, n.AuthorID); err != nil {
+		return err
+	}
+
+	n.ID = note.ID
+	n.Status = note.Status
+	n.CurrentVersion = 1
+	n.CreatedAt = note.CreatedAt.Time
+	n.UpdatedAt = note.UpdatedAt.Time
+	return nil
+}
+
+func (r *ClinicalNoteRepository) Get(ctx context.Context, id int32) (*domain.ClinicalNote, error) {
+	row, err := r.q.GetClinicalNote(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: clinical note %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.withAddenda(ctx, toDomainClinicalNote(row))
+}
+
+// GetByAppointment returns the appointment's note, or nil if it has none.
+func (r *ClinicalNoteRepository) GetByAppointment(ctx context.Context, appointmentID int32) (*domain.ClinicalNote, error) {
+	row, err := r.q.GetClinicalNoteByAppointment(ctx, &appointmentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.withAddenda(ctx, toDomainClinicalNote((*queries.GetClinicalNoteRow)(row)))
+}
+
+// ListByPatient returns the patient's notes, newest first, without their
+// addenda.
+func (r *ClinicalNoteRepository) ListByPatient(ctx context.Context, patientID int32) ([]domain.ClinicalNote, error) {
+	rows, err := r.q.ListPatientClinicalNotes(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ClinicalNote, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainClinicalNote((*queries.GetClinicalNoteRow)(row)))
+	}
+	return result, nil
+}
+
+// AddVersion saves sections as the next version of the draft note id. It
+// returns domain.ErrConflict if the note is signed or baseVersion is no
+// longer its current version.
+func (r *ClinicalNoteRepository) AddVersion(ctx context.Context, id, baseVersion int32, sections domain.SOAP, createdBy int32) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := addNoteVersion(ctx, r.q.WithTx(tx), id, baseVersion, sections, createdBy); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// addNoteVersion adds sections to note id as the version after
+// baseVersion with qtx, which must be bound to a transaction.
+func addNoteVersion(ctx context.Context, qtx *queries.Queries, id, baseVersion int32, sections domain.SOAP, createdBy int32) error {
+	note, err := qtx.LockClinicalNote(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: clinical note %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return err
+	}
+	if note.Status == domain.ClinicalNoteSigned {
+		return fmt.Errorf("%w: clinical note %d is signed; add an addendum instead", domain.ErrConflict, id)
+	}
+	if note.CurrentVersion != baseVersion {
+		return fmt.Errorf("%w: clinical note %d is at version %d, not %d", domain.ErrConflict, id, note.CurrentVersion, baseVersion)
+	}
+
+	return addVersion(ctx, qtx, note, note.CurrentVersion+1, sections, &createdBy)
+}
+
+// addVersion inserts version of note, makes it current and copies its
+// assessment and plan to the note's appointment.
+func addVersion(ctx context.Context, qtx *queries.Queries, note *queries.ClinicalNote, version int32, sections domain.SOAP, createdBy *int32) error {
+	_, err := qtx.CreateClinicalNoteVersion(ctx, queries.CreateClinicalNoteVersionParams{
+		NoteID:     note.ID,
+		Version:    version,
+		Subjective: sections.Subjective,
+		Objective:  sections.Objective,
+		Assessment: sections.Assessment,
+		Plan:       sections.Plan,
+		CreatedBy:  createdBy,
+	})
+	if err != nil {
+		return translateLocked(err)
+	}
+	if version > 1 {
+		err := qtx.SetClinicalNoteVersion(ctx, queries.SetClinicalNoteVersionParams{ID: note.ID, CurrentVersion: version})
+		if err != nil {
+			return translateLocked(err)
+		}
+	}
+	if note.AppointmentID == nil {
+		return nil
+	}
+	return qtx.SetAppointmentClinicalSummary(ctx, queries.SetAppointmentClinicalSummaryParams{
+		ID:            *note.AppointmentID,
+		Diagnosis:     sections.Assessment,
+		TreatmentPlan: sections.Plan,
+	})
+}
+
+// Sign locks the note at version, which must be its current version.
+func (r *ClinicalNoteRepository) Sign(ctx context.Context, id, version, signedBy int32) error {
+	n, err := r.q.SignClinicalNote(ctx, queries.SignClinicalNoteParams{
+		SignedBy: &signedBy,
+		ID:       id,
+		Version:  version,
+	})
+	if err != nil {
+		return translateLocked(err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: clinical note %d is signed or no longer at version %d", domain.ErrConflict, id, version)
+	}
+	return nil
+}
+
+func (r *ClinicalNoteRepository) ListVersions(ctx context.Context, id int32) ([]domain.ClinicalNoteVersion, error) {
+	rows, err := r.q.ListClinicalNoteVersions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ClinicalNoteVersion, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.ClinicalNoteVersion{
+			ID:        row.ID,
+			NoteID:    row.NoteID,
+			Version:   row.Version,
+			CreatedBy: row.CreatedBy,
+			CreatedAt: row.CreatedAt.Time,
+			SOAP: domain.SOAP{
+				Subjective: row.Subjective,
+				Objective:  row.Objective,
+				Assessment: row.Assessment,
+				Plan:       row.Plan,
+			},
+		})
+	}
+	return result, nil
+}
+
+func (r *ClinicalNoteRepository) AddAddendum(ctx context.Context, id int32, body string, createdBy int32) (*domain.ClinicalNoteAddendum, error) {
+	a, err := r.q.CreateClinicalNoteAddendum(ctx, queries.CreateClinicalNoteAddendumParams{
+		NoteID:    id,
+		Body:      body,
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDomainClinicalNoteAddendum(a), nil
+}
+
+func (r *ClinicalNoteRepository) withAddenda(ctx context.Context, n *domain.ClinicalNote) (*domain.ClinicalNote, error) {
+	rows, err := r.q.ListClinicalNoteAddenda(ctx, n.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	n.Addenda = make([]domain.ClinicalNoteAddendum, 0, len(rows))
+	for _, row := range rows {
+		n.Addenda = append(n.Addenda, *toDomainClinicalNoteAddendum(row))
+	}
+	return n, nil
+}
+
+func toDomainClinicalNote(row *queries.GetClinicalNoteRow) *domain.ClinicalNote {
+	n := &domain.ClinicalNote{
+		ID:             row.ID,
+		PatientID:      row.PatientID,
+		AppointmentID:  row.AppointmentID,
+		AuthorID:       row.AuthorID,
+		Status:         row.Status,
+		CurrentVersion: row.CurrentVersion,
+		SignedBy:       row.SignedBy,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+		SOAP: domain.SOAP{
+			Subjective: row.Subjective,
+			Objective:  row.Objective,
+			Assessment: row.Assessment,
+			Plan:       row.Plan,
+		},
+	}
+	if row.SignedAt.Valid {
+		signed := row.SignedAt.Time
+		n.SignedAt = &signed
+	}
+	return n
+}
+
+func toDomainClinicalNoteAddendum(a *queries.ClinicalNoteAddendum) *domain.ClinicalNoteAddendum {
+	return &domain.ClinicalNoteAddendum{
+		ID:        a.ID,
+		NoteID:    a.NoteID,
+		Body:      a.Body,
+		CreatedBy: a.CreatedBy,
+		CreatedAt: a.CreatedAt.Time,
+	}
+}
+
+// translateLocked turns the error raised when a signed note is written to
+// into domain.ErrConflict.
+func translateLocked(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == integrityViolation {
+		return fmt.Errorf("%w: %s", domain.ErrConflict, pgErr.Message)
+	}
+	return err
+}
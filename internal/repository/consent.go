@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+type ConsentRepository struct {
+	q    *queries.Queries
+	pool *pgxpool.Pool
+}
+
+func NewConsentRepository(q *queries.Queries, pool *pgxpool.Pool) *ConsentRepository {
+	return &ConsentRepository{q: q, pool: pool}
+}
+
+// CreateTemplate saves t as the next version of its purpose's template.
+func (r *ConsentRepository) CreateTemplate(ctx context.Context, t *domain.ConsentTemplate) error {
+	row, err := r.q.CreateConsentTemplate(ctx, queries.CreateConsentTemplateParams{
+		Purpose:      t.Purpose,
+		Title:        t.Title,
+		Body:         t.Body,
+		ValidityDays: t.ValidityDays,
+		CreatedBy:    t.CreatedBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return fmt.Errorf("%w: another %s template was published at the same time", domain.ErrConflict, t.Purpose)
+		}
+		return translateConstraint(err, "consent template")
+	}
+	*t = *toDomainConsentTemplate(row)
+	t.Current = true
+	return nil
+}
+
+func (r *ConsentRepository) GetTemplate(ctx context.Context, id int32) (*domain.ConsentTemplate, error) {
+	row, err := r.q.GetConsentTemplate(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: consent template %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainConsentTemplate(row), nil
+}
+
+// CurrentTemplate returns the latest version of the purpose's template.
+func (r *ConsentRepository) CurrentTemplate(ctx context.Context, purpose string) (*domain.ConsentTemplate, error) {
+	row, err := r.q.GetCurrentConsentTemplate(ctx, purpose)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: no %s consent template", domain.ErrNotFound, purpose)
+	}
+	if err != nil {
+		return nil, err
+	}
+	t := toDomainConsentTemplate(row)
+	t.Current = true
+	return t, nil
+}
+
+// ListTemplates returns every version, newest first within each purpose,
+// optionally of one purpose.
+func (r *ConsentRepository) ListTemplates(ctx context.Context, purpose *string) ([]domain.ConsentTemplate, error) {
+	rows, err := r.q.ListConsentTemplates(ctx, purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ConsentTemplate, 0, len(rows))
+	for i, row := range rows {
+		t := toDomainConsentTemplate(row)
+		t.Current = i == 0 || rows[i-1].Purpose != row.Purpose
+		result = append(result, *t)
+	}
+	return result, nil
+}
+
+// Record saves c, revoking the patient's previous consent for the same
+// purpose with reason.
+func (r *ConsentRepository) Record(ctx context.Context, c *domain.Consent, reason string) error {
+	var expiresAt pgtype.Timestamptz
+	if c.ExpiresAt != nil {
+		expiresAt = pgtype.Timestamptz{Time: *c.ExpiresAt, Valid: true}
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	if err := qtx.SupersedePatientConsent(ctx, queries.SupersedePatientConsentParams{
+		PatientID:        c.PatientID,
+		Purpose:          c.Purpose,
+		RevokedBy:        c.RecordedBy,
+		RevocationReason: &reason,
+	}); err != nil {
+		return err
+	}
+	row, err := qtx.CreatePatientConsent(ctx, queries.CreatePatientConsentParams{
+		PatientID:   c.PatientID,
+		TemplateID:  c.TemplateID,
+		Purpose:     c.Purpose,
+		SignedBy:    c.SignedBy,
+		SignedAt:    pgtype.Timestamptz{Time: c.SignedAt, Valid: true},
+		WitnessName: c.WitnessName,
+		RecordedBy:  c.RecordedBy,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return fmt.Errorf("%w: another %s consent was recorded at the same time", domain.ErrConflict, c.Purpose)
+		}
+		return translateConstraint(err, "consent")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	c.ID = row.ID
+	c.CreatedAt = row.CreatedAt.Time
+	return nil
+}
+
+func (r *ConsentRepository) Get(ctx context.Context, id int32) (*domain.Consent, error) {
+	row, err := r.q.GetPatientConsent(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: consent %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainConsent(row), nil
+}
+
+// Latest returns the consent for the purpose recorded last, in force or
+// not, or nil if there is none.
+func (r *ConsentRepository) Latest(ctx context.Context, patientID int32, purpose string) (*domain.Consent, error) {
+	row, err := r.q.GetLatestPatientConsent(ctx, queries.GetLatestPatientConsentParams{PatientID: patientID, Purpose: purpose})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainConsent((*queries.GetPatientConsentRow)(row)), nil
+}
+
+// List returns the patient's consents, most recently recorded first.
+func (r *ConsentRepository) List(ctx context.Context, patientID int32) ([]domain.Consent, error) {
+	rows, err := r.q.ListPatientConsents(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Consent, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainConsent((*queries.GetPatientConsentRow)(row)))
+	}
+	return result, nil
+}
+
+func (r *ConsentRepository) Revoke(ctx context.Context, id, userID int32, reason string) error {
+	n, err := r.q.RevokePatientConsent(ctx, queries.RevokePatientConsentParams{
+		ID:               id,
+		RevokedBy:        &userID,
+		RevocationReason: &reason,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: consent %d is already revoked", domain.ErrConflict, id)
+	}
+	return nil
+}
+
+func toDomainConsentTemplate(row *queries.ConsentTemplate) *domain.ConsentTemplate {
+	return &domain.ConsentTemplate{
+		ID:           row.ID,
+		Purpose:      row.Purpose,
+		Version:      row.Version,
+		Title:        row.Title,
+		Body:         row.Body,
+		ValidityDays: row.ValidityDays,
+		CreatedBy:    row.CreatedBy,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+}
+
+func toDomainConsent(row *queries.GetPatientConsentRow) *domain.Consent {
+	c := &domain.Consent{
+		ID:               row.ID,
+		PatientID:        row.PatientID,
+		TemplateID:       row.TemplateID,
+		TemplateVersion:  row.TemplateVersion,
+		TemplateTitle:    row.TemplateTitle,
+		Purpose:          row.Purpose,
+		SignedBy:         row.SignedBy,
+		SignedAt:         row.SignedAt.Time,
+		WitnessName:      row.WitnessName,
+		RecordedBy:       row.RecordedBy,
+		RevokedBy:        row.RevokedBy,
+		RevocationReason: row.RevocationReason,
+		CreatedAt:        row.CreatedAt.Time,
+	}
+	if row.ExpiresAt.Valid {
+		expires := row.ExpiresAt.Time
+		c.ExpiresAt = &expires
+	}
+	if row.RevokedAt.Valid {
+		revoked := row.RevokedAt.Time
+		c.RevokedAt = &revoked
+	}
+	c.RecordedByName, _ = row.RecordedByName.(string)
+	return c
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+type DepartmentRepository struct {
+	q *queries.Queries
+}
+
+func NewDepartmentRepository(q *queries.Queries) *DepartmentRepository {
+	return &DepartmentRepository{q: q}
+}
+
+func (r *DepartmentRepository) Create(ctx context.Context, req *domain.SaveDepartmentRequest) (*domain.Department, error) {
+	d, err := r.q.CreateDepartment(ctx, queries.CreateDepartmentParams{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, translateConstraint(err, "department")
+	}
+	return toDomainDepartment(d), nil
+}
+
+func (r *DepartmentRepository) Get(ctx context.Context, id int32) (*domain.Department, error) {
+	d, err := r.q.GetDepartment(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: department %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainDepartment(d), nil
+}
+
+func (r *DepartmentRepository) List(ctx context.Context) ([]domain.Department, error) {
+	rows, err := r.q.ListDepartments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Department, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainDepartment(row))
+	}
+	return result, nil
+}
+
+func (r *DepartmentRepository) Update(ctx context.Context, id int32, req *domain.SaveDepartmentRequest) (*domain.Department, error) {
+	d, err := r.q.UpdateDepartment(ctx, queries.UpdateDepartmentParams{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: department %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, translateConstraint(err, "department")
+	}
+	return toDomainDepartment(d), nil
+}
+
+// Delete removes a department. Its rooms, equipment, specialties and
+// appointments are kept without a department.
+func (r *DepartmentRepository) Delete(ctx context.Context, id int32) error {
+	n, err := r.q.DeleteDepartment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: department %d", domain.ErrNotFound, id)
+	}
+	return nil
+}
+
+func (r *DepartmentRepository) AddDoctor(ctx context.Context, departmentID, doctorID int32) error {
+	err := r.q.AddDepartmentDoctor(ctx, queries.AddDepartmentDoctorParams{
+		DepartmentID: departmentID,
+		DoctorID:     doctorID,
+	})
+	return translateConstraint(err, "department")
+}
+
+func (r *DepartmentRepository) RemoveDoctor(ctx context.Context, departmentID, doctorID int32) error {
+	n, err := r.q.RemoveDepartmentDoctor(ctx, queries.RemoveDepartmentDoctorParams{
+		DepartmentID: departmentID,
+		DoctorID:     doctorID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: doctor %d is not in department %d", domain.ErrNotFound, doctorID, departmentID)
+	}
+	return nil
+}
+
+// HasDoctor reports whether the doctor works in the department.
+func (r *DepartmentRepository) HasDoctor(ctx context.Context, departmentID, doctorID int32) (bool, error) {
+	return r.q.IsDepartmentDoctor(ctx, queries.IsDepartmentDoctorParams{
+		DepartmentID: departmentID,
+		DoctorID:     doctorID,
+	})
+}
+
+func (r *DepartmentRepository) ListDoctors(ctx context.Context, departmentID int32) ([]domain.DoctorProfile, error) {
+	rows, err := r.q.ListDepartmentDoctors(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.DoctorProfile, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.DoctorProfile{
+			DoctorID:  row.ID,
+			Name:      row.FirstName + " " + row.LastName,
+			Specialty: row.Specialty,
+		})
+	}
+	return result, nil
+}
+
+func (r *DepartmentRepository) CreateSpecialty(ctx context.Context, req *domain.CreateSpecialtyRequest) (*domain.Specialty, error) {
+	s, err := r.q.CreateSpecialty(ctx, queries.CreateSpecialtyParams{
+		Name:         req.Name,
+		DepartmentID: req.DepartmentID,
+	})
+	if err != nil {
+		return nil, translateConstraint(err, "specialty")
+	}
+	return toDomainSpecialty(s), nil
+}
+
+func (r *DepartmentRepository) ListSpecialties(ctx context.Context, departmentID *int32) ([]domain.Specialty, error) {
+	rows, err := r.q.ListSpecialties(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Specialty, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainSpecialty(row))
+	}
+	return result, nil
+}
+
+func (r *DepartmentRepository) DeleteSpecialty(ctx context.Context, id int32) error {
+	n, err := r.q.DeleteSpecialty(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: specialty %d", domain.ErrNotFound, id)
+	}
+	return nil
+}
+
+func (r *DepartmentRepository) AddDoctorSpecialty(ctx context.Context, doctorID, specialtyID int32) error {
+	err := r.q.AddDoctorSpecialty(ctx, queries.AddDoctorSpecialtyParams{
+		DoctorID:    doctorID,
+		SpecialtyID: specialtyID,
+	})
+	return translateConstraint(err, "specialty")
+}
+
+func (r *DepartmentRepository) RemoveDoctorSpecialty(ctx context.Context, doctorID, specialtyID int32) error {
+	n, err := r.q.RemoveDoctorSpecialty(ctx, queries.RemoveDoctorSpecialtyParams{
+		DoctorID:    doctorID,
+		SpecialtyID: specialtyID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: doctor %d does not have specialty %d", domain.ErrNotFound, doctorID, specialtyID)
+	}
+	return nil
+}
+
+func (r *DepartmentRepository) ListDoctorSpecialties(ctx context.Context, doctorID int32) ([]domain.Specialty, error) {
+	rows, err := r.q.ListDoctorSpecialties(ctx, doctorID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Specialty, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainSpecialty(row))
+	}
+	return result, nil
+}
+
+func toDomainDepartment(d *queries.Department) *domain.Department {
+	return &domain.Department{
+		ID:          d.ID,
+		Name:        d.Name,
+		Description: d.Description,
+		CreatedAt:   d.CreatedAt.Time,
+	}
+}
+
+func toDomainSpecialty(s *queries.Specialty) *domain.Specialty {
+	return &domain.Specialty{ID: s.ID, Name: s.Name, DepartmentID: s.DepartmentID}
+}
+
+// translateConstraint turns a duplicate name into domain.ErrConflict and a
+// reference to a missing row into domain.ErrInvalid.
+func translateConstraint(err error, what string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case uniqueViolation:
+			return fmt.Errorf("%w: a %s with that name already exists", domain.ErrConflict, what)
+		case foreignKeyViolation:
+			return fmt.Errorf("%w: %s", domain.ErrInvalid, pgErr.Detail)
+		}
+	}
+	return err
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+type DiagnosisRepository struct {
+	q *queries.Queries
+}
+
+func NewDiagnosisRepository(q *queries.Queries) *DiagnosisRepository {
+	return &DiagnosisRepository{q: q}
+}
+
+// Create records d. An appointment has one primary diagnosis and records
+// each code once; either violation is domain.ErrConflict.
+func (r *DiagnosisRepository) Create(ctx context.Context, d *domain.EncounterDiagnosis) error {
+	row, err := r.q.CreateEncounterDiagnosis(ctx, queries.CreateEncounterDiagnosisParams{
+		AppointmentID: d.AppointmentID,
+		PatientID:     d.PatientID,
+		Code:          d.Code,
+		Rank:          d.Rank,
+		Status:        d.Status,
+		Notes:         d.Notes,
+		RecordedBy:    d.RecordedBy,
+	})
+	if err != nil {
+		return translateDiagnosisConflict(err, d)
+	}
+	d.ID = row.ID
+	d.CreatedAt = row.CreatedAt.Time
+	d.UpdatedAt = row.UpdatedAt.Time
+	return nil
+}
+
+func (r *DiagnosisRepository) Get(ctx context.Context, id int32) (*domain.EncounterDiagnosis, error) {
+	row, err := r.q.GetEncounterDiagnosis(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: diagnosis %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainDiagnosis(row), nil
+}
+
+// ListByAppointment returns the appointment's diagnoses, the primary one
+// first.
+func (r *DiagnosisRepository) ListByAppointment(ctx context.Context, appointmentID int32) ([]domain.EncounterDiagnosis, error) {
+	rows, err := r.q.ListAppointmentDiagnoses(ctx, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.EncounterDiagnosis, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainDiagnosis((*queries.GetEncounterDiagnosisRow)(row)))
+	}
+	return result, nil
+}
+
+// Update saves d's rank, status and notes.
+func (r *DiagnosisRepository) Update(ctx context.Context, d *domain.EncounterDiagnosis) error {
+	row, err := r.q.UpdateEncounterDiagnosis(ctx, queries.UpdateEncounterDiagnosisParams{
+		ID:     d.ID,
+		Rank:   d.Rank,
+		Status: d.Status,
+		Notes:  d.Notes,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: diagnosis %d", domain.ErrNotFound, d.ID)
+	}
+	if err != nil {
+		return translateDiagnosisConflict(err, d)
+	}
+	d.UpdatedAt = row.UpdatedAt.Time
+	return nil
+}
+
+func (r *DiagnosisRepository) Delete(ctx context.Context, id int32) error {
+	n, err := r.q.DeleteEncounterDiagnosis(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: diagnosis %d", domain.ErrNotFound, id)
+	}
+	return nil
+}
+
+// ListProblems returns the patient's problem list, most recently seen
+// first. With no status, problems last ruled out are left out.
+func (r *DiagnosisRepository) ListProblems(ctx context.Context, patientID int32, status *string) ([]domain.Problem, error) {
+	rows, err := r.q.ListPatientProblems(ctx, queries.ListPatientProblemsParams{
+		PatientID: patientID,
+		Status:    status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Problem, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.Problem{
+			Code:              row.Code,
+			Description:       row.Description,
+			Status:            row.Status,
+			FirstRecorded:     row.FirstRecorded.Time,
+			LastRecorded:      row.LastRecorded.Time,
+			LastAppointmentID: row.LastAppointmentID,
+			Encounters:        row.Encounters,
+		})
+	}
+	return result, nil
+}
+
+func translateDiagnosisConflict(err error, d *domain.EncounterDiagnosis) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		if pgErr.ConstraintName == "idx_encounter_diagnoses_primary" {
+			return fmt.Errorf("%w: appointment %d already has a primary diagnosis", domain.ErrConflict, d.AppointmentID)
+		}
+		return fmt.Errorf("%w: %s is already recorded for appointment %d", domain.ErrConflict, d.Code, d.AppointmentID)
+	}
+	return translateConstraint(err, "diagnosis")
+}
+
+func toDomainDiagnosis(row *queries.GetEncounterDiagnosisRow) *domain.EncounterDiagnosis {
+	return &domain.EncounterDiagnosis{
+		ID:            row.ID,
+		AppointmentID: row.AppointmentID,
+		PatientID:     row.PatientID,
+		Code:          row.Code,
+		Description:   row.Description,
+		Rank:          row.Rank,
+		Status:        row.Status,
+		Notes:         row.Notes,
+		RecordedBy:    row.RecordedBy,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+}
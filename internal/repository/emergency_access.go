@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+type EmergencyAccessRepository struct {
+	q    *queries.Queries
+	pool *pgxpool.Pool
+}
+
+func NewEmergencyAccessRepository(q *queries.Queries, pool *pgxpool.Pool) *EmergencyAccessRepository {
+	return &EmergencyAccessRepository{q: q, pool: pool}
+}
+
+// Create saves g and appends the audit entry that entry builds for it in
+// the same transaction, so a grant never takes effect unaudited.
+func (r *EmergencyAccessRepository) Create(ctx context.Context, g *domain.EmergencyAccessGrant, entry func(*domain.EmergencyAccessGrant) *domain.AuditEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	result, err := qtx.CreateEmergencyAccessGrant(ctx, queries.CreateEmergencyAccessGrantParams{
+		PatientID:     g.PatientID,
+		DoctorID:      g.DoctorID,
+		Justification: g.Justification,
+		ExpiresAt:     pgtype.Timestamptz{Time: g.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	created := toDomainEmergencyAccessGrant(result)
+	if err := appendAudit(ctx, tx, qtx, entry(created)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	*g = *created
+	return nil
+}
+
+// GetActive returns the unexpired grant of doctorID for patientID, or nil
+// if there is none.
+func (r *EmergencyAccessRepository) GetActive(ctx context.Context, doctorID, patientID int32) (*domain.EmergencyAccessGrant, error) {
+	g, err := r.q.GetActiveEmergencyAccessGrant(ctx, queries.GetActiveEmergencyAccessGrantParams{
+		DoctorID:  doctorID,
+		PatientID: patientID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainEmergencyAccessGrant(g), nil
+}
+
+func (r *EmergencyAccessRepository) List(ctx context.Context, reviewStatus *string, limit, offset int32) ([]domain.EmergencyAccessGrant, error) {
+	rows, err := r.q.ListEmergencyAccessGrants(ctx, queries.ListEmergencyAccessGrantsParams{
+		ReviewStatus: reviewStatus,
+		Limit:        limit,
+		Offset:       offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.EmergencyAccessGrant, 0, len(rows))
+	for _, row := range rows {
+		g := toDomainEmergencyAccessGrant(&queries.EmergencyAccessGrant{
+			ID:            row.ID,
+			PatientID:     row.PatientID,
+			DoctorID:      row.DoctorID,
+			Justification: row.Justification,
+			GrantedAt:     row.GrantedAt,
+			ExpiresAt:     row.ExpiresAt,
+			ReviewStatus:  row.ReviewStatus,
+			ReviewedBy:    row.ReviewedBy,
+			ReviewedAt:    row.ReviewedAt,
+			ReviewNotes:   row.ReviewNotes,
+		})
+		g.PatientName, _ = row.PatientName.(string)
+		g.DoctorName, _ = row.DoctorName.(string)
+		result = append(result, *g)
+	}
+	return result, nil
+}
+
+// Review records a compliance decision on a grant that is still pending.
+// It returns domain.ErrNotFound if the grant does not exist or was
+// already reviewed.
+func (r *EmergencyAccessRepository) Review(ctx context.Context, id int32, status string, reviewedBy int32, notes *string) (*domain.EmergencyAccessGrant, error) {
+	g, err := r.q.ReviewEmergencyAccessGrant(ctx, queries.ReviewEmergencyAccessGrantParams{
+		ID:           id,
+		ReviewStatus: status,
+		ReviewedBy:   &reviewedBy,
+		ReviewNotes:  notes,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Either there is no such grant or it has been reviewed already.
+		current, err := r.q.GetEmergencyAccessGrant(ctx, id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: emergency access grant %d", domain.ErrNotFound, id)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: emergency access grant %d was already reviewed (%s)", domain.ErrConflict, id, current.ReviewStatus)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainEmergencyAccessGrant(g), nil
+}
+
+func toDomainEmergencyAccessGrant(g *queries.EmergencyAccessGrant) *domain.EmergencyAccessGrant {
+	grant := &domain.EmergencyAccessGrant{
+		ID:            g.ID,
+		PatientID:     g.PatientID,
+		DoctorID:      g.DoctorID,
+		Justification: g.Justification,
+		GrantedAt:     g.GrantedAt.Time,
+		ExpiresAt:     g.ExpiresAt.Time,
+		ReviewStatus:  g.ReviewStatus,
+		ReviewedBy:    g.ReviewedBy,
+		ReviewNotes:   g.ReviewNotes,
+	}
+	if g.ReviewedAt.Valid {
+		t := g.ReviewedAt.Time
+		grant.ReviewedAt = &t
+	}
+	return grant
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/fieldcrypt"
+)
+
+// EncryptionKeyRepository stores the wrapped data keys used by
+// fieldcrypt.Keyring.
+type EncryptionKeyRepository struct {
+	db      *pgxpool.Pool
+	queries *queries.Queries
+}
+
+var _ fieldcrypt.KeyStore = (*EncryptionKeyRepository)(nil)
+
+func NewEncryptionKeyRepository(pool *pgxpool.Pool) *EncryptionKeyRepository {
+	return &EncryptionKeyRepository{
+		db:      pool,
+		queries: queries.New(pool),
+	}
+}
+
+func (r *EncryptionKeyRepository) ListKeys(ctx context.Context) ([]fieldcrypt.WrappedKey, error) {
+	rows, err := r.queries.ListEncryptionKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]fieldcrypt.WrappedKey, 0, len(rows))
+	for _, k := range rows {
+		keys = append(keys, fieldcrypt.WrappedKey{
+			ID:          k.ID,
+			Wrapped:     k.WrappedKey,
+			MasterKeyID: k.MasterKeyID,
+			Retired:     k.RetiredAt.Valid,
+		})
+	}
+	return keys, nil
+}
+
+// ActiveKeyID returns the id of the newest data key that is not retired,
+// or 0 if every key is.
+func (r *EncryptionKeyRepository) ActiveKeyID(ctx context.Context) (int32, error) {
+	id, err := r.queries.GetActiveEncryptionKeyID(ctx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	return id, err
+}
+
+func (r *EncryptionKeyRepository) CreateKey(ctx context.Context, wrapped []byte, masterKeyID string) (int32, error) {
+	k, err := r.queries.CreateEncryptionKey(ctx, queries.CreateEncryptionKeyParams{
+		WrappedKey:  wrapped,
+		MasterKeyID: masterKeyID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return k.ID, nil
+}
+
+func (r *EncryptionKeyRepository) Rewrap(ctx context.Context, id int32, wrapped []byte, masterKeyID string) error {
+	return r.queries.RewrapEncryptionKey(ctx, queries.RewrapEncryptionKeyParams{
+		ID:          id,
+		WrappedKey:  wrapped,
+		MasterKeyID: masterKeyID,
+	})
+}
+
+// RetireAllExcept marks every data key other than id as retired; retired
+// keys can still decrypt but are never used for new values.
+func (r *EncryptionKeyRepository) RetireAllExcept(ctx context.Context, id int32) error {
+	return r.queries.RetireEncryptionKeysExcept(ctx, id)
+}
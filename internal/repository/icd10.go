@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+// importBatchSize is how many codes go into one INSERT during an import.
+const importBatchSize = 5000
+
+type Icd10Repository struct {
+	q    *queries.Queries
+	pool *pgxpool.Pool
+}
+
+func NewIcd10Repository(q *queries.Queries, pool *pgxpool.Pool) *Icd10Repository {
+	return &Icd10Repository{q: q, pool: pool}
+}
+
+// Import makes codes the active catalog in one transaction. Codes already
+// known are updated; those missing from codes are deactivated rather than
+// deleted, since diagnoses may refer to them.
+func (r *Icd10Repository) Import(ctx context.Context, codes []domain.Icd10Code) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	if _, err := qtx.DeactivateIcd10Codes(ctx); err != nil {
+		return err
+	}
+
+	for start := 0; start < len(codes); start += importBatchSize {
+		batch := codes[start:min(start+importBatchSize, len(codes))]
+		arg := queries.UpsertIcd10CodesParams{
+			Codes:        make([]string, len(batch)),
+			Descriptions: make([]string, len(batch)),
+			Billable:     make([]bool, len(batch)),
+		}
+		for i, c := range batch {
+			arg.Codes[i] = c.Code
+			arg.Descriptions[i] = c.Description
+			arg.Billable[i] = c.Billable
+		}
+		if _, err := qtx.UpsertIcd10Codes(ctx, arg); err != nil {
+			return fmt.Errorf("importing codes %s to %s: %w", batch[0].Code, batch[len(batch)-1].Code, err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *Icd10Repository) Get(ctx context.Context, code string) (*domain.Icd10Code, error) {
+	row, err := r.q.GetIcd10Code(ctx, code)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: ICD-10 code %s", domain.ErrNotFound, code)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainIcd10Code(row), nil
+}
+
+// SearchByPrefix returns active codes starting with prefix, in code order.
+func (r *Icd10Repository) SearchByPrefix(ctx context.Context, prefix string, billable *bool, limit int32) ([]domain.Icd10Code, error) {
+	rows, err := r.q.SearchIcd10CodesByPrefix(ctx, queries.SearchIcd10CodesByPrefixParams{
+		Prefix:     prefix,
+		Billable:   billable,
+		MaxResults: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Icd10Code, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainIcd10Code((*queries.GetIcd10CodeRow)(row)))
+	}
+	return result, nil
+}
+
+// SearchByText returns active codes whose description matches the
+// tsquery, best matches first.
+func (r *Icd10Repository) SearchByText(ctx context.Context, tsquery string, billable *bool, limit int32) ([]domain.Icd10Code, error) {
+	rows, err := r.q.SearchIcd10CodesByText(ctx, queries.SearchIcd10CodesByTextParams{
+		Query:      tsquery,
+		Billable:   billable,
+		MaxResults: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Icd10Code, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainIcd10Code((*queries.GetIcd10CodeRow)(row)))
+	}
+	return result, nil
+}
+
+func toDomainIcd10Code(row *queries.GetIcd10CodeRow) *domain.Icd10Code {
+	return &domain.Icd10Code{
+		Code:        row.Code,
+		Description: row.Description,
+		Billable:    row.Billable,
+		Active:      row.Active,
+	}
+}
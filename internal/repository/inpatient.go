@@ -0,0 +1,528 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+// InpatientRepository keeps wards, beds and admissions. Admitting,
+// transferring and discharging each run in one transaction that takes the
+// bed with a conditional update, so two admissions racing for a bed
+// cannot both get it.
+type InpatientRepository struct {
+	q    *queries.Queries
+	pool *pgxpool.Pool
+}
+
+func NewInpatientRepository(q *queries.Queries, pool *pgxpool.Pool) *InpatientRepository {
+	return &InpatientRepository{q: q, pool: pool}
+}
+
+func (r *InpatientRepository) CreateWard(ctx context.Context, w *domain.Ward) error {
+	row, err := r.q.CreateWard(ctx, queries.CreateWardParams{
+		Name:         w.Name,
+		DepartmentID: w.DepartmentID,
+		Active:       w.Active,
+	})
+	if err != nil {
+		return translateConstraint(err, "ward")
+	}
+	*w = *toDomainWard(row)
+	return nil
+}
+
+func (r *InpatientRepository) UpdateWard(ctx context.Context, w *domain.Ward) error {
+	row, err := r.q.UpdateWard(ctx, queries.UpdateWardParams{
+		ID:           w.ID,
+		Name:         w.Name,
+		DepartmentID: w.DepartmentID,
+		Active:       w.Active,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: ward %d", domain.ErrNotFound, w.ID)
+	}
+	if err != nil {
+		return translateConstraint(err, "ward")
+	}
+	*w = *toDomainWard(row)
+	return nil
+}
+
+func (r *InpatientRepository) GetWard(ctx context.Context, id int32) (*domain.Ward, error) {
+	row, err := r.q.GetWard(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: ward %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainWard(row), nil
+}
+
+func (r *InpatientRepository) ListWards(ctx context.Context, active *bool) ([]domain.Ward, error) {
+	rows, err := r.q.ListWards(ctx, active)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Ward, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainWard(row))
+	}
+	return result, nil
+}
+
+func (r *InpatientRepository) CreateBed(ctx context.Context, b *domain.Bed) error {
+	row, err := r.q.CreateBed(ctx, queries.CreateBedParams{WardID: b.WardID, Label: b.Label})
+	if err != nil {
+		return translateBedLabel(err, b.Label)
+	}
+	*b = *toDomainBed(row)
+	return nil
+}
+
+func (r *InpatientRepository) RenameBed(ctx context.Context, id int32, label string) (*domain.Bed, error) {
+	row, err := r.q.RenameBed(ctx, queries.RenameBedParams{ID: id, Label: label})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: bed %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, translateBedLabel(err, label)
+	}
+	return toDomainBed(row), nil
+}
+
+func (r *InpatientRepository) GetBed(ctx context.Context, id int32) (*domain.Bed, error) {
+	row, err := r.q.GetBed(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: bed %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainBed(row), nil
+}
+
+// ListWardBeds returns the ward's beds in label order.
+func (r *InpatientRepository) ListWardBeds(ctx context.Context, wardID int32) ([]domain.Bed, error) {
+	rows, err := r.q.ListWardBeds(ctx, wardID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Bed, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainBed(row))
+	}
+	return result, nil
+}
+
+// SetBedStatus sets a bed that is not occupied to free, cleaning or
+// blocked.
+func (r *InpatientRepository) SetBedStatus(ctx context.Context, id int32, status string, note *string) (*domain.Bed, error) {
+	row, err := r.q.SetBedStatus(ctx, queries.SetBedStatusParams{Status: status, StatusNote: note, ID: id})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: bed %d is occupied", domain.ErrConflict, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainBed(row), nil
+}
+
+// Admit opens admission a with the patient in bedID, and puts the
+// attending doctor on the patient's care team. It fails with
+// domain.ErrConflict if the bed is not free or the patient is already
+// admitted.
+func (r *InpatientRepository) Admit(ctx context.Context, a *domain.Admission, bedID int32) (*domain.CareTeamMember, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	row, err := qtx.CreateAdmission(ctx, queries.CreateAdmissionParams{
+		PatientID:         a.PatientID,
+		AttendingDoctorID: a.AttendingDoctorID,
+		AppointmentID:     a.AppointmentID,
+		Reason:            a.Reason,
+		AdmittedBy:        a.AdmittedBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, fmt.Errorf("%w: patient %d is already admitted", domain.ErrConflict, a.PatientID)
+		}
+		return nil, translateConstraint(err, "admission")
+	}
+	if err := occupyBed(ctx, qtx, row.ID, bedID, nil, a.AdmittedBy); err != nil {
+		return nil, err
+	}
+	m, err := qtx.AddCareTeamMember(ctx, queries.AddCareTeamMemberParams{
+		PatientID: row.PatientID,
+		DoctorID:  row.AttendingDoctorID,
+		Source:    domain.CareTeamSourceAssignment,
+		AddedBy:   a.AdmittedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	a.ID = row.ID
+	return &domain.CareTeamMember{
+		PatientID: m.PatientID,
+		DoctorID:  m.DoctorID,
+		Source:    m.Source,
+		AddedBy:   m.AddedBy,
+		Since:     m.CreatedAt.Time,
+	}, nil
+}
+
+// Transfer moves an admitted patient to bedID, leaving their bed to be
+// cleaned. With attendingDoctorID the patient is also handed to that
+// doctor, who joins the care team; the returned member is then set.
+func (r *InpatientRepository) Transfer(ctx context.Context, id, bedID int32, attendingDoctorID *int32, reason *string, by *int32) (*domain.CareTeamMember, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	a, err := lockAdmission(ctx, qtx, id)
+	if err != nil {
+		return nil, err
+	}
+	stay, err := qtx.EndBedStay(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	if stay.BedID == bedID {
+		return nil, fmt.Errorf("%w: the patient is already in bed %d", domain.ErrInvalid, bedID)
+	}
+	if err := qtx.VacateBed(ctx, stay.BedID); err != nil {
+		return nil, err
+	}
+	if err := occupyBed(ctx, qtx, a.ID, bedID, reason, by); err != nil {
+		return nil, err
+	}
+
+	var member *domain.CareTeamMember
+	if attendingDoctorID != nil && *attendingDoctorID != a.AttendingDoctorID {
+		if err := qtx.SetAttendingDoctor(ctx, queries.SetAttendingDoctorParams{ID: a.ID, AttendingDoctorID: *attendingDoctorID}); err != nil {
+			return nil, translateConstraint(err, "admission")
+		}
+		m, err := qtx.AddCareTeamMember(ctx, queries.AddCareTeamMemberParams{
+			PatientID: a.PatientID,
+			DoctorID:  *attendingDoctorID,
+			Source:    domain.CareTeamSourceAssignment,
+			AddedBy:   by,
+		})
+		if err != nil {
+			return nil, err
+		}
+		member = &domain.CareTeamMember{
+			PatientID: m.PatientID,
+			DoctorID:  m.DoctorID,
+			Source:    m.Source,
+			AddedBy:   m.AddedBy,
+			Since:     m.CreatedAt.Time,
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// Discharge closes an admission and leaves the patient's bed to be
+// cleaned.
+func (r *InpatientRepository) Discharge(ctx context.Context, id int32, disposition string, notes *string, by *int32) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	a, err := lockAdmission(ctx, qtx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := qtx.DischargeAdmission(ctx, queries.DischargeAdmissionParams{
+		ID:                   a.ID,
+		DischargedBy:         by,
+		DischargeDisposition: &disposition,
+		DischargeNotes:       notes,
+	}); err != nil {
+		return err
+	}
+	stay, err := qtx.EndBedStay(ctx, a.ID)
+	if err != nil {
+		return err
+	}
+	if err := qtx.VacateBed(ctx, stay.BedID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *InpatientRepository) GetAdmission(ctx context.Context, id int32) (*domain.Admission, error) {
+	row, err := r.q.GetAdmission(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: admission %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainAdmission(row), nil
+}
+
+// GetAdmissionDetail returns the admission with every bed the patient
+// has been in.
+func (r *InpatientRepository) GetAdmissionDetail(ctx context.Context, id int32) (*domain.Admission, error) {
+	a, err := r.GetAdmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.q.ListAdmissionStays(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Stays = make([]domain.BedStay, 0, len(rows))
+	for _, row := range rows {
+		s := domain.BedStay{
+			ID:          row.ID,
+			AdmissionID: row.AdmissionID,
+			BedID:       row.BedID,
+			BedLabel:    row.BedLabel,
+			WardID:      row.WardID,
+			WardName:    row.WardName,
+			StartedAt:   row.StartedAt.Time,
+			Reason:      row.Reason,
+			AssignedBy:  row.AssignedBy,
+		}
+		if row.EndedAt.Valid {
+			ended := row.EndedAt.Time
+			s.EndedAt = &ended
+		}
+		a.Stays = append(a.Stays, s)
+	}
+	return a, nil
+}
+
+// ListAdmissions returns the admissions matching filter, latest first.
+func (r *InpatientRepository) ListAdmissions(ctx context.Context, filter domain.AdmissionFilter) ([]domain.Admission, error) {
+	rows, err := r.q.ListAdmissions(ctx, queries.ListAdmissionsParams{
+		Status:       filter.Status,
+		WardID:       filter.WardID,
+		PatientID:    filter.PatientID,
+		CareDoctorID: filter.CareDoctorID,
+		Limit:        filter.Limit,
+		Offset:       filter.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Admission, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainAdmission((*queries.GetAdmissionRow)(row)))
+	}
+	return result, nil
+}
+
+// Board returns the beds of every active ward, or of one, with their
+// occupants.
+func (r *InpatientRepository) Board(ctx context.Context, wardID *int32) ([]domain.WardBoard, error) {
+	rows, err := r.q.ListBoardBeds(ctx, wardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var boards []domain.WardBoard
+	for _, row := range rows {
+		if len(boards) == 0 || boards[len(boards)-1].WardID != row.WardID {
+			boards = append(boards, domain.WardBoard{WardID: row.WardID, WardName: row.WardName})
+		}
+		bed := domain.BoardBed{
+			BedID:           row.ID,
+			Label:           row.Label,
+			Status:          row.Status,
+			StatusNote:      row.StatusNote,
+			StatusChangedAt: row.StatusChangedAt.Time,
+		}
+		if row.AdmissionID != nil {
+			bed.Occupant = &domain.BedOccupant{
+				AdmissionID:       *row.AdmissionID,
+				PatientID:         *row.PatientID,
+				AttendingDoctorID: *row.AttendingDoctorID,
+				AdmittedAt:        row.AdmittedAt.Time,
+				InBedSince:        row.InBedSince.Time,
+			}
+			bed.Occupant.PatientName, _ = row.PatientName.(string)
+		}
+		board := &boards[len(boards)-1]
+		board.Beds = append(board.Beds, bed)
+	}
+
+	for i := range boards {
+		statuses := make([]string, len(boards[i].Beds))
+		for j, bed := range boards[i].Beds {
+			statuses[j] = bed.Status
+		}
+		boards[i].Counts = domain.CountBeds(statuses)
+	}
+	return boards, nil
+}
+
+// Census returns each active ward's census at until and its movements
+// since since.
+func (r *InpatientRepository) Census(ctx context.Context, since, until time.Time) ([]domain.WardCensus, error) {
+	rows, err := r.q.WardCensus(ctx, queries.WardCensusParams{
+		Since: pgtype.Timestamptz{Time: since, Valid: true},
+		Until: pgtype.Timestamptz{Time: until, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.WardCensus, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.WardCensus{
+			WardID:           row.WardID,
+			WardName:         row.WardName,
+			Beds:             row.Beds,
+			Census:           row.Census,
+			OccupancyPercent: domain.OccupancyPercent(row.Census, row.Beds),
+			Admissions:       row.Admissions,
+			TransfersIn:      row.TransfersIn,
+			TransfersOut:     row.TransfersOut,
+			Discharges:       row.Discharges,
+		})
+	}
+	return result, nil
+}
+
+// lockAdmission locks an open admission for the rest of the transaction.
+func lockAdmission(ctx context.Context, qtx *queries.Queries, id int32) (*queries.Admission, error) {
+	a, err := qtx.LockAdmission(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: admission %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != domain.AdmissionAdmitted {
+		return nil, fmt.Errorf("%w: admission %d is %s", domain.ErrConflict, a.ID, a.Status)
+	}
+	return a, nil
+}
+
+// occupyBed puts admission admissionID in bedID if the bed is free and its
+// ward is active.
+func occupyBed(ctx context.Context, qtx *queries.Queries, admissionID, bedID int32, reason *string, by *int32) error {
+	n, err := qtx.OccupyBed(ctx, bedID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		bed, err := qtx.GetBed(ctx, bedID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: bed %d", domain.ErrNotFound, bedID)
+		}
+		if err != nil {
+			return err
+		}
+		if bed.Status == domain.BedFree {
+			return fmt.Errorf("%w: bed %s is in an inactive ward", domain.ErrConflict, bed.Label)
+		}
+		return fmt.Errorf("%w: bed %s is %s", domain.ErrConflict, bed.Label, bed.Status)
+	}
+
+	_, err = qtx.CreateBedStay(ctx, queries.CreateBedStayParams{
+		AdmissionID: admissionID,
+		BedID:       bedID,
+		Reason:      reason,
+		AssignedBy:  by,
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return fmt.Errorf("%w: bed %d is taken", domain.ErrConflict, bedID)
+	}
+	return err
+}
+
+func translateBedLabel(err error, label string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return fmt.Errorf("%w: the ward already has a bed %s", domain.ErrConflict, label)
+	}
+	return translateConstraint(err, "bed")
+}
+
+func toDomainWard(row *queries.Ward) *domain.Ward {
+	return &domain.Ward{
+		ID:           row.ID,
+		Name:         row.Name,
+		DepartmentID: row.DepartmentID,
+		Active:       row.Active,
+		CreatedAt:    row.CreatedAt.Time,
+		UpdatedAt:    row.UpdatedAt.Time,
+	}
+}
+
+func toDomainBed(row *queries.Bed) *domain.Bed {
+	return &domain.Bed{
+		ID:              row.ID,
+		WardID:          row.WardID,
+		Label:           row.Label,
+		Status:          row.Status,
+		StatusNote:      row.StatusNote,
+		StatusChangedAt: row.StatusChangedAt.Time,
+	}
+}
+
+func toDomainAdmission(row *queries.GetAdmissionRow) *domain.Admission {
+	a := &domain.Admission{
+		ID:                   row.ID,
+		PatientID:            row.PatientID,
+		AttendingDoctorID:    row.AttendingDoctorID,
+		AppointmentID:        row.AppointmentID,
+		Reason:               row.Reason,
+		Status:               row.Status,
+		BedID:                row.BedID,
+		BedLabel:             row.BedLabel,
+		WardID:               row.WardID,
+		WardName:             row.WardName,
+		AdmittedAt:           row.AdmittedAt.Time,
+		AdmittedBy:           row.AdmittedBy,
+		DischargedBy:         row.DischargedBy,
+		DischargeDisposition: row.DischargeDisposition,
+		DischargeNotes:       row.DischargeNotes,
+		CreatedAt:            row.CreatedAt.Time,
+		UpdatedAt:            row.UpdatedAt.Time,
+	}
+	a.PatientName, _ = row.PatientName.(string)
+	a.AttendingDoctorName, _ = row.AttendingDoctorName.(string)
+	if row.DischargedAt.Valid {
+		discharged := row.DischargedAt.Time
+		a.DischargedAt = &discharged
+	}
+	return a
+}
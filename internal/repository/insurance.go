@@ -0,0 +1,579 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/prem0x01/hospital/internal/billing"
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+// InsuranceRepository keeps payers, patients' coverage and the claims
+// made against it. Claims lock their invoice while they are priced or
+// paid, as billing does.
+type InsuranceRepository struct {
+	q    *queries.Queries
+	pool *pgxpool.Pool
+}
+
+func NewInsuranceRepository(q *queries.Queries, pool *pgxpool.Pool) *InsuranceRepository {
+	return &InsuranceRepository{q: q, pool: pool}
+}
+
+func (r *InsuranceRepository) CreatePayer(ctx context.Context, p *domain.InsurancePayer) error {
+	row, err := r.q.CreateInsurancePayer(ctx, queries.CreateInsurancePayerParams{
+		Name:      p.Name,
+		PayerCode: p.PayerCode,
+		Phone:     p.Phone,
+		Email:     p.Email,
+		Active:    p.Active,
+	})
+	if err != nil {
+		return translatePayer(err)
+	}
+	*p = *toDomainPayer(row)
+	return nil
+}
+
+func (r *InsuranceRepository) UpdatePayer(ctx context.Context, p *domain.InsurancePayer) error {
+	row, err := r.q.UpdateInsurancePayer(ctx, queries.UpdateInsurancePayerParams{
+		ID:        p.ID,
+		Name:      p.Name,
+		PayerCode: p.PayerCode,
+		Phone:     p.Phone,
+		Email:     p.Email,
+		Active:    p.Active,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: insurance payer %d", domain.ErrNotFound, p.ID)
+	}
+	if err != nil {
+		return translatePayer(err)
+	}
+	*p = *toDomainPayer(row)
+	return nil
+}
+
+func (r *InsuranceRepository) GetPayer(ctx context.Context, id int32) (*domain.InsurancePayer, error) {
+	row, err := r.q.GetInsurancePayer(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: insurance payer %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainPayer(row), nil
+}
+
+func (r *InsuranceRepository) ListPayers(ctx context.Context, active *bool) ([]domain.InsurancePayer, error) {
+	rows, err := r.q.ListInsurancePayers(ctx, active)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.InsurancePayer, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainPayer(row))
+	}
+	return result, nil
+}
+
+// CreateCoverage saves c. A patient cannot hold two policies of the same
+// priority on the same day.
+func (r *InsuranceRepository) CreateCoverage(ctx context.Context, c *domain.Coverage) error {
+	row, err := r.q.CreatePatientCoverage(ctx, queries.CreatePatientCoverageParams{
+		PatientID:      c.PatientID,
+		PayerID:        c.PayerID,
+		Priority:       c.Priority,
+		PolicyNumber:   c.PolicyNumber,
+		MemberNumber:   c.MemberNumber,
+		GroupNumber:    c.GroupNumber,
+		SubscriberName: c.SubscriberName,
+		Relationship:   c.Relationship,
+		ValidFrom:      c.ValidFrom,
+		ValidTo:        c.ValidTo,
+		CopayMinor:     c.CopayMinor,
+		CoinsuranceBp:  c.CoinsuranceBP,
+		CreatedBy:      c.CreatedBy,
+	})
+	if err != nil {
+		return translateCoverage(err, c.Priority)
+	}
+	saved, err := r.GetCoverage(ctx, row.ID)
+	if err != nil {
+		return err
+	}
+	*c = *saved
+	return nil
+}
+
+// UpdateCoverage replaces the policy c.ID with c. Claims already made
+// keep the copay and coinsurance they were priced with.
+func (r *InsuranceRepository) UpdateCoverage(ctx context.Context, c *domain.Coverage) error {
+	_, err := r.q.UpdatePatientCoverage(ctx, queries.UpdatePatientCoverageParams{
+		ID:             c.ID,
+		PayerID:        c.PayerID,
+		Priority:       c.Priority,
+		PolicyNumber:   c.PolicyNumber,
+		MemberNumber:   c.MemberNumber,
+		GroupNumber:    c.GroupNumber,
+		SubscriberName: c.SubscriberName,
+		Relationship:   c.Relationship,
+		ValidFrom:      c.ValidFrom,
+		ValidTo:        c.ValidTo,
+		CopayMinor:     c.CopayMinor,
+		CoinsuranceBp:  c.CoinsuranceBP,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: coverage %d", domain.ErrNotFound, c.ID)
+	}
+	if err != nil {
+		return translateCoverage(err, c.Priority)
+	}
+	saved, err := r.GetCoverage(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	*c = *saved
+	return nil
+}
+
+func (r *InsuranceRepository) GetCoverage(ctx context.Context, id int32) (*domain.Coverage, error) {
+	row, err := r.q.GetPatientCoverage(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: coverage %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainCoverage(row), nil
+}
+
+// ListCoverages returns the patient's policies, those still in force
+// first.
+func (r *InsuranceRepository) ListCoverages(ctx context.Context, patientID int32) ([]domain.Coverage, error) {
+	rows, err := r.q.ListPatientCoverages(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Coverage, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainCoverage((*queries.GetPatientCoverageRow)(row)))
+	}
+	return result, nil
+}
+
+// CoveragesInForce returns the patient's policies covering day with an
+// active payer, primary first.
+func (r *InsuranceRepository) CoveragesInForce(ctx context.Context, patientID int32, day time.Time) ([]domain.Coverage, error) {
+	rows, err := r.q.ListCoveragesInForce(ctx, queries.ListCoveragesInForceParams{
+		PatientID: patientID,
+		Day:       pgtype.Date{Time: day, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Coverage, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainCoverage((*queries.GetPatientCoverageRow)(row)))
+	}
+	return result, nil
+}
+
+// DeleteCoverage removes a policy no claim has been made against.
+func (r *InsuranceRepository) DeleteCoverage(ctx context.Context, id int32) error {
+	n, err := r.q.DeletePatientCoverage(ctx, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return fmt.Errorf("%w: coverage %d has claims; end it with valid_to instead", domain.ErrConflict, id)
+		}
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: coverage %d", domain.ErrNotFound, id)
+	}
+	return nil
+}
+
+// CreateClaim drafts a claim of c.InvoiceID against c.CoverageID, using
+// the copay and coinsurance set on c. It bills the invoice total less
+// what the invoice's other claims expect their payers to pay, and
+// returns the saved claim.
+func (r *InsuranceRepository) CreateClaim(ctx context.Context, c *domain.Claim) (*domain.Claim, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	inv, err := lockInvoice(ctx, qtx, c.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status != domain.InvoiceIssued && inv.Status != domain.InvoicePartiallyPaid {
+		return nil, fmt.Errorf("%w: invoice %d is %s; only issued invoices with a balance can be claimed", domain.ErrConflict, inv.ID, inv.Status)
+	}
+	expected, err := qtx.GetExpectedPayerShare(ctx, inv.ID)
+	if err != nil {
+		return nil, err
+	}
+	billed := inv.TotalMinor - expected
+	if billed <= 0 {
+		return nil, fmt.Errorf("%w: invoice %d is already fully claimed", domain.ErrConflict, inv.ID)
+	}
+	payerShare, patientShare, err := billing.Share(billed, c.CopayMinor, c.CoinsuranceBP)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+
+	row, err := qtx.CreateClaim(ctx, queries.CreateClaimParams{
+		InvoiceID:         inv.ID,
+		PatientID:         inv.PatientID,
+		CoverageID:        c.CoverageID,
+		PayerID:           c.PayerID,
+		ServiceDate:       c.ServiceDate,
+		BilledMinor:       billed,
+		CopayMinor:        c.CopayMinor,
+		CoinsuranceBp:     c.CoinsuranceBP,
+		PayerShareMinor:   payerShare,
+		PatientShareMinor: patientShare,
+		CreatedBy:         c.CreatedBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, fmt.Errorf("%w: invoice %d already has an open claim against coverage %d", domain.ErrConflict, inv.ID, c.CoverageID)
+		}
+		return nil, translateConstraint(err, "claim")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return r.GetClaim(ctx, row.ID)
+}
+
+func (r *InsuranceRepository) GetClaim(ctx context.Context, id int32) (*domain.Claim, error) {
+	row, err := r.q.GetClaim(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: claim %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainClaim(row), nil
+}
+
+// ListClaims returns the claims matching filter, newest first.
+func (r *InsuranceRepository) ListClaims(ctx context.Context, filter domain.ClaimFilter) ([]domain.Claim, error) {
+	rows, err := r.q.ListClaims(ctx, queries.ListClaimsParams{
+		Status:    filter.Status,
+		PayerID:   filter.PayerID,
+		InvoiceID: filter.InvoiceID,
+		PatientID: filter.PatientID,
+		BatchID:   filter.BatchID,
+		Limit:     filter.Limit,
+		Offset:    filter.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Claim, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainClaim((*queries.GetClaimRow)(row)))
+	}
+	return result, nil
+}
+
+// ClaimLines returns the invoice's lines with their catalog codes, as
+// they are claimed.
+func (r *InsuranceRepository) ClaimLines(ctx context.Context, invoiceID int32) ([]domain.InvoiceLine, error) {
+	rows, err := r.q.ListClaimLines(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.InvoiceLine, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.InvoiceLine{
+			ID:             row.ID,
+			InvoiceID:      invoiceID,
+			ServiceCode:    row.ServiceCode,
+			Description:    row.Description,
+			Quantity:       row.Quantity,
+			UnitPriceMinor: row.UnitPriceMinor,
+			TotalMinor:     row.TotalMinor,
+		})
+	}
+	return result, nil
+}
+
+// Adjudicate records the payer's decision on a submitted claim: status is
+// adjudicated with the share it will pay, or denied.
+func (r *InsuranceRepository) Adjudicate(ctx context.Context, id int32, status string, payerShare int64, reference, denialReason *string) error {
+	_, err := r.q.AdjudicateClaim(ctx, queries.AdjudicateClaimParams{
+		Status:          status,
+		PayerShareMinor: payerShare,
+		PayerReference:  reference,
+		DenialReason:    denialReason,
+		ID:              id,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: claim %d is no longer awaiting adjudication", domain.ErrConflict, id)
+	}
+	return err
+}
+
+func (r *InsuranceRepository) Cancel(ctx context.Context, id int32) error {
+	_, err := r.q.CancelClaim(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: claim %d can no longer be cancelled", domain.ErrConflict, id)
+	}
+	return err
+}
+
+// Pay records the payer's payment of an adjudicated claim as an insurance
+// payment p on its invoice, closing the claim. The payment may not exceed
+// the payer's share or the invoice's balance.
+func (r *InsuranceRepository) Pay(ctx context.Context, claimID int32, p *domain.Payment) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	claim, err := qtx.LockClaim(ctx, claimID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: claim %d", domain.ErrNotFound, claimID)
+	}
+	if err != nil {
+		return err
+	}
+	if err := domain.CheckClaimTransition(claim.Status, domain.ClaimPaid); err != nil {
+		return err
+	}
+	if p.AmountMinor > claim.PayerShareMinor {
+		return fmt.Errorf("%w: the payer agreed to pay %d on claim %d", domain.ErrInvalid, claim.PayerShareMinor, claim.ID)
+	}
+
+	inv, err := lockInvoice(ctx, qtx, claim.InvoiceID)
+	if err != nil {
+		return err
+	}
+	if inv.Status != domain.InvoiceIssued && inv.Status != domain.InvoicePartiallyPaid {
+		return fmt.Errorf("%w: invoice %d is %s", domain.ErrConflict, inv.ID, inv.Status)
+	}
+	if balance := inv.TotalMinor - inv.PaidMinor; p.AmountMinor > balance {
+		return fmt.Errorf("%w: invoice %d has %d left to pay", domain.ErrInvalid, inv.ID, balance)
+	}
+
+	p.InvoiceID = inv.ID
+	p.Kind = domain.PaymentKindPayment
+	p.Method = domain.PaymentInsurance
+	if _, err := applyPayment(ctx, qtx, p, p.AmountMinor); err != nil {
+		return err
+	}
+	if _, err := qtx.PayClaim(ctx, queries.PayClaimParams{ID: claim.ID, PaidMinor: p.AmountMinor, PaymentID: &p.ID}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// SubmitBatch puts every draft claim to the payer into a new batch and
+// marks them submitted. check is called with the batch's claims before
+// it is committed; if it fails, nothing is submitted.
+func (r *InsuranceRepository) SubmitBatch(ctx context.Context, payerID int32, createdBy *int32, check func([]domain.Claim) error) (*domain.ClaimBatch, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	batch, err := qtx.CreateClaimBatch(ctx, queries.CreateClaimBatchParams{PayerID: payerID, CreatedBy: createdBy})
+	if err != nil {
+		return nil, translateConstraint(err, "claim batch")
+	}
+	n, err := qtx.SubmitDraftClaims(ctx, queries.SubmitDraftClaimsParams{BatchID: &batch.ID, PayerID: payerID})
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("%w: there are no draft claims to payer %d", domain.ErrInvalid, payerID)
+	}
+	rows, err := qtx.ListBatchClaims(ctx, &batch.ID)
+	if err != nil {
+		return nil, err
+	}
+	claims := make([]domain.Claim, 0, len(rows))
+	for _, row := range rows {
+		claims = append(claims, *toDomainClaim((*queries.GetClaimRow)(row)))
+	}
+	if err := check(claims); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return r.GetBatch(ctx, batch.ID)
+}
+
+func (r *InsuranceRepository) GetBatch(ctx context.Context, id int32) (*domain.ClaimBatch, error) {
+	row, err := r.q.GetClaimBatch(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: claim batch %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainClaimBatch(row), nil
+}
+
+// ListBatches returns batches, newest first, optionally to one payer.
+func (r *InsuranceRepository) ListBatches(ctx context.Context, payerID *int32, limit, offset int32) ([]domain.ClaimBatch, error) {
+	rows, err := r.q.ListClaimBatches(ctx, queries.ListClaimBatchesParams{
+		PayerID: payerID,
+		Limit:   limit,
+		Offset:  offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ClaimBatch, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainClaimBatch((*queries.GetClaimBatchRow)(row)))
+	}
+	return result, nil
+}
+
+// BatchClaims returns every claim in the batch.
+func (r *InsuranceRepository) BatchClaims(ctx context.Context, batchID int32) ([]domain.Claim, error) {
+	rows, err := r.q.ListBatchClaims(ctx, &batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Claim, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainClaim((*queries.GetClaimRow)(row)))
+	}
+	return result, nil
+}
+
+func translatePayer(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return fmt.Errorf("%w: an insurance payer with that name or payer code already exists", domain.ErrConflict)
+	}
+	return err
+}
+
+func translateCoverage(err error, priority int16) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == exclusionViolation {
+		return fmt.Errorf("%w: the patient already has a priority %d policy in force in that period", domain.ErrConflict, priority)
+	}
+	return translateConstraint(err, "coverage")
+}
+
+func toDomainPayer(row *queries.InsurancePayer) *domain.InsurancePayer {
+	return &domain.InsurancePayer{
+		ID:        row.ID,
+		Name:      row.Name,
+		PayerCode: row.PayerCode,
+		Phone:     row.Phone,
+		Email:     row.Email,
+		Active:    row.Active,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
+
+func toDomainCoverage(row *queries.GetPatientCoverageRow) *domain.Coverage {
+	return &domain.Coverage{
+		ID:             row.ID,
+		PatientID:      row.PatientID,
+		PayerID:        row.PayerID,
+		PayerName:      row.PayerName,
+		PayerCode:      row.PayerCode,
+		Priority:       row.Priority,
+		PolicyNumber:   row.PolicyNumber,
+		MemberNumber:   row.MemberNumber,
+		GroupNumber:    row.GroupNumber,
+		SubscriberName: row.SubscriberName,
+		Relationship:   row.Relationship,
+		ValidFrom:      row.ValidFrom,
+		ValidTo:        row.ValidTo,
+		CopayMinor:     row.CopayMinor,
+		CoinsuranceBP:  row.CoinsuranceBp,
+		CreatedBy:      row.CreatedBy,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}
+}
+
+func toDomainClaim(row *queries.GetClaimRow) *domain.Claim {
+	c := &domain.Claim{
+		ID:                row.ID,
+		InvoiceID:         row.InvoiceID,
+		PatientID:         row.PatientID,
+		CoverageID:        row.CoverageID,
+		PayerID:           row.PayerID,
+		PayerName:         row.PayerName,
+		BatchID:           row.BatchID,
+		Status:            row.Status,
+		ServiceDate:       row.ServiceDate,
+		BilledMinor:       row.BilledMinor,
+		CopayMinor:        row.CopayMinor,
+		CoinsuranceBP:     row.CoinsuranceBp,
+		PayerShareMinor:   row.PayerShareMinor,
+		PatientShareMinor: row.PatientShareMinor,
+		PaidMinor:         row.PaidMinor,
+		PaymentID:         row.PaymentID,
+		PayerReference:    row.PayerReference,
+		DenialReason:      row.DenialReason,
+		CreatedBy:         row.CreatedBy,
+		CreatedAt:         row.CreatedAt.Time,
+		UpdatedAt:         row.UpdatedAt.Time,
+	}
+	if row.SubmittedAt.Valid {
+		submitted := row.SubmittedAt.Time
+		c.SubmittedAt = &submitted
+	}
+	if row.AdjudicatedAt.Valid {
+		adjudicated := row.AdjudicatedAt.Time
+		c.AdjudicatedAt = &adjudicated
+	}
+	if row.ClosedAt.Valid {
+		closed := row.ClosedAt.Time
+		c.ClosedAt = &closed
+	}
+	return c
+}
+
+func toDomainClaimBatch(row *queries.GetClaimBatchRow) *domain.ClaimBatch {
+	return &domain.ClaimBatch{
+		ID:          row.ID,
+		PayerID:     row.PayerID,
+		PayerName:   row.PayerName,
+		PayerCode:   row.PayerCode,
+		ClaimCount:  row.ClaimCount,
+		BilledMinor: row.BilledMinor,
+		CreatedBy:   row.CreatedBy,
+		CreatedAt:   row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+type JobRepository struct {
+	q  *queries.Queries
+	db *pgxpool.Pool
+}
+
+func NewJobRepository(pool *pgxpool.Pool) *JobRepository {
+	return &JobRepository{q: queries.New(pool), db: pool}
+}
+
+// Lock takes the job's advisory lock, which is held by a database session,
+// so the connection is kept out of the pool until unlock is called. ok is
+// false if another run of the job, on any replica, holds the lock.
+func (r *JobRepository) Lock(ctx context.Context, name string) (unlock func(), ok bool, err error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	q := queries.New(conn)
+	locked, err := q.TryJobLock(ctx, name)
+	if err != nil || !locked {
+		conn.Release()
+		return nil, false, err
+	}
+
+	return func() {
+		if err := q.ReleaseJobLock(context.Background(), name); err != nil {
+			// Closing the session releases the lock.
+			conn.Hijack().Close(context.Background())
+			return
+		}
+		conn.Release()
+	}, true, nil
+}
+
+// StartRun records the start of the job's run for scheduledAt. ok is false
+// if that run has already been started, e.g. by another replica.
+func (r *JobRepository) StartRun(ctx context.Context, name string, scheduledAt time.Time) (id int64, ok bool, err error) {
+	id, err = r.q.StartJobRun(ctx, queries.StartJobRunParams{
+		JobName:     name,
+		ScheduledAt: utils.TimeToTimestamp(scheduledAt),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+// FinishRun records the outcome of a run: succeeded with detail, or failed
+// with runErr.
+func (r *JobRepository) FinishRun(ctx context.Context, id int64, detail string, runErr error) error {
+	arg := queries.FinishJobRunParams{ID: id, Status: domain.JobSucceeded}
+	if detail != "" {
+		arg.Detail = &detail
+	}
+	if runErr != nil {
+		msg := runErr.Error()
+		arg.Status = domain.JobFailed
+		arg.Error = &msg
+	}
+	return r.q.FinishJobRun(ctx, arg)
+}
+
+func (r *JobRepository) ListRuns(ctx context.Context, filter domain.JobRunFilter) ([]domain.JobRun, error) {
+	rows, err := r.q.ListJobRuns(ctx, queries.ListJobRunsParams{
+		JobName:  filter.JobName,
+		Status:   filter.Status,
+		RowLimit: filter.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.JobRun, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, toDomainJobRun(row))
+	}
+	return result, nil
+}
+
+// LatestRuns returns the most recent run of each job, by job name.
+func (r *JobRepository) LatestRuns(ctx context.Context) (map[string]domain.JobRun, error) {
+	rows, err := r.q.ListLatestJobRuns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]domain.JobRun, len(rows))
+	for _, row := range rows {
+		result[row.JobName] = toDomainJobRun(row)
+	}
+	return result, nil
+}
+
+func toDomainJobRun(row *queries.JobRun) domain.JobRun {
+	run := domain.JobRun{
+		ID:          row.ID,
+		JobName:     row.JobName,
+		ScheduledAt: row.ScheduledAt.Time,
+		StartedAt:   row.StartedAt.Time,
+		Status:      row.Status,
+		Detail:      row.Detail,
+		Error:       row.Error,
+	}
+	if row.FinishedAt.Valid {
+		finished := row.FinishedAt.Time
+		run.FinishedAt = &finished
+	}
+	return run
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+type NotificationRepository struct {
+	q *queries.Queries
+}
+
+func NewNotificationRepository(pool *pgxpool.Pool) *NotificationRepository {
+	return &NotificationRepository{q: queries.New(pool)}
+}
+
+// Claim leases up to limit due messages to the caller for lease. A message
+// that is not finished or deferred within the lease is claimed again.
+func (r *NotificationRepository) Claim(ctx context.Context, limit int32, lease time.Duration) ([]domain.OutboxMessage, error) {
+	rows, err := r.q.ClaimDueNotifications(ctx, queries.ClaimDueNotificationsParams{
+		LeaseSeconds: int32(lease / time.Second),
+		BatchSize:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.OutboxMessage, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.OutboxMessage{
+			ID:            row.ID,
+			AppointmentID: row.AppointmentID,
+			Template:      row.Template,
+			Channel:       row.Channel,
+			SendAt:        row.SendAt.Time,
+			Attempts:      row.Attempts,
+		})
+	}
+	return result, nil
+}
+
+// Finish records the final status of a message; reason explains why it was
+// skipped or failed.
+func (r *NotificationRepository) Finish(ctx context.Context, id int64, status string, reason *string) error {
+	return r.q.FinishNotification(ctx, queries.FinishNotificationParams{
+		Status:    status,
+		LastError: reason,
+		ID:        id,
+	})
+}
+
+// Defer leaves a message pending to be retried after delay.
+func (r *NotificationRepository) Defer(ctx context.Context, id int64, reason string, delay time.Duration) error {
+	return r.q.DeferNotification(ctx, queries.DeferNotificationParams{
+		LastError:    &reason,
+		DelaySeconds: int32(delay / time.Second),
+		ID:           id,
+	})
+}
+
+// GetTemplate returns the template in language, falling back to the
+// default language.
+func (r *NotificationRepository) GetTemplate(ctx context.Context, template, channel, language string) (*domain.NotificationTemplate, error) {
+	t, err := r.q.GetNotificationTemplate(ctx, queries.GetNotificationTemplateParams{
+		Template: template,
+		Channel:  channel,
+		Language: language,
+	})
+	if errors.Is(err, pgx.ErrNoRows) && language != domain.DefaultNotificationLanguage {
+		return r.GetTemplate(ctx, template, channel, domain.DefaultNotificationLanguage)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainNotificationTemplate(t), nil
+}
+
+func (r *NotificationRepository) ListTemplates(ctx context.Context) ([]domain.NotificationTemplate, error) {
+	rows, err := r.q.ListNotificationTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.NotificationTemplate, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainNotificationTemplate(row))
+	}
+	return result, nil
+}
+
+func (r *NotificationRepository) SaveTemplate(ctx context.Context, t *domain.NotificationTemplate) error {
+	result, err := r.q.UpsertNotificationTemplate(ctx, queries.UpsertNotificationTemplateParams{
+		Template: t.Template,
+		Channel:  t.Channel,
+		Language: t.Language,
+		Subject:  t.Subject,
+		Body:     t.Body,
+	})
+	if err != nil {
+		return err
+	}
+	*t = *toDomainNotificationTemplate(result)
+	return nil
+}
+
+// GetPreferences returns the patient's preferences, or the defaults if
+// none were saved.
+func (r *NotificationRepository) GetPreferences(ctx context.Context, patientID int32) (*domain.NotificationPreferences, error) {
+	p, err := r.q.GetNotificationPreferences(ctx, patientID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &domain.NotificationPreferences{
+			PatientID:    patientID,
+			Language:     domain.DefaultNotificationLanguage,
+			EmailEnabled: true,
+			SMSEnabled:   true,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainNotificationPreferences(p), nil
+}
+
+func (r *NotificationRepository) SavePreferences(ctx context.Context, p *domain.NotificationPreferences) error {
+	result, err := r.q.UpsertNotificationPreferences(ctx, queries.UpsertNotificationPreferencesParams{
+		PatientID:    p.PatientID,
+		Language:     p.Language,
+		EmailEnabled: p.EmailEnabled,
+		SmsEnabled:   p.SMSEnabled,
+	})
+	if err != nil {
+		return err
+	}
+	*p = *toDomainNotificationPreferences(result)
+	return nil
+}
+
+func toDomainNotificationTemplate(t *queries.NotificationTemplate) *domain.NotificationTemplate {
+	return &domain.NotificationTemplate{
+		Template:  t.Template,
+		Channel:   t.Channel,
+		Language:  t.Language,
+		Subject:   t.Subject,
+		Body:      t.Body,
+		UpdatedAt: t.UpdatedAt.Time,
+	}
+}
+
+func toDomainNotificationPreferences(p *queries.NotificationPreference) *domain.NotificationPreferences {
+	return &domain.NotificationPreferences{
+		PatientID:    p.PatientID,
+		Language:     p.Language,
+		EmailEnabled: p.EmailEnabled,
+		SMSEnabled:   p.SmsEnabled,
+	}
+}
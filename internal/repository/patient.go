@@ -2,34 +2,59 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/prem0x01/hospital/internal/database/queries"
 	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/fieldcrypt"
 	"github.com/prem0x01/hospital/internal/utils"
 )
 
+const reencryptBatchSize = 200
+
+// PatientRepository encrypts the columns listed in domain.ProtectedPatientFields
+// on the way in and decrypts them on the way out; callers only ever see
+// plaintext patients.
 type PatientRepository struct {
-	q *queries.Queries
+	q       *queries.Queries
+	keyring *fieldcrypt.Keyring
 }
 
-func NewPatientRepository(q *queries.Queries) *PatientRepository {
-	return &PatientRepository{q: q}
+func NewPatientRepository(q *queries.Queries, keyring *fieldcrypt.Keyring) *PatientRepository {
+	return &PatientRepository{q: q, keyring: keyring}
 }
 
 func (r *PatientRepository) Create(ctx context.Context, p domain.Patient) (*domain.Patient, error) {
+	// The id is taken first as it is bound into the ciphertext.
+	id, err := r.q.NextPatientID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.ID = id
+	sealed, err := r.seal(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
 	arg := queries.CreatePatientParams{
+		ID:                    id,
 		FirstName:             p.FirstName,
 		LastName:              p.LastName,
-		Email:                 p.Email,
-		Phone:                 p.Phone,
+		Email:                 sealed.Email,
+		Phone:                 sealed.Phone,
 		DateOfBirth:           p.DateOfBirth,
 		Gender:                p.Gender,
-		Address:               p.Address,
-		MedicalHistory:        p.MedicalHistory,
-		Allergies:             p.Allergies,
-		EmergencyContactName:  p.EmergencyContactName,
-		EmergencyContactPhone: p.EmergencyContactPhone,
+		Address:               sealed.Address,
+		MedicalHistory:        sealed.MedicalHistory,
+		Allergies:             sealed.Allergies,
+		EmergencyContactName:  sealed.EmergencyContactName,
+		EmergencyContactPhone: sealed.EmergencyContactPhone,
 		CreatedBy:             p.CreatedBy,
+		EmailBidx:             sealed.EmailBidx,
+		PhoneBidx:             sealed.PhoneBidx,
 	}
 
 	result, err := r.q.CreatePatient(ctx, arg)
@@ -37,7 +62,7 @@ func (r *PatientRepository) Create(ctx context.Context, p domain.Patient) (*doma
 		return nil, err
 	}
 
-	return toDomainPatient(result), nil
+	return r.toDomainPatient(ctx, result)
 }
 
 func (r *PatientRepository) GetByID(ctx context.Context, id int32) (*domain.Patient, error) {
@@ -45,7 +70,7 @@ func (r *PatientRepository) GetByID(ctx context.Context, id int32) (*domain.Pati
 	if err != nil {
 		return nil, err
 	}
-	return toDomainPatient(p), nil
+	return r.toDomainPatient(ctx, p)
 }
 
 func (r *PatientRepository) GetAll(ctx context.Context, limit, offset int32) ([]domain.Patient, error) {
@@ -54,49 +79,63 @@ func (r *PatientRepository) GetAll(ctx context.Context, limit, offset int32) ([]
 		return nil, err
 	}
 
-	var result []domain.Patient
-	for _, p := range patients {
-		result = append(result, *toDomainPatient(p))
-	}
-	return result, nil
+	return r.toDomainPatients(ctx, patients)
 }
 
 func (r *PatientRepository) Update(ctx context.Context, p domain.Patient) (*domain.Patient, error) {
+	sealed, err := r.seal(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
 	arg := queries.UpdatePatientParams{
 		ID:                    p.ID,
 		FirstName:             utils.StrPtr(p.FirstName),
 		LastName:              utils.StrPtr(p.LastName),
-		Email:                 p.Email,
-		Phone:                 p.Phone,
+		Email:                 sealed.Email,
+		Phone:                 sealed.Phone,
 		DateOfBirth:           p.DateOfBirth,
 		Gender:                p.Gender,
-		Address:               p.Address,
-		MedicalHistory:        p.MedicalHistory,
-		Allergies:             p.Allergies,
-		EmergencyContactName:  p.EmergencyContactName,
-		EmergencyContactPhone: p.EmergencyContactPhone,
+		Address:               sealed.Address,
+		MedicalHistory:        sealed.MedicalHistory,
+		Allergies:             sealed.Allergies,
+		EmergencyContactName:  sealed.EmergencyContactName,
+		EmergencyContactPhone: sealed.EmergencyContactPhone,
+		EmailBidx:             sealed.EmailBidx,
+		PhoneBidx:             sealed.PhoneBidx,
 	}
 
 	updated, err := r.q.UpdatePatient(ctx, arg)
 	if err != nil {
 		return nil, err
 	}
-	return toDomainPatient(updated), nil
+	return r.toDomainPatient(ctx, updated)
 }
 
+// Delete removes the patient and their records. Patients with invoices
+// are kept, as invoices are financial records.
 func (r *PatientRepository) Delete(ctx context.Context, id int32) error {
-	return r.q.DeletePatient(ctx, id)
+	err := r.q.DeletePatient(ctx, id)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return fmt.Errorf("%w: patient %d has invoices and cannot be deleted", domain.ErrConflict, id)
+	}
+	return err
 }
 
 func (r *PatientRepository) Count(ctx context.Context) (int64, error) {
 	return r.q.CountPatients(ctx)
 }
 
+// Search matches names by substring. Email and phone are encrypted, so
+// they can only be matched exactly, through their blind indexes.
 func (r *PatientRepository) Search(ctx context.Context, keyword string, limit, offset int32) ([]domain.Patient, error) {
 	arg := queries.SearchPatientsParams{
-		Column1: &keyword,
-		Limit:   limit,
-		Offset:  offset,
+		Column1:   &keyword,
+		Limit:     limit,
+		Offset:    offset,
+		EmailBidx: r.blindIndex("email", fieldcrypt.NormalizeEmail(keyword)),
+		PhoneBidx: r.blindIndex("phone", fieldcrypt.NormalizePhone(keyword)),
 	}
 
 	patients, err := r.q.SearchPatients(ctx, arg)
@@ -104,29 +143,248 @@ func (r *PatientRepository) Search(ctx context.Context, keyword string, limit, o
 		return nil, err
 	}
 
+	return r.toDomainPatients(ctx, patients)
+}
+
+// GetAllForDoctor lists only the patients the doctor has a care
+// relationship with.
+func (r *PatientRepository) GetAllForDoctor(ctx context.Context, doctorID, limit, offset int32) ([]domain.Patient, error) {
+	patients, err := r.q.GetPatientsForDoctor(ctx, queries.GetPatientsForDoctorParams{
+		DoctorID: doctorID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomainPatients(ctx, patients)
+}
+
+// SearchForDoctor is Search restricted to the doctor's care relationships.
+func (r *PatientRepository) SearchForDoctor(ctx context.Context, doctorID int32, keyword string, limit, offset int32) ([]domain.Patient, error) {
+	arg := queries.SearchPatientsForDoctorParams{
+		Keyword:   keyword,
+		EmailBidx: r.blindIndex("email", fieldcrypt.NormalizeEmail(keyword)),
+		PhoneBidx: r.blindIndex("phone", fieldcrypt.NormalizePhone(keyword)),
+		DoctorID:  doctorID,
+		Limit:     limit,
+		Offset:    offset,
+	}
+
+	patients, err := r.q.SearchPatientsForDoctor(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomainPatients(ctx, patients)
+}
+
+// ReencryptAll rewrites every patient's protected columns with the active
+// data key and recomputes the blind indexes. Legacy plaintext rows are
+// encrypted on the way. It returns the number of rows rewritten.
+func (r *PatientRepository) ReencryptAll(ctx context.Context) (int, error) {
+	return r.rewriteAll(ctx, r.q.ListPatientsAfterID, func(p domain.Patient) (*sealedPatientFields, error) {
+		return r.seal(ctx, p)
+	})
+}
+
+// EncryptLegacy seals the patients written before field encryption, or
+// before values were bound to their row, as ReencryptAll would. It only
+// reads those rows, so it is cheap once they have been rewritten.
+func (r *PatientRepository) EncryptLegacy(ctx context.Context) (int, error) {
+	list := func(ctx context.Context, arg queries.ListPatientsAfterIDParams) ([]*queries.Patient, error) {
+		return r.q.ListPatientsToSeal(ctx, queries.ListPatientsToSealParams(arg))
+	}
+	return r.rewriteAll(ctx, list, func(p domain.Patient) (*sealedPatientFields, error) {
+		return r.seal(ctx, p)
+	})
+}
+
+// DecryptAll writes every patient's protected columns back as plaintext
+// and clears the blind indexes, undoing field encryption.
+func (r *PatientRepository) DecryptAll(ctx context.Context) (int, error) {
+	return r.rewriteAll(ctx, r.q.ListPatientsAfterID, func(p domain.Patient) (*sealedPatientFields, error) {
+		return &sealedPatientFields{
+			Email:                 p.Email,
+			Phone:                 p.Phone,
+			Address:               p.Address,
+			MedicalHistory:        p.MedicalHistory,
+			Allergies:             p.Allergies,
+			EmergencyContactName:  p.EmergencyContactName,
+			EmergencyContactPhone: p.EmergencyContactPhone,
+		}, nil
+	})
+}
+
+// rewriteAll rewrites the patients list returns, a batch at a time. Rows
+// are read with DecryptLegacy so that values in older formats can be
+// rewritten.
+func (r *PatientRepository) rewriteAll(ctx context.Context, list func(context.Context, queries.ListPatientsAfterIDParams) ([]*queries.Patient, error), rewrite func(domain.Patient) (*sealedPatientFields, error)) (int, error) {
+	var lastID int32
+	count := 0
+	for {
+		batch, err := list(ctx, queries.ListPatientsAfterIDParams{
+			ID:    lastID,
+			Limit: reencryptBatchSize,
+		})
+		if err != nil {
+			return count, err
+		}
+
+		for _, row := range batch {
+			p, err := r.decode(ctx, row, r.keyring.DecryptLegacy)
+			if err != nil {
+				return count, err
+			}
+			fields, err := rewrite(*p)
+			if err != nil {
+				return count, err
+			}
+
+			err = r.q.UpdatePatientProtectedFields(ctx, queries.UpdatePatientProtectedFieldsParams{
+				ID:                    row.ID,
+				Email:                 fields.Email,
+				Phone:                 fields.Phone,
+				Address:               fields.Address,
+				MedicalHistory:        fields.MedicalHistory,
+				Allergies:             fields.Allergies,
+				EmergencyContactName:  fields.EmergencyContactName,
+				EmergencyContactPhone: fields.EmergencyContactPhone,
+				EmailBidx:             fields.EmailBidx,
+				PhoneBidx:             fields.PhoneBidx,
+			})
+			if err != nil {
+				return count, err
+			}
+			lastID = row.ID
+			count++
+		}
+
+		if len(batch) < reencryptBatchSize {
+			return count, nil
+		}
+	}
+}
+
+type sealedPatientFields struct {
+	Email                 *string
+	Phone                 *string
+	Address               *string
+	MedicalHistory        *string
+	Allergies             *string
+	EmergencyContactName  *string
+	EmergencyContactPhone *string
+	EmailBidx             *string
+	PhoneBidx             *string
+}
+
+func (r *PatientRepository) seal(ctx context.Context, p domain.Patient) (*sealedPatientFields, error) {
+	var s sealedPatientFields
+	fields := []struct {
+		column string
+		in     *string
+		out    **string
+	}{
+		{"email", p.Email, &s.Email},
+		{"phone", p.Phone, &s.Phone},
+		{"address", p.Address, &s.Address},
+		{"medical_history", p.MedicalHistory, &s.MedicalHistory},
+		{"allergies", p.Allergies, &s.Allergies},
+		{"emergency_contact_name", p.EmergencyContactName, &s.EmergencyContactName},
+		{"emergency_contact_phone", p.EmergencyContactPhone, &s.EmergencyContactPhone},
+	}
+
+	for _, f := range fields {
+		if f.in == nil {
+			continue
+		}
+		sealed, err := r.keyring.Encrypt(ctx, f.column, p.ID, *f.in)
+		if err != nil {
+			return nil, err
+		}
+		*f.out = &sealed
+	}
+
+	if p.Email != nil {
+		s.EmailBidx = r.blindIndex("email", fieldcrypt.NormalizeEmail(*p.Email))
+	}
+	if p.Phone != nil {
+		s.PhoneBidx = r.blindIndex("phone", fieldcrypt.NormalizePhone(*p.Phone))
+	}
+	return &s, nil
+}
+
+func (r *PatientRepository) blindIndex(column, normalized string) *string {
+	if normalized == "" {
+		return nil
+	}
+	idx := r.keyring.BlindIndex(column, normalized)
+	return &idx
+}
+
+// decryptFunc is Keyring.Decrypt or Keyring.DecryptLegacy.
+type decryptFunc func(ctx context.Context, column string, rowID int32, value string) (string, error)
+
+func open(ctx context.Context, decrypt decryptFunc, column string, rowID int32, v *string) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	plain, err := decrypt(ctx, column, rowID, *v)
+	if err != nil {
+		return nil, err
+	}
+	return &plain, nil
+}
+
+func (r *PatientRepository) toDomainPatients(ctx context.Context, patients []*queries.Patient) ([]domain.Patient, error) {
 	var result []domain.Patient
 	for _, p := range patients {
-		result = append(result, *toDomainPatient(p))
+		patient, err := r.toDomainPatient(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *patient)
 	}
 	return result, nil
 }
 
-func toDomainPatient(p *queries.Patient) *domain.Patient {
-	return &domain.Patient{
-		ID:                    p.ID,
-		FirstName:             p.FirstName,
-		LastName:              p.LastName,
-		Email:                 p.Email,
-		Phone:                 p.Phone,
-		DateOfBirth:           p.DateOfBirth,
-		Gender:                p.Gender,
-		Address:               p.Address,
-		MedicalHistory:        p.MedicalHistory,
-		Allergies:             p.Allergies,
-		EmergencyContactName:  p.EmergencyContactName,
-		EmergencyContactPhone: p.EmergencyContactPhone,
-		CreatedBy:             p.CreatedBy,
-		CreatedAt:             p.CreatedAt,
-		UpdatedAt:             p.UpdatedAt,
+func (r *PatientRepository) toDomainPatient(ctx context.Context, p *queries.Patient) (*domain.Patient, error) {
+	return r.decode(ctx, p, r.keyring.Decrypt)
+}
+
+func (r *PatientRepository) decode(ctx context.Context, p *queries.Patient, decrypt decryptFunc) (*domain.Patient, error) {
+	patient := &domain.Patient{
+		ID:          p.ID,
+		MRN:         domain.MRN(p.ID),
+		FirstName:   p.FirstName,
+		LastName:    p.LastName,
+		DateOfBirth: p.DateOfBirth,
+		Gender:      p.Gender,
+		CreatedBy:   p.CreatedBy,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+
+	fields := []struct {
+		column string
+		in     *string
+		out    **string
+	}{
+		{"email", p.Email, &patient.Email},
+		{"phone", p.Phone, &patient.Phone},
+		{"address", p.Address, &patient.Address},
+		{"medical_history", p.MedicalHistory, &patient.MedicalHistory},
+		{"allergies", p.Allergies, &patient.Allergies},
+		{"emergency_contact_name", p.EmergencyContactName, &patient.EmergencyContactName},
+		{"emergency_contact_phone", p.EmergencyContactPhone, &patient.EmergencyContactPhone},
+	}
+	for _, f := range fields {
+		plain, err := open(ctx, decrypt, f.column, p.ID, f.in)
+		if err != nil {
+			return nil, err
+		}
+		*f.out = plain
 	}
+	return patient, nil
 }
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+// PatientDocumentRepository keeps document records. Blobs are shared
+// between documents with the same contents, so storing, adding and
+// removing the documents of one blob hold a lock on its storage key: a
+// blob is only deleted once no document refers to it, and none can be
+// added meanwhile.
+type PatientDocumentRepository struct {
+	q    *queries.Queries
+	pool *pgxpool.Pool
+}
+
+func NewPatientDocumentRepository(q *queries.Queries, pool *pgxpool.Pool) *PatientDocumentRepository {
+	return &PatientDocumentRepository{q: q, pool: pool}
+}
+
+// Create stores the contents with put and then records d, both under the
+// lock on d.StorageKey.
+func (r *PatientDocumentRepository) Create(ctx context.Context, d *domain.PatientDocument, put func() error) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	if err := qtx.LockStorageKey(ctx, d.StorageKey); err != nil {
+		return err
+	}
+	if err := put(); err != nil {
+		return err
+	}
+	row, err := qtx.CreatePatientDocument(ctx, queries.CreatePatientDocumentParams{
+		PatientID:   d.PatientID,
+		Category:    d.Category,
+		Description: d.Description,
+		Filename:    d.Filename,
+		ContentType: d.ContentType,
+		SizeBytes:   d.SizeBytes,
+		Sha256:      d.SHA256,
+		StorageKey:  d.StorageKey,
+		UploadedBy:  d.UploadedBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return fmt.Errorf("%w: patient %d already has this file", domain.ErrConflict, d.PatientID)
+		}
+		return translateConstraint(err, "document")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	d.ID = row.ID
+	d.CreatedAt = row.CreatedAt.Time
+	return nil
+}
+
+func (r *PatientDocumentRepository) Get(ctx context.Context, id int32) (*domain.PatientDocument, error) {
+	row, err := r.q.GetPatientDocument(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: document %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainPatientDocument(row), nil
+}
+
+// FindByHash returns the patient's document with these contents, or nil.
+func (r *PatientDocumentRepository) FindByHash(ctx context.Context, patientID int32, sha256 string) (*domain.PatientDocument, error) {
+	row, err := r.q.GetPatientDocumentByHash(ctx, queries.GetPatientDocumentByHashParams{PatientID: patientID, Sha256: sha256})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainPatientDocument((*queries.GetPatientDocumentRow)(row)), nil
+}
+
+// List returns the patient's documents, newest first, optionally of one
+// category.
+func (r *PatientDocumentRepository) List(ctx context.Context, patientID int32, category *string) ([]domain.PatientDocument, error) {
+	rows, err := r.q.ListPatientDocuments(ctx, queries.ListPatientDocumentsParams{PatientID: patientID, Category: category})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.PatientDocument, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainPatientDocument((*queries.GetPatientDocumentRow)(row)))
+	}
+	return result, nil
+}
+
+// Delete removes document d and, if no other document refers to its
+// contents, calls remove to delete them, under the lock on its storage
+// key. The record is kept if remove fails.
+func (r *PatientDocumentRepository) Delete(ctx context.Context, d *domain.PatientDocument, remove func() error) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	if err := qtx.LockStorageKey(ctx, d.StorageKey); err != nil {
+		return err
+	}
+	n, err := qtx.DeletePatientDocument(ctx, d.ID)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: document %d", domain.ErrNotFound, d.ID)
+	}
+	if err := releaseBlob(ctx, qtx, d.StorageKey, remove); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ReleaseBlob calls remove if no document refers to the blob, under the
+// lock on its storage key.
+func (r *PatientDocumentRepository) ReleaseBlob(ctx context.Context, storageKey string, remove func() error) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	if err := qtx.LockStorageKey(ctx, storageKey); err != nil {
+		return err
+	}
+	if err := releaseBlob(ctx, qtx, storageKey, remove); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func releaseBlob(ctx context.Context, qtx *queries.Queries, storageKey string, remove func() error) error {
+	n, err := qtx.CountDocumentsByStorageKey(ctx, storageKey)
+	if err != nil || n > 0 {
+		return err
+	}
+	return remove()
+}
+
+func toDomainPatientDocument(row *queries.GetPatientDocumentRow) *domain.PatientDocument {
+	d := &domain.PatientDocument{
+		ID:          row.ID,
+		PatientID:   row.PatientID,
+		Category:    row.Category,
+		Description: row.Description,
+		Filename:    row.Filename,
+		ContentType: row.ContentType,
+		SizeBytes:   row.SizeBytes,
+		SHA256:      row.Sha256,
+		StorageKey:  row.StorageKey,
+		UploadedBy:  row.UploadedBy,
+		CreatedAt:   row.CreatedAt.Time,
+	}
+	if name, ok := row.UploadedByName.(string); ok {
+		d.UploadedByName = name
+	}
+	return d
+}
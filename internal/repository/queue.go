@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+// queueChannel is notified by triggers on appointments and check-ins.
+const queueChannel = "queue_changed"
+
+type QueueRepository struct {
+	q  *queries.Queries
+	db *pgxpool.Pool
+}
+
+func NewQueueRepository(pool *pgxpool.Pool) *QueueRepository {
+	return &QueueRepository{q: queries.New(pool), db: pool}
+}
+
+// ListTodaysCheckIns returns the check-ins for today's appointments, by
+// appointment ID.
+func (r *QueueRepository) ListTodaysCheckIns(ctx context.Context) (map[int32]domain.CheckIn, error) {
+	rows, err := r.q.ListTodaysCheckIns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int32]domain.CheckIn, len(rows))
+	for _, row := range rows {
+		result[row.AppointmentID] = domain.CheckIn{
+			AppointmentID: row.AppointmentID,
+			ArrivedAt:     row.ArrivedAt.Time,
+			Priority:      row.Priority,
+			CheckedInBy:   row.CheckedInBy,
+		}
+	}
+	return result, nil
+}
+
+// Listen holds a connection listening for queue changes and calls changed
+// for each one, until ctx is done or the connection fails.
+func (r *QueueRepository) Listen(ctx context.Context, changed func()) error {
+	pooled, err := r.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection stays subscribed, so it is taken out of the pool and
+	// closed rather than released.
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+queueChannel); err != nil {
+		return err
+	}
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return err
+		}
+		changed()
+	}
+}
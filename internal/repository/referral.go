@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+type ReferralRepository struct {
+	q    *queries.Queries
+	pool *pgxpool.Pool
+}
+
+func NewReferralRepository(q *queries.Queries, pool *pgxpool.Pool) *ReferralRepository {
+	return &ReferralRepository{q: q, pool: pool}
+}
+
+// Create saves ref as sent.
+func (r *ReferralRepository) Create(ctx context.Context, ref *domain.Referral) error {
+	row, err := r.q.CreateReferral(ctx, queries.CreateReferralParams{
+		PatientID:          ref.PatientID,
+		SourceDoctorID:     ref.SourceDoctorID,
+		TargetDoctorID:     ref.TargetDoctorID,
+		TargetDepartmentID: ref.TargetDepartmentID,
+		Reason:             ref.Reason,
+		Urgency:            ref.Urgency,
+		ClinicalContext:    ref.ClinicalContext,
+		NoteID:             ref.NoteID,
+	})
+	if err != nil {
+		return translateConstraint(err, "referral")
+	}
+	ref.ID = row.ID
+	ref.Status = row.Status
+	ref.CreatedAt = row.CreatedAt.Time
+	ref.UpdatedAt = row.UpdatedAt.Time
+	return nil
+}
+
+func (r *ReferralRepository) Get(ctx context.Context, id int32) (*domain.Referral, error) {
+	row, err := r.q.GetReferral(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: referral %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainReferral(row), nil
+}
+
+// List returns the referrals matching filter, most urgent first.
+func (r *ReferralRepository) List(ctx context.Context, filter domain.ReferralFilter) ([]domain.Referral, error) {
+	rows, err := r.q.ListReferrals(ctx, queries.ListReferralsParams{
+		PatientID:      filter.PatientID,
+		Status:         filter.Status,
+		SourceDoctorID: filter.SourceDoctorID,
+		ReceiverID:     filter.ReceiverID,
+		Limit:          filter.Limit,
+		Offset:         filter.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Referral, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainReferral((*queries.GetReferralRow)(row)))
+	}
+	return result, nil
+}
+
+// Accept makes doctorID the receiver of the sent referral and puts them on
+// the patient's care team, crediting the referring doctor.
+func (r *ReferralRepository) Accept(ctx context.Context, ref *domain.Referral, doctorID int32) (*domain.CareTeamMember, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.q.WithTx(tx)
+
+	n, err := qtx.AcceptReferral(ctx, queries.AcceptReferralParams{DoctorID: &doctorID, ID: ref.ID})
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("%w: referral %d is no longer %s", domain.ErrConflict, ref.ID, domain.ReferralSent)
+	}
+	m, err := qtx.AddCareTeamMember(ctx, queries.AddCareTeamMemberParams{
+		PatientID: ref.PatientID,
+		DoctorID:  doctorID,
+		Source:    domain.CareTeamSourceReferral,
+		AddedBy:   &ref.SourceDoctorID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &domain.CareTeamMember{
+		PatientID: m.PatientID,
+		DoctorID:  m.DoctorID,
+		Source:    m.Source,
+		AddedBy:   m.AddedBy,
+		Since:     m.CreatedAt.Time,
+	}, nil
+}
+
+func (r *ReferralRepository) Decline(ctx context.Context, id, doctorID int32, reason string) error {
+	n, err := r.q.DeclineReferral(ctx, queries.DeclineReferralParams{
+		ID:            id,
+		RespondedBy:   &doctorID,
+		DeclineReason: &reason,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: referral %d is no longer %s", domain.ErrConflict, id, domain.ReferralSent)
+	}
+	return nil
+}
+
+// Schedule links the accepted referral to the appointment booked for it.
+func (r *ReferralRepository) Schedule(ctx context.Context, id, appointmentID int32) error {
+	n, err := r.q.ScheduleReferral(ctx, queries.ScheduleReferralParams{
+		ID:            id,
+		AppointmentID: &appointmentID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: referral %d is no longer %s", domain.ErrConflict, id, domain.ReferralAccepted)
+	}
+	return nil
+}
+
+func toDomainReferral(row *queries.GetReferralRow) *domain.Referral {
+	ref := &domain.Referral{
+		ID:                   row.ID,
+		PatientID:            row.PatientID,
+		SourceDoctorID:       row.SourceDoctorID,
+		TargetDoctorID:       row.TargetDoctorID,
+		TargetDepartmentID:   row.TargetDepartmentID,
+		TargetDepartmentName: row.TargetDepartmentName,
+		Reason:               row.Reason,
+		Urgency:              row.Urgency,
+		ClinicalContext:      row.ClinicalContext,
+		NoteID:               row.NoteID,
+		Status:               row.Status,
+		RespondedBy:          row.RespondedBy,
+		DeclineReason:        row.DeclineReason,
+		AppointmentID:        row.AppointmentID,
+		CreatedAt:            row.CreatedAt.Time,
+		UpdatedAt:            row.UpdatedAt.Time,
+	}
+	if row.RespondedAt.Valid {
+		responded := row.RespondedAt.Time
+		ref.RespondedAt = &responded
+	}
+	if row.CompletedAt.Valid {
+		completed := row.CompletedAt.Time
+		ref.CompletedAt = &completed
+	}
+	ref.SourceDoctorName, _ = row.SourceDoctorName.(string)
+	ref.TargetDoctorName, _ = row.TargetDoctorName.(string)
+	return ref
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+// ResourceRepository stores rooms, equipment and equipment bookings. Rooms
+// are booked through appointments.
+type ResourceRepository struct {
+	q *queries.Queries
+}
+
+func NewResourceRepository(q *queries.Queries) *ResourceRepository {
+	return &ResourceRepository{q: q}
+}
+
+func (r *ResourceRepository) CreateRoom(ctx context.Context, name string, departmentID *int32) (*domain.Room, error) {
+	room, err := r.q.CreateRoom(ctx, queries.CreateRoomParams{Name: name, DepartmentID: departmentID})
+	if err != nil {
+		return nil, translateConstraint(err, "room")
+	}
+	return toDomainRoom(room), nil
+}
+
+func (r *ResourceRepository) GetRoom(ctx context.Context, id int32) (*domain.Room, error) {
+	room, err := r.q.GetRoom(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: room %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainRoom(room), nil
+}
+
+func (r *ResourceRepository) ListRooms(ctx context.Context, departmentID *int32) ([]domain.Room, error) {
+	rows, err := r.q.ListRooms(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Room, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainRoom(row))
+	}
+	return result, nil
+}
+
+func (r *ResourceRepository) UpdateRoom(ctx context.Context, room *domain.Room) error {
+	_, err := r.q.UpdateRoom(ctx, queries.UpdateRoomParams{
+		ID:           room.ID,
+		Name:         room.Name,
+		DepartmentID: room.DepartmentID,
+		Active:       room.Active,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: room %d", domain.ErrNotFound, room.ID)
+	}
+	return translateConstraint(err, "room")
+}
+
+// ListRoomBookings returns the live appointments in the room that overlap
+// [since, until).
+func (r *ResourceRepository) ListRoomBookings(ctx context.Context, roomID int32, since, until time.Time) ([]domain.ResourceBooking, error) {
+	rows, err := r.q.ListRoomBookings(ctx, queries.ListRoomBookingsParams{
+		RoomID: &roomID,
+		Until:  utils.TimeToTimestamp(until),
+		Since:  utils.TimeToTimestamp(since),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ResourceBooking, 0, len(rows))
+	for _, row := range rows {
+		id := row.ID
+		result = append(result, domain.ResourceBooking{
+			ID:            row.ID,
+			AppointmentID: &id,
+			StartsAt:      row.AppointmentDate.Time,
+			EndsAt:        row.EndTime.Time,
+		})
+	}
+	return result, nil
+}
+
+func (r *ResourceRepository) CreateEquipment(ctx context.Context, name string, departmentID *int32) (*domain.Equipment, error) {
+	e, err := r.q.CreateEquipment(ctx, queries.CreateEquipmentParams{Name: name, DepartmentID: departmentID})
+	if err != nil {
+		return nil, translateConstraint(err, "piece of equipment")
+	}
+	return toDomainEquipment(e), nil
+}
+
+func (r *ResourceRepository) GetEquipment(ctx context.Context, id int32) (*domain.Equipment, error) {
+	e, err := r.q.GetEquipment(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: equipment %d", domain.ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainEquipment(e), nil
+}
+
+func (r *ResourceRepository) ListEquipment(ctx context.Context, departmentID *int32) ([]domain.Equipment, error) {
+	rows, err := r.q.ListEquipment(ctx, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Equipment, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainEquipment(row))
+	}
+	return result, nil
+}
+
+func (r *ResourceRepository) UpdateEquipment(ctx context.Context, e *domain.Equipment) error {
+	_, err := r.q.UpdateEquipment(ctx, queries.UpdateEquipmentParams{
+		ID:           e.ID,
+		Name:         e.Name,
+		DepartmentID: e.DepartmentID,
+		Active:       e.Active,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: equipment %d", domain.ErrNotFound, e.ID)
+	}
+	return translateConstraint(err, "piece of equipment")
+}
+
+// CreateEquipmentBooking books the equipment for [start, end). If the
+// equipment is already booked for part of it, the error is a
+// *domain.ResourceConflictError listing the clashing bookings.
+func (r *ResourceRepository) CreateEquipmentBooking(ctx context.Context, equipmentID int32, appointmentID *int32, start, end time.Time, bookedBy int32) (*domain.ResourceBooking, error) {
+	b, err := r.q.CreateEquipmentBooking(ctx, queries.CreateEquipmentBookingParams{
+		EquipmentID:   equipmentID,
+		AppointmentID: appointmentID,
+		StartsAt:      utils.TimeToTimestamp(start),
+		EndsAt:        utils.TimeToTimestamp(end),
+		BookedBy:      &bookedBy,
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == exclusionViolation {
+		conflicts, listErr := r.ListEquipmentBookings(ctx, equipmentID, start, end)
+		if listErr != nil {
+			return nil, listErr
+		}
+		return nil, &domain.ResourceConflictError{Conflicts: conflicts}
+	}
+	if err != nil {
+		return nil, translateConstraint(err, "equipment booking")
+	}
+	return toDomainEquipmentBooking(b), nil
+}
+
+func (r *ResourceRepository) ListEquipmentBookings(ctx context.Context, equipmentID int32, since, until time.Time) ([]domain.ResourceBooking, error) {
+	rows, err := r.q.ListEquipmentBookings(ctx, queries.ListEquipmentBookingsParams{
+		EquipmentID: equipmentID,
+		Until:       utils.TimeToTimestamp(until),
+		Since:       utils.TimeToTimestamp(since),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ResourceBooking, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainEquipmentBooking(row))
+	}
+	return result, nil
+}
+
+func (r *ResourceRepository) DeleteEquipmentBooking(ctx context.Context, id int32) error {
+	n, err := r.q.DeleteEquipmentBooking(ctx, id)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: equipment booking %d", domain.ErrNotFound, id)
+	}
+	return nil
+}
+
+func toDomainRoom(r *queries.Room) *domain.Room {
+	return &domain.Room{ID: r.ID, Name: r.Name, DepartmentID: r.DepartmentID, Active: r.Active}
+}
+
+func toDomainEquipment(e *queries.Equipment) *domain.Equipment {
+	return &domain.Equipment{ID: e.ID, Name: e.Name, DepartmentID: e.DepartmentID, Active: e.Active}
+}
+
+func toDomainEquipmentBooking(b *queries.EquipmentBooking) *domain.ResourceBooking {
+	return &domain.ResourceBooking{
+		ID:            b.ID,
+		AppointmentID: b.AppointmentID,
+		StartsAt:      b.StartsAt.Time,
+		EndsAt:        b.EndsAt.Time,
+		BookedBy:      b.BookedBy,
+	}
+}
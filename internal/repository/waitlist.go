@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prem0x01/hospital/internal/database/queries"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+type WaitlistRepository struct {
+	db *pgxpool.Pool
+	q  *queries.Queries
+	// appointmentRepo books confirmed holds inside the waitlist's own
+	// transaction.
+	appointmentRepo *AppointmentRepository
+}
+
+func NewWaitlistRepository(pool *pgxpool.Pool, appointmentRepo *AppointmentRepository) *WaitlistRepository {
+	return &WaitlistRepository{db: pool, q: queries.New(pool), appointmentRepo: appointmentRepo}
+}
+
+func (r *WaitlistRepository) CreateEntry(ctx context.Context, e *domain.WaitlistEntry) error {
+	result, err := r.q.CreateWaitlistEntry(ctx, queries.CreateWaitlistEntryParams{
+		PatientID:       e.PatientID,
+		DoctorID:        e.DoctorID,
+		Specialty:       e.Specialty,
+		Earliest:        utils.TimeToTimestamp(e.Earliest),
+		Latest:          utils.TimeToTimestamp(e.Latest),
+		DurationMinutes: e.DurationMinutes,
+		Notes:           e.Notes,
+		CreatedBy:       e.CreatedBy,
+	})
+	if err != nil {
+		return err
+	}
+	*e = *toDomainWaitlistEntry(result)
+	return nil
+}
+
+// GetEntry returns the entry with every hold ever offered to it.
+func (r *WaitlistRepository) GetEntry(ctx context.Context, id int32) (*domain.WaitlistEntry, error) {
+	e, err := r.q.GetWaitlistEntry(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	holds, err := r.q.ListWaitlistHolds(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := toDomainWaitlistEntry(e)
+	for _, h := range holds {
+		entry.Holds = append(entry.Holds, *toDomainWaitlistHold(h))
+	}
+	return entry, nil
+}
+
+// ListEntries returns the entries with the given status, or all of them
+// if status is nil, oldest first.
+func (r *WaitlistRepository) ListEntries(ctx context.Context, status *string) ([]domain.WaitlistEntry, error) {
+	rows, err := r.q.ListWaitlistEntries(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainWaitlistEntries(rows), nil
+}
+
+// SetEntryStatus moves the entry from one status to another. It returns
+// domain.ErrConflict if the entry is no longer in the from status.
+func (r *WaitlistRepository) SetEntryStatus(ctx context.Context, id int32, from, to string) error {
+	return setEntryStatus(ctx, r.q, id, from, to)
+}
+
+// FindCandidates returns the waiting entries that could take the doctor's
+// free time [start, end), best candidate first.
+func (r *WaitlistRepository) FindCandidates(ctx context.Context, doctorID int32, start, end time.Time) ([]domain.WaitlistEntry, error) {
+	rows, err := r.q.FindWaitlistCandidates(ctx, queries.FindWaitlistCandidatesParams{
+		DoctorID: &doctorID,
+		StartsAt: utils.TimeToTimestamp(start),
+		EndsAt:   utils.TimeToTimestamp(end),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDomainWaitlistEntries(rows), nil
+}
+
+// Offer marks the entry offered and saves the hold. It returns
+// domain.ErrConflict if the entry is no longer waiting.
+func (r *WaitlistRepository) Offer(ctx context.Context, h *domain.WaitlistHold) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.q.WithTx(tx)
+	if err := setEntryStatus(ctx, qtx, h.EntryID, domain.WaitlistWaiting, domain.WaitlistOffered); err != nil {
+		return err
+	}
+	result, err := qtx.CreateWaitlistHold(ctx, queries.CreateWaitlistHoldParams{
+		EntryID:    h.EntryID,
+		DoctorID:   h.DoctorID,
+		StartsAt:   utils.TimeToTimestamp(h.StartsAt),
+		EndsAt:     utils.TimeToTimestamp(h.EndsAt),
+		SlotEndsAt: utils.TimeToTimestamp(h.SlotEndsAt),
+		ExpiresAt:  pgtype.Timestamptz{Time: h.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	*h = *toDomainWaitlistHold(result)
+	return nil
+}
+
+func (r *WaitlistRepository) GetHold(ctx context.Context, id int32) (*domain.WaitlistHold, error) {
+	h, err := r.q.GetWaitlistHold(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainWaitlistHold(h), nil
+}
+
+// Release closes a pending hold as declined or expired and puts its entry
+// back on the waitlist. It returns domain.ErrConflict if the hold is no
+// longer pending.
+func (r *WaitlistRepository) Release(ctx context.Context, h *domain.WaitlistHold, status string) error {
+	return r.resolve(ctx, h, status, domain.WaitlistWaiting, nil)
+}
+
+// Confirm books a for the hold's entry and closes both, in one
+// transaction. It returns domain.ErrConflict if the hold is no longer
+// pending or the appointment overlaps another.
+func (r *WaitlistRepository) Confirm(ctx context.Context, h *domain.WaitlistHold, a *domain.Appointment) error {
+	return r.resolve(ctx, h, domain.HoldConfirmed, domain.WaitlistBooked, a)
+}
+
+// CancelEntry takes the entry off the waitlist, declining its pending hold
+// if it has one. It returns the declined hold, or nil.
+func (r *WaitlistRepository) CancelEntry(ctx context.Context, e *domain.WaitlistEntry) (*domain.WaitlistHold, error) {
+	if e.Status == domain.WaitlistWaiting {
+		return nil, r.SetEntryStatus(ctx, e.ID, domain.WaitlistWaiting, domain.WaitlistCancelled)
+	}
+
+	var pending *domain.WaitlistHold
+	for i := range e.Holds {
+		if e.Holds[i].Status == domain.HoldPending {
+			pending = &e.Holds[i]
+		}
+	}
+	if e.Status != domain.WaitlistOffered || pending == nil {
+		return nil, fmt.Errorf("%w: waitlist entry %d is %s", domain.ErrConflict, e.ID, e.Status)
+	}
+	if err := r.resolve(ctx, pending, domain.HoldDeclined, domain.WaitlistCancelled, nil); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+func (r *WaitlistRepository) resolve(ctx context.Context, h *domain.WaitlistHold, status, entryStatus string, a *domain.Appointment) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.q.WithTx(tx)
+	var appointmentID *int32
+	if a != nil {
+		if err := r.appointmentRepo.insert(ctx, qtx, a); err != nil {
+			return err
+		}
+		appointmentID = &a.ID
+	}
+
+	n, err := qtx.ResolveWaitlistHold(ctx, queries.ResolveWaitlistHoldParams{
+		Status:        status,
+		AppointmentID: appointmentID,
+		ID:            h.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: hold %d is no longer pending", domain.ErrConflict, h.ID)
+	}
+	if err := setEntryStatus(ctx, qtx, h.EntryID, domain.WaitlistOffered, entryStatus); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListExpiredHolds returns the pending holds whose time is up.
+func (r *WaitlistRepository) ListExpiredHolds(ctx context.Context) ([]domain.WaitlistHold, error) {
+	rows, err := r.q.ListExpiredWaitlistHolds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainWaitlistHolds(rows), nil
+}
+
+// ListActiveHolds returns the live holds on the doctor's time that
+// intersect [start, end), other than those for patientID.
+func (r *WaitlistRepository) ListActiveHolds(ctx context.Context, doctorID, patientID int32, start, end time.Time) ([]domain.WaitlistHold, error) {
+	rows, err := r.q.ListActiveWaitlistHolds(ctx, queries.ListActiveWaitlistHoldsParams{
+		DoctorID:  doctorID,
+		EndsAt:    utils.TimeToTimestamp(end),
+		StartsAt:  utils.TimeToTimestamp(start),
+		PatientID: patientID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toDomainWaitlistHolds(rows), nil
+}
+
+func setEntryStatus(ctx context.Context, q *queries.Queries, id int32, from, to string) error {
+	n, err := q.SetWaitlistEntryStatus(ctx, queries.SetWaitlistEntryStatusParams{
+		ToStatus:   to,
+		ID:         id,
+		FromStatus: from,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: waitlist entry %d is no longer %s", domain.ErrConflict, id, from)
+	}
+	return nil
+}
+
+func toDomainWaitlistEntries(rows []*queries.WaitlistEntry) []domain.WaitlistEntry {
+	result := make([]domain.WaitlistEntry, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainWaitlistEntry(row))
+	}
+	return result
+}
+
+func toDomainWaitlistEntry(e *queries.WaitlistEntry) *domain.WaitlistEntry {
+	return &domain.WaitlistEntry{
+		ID:              e.ID,
+		PatientID:       e.PatientID,
+		DoctorID:        e.DoctorID,
+		Specialty:       e.Specialty,
+		Earliest:        e.Earliest.Time,
+		Latest:          e.Latest.Time,
+		DurationMinutes: e.DurationMinutes,
+		Notes:           e.Notes,
+		Status:          e.Status,
+		CreatedBy:       e.CreatedBy,
+		CreatedAt:       e.CreatedAt.Time,
+	}
+}
+
+func toDomainWaitlistHolds(rows []*queries.WaitlistHold) []domain.WaitlistHold {
+	result := make([]domain.WaitlistHold, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *toDomainWaitlistHold(row))
+	}
+	return result
+}
+
+func toDomainWaitlistHold(h *queries.WaitlistHold) *domain.WaitlistHold {
+	hold := &domain.WaitlistHold{
+		ID:            h.ID,
+		EntryID:       h.EntryID,
+		DoctorID:      h.DoctorID,
+		StartsAt:      h.StartsAt.Time,
+		EndsAt:        h.EndsAt.Time,
+		SlotEndsAt:    h.SlotEndsAt.Time,
+		Status:        h.Status,
+		ExpiresAt:     h.ExpiresAt.Time,
+		AppointmentID: h.AppointmentID,
+		CreatedAt:     h.CreatedAt.Time,
+	}
+	if h.ResolvedAt.Valid {
+		t := h.ResolvedAt.Time
+		hold.ResolvedAt = &t
+	}
+	return hold
+}
@@ -0,0 +1,179 @@
+// Package scheduling computes when a doctor can be booked from a weekly
+// template of working hours, one-off exceptions and existing appointments.
+//
+// All times are wall-clock times in the location of the times passed in;
+// a Clock is turned into an instant on a given day with time.Date, so a
+// window keeps its wall-clock hours across daylight saving changes. No slot
+// starts at a time the clocks skip over.
+package scheduling
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Clock is a time of day in minutes since midnight.
+type Clock int
+
+// ParseClock parses "HH:MM" (24-hour).
+func ParseClock(s string) (Clock, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, want HH:MM", s)
+	}
+	return Clock(t.Hour()*60 + t.Minute()), nil
+}
+
+func (c Clock) String() string {
+	return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60)
+}
+
+func (c Clock) on(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, int(c), 0, 0, day.Location())
+}
+
+// shows reports whether the wall clock reads c at t. It does not when
+// time.Date had to move a time that does not exist.
+func (c Clock) shows(t time.Time) bool {
+	return t.Hour()*60+t.Minute() == int(c)%(24*60)
+}
+
+// Span is a range of the day, [Start, End).
+type Span struct {
+	Start Clock
+	End   Clock
+}
+
+// Window is one block of working hours on a weekday. Slots of SlotMinutes
+// are laid out from Start, and restart after each break.
+type Window struct {
+	Weekday     time.Weekday
+	Start       Clock
+	End         Clock
+	SlotMinutes int
+	Breaks      []Span
+}
+
+// Validate checks that the window is non-empty and its breaks lie inside it.
+func (w Window) Validate() error {
+	if w.End <= w.Start {
+		return fmt.Errorf("window %s-%s ends before it starts", w.Start, w.End)
+	}
+	if w.SlotMinutes <= 0 {
+		return fmt.Errorf("window %s-%s has no slot length", w.Start, w.End)
+	}
+	for _, b := range w.Breaks {
+		if b.End <= b.Start || b.Start < w.Start || b.End > w.End {
+			return fmt.Errorf("break %s-%s is outside window %s-%s", b.Start, b.End, w.Start, w.End)
+		}
+	}
+	return nil
+}
+
+// working returns the parts of the window that are not breaks.
+func (w Window) working() []Span {
+	breaks := append([]Span(nil), w.Breaks...)
+	sort.Slice(breaks, func(i, j int) bool { return breaks[i].Start < breaks[j].Start })
+
+	var spans []Span
+	start := w.Start
+	for _, b := range breaks {
+		if b.Start > start {
+			spans = append(spans, Span{Start: start, End: b.Start})
+		}
+		if b.End > start {
+			start = b.End
+		}
+	}
+	if w.End > start {
+		spans = append(spans, Span{Start: start, End: w.End})
+	}
+	return spans
+}
+
+// Interval is a range of instants, [Start, End).
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (i Interval) Overlaps(o Interval) bool {
+	return i.Start.Before(o.End) && o.Start.Before(i.End)
+}
+
+// Schedule is everything needed to decide when a doctor is free.
+// Exceptions (leave, holidays) and Busy (existing appointments) both block
+// time; they are kept apart because only Exceptions make a time fall
+// outside the doctor's availability.
+type Schedule struct {
+	Windows    []Window
+	Exceptions []Interval
+	Busy       []Interval
+}
+
+// Slots lists the free slots that start in [from, to), in order.
+func (s Schedule) Slots(from, to time.Time) []Interval {
+	var slots []Interval
+	for day := midnight(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, w := range s.Windows {
+			if w.Weekday != day.Weekday() || w.SlotMinutes <= 0 {
+				continue
+			}
+			for _, span := range w.working() {
+				for c := span.Start; c+Clock(w.SlotMinutes) <= span.End; c += Clock(w.SlotMinutes) {
+					slot := Interval{Start: c.on(day), End: (c + Clock(w.SlotMinutes)).on(day)}
+					if !c.shows(slot.Start) {
+						continue
+					}
+					if slot.Start.Before(from) || !slot.Start.Before(to) {
+						continue
+					}
+					if overlapsAny(slot, s.Exceptions) || overlapsAny(slot, s.Busy) {
+						continue
+					}
+					slots = append(slots, slot)
+				}
+			}
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Start.Before(slots[j].Start) })
+	return slots
+}
+
+// Covers reports whether [start, end) lies within working hours on a
+// single day, outside breaks and exceptions. It does not require the
+// range to line up with slot boundaries, and ignores Busy.
+func (s Schedule) Covers(start, end time.Time) bool {
+	want := Interval{Start: start, End: end}
+	if !start.Before(end) || overlapsAny(want, s.Exceptions) {
+		return false
+	}
+
+	day := midnight(start)
+	for _, w := range s.Windows {
+		if w.Weekday != day.Weekday() {
+			continue
+		}
+		for _, span := range w.working() {
+			if !start.Before(span.Start.on(day)) && !end.After(span.End.on(day)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func overlapsAny(i Interval, others []Interval) bool {
+	for _, o := range others {
+		if i.Overlaps(o) {
+			return true
+		}
+	}
+	return false
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
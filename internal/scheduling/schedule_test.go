@@ -0,0 +1,113 @@
+package scheduling_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/scheduling"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02T15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func clock(s string) scheduling.Clock {
+	c, err := scheduling.ParseClock(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func starts(slots []scheduling.Interval) []string {
+	out := make([]string, 0, len(slots))
+	for _, s := range slots {
+		out = append(out, s.Start.Format("Mon 15:04"))
+	}
+	return out
+}
+
+// 2025-03-03 is a Monday.
+var mondayMorning = scheduling.Window{
+	Weekday:     time.Monday,
+	Start:       clock("09:00"),
+	End:         clock("11:00"),
+	SlotMinutes: 30,
+	Breaks:      []scheduling.Span{{Start: clock("10:00"), End: clock("10:15")}},
+}
+
+func TestSlotsRestartAfterBreak(t *testing.T) {
+	s := scheduling.Schedule{Windows: []scheduling.Window{mondayMorning}}
+
+	slots := s.Slots(at("2025-03-03T00:00"), at("2025-03-04T00:00"))
+
+	assert.Equal(t, []string{"Mon 09:00", "Mon 09:30", "Mon 10:15"}, starts(slots))
+	assert.Equal(t, at("2025-03-03T10:45"), slots[2].End)
+}
+
+func TestSlotsSkipBusyAndExceptions(t *testing.T) {
+	s := scheduling.Schedule{
+		Windows:    []scheduling.Window{mondayMorning},
+		Busy:       []scheduling.Interval{{Start: at("2025-03-03T09:10"), End: at("2025-03-03T09:20")}},
+		Exceptions: []scheduling.Interval{{Start: at("2025-03-10T00:00"), End: at("2025-03-11T00:00")}},
+	}
+
+	slots := s.Slots(at("2025-03-03T00:00"), at("2025-03-11T00:00"))
+
+	assert.Equal(t, []string{"Mon 09:30", "Mon 10:15"}, starts(slots))
+}
+
+func TestSlotsHonourRangeBounds(t *testing.T) {
+	s := scheduling.Schedule{Windows: []scheduling.Window{mondayMorning}}
+
+	slots := s.Slots(at("2025-03-03T09:30"), at("2025-03-03T10:15"))
+
+	assert.Equal(t, []string{"Mon 09:30"}, starts(slots))
+}
+
+func TestCovers(t *testing.T) {
+	s := scheduling.Schedule{
+		Windows:    []scheduling.Window{mondayMorning},
+		Exceptions: []scheduling.Interval{{Start: at("2025-03-10T09:00"), End: at("2025-03-10T12:00")}},
+	}
+
+	assert.True(t, s.Covers(at("2025-03-03T09:10"), at("2025-03-03T09:55")), "off-grid inside hours")
+	assert.False(t, s.Covers(at("2025-03-03T09:45"), at("2025-03-03T10:05")), "runs into break")
+	assert.False(t, s.Covers(at("2025-03-03T10:45"), at("2025-03-03T11:15")), "runs past end")
+	assert.False(t, s.Covers(at("2025-03-04T09:00"), at("2025-03-04T09:30")), "not a working day")
+	assert.False(t, s.Covers(at("2025-03-10T09:00"), at("2025-03-10T09:30")), "on leave")
+}
+
+func TestWindowValidate(t *testing.T) {
+	require.NoError(t, mondayMorning.Validate())
+
+	bad := mondayMorning
+	bad.Breaks = []scheduling.Span{{Start: clock("08:30"), End: clock("09:15")}}
+	assert.Error(t, bad.Validate())
+
+	_, err := scheduling.ParseClock("25:00")
+	assert.Error(t, err)
+}
+
+func TestSlotsSkipTimesLostToDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+	// Clocks went forward from 01:00 to 02:00 on Sunday 2025-03-30.
+	s := scheduling.Schedule{Windows: []scheduling.Window{{
+		Weekday:     time.Sunday,
+		Start:       clock("00:00"),
+		End:         clock("03:00"),
+		SlotMinutes: 30,
+	}}}
+
+	day := time.Date(2025, 3, 30, 0, 0, 0, 0, loc)
+	slots := s.Slots(day, day.AddDate(0, 0, 1))
+
+	assert.Equal(t, []string{"Sun 00:00", "Sun 00:30", "Sun 02:00", "Sun 02:30"}, starts(slots))
+}
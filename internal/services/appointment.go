@@ -2,93 +2,471 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/prem0x01/hospital/internal/audit"
 	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/localtime"
 	"github.com/prem0x01/hospital/internal/repository"
 	"github.com/prem0x01/hospital/internal/utils"
 )
 
+const defaultAppointmentDuration = 30 * time.Minute
+
 type AppointmentService struct {
-	appointmentRepo *repository.AppointmentRepository
-	patientRepo     *repository.PatientRepository
+	appointmentRepo     *repository.AppointmentRepository
+	patientRepo         *repository.PatientRepository
+	careTeamService     *CareTeamService
+	availabilityService *AvailabilityService
+	waitlistService     *WaitlistService
+	resourceService     *ResourceService
+	noteService         *ClinicalNoteService
+	auditService        *AuditService
+	// loc is the hospital's time zone, in which times without a UTC
+	// offset are read.
+	loc *time.Location
 }
 
-func NewAppointmentService(appointmentRepo *repository.AppointmentRepository, patientRepo *repository.PatientRepository) *AppointmentService {
+func NewAppointmentService(appointmentRepo *repository.AppointmentRepository, patientRepo *repository.PatientRepository, careTeamService *CareTeamService, availabilityService *AvailabilityService, waitlistService *WaitlistService, resourceService *ResourceService, noteService *ClinicalNoteService, auditService *AuditService, loc *time.Location) *AppointmentService {
 	return &AppointmentService{
-		appointmentRepo: appointmentRepo,
-		patientRepo:     patientRepo,
+		appointmentRepo:     appointmentRepo,
+		patientRepo:         patientRepo,
+		careTeamService:     careTeamService,
+		availabilityService: availabilityService,
+		waitlistService:     waitlistService,
+		resourceService:     resourceService,
+		noteService:         noteService,
+		auditService:        auditService,
+		loc:                 loc,
 	}
 }
 
-func (s *AppointmentService) GetAppointments(limit, offset int, userRole string, userID int) ([]domain.Appointment, error) {
+func (s *AppointmentService) GetAppointments(actor domain.Actor, limit, offset int, filter domain.AppointmentFilter) ([]domain.Appointment, error) {
 	ctx := context.Background()
-	return s.appointmentRepo.GetAll(ctx, int32(limit), int32(offset), userRole, int32(userID))
+	appointments, err := s.appointmentRepo.GetAll(ctx, int32(limit), int32(offset), actor.Role, actor.UserID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(appointments))
+	for i := range appointments {
+		a := appointments[i]
+		entries = append(entries, NewEntry(actor, domain.AuditActionList, domain.ResourceAppointment, &a.ID, a.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return appointments, nil
 }
 
-func (s *AppointmentService) GetAppointment(id int) (*domain.Appointment, error) {
+func (s *AppointmentService) GetAppointment(actor domain.Actor, id int) (*domain.Appointment, error) {
 	ctx := context.Background()
-	return s.appointmentRepo.GetByID(ctx, int32(id))
+	appointment, err := s.appointmentRepo.GetByID(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, actor, appointment); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourceAppointment, &appointment.ID, appointment.PatientID, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return appointment, nil
 }
 
-func (s *AppointmentService) CreateAppointment(req *domain.CreateAppointmentRequest, createdBy int) (*domain.Appointment, error) {
+func (s *AppointmentService) CreateAppointment(actor domain.Actor, req *domain.CreateAppointmentRequest) (*domain.Appointment, error) {
 	ctx := context.Background()
+	// Appointments put the doctor on the care team, so a doctor may only
+	// book patients they can already see.
+	if err := s.careTeamService.Authorize(ctx, actor, int32(req.PatientID)); err != nil {
+		return nil, err
+	}
+
 	_, err := s.patientRepo.GetByID(ctx, int32(req.PatientID))
 	if err != nil {
 		return nil, err
 	}
 
-	appointmentDate, err := time.Parse("2006-01-02T15:04", req.AppointmentDate)
+	appointmentDate, err := localtime.Parse(req.AppointmentDate, s.loc)
 	if err != nil {
+		return nil, fmt.Errorf("%w: appointment_date: %v", domain.ErrInvalid, err)
+	}
+	if err := s.resourceService.checkPlacement(ctx, req.DepartmentID, req.RoomID); err != nil {
 		return nil, err
 	}
 
+	duration := defaultAppointmentDuration
+	if req.DurationMinutes > 0 {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+	}
+
 	appointment := &domain.Appointment{
 		PatientID:       utils.Int32Ptr(req.PatientID),
 		DoctorID:        req.DoctorID,
 		AppointmentDate: utils.TimeToTimestamp(appointmentDate),
+		EndTime:         utils.TimeToTimestamp(appointmentDate.Add(duration)),
+		DepartmentID:    req.DepartmentID,
+		RoomID:          req.RoomID,
 		Notes:           req.Notes,
-		CreatedBy:       utils.IintPtrToInt32Ptr(&createdBy),
+		CreatedBy:       utils.Int32Ptr(actor.UserID),
+	}
+
+	if err := s.book(ctx, appointment, req.OverrideAvailability); err != nil {
+		return nil, err
 	}
 
-	if err := s.appointmentRepo.Create(ctx, appointment); err != nil {
+	changes := audit.Diff(nil, appointment)
+	if req.OverrideAvailability && appointment.DoctorID != nil {
+		changes = markOverride(changes)
+	}
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceAppointment, &appointment.ID, appointment.PatientID, changes)
+	if err := s.auditService.Record(ctx, entry); err != nil {
 		return nil, err
 	}
 
 	return appointment, nil
 }
 
-func (s *AppointmentService) UpdateAppointment(id int, req *domain.UpdateAppointmentRequest) error {
+func (s *AppointmentService) UpdateAppointment(actor domain.Actor, id int, req *domain.UpdateAppointmentRequest) error {
+	ctx := context.Background()
+	freed, err := s.updateAppointment(ctx, actor, id, req)
+	if err != nil {
+		return err
+	}
+	if freed != nil {
+		s.releaseSlot(ctx, actor, freed)
+	}
+	return nil
+}
+
+// updateAppointment applies req and, if that gives up the time the
+// appointment was booked for, returns the appointment as it was so the
+// caller can offer the time to the waitlist.
+func (s *AppointmentService) updateAppointment(ctx context.Context, actor domain.Actor, id int, req *domain.UpdateAppointmentRequest) (*domain.Appointment, error) {
+	before, err := s.appointmentRepo.GetByID(ctx, int32(id))
+	if err != nil {
+		return nil, fmt.Errorf("appointment not found: %w", err)
+	}
+	if err := s.authorize(ctx, actor, before); err != nil {
+		return nil, err
+	}
+
 	updates := make(map[string]interface{})
+	// proposed holds the booking as it will be after the update, for
+	// reporting overlaps.
+	proposed := *before
 
 	if req.DoctorID != nil {
 		updates["doctor_id"] = *req.DoctorID
+		proposed.DoctorID = req.DoctorID
+	}
+	if err := s.resourceService.checkPlacement(ctx, req.DepartmentID, req.RoomID); err != nil {
+		return nil, err
+	}
+	if req.DepartmentID != nil {
+		updates["department_id"] = *req.DepartmentID
+	}
+	if req.RoomID != nil {
+		updates["room_id"] = *req.RoomID
+		proposed.RoomID = req.RoomID
 	}
+
+	// Moving an appointment keeps its length unless a new one is given.
+	start := before.AppointmentDate.Time
+	duration := before.EndTime.Time.Sub(start)
 	if req.AppointmentDate != nil && *req.AppointmentDate != "" {
-		appointmentDate, err := time.Parse("2006-01-02T15:04", *req.AppointmentDate)
+		appointmentDate, err := localtime.Parse(*req.AppointmentDate, s.loc)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("%w: appointment_date: %v", domain.ErrInvalid, err)
 		}
 		updates["appointment_date"] = appointmentDate
+		start = appointmentDate
+	}
+	if req.DurationMinutes != nil {
+		duration = time.Duration(*req.DurationMinutes) * time.Minute
 	}
-	if req.Status != nil {
-		updates["status"] = *req.Status
+	if _, ok := updates["appointment_date"]; ok || req.DurationMinutes != nil {
+		updates["end_time"] = start.Add(duration)
+		proposed.AppointmentDate = utils.TimeToTimestamp(start)
+		proposed.EndTime = utils.TimeToTimestamp(start.Add(duration))
+	}
+	// Status changes go through the state machine rather than the
+	// generic update.
+	var statusFrom string
+	statusChanged := req.Status != nil && *req.Status != statusOf(before)
+	if statusChanged {
+		if statusFrom, err = s.checkTransition(actor, before, *req.Status, req.StatusReason); err != nil {
+			return nil, err
+		}
 	}
 	if req.Notes != nil {
 		updates["notes"] = *req.Notes
 	}
-	if req.Diagnosis != nil {
-		updates["diagnosis"] = *req.Diagnosis
+
+	_, moved := updates["end_time"]
+	if moved || req.DoctorID != nil {
+		if err := s.checkAvailability(ctx, &proposed, req.OverrideAvailability); err != nil {
+			return nil, err
+		}
+		if err := s.checkHeld(ctx, &proposed); err != nil {
+			return nil, err
+		}
+	}
+
+	// A diagnosis or treatment plan goes into a new version of the
+	// encounter note, which copies it back onto the appointment.
+	var note *domain.ClinicalNote
+	recorded := req.Diagnosis != nil || req.TreatmentPlan != nil
+	if recorded {
+		current, err := s.noteService.checkRecordFromAppointment(ctx, actor, before)
+		if err != nil {
+			return nil, err
+		}
+		note = noteFromAppointment(actor, before, current, req.Diagnosis, req.TreatmentPlan)
+	}
+	var status *domain.AppointmentStatusChange
+	if statusChanged {
+		status = &domain.AppointmentStatusChange{
+			FromStatus: &statusFrom,
+			ToStatus:   *req.Status,
+			Reason:     req.StatusReason,
+			ChangedBy:  &actor.UserID,
+		}
+	}
+
+	if len(updates) == 0 && !statusChanged && !recorded {
+		return nil, fmt.Errorf("%w: no updates provided", domain.ErrInvalid)
 	}
-	if req.TreatmentPlan != nil {
-		updates["treatment_plan"] = *req.TreatmentPlan
+	if err := s.appointmentRepo.Change(ctx, int32(id), updates, status, note); err != nil {
+		return nil, s.conflictError(ctx, err, before.ID, &proposed)
 	}
+
+	after, err := s.appointmentRepo.GetByID(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := audit.Diff(before, after)
+	if req.OverrideAvailability && (moved || req.DoctorID != nil) {
+		changes = markOverride(changes)
+	}
+	entries := []*domain.AuditEntry{NewEntry(actor, domain.AuditActionUpdate, domain.ResourceAppointment, &after.ID, after.PatientID, changes)}
+	if note != nil {
+		entries = append(entries, noteEntry(actor, note))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+
+	if moved || req.DoctorID != nil || (statusChanged && *req.Status == domain.AppointmentCancelled) {
+		return before, nil
+	}
+	return nil, nil
+}
+
+// ChangeStatus moves an appointment through its workflow; see
+// domain.CheckAppointmentTransition for the allowed moves.
+func (s *AppointmentService) ChangeStatus(actor domain.Actor, id int, req *domain.AppointmentStatusRequest) (*domain.Appointment, error) {
 	ctx := context.Background()
+	before, err := s.appointmentRepo.GetByID(ctx, int32(id))
+	if err != nil {
+		return nil, fmt.Errorf("%w: appointment %d", domain.ErrNotFound, id)
+	}
+
+	from, err := s.checkTransition(actor, before, req.Status, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.appointmentRepo.Transition(ctx, before.ID, from, req.Status, req.Reason, actor.UserID); err != nil {
+		return nil, err
+	}
 
-	return s.appointmentRepo.Update(ctx, int32(id), updates)
+	after, err := s.appointmentRepo.GetByID(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceAppointment, &after.ID, after.PatientID, audit.Diff(before, after))
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	if req.Status == domain.AppointmentCancelled {
+		s.releaseSlot(ctx, actor, before)
+	}
+	return after, nil
 }
 
-func (s *AppointmentService) DeleteAppointment(id int) error {
+// CheckIn records a patient's arrival for a scheduled appointment and
+// puts them in the doctor's queue with the requested priority.
+func (s *AppointmentService) CheckIn(actor domain.Actor, id int, req *domain.CheckInRequest) (*domain.Appointment, error) {
 	ctx := context.Background()
-	return s.appointmentRepo.Delete(ctx, int32(id))
+	before, err := s.appointmentRepo.GetByID(ctx, int32(id))
+	if err != nil {
+		return nil, fmt.Errorf("%w: appointment %d", domain.ErrNotFound, id)
+	}
+
+	if _, err := s.checkTransition(actor, before, domain.AppointmentCheckedIn, nil); err != nil {
+		return nil, err
+	}
+	priority := req.Priority
+	if priority == "" {
+		priority = domain.PriorityRoutine
+	}
+	if err := s.appointmentRepo.CheckIn(ctx, before.ID, priority, actor.UserID); err != nil {
+		return nil, err
+	}
+
+	after, err := s.appointmentRepo.GetByID(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := audit.Diff(before, after)
+	changes["priority"] = domain.FieldChange{After: priority}
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceAppointment, &after.ID, after.PatientID, changes)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (s *AppointmentService) GetStatusHistory(actor domain.Actor, id int) ([]domain.AppointmentStatusChange, error) {
+	ctx := context.Background()
+	appointment, err := s.appointmentRepo.GetByID(ctx, int32(id))
+	if err != nil {
+		return nil, fmt.Errorf("%w: appointment %d", domain.ErrNotFound, id)
+	}
+	if err := s.authorize(ctx, actor, appointment); err != nil {
+		return nil, err
+	}
+
+	history, err := s.appointmentRepo.ListStatusHistory(ctx, appointment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourceAppointment, &appointment.ID, appointment.PatientID, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// checkTransition validates a status change of a by actor and returns the
+// status it starts from. Doctors may only move their own appointments.
+func (s *AppointmentService) checkTransition(actor domain.Actor, a *domain.Appointment, to string, reason *string) (string, error) {
+	from := statusOf(a)
+	if err := domain.CheckAppointmentTransition(from, to, actor.Role); err != nil {
+		return "", err
+	}
+	if actor.Role == domain.RoleDoctor && (a.DoctorID == nil || *a.DoctorID != actor.UserID) {
+		return "", fmt.Errorf("%w: appointment %d is not yours", domain.ErrForbidden, a.ID)
+	}
+	if to == domain.AppointmentCancelled && (reason == nil || strings.TrimSpace(*reason) == "") {
+		return "", fmt.Errorf("%w: a reason is required to cancel an appointment", domain.ErrInvalid)
+	}
+	return from, nil
+}
+
+// authorize checks that actor may open appointment a. Doctors need to be
+// its doctor or to have access to its patient's chart, as for reading the
+// patient; putting themselves on an appointment does not give them that.
+func (s *AppointmentService) authorize(ctx context.Context, actor domain.Actor, a *domain.Appointment) error {
+	if actor.Role != domain.RoleDoctor {
+		return nil
+	}
+	if a.DoctorID != nil && *a.DoctorID == actor.UserID {
+		return nil
+	}
+	if a.PatientID == nil {
+		return fmt.Errorf("%w: appointment %d is not yours", domain.ErrForbidden, a.ID)
+	}
+	return s.careTeamService.Authorize(ctx, actor, *a.PatientID)
+}
+
+func statusOf(a *domain.Appointment) string {
+	if a.Status == nil {
+		return domain.AppointmentScheduled
+	}
+	return *a.Status
+}
+
+func (s *AppointmentService) DeleteAppointment(actor domain.Actor, id int) error {
+	ctx := context.Background()
+	existing, err := s.appointmentRepo.GetByID(ctx, int32(id))
+	if err != nil {
+		return fmt.Errorf("appointment not found: %w", err)
+	}
+
+	if err := s.appointmentRepo.Delete(ctx, existing.ID); err != nil {
+		return err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionDelete, domain.ResourceAppointment, &existing.ID, existing.PatientID, audit.Diff(existing, nil))
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return err
+	}
+
+	s.releaseSlot(ctx, actor, existing)
+	return nil
+}
+
+// checkAvailability refuses bookings outside the doctor's working hours
+// unless the caller explicitly overrides it.
+func (s *AppointmentService) checkAvailability(ctx context.Context, a *domain.Appointment, override bool) error {
+	if a.DoctorID == nil || override {
+		return nil
+	}
+	return s.availabilityService.CheckAvailable(ctx, *a.DoctorID, a.AppointmentDate.Time, a.EndTime.Time)
+}
+
+// checkHeld refuses to book a doctor's time that is on hold for another
+// patient from the waitlist.
+func (s *AppointmentService) checkHeld(ctx context.Context, a *domain.Appointment) error {
+	if a.DoctorID == nil || a.PatientID == nil {
+		return nil
+	}
+	return s.waitlistService.CheckHeld(ctx, *a.DoctorID, *a.PatientID, a.AppointmentDate.Time, a.EndTime.Time)
+}
+
+// releaseSlot offers the time a was booked for to the waitlist, if a was
+// still going to take place.
+func (s *AppointmentService) releaseSlot(ctx context.Context, actor domain.Actor, a *domain.Appointment) {
+	switch statusOf(a) {
+	case domain.AppointmentScheduled, domain.AppointmentCheckedIn:
+		s.waitlistService.SlotReleased(ctx, actor, a)
+	}
+}
+
+// markOverride records in the audit changes that availability was
+// overridden.
+func markOverride(changes map[string]domain.FieldChange) map[string]domain.FieldChange {
+	if changes == nil {
+		changes = make(map[string]domain.FieldChange)
+	}
+	changes["availability_override"] = domain.FieldChange{After: true}
+	return changes
+}
+
+// conflictError replaces an overlap reported by the database with an
+// AppointmentConflictError listing the bookings that are in the way.
+// Other errors are returned unchanged.
+func (s *AppointmentService) conflictError(ctx context.Context, err error, excludeID int32, a *domain.Appointment) error {
+	if !errors.Is(err, domain.ErrConflict) {
+		return err
+	}
+
+	// Other conflicts, such as a status that changed meanwhile, have no
+	// overlapping appointments to list.
+	conflicts, lookupErr := s.appointmentRepo.ListOverlapping(ctx, excludeID, a.DoctorID, a.PatientID, a.RoomID, a.AppointmentDate, a.EndTime)
+	if lookupErr != nil || len(conflicts) == 0 {
+		return err
+	}
+	return &domain.AppointmentConflictError{Conflicts: conflicts}
 }
@@ -5,9 +5,16 @@ import (
 )
 
 type IAppointmentService interface {
-	GetAppointments(limit, offset int, userRole string, userID int) ([]domain.Appointment, error)
-	GetAppointment(id int) (*domain.Appointment, error)
-	CreateAppointment(req *domain.CreateAppointmentRequest, createdBy int) (*domain.Appointment, error)
-	UpdateAppointment(id int, req *domain.UpdateAppointmentRequest) error
-	DeleteAppointment(id int) error
+	GetAppointments(actor domain.Actor, limit, offset int, filter domain.AppointmentFilter) ([]domain.Appointment, error)
+	GetAppointment(actor domain.Actor, id int) (*domain.Appointment, error)
+	CreateAppointment(actor domain.Actor, req *domain.CreateAppointmentRequest) (*domain.Appointment, error)
+	UpdateAppointment(actor domain.Actor, id int, req *domain.UpdateAppointmentRequest) error
+	DeleteAppointment(actor domain.Actor, id int) error
+	ChangeStatus(actor domain.Actor, id int, req *domain.AppointmentStatusRequest) (*domain.Appointment, error)
+	CheckIn(actor domain.Actor, id int, req *domain.CheckInRequest) (*domain.Appointment, error)
+	GetStatusHistory(actor domain.Actor, id int) ([]domain.AppointmentStatusChange, error)
+	CreateSeries(actor domain.Actor, req *domain.CreateAppointmentSeriesRequest) (*domain.AppointmentSeriesResult, error)
+	GetSeries(actor domain.Actor, id int) (*domain.AppointmentSeries, error)
+	UpdateSeries(actor domain.Actor, id int, scope string, req *domain.UpdateAppointmentRequest) (*domain.AppointmentSeriesResult, error)
+	CancelSeries(actor domain.Actor, id int, req *domain.CancelAppointmentSeriesRequest) (*domain.AppointmentSeriesResult, error)
 }
@@ -0,0 +1,359 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/audit"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/localtime"
+	"github.com/prem0x01/hospital/internal/recurrence"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+// maxSeriesOccurrences bounds how many appointments one series may book:
+// two years of weekly visits.
+const maxSeriesOccurrences = 104
+
+// CreateSeries books every occurrence of req.RRule. Occurrences that clash
+// with other bookings or fall outside the doctor's hours are reported in
+// the result rather than failing the whole series; if none can be booked
+// the series is discarded and ErrConflict is returned with the result.
+func (s *AppointmentService) CreateSeries(actor domain.Actor, req *domain.CreateAppointmentSeriesRequest) (*domain.AppointmentSeriesResult, error) {
+	ctx := context.Background()
+	if err := s.careTeamService.Authorize(ctx, actor, req.PatientID); err != nil {
+		return nil, err
+	}
+	if _, err := s.patientRepo.GetByID(ctx, req.PatientID); err != nil {
+		return nil, err
+	}
+
+	start, err := localtime.Parse(req.AppointmentDate, s.loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: appointment_date: %v", domain.ErrInvalid, err)
+	}
+	rule, err := recurrence.Parse(req.RRule, s.loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	occurrences, err := rule.Expand(start, maxSeriesOccurrences)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+
+	duration := defaultAppointmentDuration
+	if req.DurationMinutes > 0 {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+	}
+
+	series := &domain.AppointmentSeries{
+		PatientID:       req.PatientID,
+		DoctorID:        req.DoctorID,
+		RRule:           req.RRule,
+		StartsAt:        start,
+		DurationMinutes: int32(duration / time.Minute),
+		Notes:           req.Notes,
+		CreatedBy:       utils.Int32Ptr(actor.UserID),
+	}
+	if err := s.appointmentRepo.CreateSeries(ctx, series); err != nil {
+		return nil, err
+	}
+
+	result := &domain.AppointmentSeriesResult{
+		Series:    series,
+		Succeeded: []domain.Appointment{},
+		Failed:    []domain.OccurrenceFailure{},
+	}
+	entries := []*domain.AuditEntry{
+		NewEntry(actor, domain.AuditActionCreate, domain.ResourceAppointmentSeries, &series.ID, &series.PatientID, audit.Diff(nil, series)),
+	}
+	for _, at := range occurrences {
+		// An occurrence whose time is skipped or repeated on its day
+		// because the clocks change is reported rather than moved.
+		wall := time.Date(at.Year(), at.Month(), at.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+		resolved, err := localtime.Resolve(wall, s.loc)
+		if err != nil {
+			result.Failed = append(result.Failed, occurrenceFailure(nil, at, fmt.Errorf("%w: %v", domain.ErrInvalid, err)))
+			continue
+		}
+		at = resolved
+
+		appointment := &domain.Appointment{
+			PatientID:       utils.Int32Ptr(req.PatientID),
+			DoctorID:        req.DoctorID,
+			AppointmentDate: utils.TimeToTimestamp(at),
+			EndTime:         utils.TimeToTimestamp(at.Add(duration)),
+			SeriesID:        &series.ID,
+			Notes:           req.Notes,
+			CreatedBy:       utils.Int32Ptr(actor.UserID),
+		}
+		if err := s.book(ctx, appointment, req.OverrideAvailability); err != nil {
+			result.Failed = append(result.Failed, occurrenceFailure(nil, at, err))
+			continue
+		}
+
+		changes := audit.Diff(nil, appointment)
+		if req.OverrideAvailability && appointment.DoctorID != nil {
+			changes = markOverride(changes)
+		}
+		entries = append(entries, NewEntry(actor, domain.AuditActionCreate, domain.ResourceAppointment, &appointment.ID, appointment.PatientID, changes))
+		result.Succeeded = append(result.Succeeded, *appointment)
+	}
+
+	if len(result.Succeeded) == 0 {
+		if err := s.appointmentRepo.DeleteSeries(ctx, series.ID); err != nil {
+			return nil, err
+		}
+		result.Series = nil
+		return result, fmt.Errorf("%w: no occurrence of the series could be booked", domain.ErrConflict)
+	}
+
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	series.Appointments = result.Succeeded
+	return result, nil
+}
+
+func (s *AppointmentService) GetSeries(actor domain.Actor, id int) (*domain.AppointmentSeries, error) {
+	ctx := context.Background()
+	series, err := s.appointmentRepo.GetSeries(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.careTeamService.Authorize(ctx, actor, series.PatientID); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourceAppointmentSeries, &series.ID, &series.PatientID, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// UpdateSeries edits the appointment id and, depending on scope, the
+// scheduled appointments after it in its series or all of them. A new
+// appointment date moves every affected occurrence by the same number of
+// days and to the same time of day; doctor, duration and notes are copied
+// as given. Each occurrence is updated as by UpdateAppointment, so
+// conflicts and availability are checked one by one and failures are
+// reported in the result.
+func (s *AppointmentService) UpdateSeries(actor domain.Actor, id int, scope string, req *domain.UpdateAppointmentRequest) (*domain.AppointmentSeriesResult, error) {
+	ctx := context.Background()
+	selected, err := s.appointmentRepo.GetByID(ctx, int32(id))
+	if err != nil {
+		return nil, fmt.Errorf("%w: appointment %d", domain.ErrNotFound, id)
+	}
+
+	targets := []domain.Appointment{*selected}
+	var series *domain.AppointmentSeries
+	switch scope {
+	case domain.SeriesScopeThis:
+	case domain.SeriesScopeFollowing, domain.SeriesScopeAll:
+		if selected.SeriesID == nil {
+			return nil, fmt.Errorf("%w: appointment %d is not part of a series", domain.ErrInvalid, id)
+		}
+		if req.Status != nil || req.Diagnosis != nil || req.TreatmentPlan != nil {
+			return nil, fmt.Errorf("%w: status, diagnosis and treatment plan can only be changed one appointment at a time", domain.ErrInvalid)
+		}
+		if series, err = s.appointmentRepo.GetSeries(ctx, *selected.SeriesID); err != nil {
+			return nil, err
+		}
+
+		// Visits that already happened or were called off stay as they were.
+		targets = targets[:0]
+		for _, a := range series.Appointments {
+			if statusOf(&a) != domain.AppointmentScheduled {
+				continue
+			}
+			if scope == domain.SeriesScopeFollowing && a.AppointmentDate.Time.Before(selected.AppointmentDate.Time) {
+				continue
+			}
+			targets = append(targets, a)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown scope %q", domain.ErrInvalid, scope)
+	}
+
+	// Occurrences move by the same days and wall-clock time as the
+	// selected one, so they keep their time of day across clock changes.
+	var shift time.Duration
+	var movedTo time.Time
+	if req.AppointmentDate != nil && *req.AppointmentDate != "" {
+		if movedTo, err = localtime.Parse(*req.AppointmentDate, s.loc); err != nil {
+			return nil, fmt.Errorf("%w: appointment_date: %v", domain.ErrInvalid, err)
+		}
+		shift = movedTo.Sub(selected.AppointmentDate.Time)
+	}
+	var startsAt *time.Time
+	if scope == domain.SeriesScopeAll && shift != 0 {
+		t, err := localtime.Shift(series.StartsAt, selected.AppointmentDate.Time, movedTo, s.loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: series start: %v", domain.ErrInvalid, err)
+		}
+		startsAt = &t
+	}
+	// Move the last occurrence first when moving forward, and the first
+	// one first when moving back, so the series never overlaps itself.
+	if shift > 0 {
+		sort.Slice(targets, func(i, j int) bool {
+			return targets[i].AppointmentDate.Time.After(targets[j].AppointmentDate.Time)
+		})
+	}
+
+	result := &domain.AppointmentSeriesResult{
+		Series:    series,
+		Succeeded: []domain.Appointment{},
+		Failed:    []domain.OccurrenceFailure{},
+	}
+	// Freed time goes to the waitlist only once the whole series has
+	// moved, or it could be held against the series' own occurrences.
+	var freed []*domain.Appointment
+	defer func() {
+		for _, a := range freed {
+			s.releaseSlot(ctx, actor, a)
+		}
+	}()
+
+	for _, a := range targets {
+		occurrence := *req
+		occurrence.AppointmentDate = nil
+		if shift != 0 {
+			at, err := localtime.Shift(a.AppointmentDate.Time, selected.AppointmentDate.Time, movedTo, s.loc)
+			if err != nil {
+				result.Failed = append(result.Failed, occurrenceFailure(&a.ID, a.AppointmentDate.Time, fmt.Errorf("%w: %v", domain.ErrInvalid, err)))
+				continue
+			}
+			moved := at.Format(time.RFC3339)
+			occurrence.AppointmentDate = &moved
+		}
+
+		before, err := s.updateAppointment(ctx, actor, int(a.ID), &occurrence)
+		if err != nil {
+			result.Failed = append(result.Failed, occurrenceFailure(&a.ID, a.AppointmentDate.Time, err))
+			continue
+		}
+		if before != nil {
+			freed = append(freed, before)
+		}
+		after, err := s.appointmentRepo.GetByID(ctx, a.ID)
+		if err != nil {
+			return nil, err
+		}
+		result.Succeeded = append(result.Succeeded, *after)
+	}
+	sort.Slice(result.Succeeded, func(i, j int) bool {
+		return result.Succeeded[i].AppointmentDate.Time.Before(result.Succeeded[j].AppointmentDate.Time)
+	})
+
+	// Editing the whole series also changes what it is based on.
+	if scope == domain.SeriesScopeAll && len(result.Succeeded) > 0 {
+		var duration *int32
+		if req.DurationMinutes != nil {
+			duration = utils.Int32Ptr(int32(*req.DurationMinutes))
+		}
+		if err := s.appointmentRepo.UpdateSeries(ctx, series.ID, req.DoctorID, startsAt, duration, req.Notes); err != nil {
+			return nil, err
+		}
+		if result.Series, err = s.appointmentRepo.GetSeries(ctx, series.ID); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// CancelSeries cancels every scheduled appointment of the series that has
+// not started yet and closes the series. Past visits are kept.
+func (s *AppointmentService) CancelSeries(actor domain.Actor, id int, req *domain.CancelAppointmentSeriesRequest) (*domain.AppointmentSeriesResult, error) {
+	ctx := context.Background()
+	series, err := s.appointmentRepo.GetSeries(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if series.CancelledAt != nil {
+		return nil, fmt.Errorf("%w: series %d is already cancelled", domain.ErrConflict, id)
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, fmt.Errorf("%w: a reason is required to cancel a series", domain.ErrInvalid)
+	}
+
+	result := &domain.AppointmentSeriesResult{
+		Series:    series,
+		Succeeded: []domain.Appointment{},
+		Failed:    []domain.OccurrenceFailure{},
+	}
+	now := time.Now()
+	for _, a := range series.Appointments {
+		if statusOf(&a) != domain.AppointmentScheduled || a.AppointmentDate.Time.Before(now) {
+			continue
+		}
+		after, err := s.ChangeStatus(actor, int(a.ID), &domain.AppointmentStatusRequest{
+			Status: domain.AppointmentCancelled,
+			Reason: &req.Reason,
+		})
+		if err != nil {
+			result.Failed = append(result.Failed, occurrenceFailure(&a.ID, a.AppointmentDate.Time, err))
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, *after)
+	}
+
+	// Leave the series open if some occurrences could not be cancelled, so
+	// the caller can retry.
+	if len(result.Failed) > 0 {
+		return result, nil
+	}
+	if err := s.appointmentRepo.CancelSeries(ctx, series.ID); err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.appointmentRepo.GetSeries(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The appointments have their own audit entries from ChangeStatus.
+	changes := map[string]domain.FieldChange{
+		"cancelled_at": {After: cancelled.CancelledAt},
+		"reason":       {After: req.Reason},
+	}
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceAppointmentSeries, &series.ID, &series.PatientID, changes)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	result.Series = cancelled
+	return result, nil
+}
+
+// book checks the doctor's hours and waitlist holds and saves a. Overlaps are returned as an
+// AppointmentConflictError.
+func (s *AppointmentService) book(ctx context.Context, a *domain.Appointment, override bool) error {
+	if err := s.checkAvailability(ctx, a, override); err != nil {
+		return err
+	}
+	if err := s.checkHeld(ctx, a); err != nil {
+		return err
+	}
+	if err := s.appointmentRepo.Create(ctx, a); err != nil {
+		return s.conflictError(ctx, err, 0, a)
+	}
+	return nil
+}
+
+func occurrenceFailure(id *int32, at time.Time, err error) domain.OccurrenceFailure {
+	failure := domain.OccurrenceFailure{
+		AppointmentID:   id,
+		AppointmentDate: at,
+		Error:           err.Error(),
+	}
+	var conflict *domain.AppointmentConflictError
+	if errors.As(err, &conflict) {
+		failure.Conflicts = conflict.Conflicts
+	}
+	return failure
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/audit"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+const auditVerifyBatchSize = 500
+
+// maxPendingAudit bounds how many entries are kept for retry while the
+// audit log cannot be written.
+const maxPendingAudit = 10000
+
+var errChainBroken = errors.New("audit chain broken")
+
+type AuditService struct {
+	auditRepo *repository.AuditRepository
+
+	// mu guards the entries that could not be written yet and the
+	// failure state reported by Status.
+	mu          sync.Mutex
+	pending     []*domain.AuditEntry
+	failures    int64
+	lastFailure *time.Time
+	lastError   string
+}
+
+func NewAuditService(auditRepo *repository.AuditRepository) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// NewEntry builds an unsealed audit entry for an operation performed by actor.
+func NewEntry(actor domain.Actor, action, resourceType string, resourceID, patientID *int32, changes map[string]domain.FieldChange) *domain.AuditEntry {
+	e := &domain.AuditEntry{
+		OccurredAt:   time.Now(),
+		ActorRole:    actor.Role,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		PatientID:    patientID,
+		Changes:      changes,
+		IPAddress:    actor.IPAddress,
+		RequestID:    actor.RequestID,
+	}
+	if actor.UserID != 0 {
+		id := actor.UserID
+		e.ActorID = &id
+	}
+	return e
+}
+
+// Record appends entries to the audit chain. What happens when they cannot
+// be written depends on what they record:
+//
+//   - Access to data (read, list, search) has not happened yet, and PHI
+//     access that cannot be recorded must not be served, so Record returns
+//     the error and callers fail the request. These entries are dropped.
+//   - Changes have already been committed by the time they are recorded, so
+//     failing the request would only invite the client to repeat them.
+//     These entries are kept, retried by later calls and by Run, and
+//     reported by Status, and Record returns nil for them.
+//
+// Either way the failure is logged with the entries' identifiers.
+func (s *AuditService) Record(ctx context.Context, entries ...*domain.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// A backlog that still cannot be written must not hold up new
+	// entries; its failure has been logged.
+	_ = s.flush(ctx)
+	err := s.auditRepo.Append(ctx, entries...)
+	if err == nil {
+		return nil
+	}
+
+	s.noteFailure(err)
+	var kept []*domain.AuditEntry
+	refused := false
+	for _, e := range entries {
+		if isAccess(e.Action) {
+			refused = true
+			log.Printf("AUDIT LOG WRITE FAILED: %v; refusing %s", err, describeAuditEntry(e))
+			continue
+		}
+		log.Printf("AUDIT LOG WRITE FAILED: %v; keeping %s", err, describeAuditEntry(e))
+		kept = append(kept, e)
+	}
+	s.keep(kept)
+	if refused {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// refuse logs and reports entries that could not be written along with
+// the change they record, which was therefore not made.
+func (s *AuditService) refuse(err error, entries ...*domain.AuditEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.noteFailure(err)
+	for _, e := range entries {
+		log.Printf("AUDIT LOG WRITE FAILED: %v; refusing %s", err, describeAuditEntry(e))
+	}
+}
+
+// isAccess reports whether action records looking at data rather than
+// changing it.
+func isAccess(action string) bool {
+	switch action {
+	case domain.AuditActionRead, domain.AuditActionList, domain.AuditActionSearch:
+		return true
+	}
+	return false
+}
+
+// Run retries entries left over from failed Record calls every interval
+// until ctx is done, so they are written even when no new entries arrive.
+func (s *AuditService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			_ = s.flush(ctx)
+			s.mu.Unlock()
+		}
+	}
+}
+
+// Status reports whether the audit log is being written. It is unhealthy
+// while entries are waiting to be written.
+func (s *AuditService) Status() *domain.AuditStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &domain.AuditStatus{
+		Healthy:        len(s.pending) == 0,
+		PendingEntries: len(s.pending),
+		Failures:       s.failures,
+		LastFailure:    s.lastFailure,
+		LastError:      s.lastError,
+	}
+}
+
+// flush writes the pending entries. Callers hold s.mu.
+func (s *AuditService) flush(ctx context.Context) error {
+	if len(s.pending) == 0 {
+		return nil
+	}
+	if err := s.auditRepo.Append(ctx, s.pending...); err != nil {
+		s.noteFailure(err)
+		log.Printf("AUDIT LOG WRITE FAILED: %v; %d entries still pending", err, len(s.pending))
+		return err
+	}
+	log.Printf("audit: wrote %d entries left over from earlier failures", len(s.pending))
+	s.pending = nil
+	return nil
+}
+
+// keep queues entries for retry, dropping (and logging) the oldest beyond
+// maxPendingAudit. Callers hold s.mu.
+func (s *AuditService) keep(entries []*domain.AuditEntry) {
+	s.pending = append(s.pending, entries...)
+	if over := len(s.pending) - maxPendingAudit; over > 0 {
+		for _, e := range s.pending[:over] {
+			log.Printf("AUDIT LOG WRITE FAILED: dropping %s", describeAuditEntry(e))
+		}
+		s.pending = s.pending[over:]
+	}
+}
+
+// noteFailure records err for Status. Callers hold s.mu.
+func (s *AuditService) noteFailure(err error) {
+	now := time.Now()
+	s.failures++
+	s.lastFailure = &now
+	s.lastError = err.Error()
+}
+
+// describeAuditEntry identifies e for the server log. The changes are left
+// out since they may hold PHI.
+func describeAuditEntry(e *domain.AuditEntry) string {
+	return fmt.Sprintf("at=%s actor=%s role=%s action=%s resource=%s/%s patient=%s request=%s",
+		e.OccurredAt.Format(time.RFC3339Nano), optionalID(e.ActorID), e.ActorRole, e.Action,
+		e.ResourceType, optionalID(e.ResourceID), optionalID(e.PatientID), e.RequestID)
+}
+
+func optionalID(id *int32) string {
+	if id == nil {
+		return "-"
+	}
+	return fmt.Sprint(*id)
+}
+
+func (s *AuditService) GetAuditLog(filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	ctx := context.Background()
+	return s.auditRepo.List(ctx, filter)
+}
+
+// Verify walks the whole chain from the genesis entry and stops at the
+// first entry whose link or content does not match its hash.
+func (s *AuditService) Verify(ctx context.Context) (*domain.AuditVerifyResult, error) {
+	verifier := audit.NewVerifier()
+	result := &domain.AuditVerifyResult{Valid: true}
+
+	err := s.auditRepo.Walk(ctx, auditVerifyBatchSize, func(e *domain.AuditEntry) error {
+		if err := verifier.Check(e); err != nil {
+			result.Valid = false
+			result.BrokenAtID = e.ID
+			result.Reason = err.Error()
+			return errChainBroken
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errChainBroken) {
+		return nil, err
+	}
+
+	result.EntriesChecked = verifier.Checked()
+	return result, nil
+}
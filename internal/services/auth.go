@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"fmt"
 
 	"context"
 
@@ -11,14 +12,16 @@ import (
 )
 
 type AuthService struct {
-	userRepo  *repository.UserRepository
-	jwtSecret string
+	userRepo     *repository.UserRepository
+	auditService *AuditService
+	jwtSecret    string
 }
 
-func NewAuthService(userRepo *repository.UserRepository, jwtSecret string) *AuthService {
+func NewAuthService(userRepo *repository.UserRepository, auditService *AuditService, jwtSecret string) *AuthService {
 	return &AuthService{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
+		userRepo:     userRepo,
+		auditService: auditService,
+		jwtSecret:    jwtSecret,
 	}
 }
 
@@ -44,37 +47,55 @@ func (s *AuthService) Login(req *domain.LoginRequest) (*domain.AuthResponse, err
 	}, nil
 }
 
-func (s *AuthService) Register(req *domain.RegisterRequest) (*domain.AuthResponse, error) {
+// CreateUser creates a staff account; there is no self-registration, as
+// a doctor account alone can be given access to patients' charts.
+// Receptionists can create receptionist and doctor accounts, compliance
+// officers any account. Every account created is audited.
+func (s *AuthService) CreateUser(actor domain.Actor, req *domain.CreateUserRequest) (*domain.User, error) {
 	ctx := context.Background()
-	_, err := s.userRepo.GetByEmail(ctx, req.Email)
-	if err == nil {
-		return nil, errors.New("user already exists")
+	switch {
+	case actor.Role == domain.RoleCompliance:
+	case actor.Role == domain.RoleReceptionist && req.Role != domain.RoleCompliance:
+	default:
+		return nil, fmt.Errorf("%w: %s accounts cannot create %s accounts", domain.ErrForbidden, actor.Role, req.Role)
 	}
 
-	hashedPassword, err := utils.HashPassword(req.Password)
+	user, err := s.createUser(ctx, req.Email, req.Password, req.Role, req.FirstName, req.LastName)
 	if err != nil {
 		return nil, err
 	}
 
-	user := &domain.User{
-		Email:        req.Email,
-		PasswordHash: hashedPassword,
-		Role:         req.Role,
-		FirstName:    req.FirstName,
-		LastName:     req.LastName,
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceUser, &user.ID, nil, map[string]domain.FieldChange{
+		"email": {After: user.Email},
+		"role":  {After: user.Role},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
 	}
+	return user, nil
+}
 
-	if err := s.userRepo.Create(ctx, user); err != nil {
-		return nil, err
+func (s *AuthService) createUser(ctx context.Context, email, password, role, firstName, lastName string) (*domain.User, error) {
+	_, err := s.userRepo.GetByEmail(ctx, email)
+	if err == nil {
+		return nil, fmt.Errorf("%w: user already exists", domain.ErrConflict)
 	}
 
-	token, err := utils.GenerateJWT(int(user.ID), user.Role, s.jwtSecret)
+	hashedPassword, err := utils.HashPassword(password)
 	if err != nil {
 		return nil, err
 	}
 
-	return &domain.AuthResponse{
-		Token: token,
-		User:  *user,
-	}, nil
+	user := &domain.User{
+		Email:        email,
+		PasswordHash: hashedPassword,
+		Role:         role,
+		FirstName:    firstName,
+		LastName:     lastName,
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
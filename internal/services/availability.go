@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/localtime"
+	"github.com/prem0x01/hospital/internal/repository"
+	"github.com/prem0x01/hospital/internal/scheduling"
+)
+
+const (
+	defaultSlotMinutes = 30
+	maxSlotRange       = 31 * 24 * time.Hour
+)
+
+type AvailabilityService struct {
+	availabilityRepo *repository.AvailabilityRepository
+	userRepo         *repository.UserRepository
+	// loc is the hospital's time zone. Weekly templates are wall-clock
+	// hours in it.
+	loc *time.Location
+}
+
+func NewAvailabilityService(availabilityRepo *repository.AvailabilityRepository, userRepo *repository.UserRepository, loc *time.Location) *AvailabilityService {
+	return &AvailabilityService{availabilityRepo: availabilityRepo, userRepo: userRepo, loc: loc}
+}
+
+// GetAvailability returns the doctor's weekly template and the exceptions
+// that have not ended yet.
+func (s *AvailabilityService) GetAvailability(doctorID int) (*domain.DoctorAvailability, error) {
+	ctx := context.Background()
+	if err := s.requireDoctor(ctx, int32(doctorID)); err != nil {
+		return nil, err
+	}
+	return s.availability(ctx, int32(doctorID))
+}
+
+// SetAvailability replaces the doctor's weekly template. Receptionists can
+// set anyone's hours; doctors only their own.
+func (s *AvailabilityService) SetAvailability(actor domain.Actor, doctorID int, req *domain.SetAvailabilityRequest) (*domain.DoctorAvailability, error) {
+	ctx := context.Background()
+	if err := s.authorize(ctx, actor, int32(doctorID)); err != nil {
+		return nil, err
+	}
+
+	templates := make([]domain.AvailabilityTemplate, len(req.Templates))
+	copy(templates, req.Templates)
+	for i := range templates {
+		if templates[i].SlotMinutes == 0 {
+			templates[i].SlotMinutes = defaultSlotMinutes
+		}
+	}
+	if _, err := windowsFromTemplates(templates); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+
+	specialty := req.Specialty
+	if specialty != nil {
+		trimmed := strings.TrimSpace(*specialty)
+		specialty = &trimmed
+		if trimmed == "" {
+			specialty = nil
+		}
+	}
+
+	if err := s.availabilityRepo.ReplaceTemplates(ctx, int32(doctorID), specialty, templates); err != nil {
+		return nil, err
+	}
+	return s.availability(ctx, int32(doctorID))
+}
+
+func (s *AvailabilityService) AddException(actor domain.Actor, doctorID int, req *domain.CreateAvailabilityExceptionRequest) (*domain.AvailabilityException, error) {
+	ctx := context.Background()
+	if err := s.authorize(ctx, actor, int32(doctorID)); err != nil {
+		return nil, err
+	}
+
+	startsAt, err := localtime.Parse(req.StartsAt, s.loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: starts_at: %v", domain.ErrInvalid, err)
+	}
+	endsAt, err := localtime.Parse(req.EndsAt, s.loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ends_at: %v", domain.ErrInvalid, err)
+	}
+	if !endsAt.After(startsAt) {
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at", domain.ErrInvalid)
+	}
+
+	exception := &domain.AvailabilityException{
+		DoctorID:  int32(doctorID),
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		Kind:      req.Kind,
+		Reason:    req.Reason,
+		CreatedBy: &actor.UserID,
+	}
+	if err := s.availabilityRepo.CreateException(ctx, exception); err != nil {
+		return nil, err
+	}
+	return exception, nil
+}
+
+func (s *AvailabilityService) DeleteException(actor domain.Actor, doctorID, id int) error {
+	ctx := context.Background()
+	if err := s.authorize(ctx, actor, int32(doctorID)); err != nil {
+		return err
+	}
+	if err := s.availabilityRepo.DeleteException(ctx, int32(doctorID), int32(id)); err != nil {
+		return fmt.Errorf("exception %d: %w", id, err)
+	}
+	return nil
+}
+
+// GetDoctorSlots lists the doctor's free slots starting in [from, to).
+func (s *AvailabilityService) GetDoctorSlots(doctorID int, from, to time.Time) ([]domain.Slot, error) {
+	if err := checkSlotRange(from, to); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	if err := s.requireDoctor(ctx, int32(doctorID)); err != nil {
+		return nil, err
+	}
+	return s.slots(ctx, domain.DoctorProfile{DoctorID: int32(doctorID)}, from.In(s.loc), to.In(s.loc))
+}
+
+// SearchSlots lists the free slots on date of every doctor with the given
+// specialty, or of every doctor if specialty is empty, optionally limited
+// to one department.
+func (s *AvailabilityService) SearchSlots(specialty string, departmentID *int32, date time.Time) ([]domain.Slot, error) {
+	ctx := context.Background()
+	var filter *string
+	if specialty = strings.TrimSpace(specialty); specialty != "" {
+		filter = &specialty
+	}
+
+	doctors, err := s.availabilityRepo.ListDoctors(ctx, filter, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := localtime.StartOfDay(date, s.loc)
+	to := localtime.StartOfDay(from.AddDate(0, 0, 1), s.loc)
+	result := []domain.Slot{}
+	for _, d := range doctors {
+		slots, err := s.slots(ctx, d, from, to)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, slots...)
+	}
+	return result, nil
+}
+
+// CheckAvailable returns domain.ErrUnavailable unless [start, end) falls in
+// the doctor's working hours. Doctors without a weekly template are
+// treated as always available, so that scheduling can be rolled out one
+// doctor at a time.
+func (s *AvailabilityService) CheckAvailable(ctx context.Context, doctorID int32, start, end time.Time) error {
+	templates, err := s.availabilityRepo.ListTemplates(ctx, doctorID)
+	if err != nil {
+		return err
+	}
+	if len(templates) == 0 {
+		return nil
+	}
+
+	windows, err := windowsFromTemplates(templates)
+	if err != nil {
+		return err
+	}
+	exceptions, err := s.exceptionIntervals(ctx, doctorID, start, end)
+	if err != nil {
+		return err
+	}
+
+	start, end = start.In(s.loc), end.In(s.loc)
+	schedule := scheduling.Schedule{Windows: windows, Exceptions: exceptions}
+	if !schedule.Covers(start, end) {
+		return fmt.Errorf("%w: doctor %d is not available %s-%s", domain.ErrUnavailable, doctorID,
+			start.Format("2006-01-02T15:04"), end.Format("15:04 MST"))
+	}
+	return nil
+}
+
+func (s *AvailabilityService) slots(ctx context.Context, doctor domain.DoctorProfile, from, to time.Time) ([]domain.Slot, error) {
+	templates, err := s.availabilityRepo.ListTemplates(ctx, doctor.DoctorID)
+	if err != nil {
+		return nil, err
+	}
+	windows, err := windowsFromTemplates(templates)
+	if err != nil {
+		return nil, err
+	}
+	exceptions, err := s.exceptionIntervals(ctx, doctor.DoctorID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	// Slots starting before to may end after it.
+	busy, err := s.availabilityRepo.ListBusy(ctx, doctor.DoctorID, from, to.Add(24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := scheduling.Schedule{Windows: windows, Exceptions: exceptions, Busy: busy}
+	free := schedule.Slots(from, to)
+	result := make([]domain.Slot, 0, len(free))
+	for _, f := range free {
+		result = append(result, domain.Slot{
+			DoctorID:   doctor.DoctorID,
+			DoctorName: doctor.Name,
+			Specialty:  doctor.Specialty,
+			Start:      f.Start,
+			End:        f.End,
+		})
+	}
+	return result, nil
+}
+
+func (s *AvailabilityService) exceptionIntervals(ctx context.Context, doctorID int32, from, to time.Time) ([]scheduling.Interval, error) {
+	exceptions, err := s.availabilityRepo.ListExceptions(ctx, doctorID, from, to.Add(24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]scheduling.Interval, 0, len(exceptions))
+	for _, e := range exceptions {
+		result = append(result, scheduling.Interval{Start: e.StartsAt, End: e.EndsAt})
+	}
+	return result, nil
+}
+
+func (s *AvailabilityService) availability(ctx context.Context, doctorID int32) (*domain.DoctorAvailability, error) {
+	specialty, err := s.availabilityRepo.GetSpecialty(ctx, doctorID)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := s.availabilityRepo.ListTemplates(ctx, doctorID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	exceptions, err := s.availabilityRepo.ListExceptions(ctx, doctorID, now, now.AddDate(10, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DoctorAvailability{
+		DoctorID:   doctorID,
+		Specialty:  specialty,
+		Templates:  templates,
+		Exceptions: exceptions,
+	}, nil
+}
+
+func (s *AvailabilityService) authorize(ctx context.Context, actor domain.Actor, doctorID int32) error {
+	switch {
+	case actor.Role == domain.RoleReceptionist:
+	case actor.Role == domain.RoleDoctor && actor.UserID == doctorID:
+	default:
+		return fmt.Errorf("%w: cannot change the availability of doctor %d", domain.ErrForbidden, doctorID)
+	}
+	return s.requireDoctor(ctx, doctorID)
+}
+
+func (s *AvailabilityService) requireDoctor(ctx context.Context, doctorID int32) error {
+	user, err := s.userRepo.GetByID(ctx, doctorID)
+	if err != nil || user.Role != domain.RoleDoctor {
+		return fmt.Errorf("%w: doctor %d", domain.ErrNotFound, doctorID)
+	}
+	return nil
+}
+
+func checkSlotRange(from, to time.Time) error {
+	if !to.After(from) {
+		return fmt.Errorf("%w: to must be after from", domain.ErrInvalid)
+	}
+	if to.Sub(from) > maxSlotRange {
+		return fmt.Errorf("%w: slot searches are limited to %d days", domain.ErrInvalid, int(maxSlotRange.Hours()/24))
+	}
+	return nil
+}
+
+func windowsFromTemplates(templates []domain.AvailabilityTemplate) ([]scheduling.Window, error) {
+	windows := make([]scheduling.Window, 0, len(templates))
+	for _, t := range templates {
+		start, err := scheduling.ParseClock(t.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		end, err := scheduling.ParseClock(t.EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		w := scheduling.Window{
+			Weekday:     time.Weekday(t.Weekday),
+			Start:       start,
+			End:         end,
+			SlotMinutes: t.SlotMinutes,
+		}
+		for _, b := range t.Breaks {
+			bStart, err := scheduling.ParseClock(b.StartTime)
+			if err != nil {
+				return nil, err
+			}
+			bEnd, err := scheduling.ParseClock(b.EndTime)
+			if err != nil {
+				return nil, err
+			}
+			w.Breaks = append(w.Breaks, scheduling.Span{Start: bStart, End: bEnd})
+		}
+
+		if err := w.Validate(); err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
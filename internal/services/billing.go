@@ -0,0 +1,462 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/billing"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/localtime"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+// maxInvoiceLines caps the lines on one invoice.
+const maxInvoiceLines = 200
+
+// BillingService prices completed appointments from the catalog, issues
+// the invoices and takes payments against them. Everything is in the one
+// billing currency.
+type BillingService struct {
+	billingRepo     *repository.BillingRepository
+	appointmentRepo *repository.AppointmentRepository
+	patientRepo     *repository.PatientRepository
+	auditService    *AuditService
+	currency        string
+	// paymentTerms is how many days after issue an invoice is due unless
+	// a due date is given.
+	paymentTerms int
+	loc          *time.Location
+}
+
+func NewBillingService(billingRepo *repository.BillingRepository, appointmentRepo *repository.AppointmentRepository, patientRepo *repository.PatientRepository, auditService *AuditService, currency string, paymentTerms int, loc *time.Location) *BillingService {
+	return &BillingService{
+		billingRepo:     billingRepo,
+		appointmentRepo: appointmentRepo,
+		patientRepo:     patientRepo,
+		auditService:    auditService,
+		currency:        currency,
+		paymentTerms:    paymentTerms,
+		loc:             loc,
+	}
+}
+
+func (s *BillingService) ListServices(category *string, active *bool, query *string) ([]domain.BillableService, error) {
+	return s.billingRepo.ListServices(context.Background(), category, active, query)
+}
+
+func (s *BillingService) GetService(id int) (*domain.BillableService, error) {
+	return s.billingRepo.GetService(context.Background(), int32(id))
+}
+
+func (s *BillingService) CreateService(req *domain.SaveBillableServiceRequest) (*domain.BillableService, error) {
+	svc, err := billableService(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.billingRepo.CreateService(context.Background(), svc); err != nil {
+		return nil, err
+	}
+	return svc, nil
+}
+
+// UpdateService replaces a catalog entry. Invoices keep the prices they
+// were drawn up with.
+func (s *BillingService) UpdateService(id int, req *domain.SaveBillableServiceRequest) (*domain.BillableService, error) {
+	svc, err := billableService(req)
+	if err != nil {
+		return nil, err
+	}
+	svc.ID = int32(id)
+	if err := s.billingRepo.UpdateService(context.Background(), svc); err != nil {
+		return nil, err
+	}
+	return svc, nil
+}
+
+func billableService(req *domain.SaveBillableServiceRequest) (*domain.BillableService, error) {
+	svc := &domain.BillableService{
+		Code:         strings.ToUpper(strings.TrimSpace(req.Code)),
+		Name:         strings.TrimSpace(req.Name),
+		Description:  trimNotes(req.Description),
+		Category:     req.Category,
+		DepartmentID: req.DepartmentID,
+		PriceMinor:   req.PriceMinor,
+		TaxRateBP:    req.TaxRateBP,
+		Active:       true,
+	}
+	if svc.Code == "" || svc.Name == "" {
+		return nil, fmt.Errorf("%w: code and name are required", domain.ErrInvalid)
+	}
+	if req.Active != nil {
+		svc.Active = *req.Active
+	}
+	return svc, nil
+}
+
+// CreateInvoice drafts an invoice for a completed appointment, charging
+// the requested items or, without any, the default consultation for the
+// appointment's department.
+func (s *BillingService) CreateInvoice(actor domain.Actor, appointmentID int, req *domain.CreateInvoiceRequest) (*domain.Invoice, error) {
+	ctx := context.Background()
+	a, err := s.appointmentRepo.GetByID(ctx, int32(appointmentID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: appointment %d", domain.ErrNotFound, appointmentID)
+	}
+	if statusOf(a) != domain.AppointmentCompleted {
+		return nil, fmt.Errorf("%w: appointment %d is %s; only completed appointments are billed", domain.ErrConflict, a.ID, statusOf(a))
+	}
+	if a.PatientID == nil {
+		return nil, fmt.Errorf("%w: appointment %d has no patient", domain.ErrInvalid, a.ID)
+	}
+	if len(req.Items) > maxInvoiceLines {
+		return nil, fmt.Errorf("%w: an invoice can have at most %d lines", domain.ErrInvalid, maxInvoiceLines)
+	}
+
+	items := req.Items
+	if len(items) == 0 {
+		consultation, err := s.billingRepo.DefaultConsultation(ctx, a.DepartmentID)
+		if err != nil {
+			return nil, err
+		}
+		items = []domain.InvoiceLineRequest{{ServiceID: &consultation.ID}}
+	}
+	lines := make([]domain.InvoiceLine, 0, len(items))
+	for i := range items {
+		line, err := s.priceLine(ctx, &items[i])
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		lines = append(lines, *line)
+	}
+
+	inv := &domain.Invoice{
+		PatientID:     *a.PatientID,
+		AppointmentID: &a.ID,
+		Currency:      s.currency,
+		Notes:         trimNotes(req.Notes),
+		CreatedBy:     &actor.UserID,
+	}
+	if err := s.billingRepo.CreateInvoice(ctx, inv, lines); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceInvoice, &inv.ID, &inv.PatientID, map[string]domain.FieldChange{
+		"appointment_id": {After: a.ID},
+		"total_minor":    {After: inv.TotalMinor},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (s *BillingService) ListInvoices(actor domain.Actor, filter domain.InvoiceFilter) ([]domain.Invoice, error) {
+	ctx := context.Background()
+	invoices, err := s.billingRepo.ListInvoices(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(invoices))
+	for i := range invoices {
+		inv := invoices[i]
+		entries = append(entries, NewEntry(actor, domain.AuditActionList, domain.ResourceInvoice, &inv.ID, &inv.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+// GetInvoice returns the invoice with its lines and payments.
+func (s *BillingService) GetInvoice(actor domain.Actor, id int) (*domain.Invoice, error) {
+	ctx := context.Background()
+	inv, err := s.billingRepo.GetInvoiceDetail(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourceInvoice, &inv.ID, &inv.PatientID, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// AddLine adds a charge to a draft invoice.
+func (s *BillingService) AddLine(actor domain.Actor, invoiceID int, req *domain.InvoiceLineRequest) (*domain.Invoice, error) {
+	ctx := context.Background()
+	line, err := s.priceLine(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	inv, err := s.billingRepo.AddLine(ctx, int32(invoiceID), line, maxInvoiceLines)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceInvoice, &inv.ID, &inv.PatientID, map[string]domain.FieldChange{
+		"line_added":  {After: line.Description},
+		"total_minor": {Before: inv.TotalMinor - line.TotalMinor, After: inv.TotalMinor},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return s.billingRepo.GetInvoiceDetail(ctx, inv.ID)
+}
+
+// DeleteLine removes a charge from a draft invoice.
+func (s *BillingService) DeleteLine(actor domain.Actor, invoiceID, lineID int) (*domain.Invoice, error) {
+	ctx := context.Background()
+	before, err := s.billingRepo.GetInvoice(ctx, int32(invoiceID))
+	if err != nil {
+		return nil, err
+	}
+	inv, err := s.billingRepo.DeleteLine(ctx, before.ID, int32(lineID))
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceInvoice, &inv.ID, &inv.PatientID, map[string]domain.FieldChange{
+		"line_removed": {Before: lineID},
+		"total_minor":  {Before: before.TotalMinor, After: inv.TotalMinor},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return s.billingRepo.GetInvoiceDetail(ctx, inv.ID)
+}
+
+// IssueInvoice finalises a draft so it can be paid. Its lines can no
+// longer change; a mistake is corrected by voiding it and drafting again.
+func (s *BillingService) IssueInvoice(actor domain.Actor, id int, req *domain.IssueInvoiceRequest) (*domain.Invoice, error) {
+	ctx := context.Background()
+	today := s.today()
+	due := today.AddDate(0, 0, s.paymentTerms)
+	if req.DueDate != nil {
+		var err error
+		if due, err = localtime.ParseDate(*req.DueDate, s.loc); err != nil {
+			return nil, fmt.Errorf("%w: due_date: %v", domain.ErrInvalid, err)
+		}
+		if due.Before(today) {
+			return nil, fmt.Errorf("%w: due_date is in the past", domain.ErrInvalid)
+		}
+	}
+
+	inv, err := s.billingRepo.Issue(ctx, int32(id), due)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceInvoice, &inv.ID, &inv.PatientID, map[string]domain.FieldChange{
+		"status":   {Before: domain.InvoiceDraft, After: inv.Status},
+		"due_date": {After: due.Format("2006-01-02")},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return s.billingRepo.GetInvoiceDetail(ctx, inv.ID)
+}
+
+// VoidInvoice cancels an invoice nothing has been paid on. One with
+// payments must have them refunded first.
+func (s *BillingService) VoidInvoice(actor domain.Actor, id int, req *domain.VoidInvoiceRequest) (*domain.Invoice, error) {
+	ctx := context.Background()
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason must not be blank", domain.ErrInvalid)
+	}
+	before, err := s.billingRepo.GetInvoice(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	inv, err := s.billingRepo.Void(ctx, before.ID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceInvoice, &inv.ID, &inv.PatientID, map[string]domain.FieldChange{
+		"status":      {Before: before.Status, After: inv.Status},
+		"void_reason": {After: reason},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// RecordPayment takes a full or partial payment against an issued
+// invoice. It may not be more than the balance.
+func (s *BillingService) RecordPayment(actor domain.Actor, invoiceID int, req *domain.RecordPaymentRequest) (*domain.Invoice, error) {
+	ctx := context.Background()
+	p := &domain.Payment{
+		InvoiceID:   int32(invoiceID),
+		Method:      req.Method,
+		AmountMinor: req.AmountMinor,
+		Reference:   trimNotes(req.Reference),
+		ReceivedBy:  &actor.UserID,
+	}
+	inv, err := s.billingRepo.RecordPayment(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordPayment(ctx, actor, inv, p); err != nil {
+		return nil, err
+	}
+	return s.billingRepo.GetInvoiceDetail(ctx, inv.ID)
+}
+
+// RefundPayment gives back part or all of a payment on the invoice.
+func (s *BillingService) RefundPayment(actor domain.Actor, invoiceID, paymentID int, req *domain.RefundPaymentRequest) (*domain.Invoice, error) {
+	ctx := context.Background()
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason must not be blank", domain.ErrInvalid)
+	}
+	refundOf := int32(paymentID)
+	p := &domain.Payment{
+		InvoiceID:   int32(invoiceID),
+		AmountMinor: req.AmountMinor,
+		Reference:   trimNotes(req.Reference),
+		RefundOf:    &refundOf,
+		Reason:      &reason,
+		ReceivedBy:  &actor.UserID,
+	}
+	inv, err := s.billingRepo.Refund(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordPayment(ctx, actor, inv, p); err != nil {
+		return nil, err
+	}
+	return s.billingRepo.GetInvoiceDetail(ctx, inv.ID)
+}
+
+// recordPayment audits payment p and its effect on inv.
+func (s *BillingService) recordPayment(ctx context.Context, actor domain.Actor, inv *domain.Invoice, p *domain.Payment) error {
+	changes := map[string]domain.FieldChange{
+		"kind":         {After: p.Kind},
+		"method":       {After: p.Method},
+		"amount_minor": {After: p.AmountMinor},
+	}
+	if p.RefundOf != nil {
+		changes["refund_of"] = domain.FieldChange{After: *p.RefundOf}
+	}
+	return s.auditService.Record(ctx,
+		NewEntry(actor, domain.AuditActionCreate, domain.ResourcePayment, &p.ID, &inv.PatientID, changes),
+		NewEntry(actor, domain.AuditActionUpdate, domain.ResourceInvoice, &inv.ID, &inv.PatientID, map[string]domain.FieldChange{
+			"paid_minor": {After: inv.PaidMinor},
+			"status":     {After: inv.Status},
+		}),
+	)
+}
+
+// PatientBalance returns what the patient owes, with the invoices still
+// open.
+func (s *BillingService) PatientBalance(actor domain.Actor, patientID int) (*domain.PatientBalance, error) {
+	ctx := context.Background()
+	pid := int32(patientID)
+	if _, err := s.patientRepo.GetByID(ctx, pid); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, pid)
+	}
+
+	balance, err := s.billingRepo.PatientBalance(ctx, pid, s.currency, s.today())
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(balance.Invoices))
+	for i := range balance.Invoices {
+		inv := balance.Invoices[i]
+		entries = append(entries, NewEntry(actor, domain.AuditActionList, domain.ResourceInvoice, &inv.ID, &inv.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// OutstandingBalances lists the patients who owe at least minBalance,
+// largest balance first.
+func (s *BillingService) OutstandingBalances(actor domain.Actor, minBalance int64, limit, offset int32) ([]domain.PatientBalance, error) {
+	ctx := context.Background()
+	if minBalance < 1 {
+		minBalance = 1
+	}
+	balances, err := s.billingRepo.OutstandingBalances(ctx, s.currency, s.today(), minBalance, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(balances))
+	for i := range balances {
+		b := balances[i]
+		entries = append(entries, NewEntry(actor, domain.AuditActionList, domain.ResourcePatient, &b.PatientID, &b.PatientID, map[string]domain.FieldChange{
+			"outstanding_minor": {After: b.OutstandingMinor},
+		}))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// priceLine works out a line from the catalog or a free-text charge.
+func (s *BillingService) priceLine(ctx context.Context, req *domain.InvoiceLineRequest) (*domain.InvoiceLine, error) {
+	line := &domain.InvoiceLine{Quantity: req.Quantity}
+	if line.Quantity == 0 {
+		line.Quantity = 1
+	}
+
+	if req.ServiceID != nil {
+		svc, err := s.billingRepo.GetService(ctx, *req.ServiceID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: billable service %d", domain.ErrInvalid, *req.ServiceID)
+		}
+		if !svc.Active {
+			return nil, fmt.Errorf("%w: billable service %s is no longer offered", domain.ErrInvalid, svc.Code)
+		}
+		line.ServiceID = &svc.ID
+		line.Description = svc.Name
+		line.UnitPriceMinor = svc.PriceMinor
+		line.TaxRateBP = svc.TaxRateBP
+	} else if req.UnitPriceMinor == nil {
+		return nil, fmt.Errorf("%w: a line needs a service_id or a unit_price_minor", domain.ErrInvalid)
+	}
+	if req.Description != nil {
+		line.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.UnitPriceMinor != nil {
+		line.UnitPriceMinor = *req.UnitPriceMinor
+	}
+	if req.TaxRateBP != nil {
+		line.TaxRateBP = *req.TaxRateBP
+	}
+	if line.Description == "" {
+		return nil, fmt.Errorf("%w: description is required", domain.ErrInvalid)
+	}
+
+	a, err := billing.Line(line.Quantity, line.UnitPriceMinor, req.DiscountMinor, req.DiscountPercent*100, line.TaxRateBP)
+	if errors.Is(err, billing.ErrAmount) {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	line.SubtotalMinor = a.Subtotal
+	line.DiscountMinor = a.Discount
+	line.TaxMinor = a.Tax
+	line.TotalMinor = a.Total
+	return line, nil
+}
+
+// today is the first instant of the current day in the hospital's time
+// zone.
+func (s *BillingService) today() time.Time {
+	return localtime.StartOfDay(time.Now().In(s.loc), s.loc)
+}
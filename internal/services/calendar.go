@@ -0,0 +1,152 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/ical"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+const (
+	calendarTokenBytes = 32
+	// calendarFeedLimit caps how many of a doctor's latest appointments a
+	// feed carries.
+	calendarFeedLimit = 500
+)
+
+type CalendarService struct {
+	calendarRepo       *repository.CalendarRepository
+	appointmentRepo    *repository.AppointmentRepository
+	appointmentService *AppointmentService
+	auditService       *AuditService
+}
+
+func NewCalendarService(calendarRepo *repository.CalendarRepository, appointmentRepo *repository.AppointmentRepository, appointmentService *AppointmentService, auditService *AuditService) *CalendarService {
+	return &CalendarService{
+		calendarRepo:       calendarRepo,
+		appointmentRepo:    appointmentRepo,
+		appointmentService: appointmentService,
+		auditService:       auditService,
+	}
+}
+
+// CreateToken gives the actor a new feed token. The token itself is only
+// returned here; just its hash is stored.
+func (s *CalendarService) CreateToken(actor domain.Actor) (*domain.NewCalendarToken, error) {
+	ctx := context.Background()
+	raw := make([]byte, calendarTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	t, err := s.calendarRepo.CreateToken(ctx, actor.UserID, hashCalendarToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceCalendarToken, &t.ID, nil, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return &domain.NewCalendarToken{CalendarToken: *t, Token: token}, nil
+}
+
+func (s *CalendarService) ListTokens(actor domain.Actor) ([]domain.CalendarToken, error) {
+	return s.calendarRepo.ListTokens(context.Background(), actor.UserID)
+}
+
+func (s *CalendarService) RevokeToken(actor domain.Actor, id int) error {
+	ctx := context.Background()
+	if err := s.calendarRepo.RevokeToken(ctx, int32(id), actor.UserID); err != nil {
+		return err
+	}
+
+	tokenID := int32(id)
+	entry := NewEntry(actor, domain.AuditActionDelete, domain.ResourceCalendarToken, &tokenID, nil, nil)
+	return s.auditService.Record(ctx, entry)
+}
+
+// Feed renders the appointments of the doctor who owns token. Anyone with
+// the URL can read it, so events carry only times, status and the
+// appointment number: no patient names and no clinical fields.
+func (s *CalendarService) Feed(actor domain.Actor, token string) ([]byte, error) {
+	ctx := context.Background()
+	t, err := s.calendarRepo.GetToken(ctx, hashCalendarToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	appointments, err := s.appointmentRepo.GetByDoctor(ctx, t.DoctorID, calendarFeedLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	// The feed identifies no patients, so one entry per fetch is recorded
+	// rather than one per appointment.
+	actor.UserID = t.DoctorID
+	actor.Role = domain.RoleDoctor
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourceCalendarToken, &t.ID, nil, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	cal := &ical.Calendar{Name: "Hospital appointments"}
+	for i := range appointments {
+		a := &appointments[i]
+		cal.Events = append(cal.Events, appointmentEvent(a, fmt.Sprintf("Appointment #%d", a.ID)))
+	}
+	return writeCalendar(cal)
+}
+
+// AppointmentCalendar renders one appointment as a calendar file for the
+// patient, with the same access checks as reading the appointment.
+func (s *CalendarService) AppointmentCalendar(actor domain.Actor, id int) ([]byte, error) {
+	a, err := s.appointmentService.GetAppointment(actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := "Hospital appointment"
+	if a.DoctorName != "" {
+		summary = "Appointment with " + a.DoctorName
+	}
+	return writeCalendar(&ical.Calendar{Events: []ical.Event{appointmentEvent(a, summary)}})
+}
+
+// appointmentEvent converts a. The UID depends only on the appointment ID,
+// so calendar apps update the event in place when it is moved or
+// cancelled.
+func appointmentEvent(a *domain.Appointment, summary string) ical.Event {
+	status := ical.StatusConfirmed
+	if statusOf(a) == domain.AppointmentCancelled {
+		status = ical.StatusCancelled
+	}
+	return ical.Event{
+		UID:          fmt.Sprintf("appointment-%d@hospital", a.ID),
+		Start:        a.AppointmentDate.Time,
+		End:          a.EndTime.Time,
+		Summary:      summary,
+		Status:       status,
+		LastModified: a.UpdatedAt.Time,
+	}
+}
+
+func writeCalendar(cal *ical.Calendar) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := cal.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func hashCalendarToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+type CareTeamService struct {
+	careTeamRepo           *repository.CareTeamRepository
+	userRepo               *repository.UserRepository
+	patientRepo            *repository.PatientRepository
+	emergencyAccessService *EmergencyAccessService
+	auditService           *AuditService
+}
+
+func NewCareTeamService(careTeamRepo *repository.CareTeamRepository, userRepo *repository.UserRepository, patientRepo *repository.PatientRepository, emergencyAccessService *EmergencyAccessService, auditService *AuditService) *CareTeamService {
+	return &CareTeamService{
+		careTeamRepo:           careTeamRepo,
+		userRepo:               userRepo,
+		patientRepo:            patientRepo,
+		emergencyAccessService: emergencyAccessService,
+		auditService:           auditService,
+	}
+}
+
+// Authorize checks that actor may open the chart of patientID. Only doctors
+// are restricted: they need a care relationship with the patient or an
+// active break-the-glass grant.
+func (s *CareTeamService) Authorize(ctx context.Context, actor domain.Actor, patientID int32) error {
+	if actor.Role != domain.RoleDoctor {
+		return nil
+	}
+
+	ok, err := s.careTeamRepo.HasAccess(ctx, actor.UserID, patientID)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+
+	ok, err = s.emergencyAccessService.HasActiveGrant(ctx, actor.UserID, patientID)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+	return fmt.Errorf("%w: patient %d is not under your care", domain.ErrForbidden, patientID)
+}
+
+func (s *CareTeamService) GetCareTeam(actor domain.Actor, patientID int) ([]domain.CareTeamMember, error) {
+	ctx := context.Background()
+	if err := s.Authorize(ctx, actor, int32(patientID)); err != nil {
+		return nil, err
+	}
+	return s.careTeamRepo.List(ctx, int32(patientID))
+}
+
+// AddMember puts a doctor on the patient's care team. Receptionists can
+// assign any doctor; doctors can refer a colleague to a patient they are
+// already caring for.
+func (s *CareTeamService) AddMember(actor domain.Actor, patientID int, req *domain.AddCareTeamMemberRequest) (*domain.CareTeamMember, error) {
+	ctx := context.Background()
+	source := req.Source
+	if source == "" {
+		source = domain.CareTeamSourceAssignment
+	}
+
+	switch actor.Role {
+	case domain.RoleReceptionist:
+	case domain.RoleDoctor:
+		if err := s.Authorize(ctx, actor, int32(patientID)); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: cannot manage care teams", domain.ErrForbidden)
+	}
+
+	if _, err := s.patientRepo.GetByID(ctx, int32(patientID)); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, patientID)
+	}
+	doctor, err := s.userRepo.GetByID(ctx, req.DoctorID)
+	if err != nil || doctor.Role != domain.RoleDoctor {
+		return nil, fmt.Errorf("%w: doctor %d", domain.ErrNotFound, req.DoctorID)
+	}
+
+	member, err := s.careTeamRepo.Add(ctx, int32(patientID), req.DoctorID, source, &actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	member.DoctorName = doctor.FirstName + " " + doctor.LastName
+
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceCareTeamMember, &member.DoctorID, &member.PatientID, map[string]domain.FieldChange{
+		"doctor_id": {After: member.DoctorID},
+		"source":    {After: member.Source},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember drops an explicit membership. Access that comes from an
+// appointment cannot be removed this way.
+func (s *CareTeamService) RemoveMember(actor domain.Actor, patientID, doctorID int, source string) error {
+	if actor.Role != domain.RoleReceptionist {
+		return fmt.Errorf("%w: only receptionists can remove care team members", domain.ErrForbidden)
+	}
+	if source == "" {
+		source = domain.CareTeamSourceAssignment
+	}
+
+	ctx := context.Background()
+	if err := s.careTeamRepo.Remove(ctx, int32(patientID), int32(doctorID), source); err != nil {
+		return fmt.Errorf("doctor %d has no %s for patient %d: %w", doctorID, source, patientID, err)
+	}
+
+	pid, did := int32(patientID), int32(doctorID)
+	entry := NewEntry(actor, domain.AuditActionDelete, domain.ResourceCareTeamMember, &did, &pid, map[string]domain.FieldChange{
+		"doctor_id": {Before: did},
+		"source":    {Before: source},
+	})
+	return s.auditService.Record(ctx, entry)
+}
+
+// assignCreator puts the doctor who registered a patient on the care team.
+func (s *CareTeamService) assignCreator(ctx context.Context, actor domain.Actor, patientID int32) error {
+	member, err := s.careTeamRepo.Add(ctx, patientID, actor.UserID, domain.CareTeamSourceAssignment, &actor.UserID)
+	if err != nil {
+		return err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceCareTeamMember, &member.DoctorID, &member.PatientID, map[string]domain.FieldChange{
+		"doctor_id": {After: member.DoctorID},
+		"source":    {After: member.Source},
+	})
+	return s.auditService.Record(ctx, entry)
+}
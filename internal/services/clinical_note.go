@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+// ClinicalNoteService keeps doctors' SOAP notes. Only doctors with access
+// to the patient's chart can read or write them, and only the author can
+// edit or sign a draft.
+type ClinicalNoteService struct {
+	noteRepo        *repository.ClinicalNoteRepository
+	appointmentRepo *repository.AppointmentRepository
+	patientRepo     *repository.PatientRepository
+	careTeamService *CareTeamService
+	auditService    *AuditService
+}
+
+func NewClinicalNoteService(noteRepo *repository.ClinicalNoteRepository, appointmentRepo *repository.AppointmentRepository, patientRepo *repository.PatientRepository, careTeamService *CareTeamService, auditService *AuditService) *ClinicalNoteService {
+	return &ClinicalNoteService{
+		noteRepo:        noteRepo,
+		appointmentRepo: appointmentRepo,
+		patientRepo:     patientRepo,
+		careTeamService: careTeamService,
+		auditService:    auditService,
+	}
+}
+
+// CreateNote starts a draft note about the patient, optionally as the
+// encounter note of one of the patient's appointments.
+func (s *ClinicalNoteService) CreateNote(actor domain.Actor, patientID int, req *domain.CreateClinicalNoteRequest) (*domain.ClinicalNote, error) {
+	ctx := context.Background()
+	if err := s.authorize(ctx, actor, int32(patientID)); err != nil {
+		return nil, err
+	}
+	if _, err := s.patientRepo.GetByID(ctx, int32(patientID)); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, patientID)
+	}
+	if req.AppointmentID != nil {
+		a, err := s.appointmentRepo.GetByID(ctx, *req.AppointmentID)
+		if err != nil || a.PatientID == nil || *a.PatientID != int32(patientID) {
+			return nil, fmt.Errorf("%w: appointment %d is not one of patient %d's", domain.ErrInvalid, *req.AppointmentID, patientID)
+		}
+	}
+
+	note := &domain.ClinicalNote{
+		PatientID:     int32(patientID),
+		AppointmentID: req.AppointmentID,
+		AuthorID:      &actor.UserID,
+		SOAP:          trimSOAP(req.SOAP),
+	}
+	if err := s.noteRepo.Create(ctx, note); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceClinicalNote, &note.ID, &note.PatientID, map[string]domain.FieldChange{
+		"appointment_id": {After: note.AppointmentID},
+		"version":        {After: note.CurrentVersion},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+func (s *ClinicalNoteService) ListNotes(actor domain.Actor, patientID int) ([]domain.ClinicalNote, error) {
+	ctx := context.Background()
+	if err := s.authorize(ctx, actor, int32(patientID)); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.noteRepo.ListByPatient(ctx, int32(patientID))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(notes))
+	for i := range notes {
+		n := notes[i]
+		entries = append(entries, NewEntry(actor, domain.AuditActionList, domain.ResourceClinicalNote, &n.ID, &n.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// GetNote returns the note's current version with its addenda.
+func (s *ClinicalNoteService) GetNote(actor domain.Actor, id int) (*domain.ClinicalNote, error) {
+	ctx := context.Background()
+	note, err := s.readable(ctx, actor, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourceClinicalNote, &note.ID, &note.PatientID, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// GetAppointmentNote returns the appointment's encounter note.
+func (s *ClinicalNoteService) GetAppointmentNote(actor domain.Actor, appointmentID int) (*domain.ClinicalNote, error) {
+	ctx := context.Background()
+	note, err := s.noteRepo.GetByAppointment(ctx, int32(appointmentID))
+	if err != nil {
+		return nil, err
+	}
+	if note == nil {
+		return nil, fmt.Errorf("%w: appointment %d has no clinical note", domain.ErrNotFound, appointmentID)
+	}
+	return s.GetNote(actor, int(note.ID))
+}
+
+// ListVersions returns every version of the note, oldest first.
+func (s *ClinicalNoteService) ListVersions(actor domain.Actor, id int) ([]domain.ClinicalNoteVersion, error) {
+	ctx := context.Background()
+	note, err := s.readable(ctx, actor, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.noteRepo.ListVersions(ctx, note.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourceClinicalNote, &note.ID, &note.PatientID, map[string]domain.FieldChange{
+		"versions": {After: len(versions)},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// UpdateNote saves a new version of a draft. Earlier versions are kept.
+func (s *ClinicalNoteService) UpdateNote(actor domain.Actor, id int, req *domain.UpdateClinicalNoteRequest) (*domain.ClinicalNote, error) {
+	ctx := context.Background()
+	note, err := s.editable(ctx, actor, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	return s.addVersion(ctx, actor, note, req.BaseVersion, trimSOAP(req.SOAP))
+}
+
+// SignNote locks the note. Only its author can sign it.
+func (s *ClinicalNoteService) SignNote(actor domain.Actor, id int, req *domain.SignClinicalNoteRequest) (*domain.ClinicalNote, error) {
+	ctx := context.Background()
+	note, err := s.editable(ctx, actor, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.noteRepo.Sign(ctx, note.ID, req.Version, actor.UserID); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceClinicalNote, &note.ID, &note.PatientID, map[string]domain.FieldChange{
+		"status":  {Before: note.Status, After: domain.ClinicalNoteSigned},
+		"version": {Before: req.Version, After: req.Version},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return s.noteRepo.Get(ctx, note.ID)
+}
+
+// AddAddendum appends text to a signed note. Any doctor with access to
+// the patient can add one; drafts are edited instead.
+func (s *ClinicalNoteService) AddAddendum(actor domain.Actor, id int, req *domain.CreateAddendumRequest) (*domain.ClinicalNoteAddendum, error) {
+	ctx := context.Background()
+	note, err := s.readable(ctx, actor, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if note.Status != domain.ClinicalNoteSigned {
+		return nil, fmt.Errorf("%w: clinical note %d is a draft; edit it instead", domain.ErrConflict, note.ID)
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, fmt.Errorf("%w: body must not be blank", domain.ErrInvalid)
+	}
+
+	addendum, err := s.noteRepo.AddAddendum(ctx, note.ID, body, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceClinicalNote, &note.ID, &note.PatientID, map[string]domain.FieldChange{
+		"addendum_id": {After: addendum.ID},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return addendum, nil
+}
+
+// checkRecordFromAppointment checks that actor may record a diagnosis or
+// treatment plan on a, and returns the appointment's note, or nil if it
+// has none yet.
+func (s *ClinicalNoteService) checkRecordFromAppointment(ctx context.Context, actor domain.Actor, a *domain.Appointment) (*domain.ClinicalNote, error) {
+	if actor.Role != domain.RoleDoctor {
+		return nil, fmt.Errorf("%w: only doctors can record a diagnosis or treatment plan", domain.ErrForbidden)
+	}
+	if a.PatientID == nil {
+		return nil, fmt.Errorf("%w: appointment %d has no patient", domain.ErrInvalid, a.ID)
+	}
+	if err := s.authorize(ctx, actor, *a.PatientID); err != nil {
+		return nil, err
+	}
+
+	note, err := s.noteRepo.GetByAppointment(ctx, a.ID)
+	if err != nil || note == nil {
+		return nil, err
+	}
+	if note.AuthorID == nil || *note.AuthorID != actor.UserID {
+		return nil, fmt.Errorf("%w: only the author can edit clinical note %d", domain.ErrForbidden, note.ID)
+	}
+	if note.Status == domain.ClinicalNoteSigned {
+		return nil, fmt.Errorf("%w: clinical note %d is signed; add an addendum instead", domain.ErrConflict, note.ID)
+	}
+	return note, nil
+}
+
+// noteFromAppointment returns what to save for a diagnosis or treatment
+// plan given on appointment a: a new version of note, the appointment's
+// note as returned by checkRecordFromAppointment, with them as its
+// assessment or plan, or a new note if there is none. The appointment
+// repository saves it along with the appointment.
+func noteFromAppointment(actor domain.Actor, a *domain.Appointment, note *domain.ClinicalNote, diagnosis, treatmentPlan *string) *domain.ClinicalNote {
+	if note == nil {
+		return &domain.ClinicalNote{
+			PatientID:     *a.PatientID,
+			AppointmentID: &a.ID,
+			AuthorID:      &actor.UserID,
+			SOAP:          trimSOAP(domain.SOAP{Assessment: diagnosis, Plan: treatmentPlan}),
+		}
+	}
+
+	next := *note
+	if diagnosis != nil {
+		next.SOAP.Assessment = diagnosis
+	}
+	if treatmentPlan != nil {
+		next.SOAP.Plan = treatmentPlan
+	}
+	next.SOAP = trimSOAP(next.SOAP)
+	return &next
+}
+
+// noteEntry is the audit entry for note once noteFromAppointment's result
+// has been saved.
+func noteEntry(actor domain.Actor, note *domain.ClinicalNote) *domain.AuditEntry {
+	if note.CurrentVersion == 1 {
+		return NewEntry(actor, domain.AuditActionCreate, domain.ResourceClinicalNote, &note.ID, &note.PatientID, map[string]domain.FieldChange{
+			"appointment_id": {After: note.AppointmentID},
+			"version":        {After: note.CurrentVersion},
+		})
+	}
+	return NewEntry(actor, domain.AuditActionUpdate, domain.ResourceClinicalNote, &note.ID, &note.PatientID, map[string]domain.FieldChange{
+		"version": {Before: note.CurrentVersion - 1, After: note.CurrentVersion},
+	})
+}
+
+func (s *ClinicalNoteService) addVersion(ctx context.Context, actor domain.Actor, note *domain.ClinicalNote, baseVersion int32, sections domain.SOAP) (*domain.ClinicalNote, error) {
+	if err := s.noteRepo.AddVersion(ctx, note.ID, baseVersion, sections, actor.UserID); err != nil {
+		return nil, err
+	}
+
+	after, err := s.noteRepo.Get(ctx, note.ID)
+	if err != nil {
+		return nil, err
+	}
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceClinicalNote, &note.ID, &note.PatientID, map[string]domain.FieldChange{
+		"version": {Before: baseVersion, After: after.CurrentVersion},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// readable loads the note if actor may see the patient's chart.
+func (s *ClinicalNoteService) readable(ctx context.Context, actor domain.Actor, id int32) (*domain.ClinicalNote, error) {
+	note, err := s.noteRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, actor, note.PatientID); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// editable loads the note if actor is its author and may still see the
+// patient's chart.
+func (s *ClinicalNoteService) editable(ctx context.Context, actor domain.Actor, id int32) (*domain.ClinicalNote, error) {
+	note, err := s.readable(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if note.AuthorID == nil || *note.AuthorID != actor.UserID {
+		return nil, fmt.Errorf("%w: only the author can edit or sign clinical note %d", domain.ErrForbidden, note.ID)
+	}
+	return note, nil
+}
+
+func (s *ClinicalNoteService) authorize(ctx context.Context, actor domain.Actor, patientID int32) error {
+	if actor.Role != domain.RoleDoctor {
+		return fmt.Errorf("%w: clinical notes are for doctors only", domain.ErrForbidden)
+	}
+	return s.careTeamService.Authorize(ctx, actor, patientID)
+}
+
+// trimSOAP trims each section and drops the ones left empty.
+func trimSOAP(in domain.SOAP) domain.SOAP {
+	out := in
+	for _, section := range []**string{&out.Subjective, &out.Objective, &out.Assessment, &out.Plan} {
+		if *section == nil {
+			continue
+		}
+		v := strings.TrimSpace(**section)
+		if v == "" {
+			*section = nil
+		} else {
+			*section = &v
+		}
+	}
+	return out
+}
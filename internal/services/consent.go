@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/localtime"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+// supersededReason is recorded on a consent replaced by a newer one for
+// the same purpose.
+const supersededReason = "superseded by a newer consent"
+
+// ConsentService keeps the versioned consent templates and the patients'
+// consent records. Other services call Require before doing something
+// the patient must have agreed to.
+type ConsentService struct {
+	consentRepo     *repository.ConsentRepository
+	patientRepo     *repository.PatientRepository
+	careTeamService *CareTeamService
+	auditService    *AuditService
+	loc             *time.Location
+}
+
+func NewConsentService(consentRepo *repository.ConsentRepository, patientRepo *repository.PatientRepository, careTeamService *CareTeamService, auditService *AuditService, loc *time.Location) *ConsentService {
+	return &ConsentService{
+		consentRepo:     consentRepo,
+		patientRepo:     patientRepo,
+		careTeamService: careTeamService,
+		auditService:    auditService,
+		loc:             loc,
+	}
+}
+
+// ListTemplates returns every template version, optionally of one purpose.
+func (s *ConsentService) ListTemplates(purpose *string) ([]domain.ConsentTemplate, error) {
+	return s.consentRepo.ListTemplates(context.Background(), purpose)
+}
+
+func (s *ConsentService) GetTemplate(id int) (*domain.ConsentTemplate, error) {
+	ctx := context.Background()
+	t, err := s.consentRepo.GetTemplate(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.consentRepo.CurrentTemplate(ctx, t.Purpose)
+	if err != nil {
+		return nil, err
+	}
+	t.Current = current.ID == t.ID
+	return t, nil
+}
+
+// CreateTemplate publishes new wording for a purpose as its next version.
+// Consents already recorded keep referring to the version that was signed.
+func (s *ConsentService) CreateTemplate(actor domain.Actor, req *domain.CreateConsentTemplateRequest) (*domain.ConsentTemplate, error) {
+	t := &domain.ConsentTemplate{
+		Purpose:      req.Purpose,
+		Title:        strings.TrimSpace(req.Title),
+		Body:         strings.TrimSpace(req.Body),
+		ValidityDays: req.ValidityDays,
+		CreatedBy:    &actor.UserID,
+	}
+	if t.Title == "" || t.Body == "" {
+		return nil, fmt.Errorf("%w: title and body are required", domain.ErrInvalid)
+	}
+	if err := s.consentRepo.CreateTemplate(context.Background(), t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// RecordConsent records the patient's consent to the current version of a
+// template, replacing any earlier consent for the same purpose.
+func (s *ConsentService) RecordConsent(actor domain.Actor, patientID int, req *domain.RecordConsentRequest) (*domain.Consent, error) {
+	ctx := context.Background()
+	pid := int32(patientID)
+	if err := s.careTeamService.Authorize(ctx, actor, pid); err != nil {
+		return nil, err
+	}
+	if _, err := s.patientRepo.GetByID(ctx, pid); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, pid)
+	}
+
+	t, err := s.consentRepo.GetTemplate(ctx, req.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: consent template %d", domain.ErrInvalid, req.TemplateID)
+	}
+	current, err := s.consentRepo.CurrentTemplate(ctx, t.Purpose)
+	if err != nil {
+		return nil, err
+	}
+	if current.ID != t.ID {
+		return nil, fmt.Errorf("%w: template %d is version %d of %s; consent must be given to version %d (template %d)",
+			domain.ErrConflict, t.ID, t.Version, t.Purpose, current.Version, current.ID)
+	}
+
+	now := time.Now()
+	signedAt := now
+	if req.SignedAt != nil {
+		signedAt, err = localtime.Parse(*req.SignedAt, s.loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: signed_at: %v", domain.ErrInvalid, err)
+		}
+		if signedAt.After(now) {
+			return nil, fmt.Errorf("%w: signed_at is in the future", domain.ErrInvalid)
+		}
+	}
+	var expiresAt *time.Time
+	switch {
+	case req.ExpiresAt != nil:
+		at, err := localtime.Parse(*req.ExpiresAt, s.loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: expires_at: %v", domain.ErrInvalid, err)
+		}
+		expiresAt = &at
+	case t.ValidityDays != nil:
+		at := signedAt.AddDate(0, 0, int(*t.ValidityDays))
+		expiresAt = &at
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, fmt.Errorf("%w: the consent would already have expired", domain.ErrInvalid)
+	}
+
+	signedBy := strings.TrimSpace(req.SignedBy)
+	if signedBy == "" {
+		return nil, fmt.Errorf("%w: signed_by is required", domain.ErrInvalid)
+	}
+	c := &domain.Consent{
+		PatientID:       pid,
+		TemplateID:      t.ID,
+		TemplateVersion: t.Version,
+		TemplateTitle:   t.Title,
+		Purpose:         t.Purpose,
+		SignedBy:        signedBy,
+		SignedAt:        signedAt,
+		WitnessName:     trimNotes(req.WitnessName),
+		RecordedBy:      &actor.UserID,
+		ExpiresAt:       expiresAt,
+	}
+	if err := s.consentRepo.Record(ctx, c, supersededReason); err != nil {
+		return nil, err
+	}
+	c.Status = c.StatusAt(now)
+
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceConsent, &c.ID, &c.PatientID, map[string]domain.FieldChange{
+		"purpose":          {After: c.Purpose},
+		"template_version": {After: c.TemplateVersion},
+		"signed_at":        {After: c.SignedAt},
+		"expires_at":       {After: c.ExpiresAt},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ListConsents returns all of the patient's consents, current and past.
+func (s *ConsentService) ListConsents(actor domain.Actor, patientID int) ([]domain.Consent, error) {
+	ctx := context.Background()
+	pid := int32(patientID)
+	if err := s.careTeamService.Authorize(ctx, actor, pid); err != nil {
+		return nil, err
+	}
+
+	consents, err := s.consentRepo.List(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entries := make([]*domain.AuditEntry, 0, len(consents))
+	for i := range consents {
+		c := &consents[i]
+		c.Status = c.StatusAt(now)
+		entries = append(entries, NewEntry(actor, domain.AuditActionList, domain.ResourceConsent, &c.ID, &c.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return consents, nil
+}
+
+func (s *ConsentService) GetConsent(actor domain.Actor, patientID, id int) (*domain.Consent, error) {
+	ctx := context.Background()
+	c, err := s.consent(ctx, actor, int32(patientID), int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourceConsent, &c.ID, &c.PatientID, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// RevokeConsent records that the patient withdrew the consent.
+func (s *ConsentService) RevokeConsent(actor domain.Actor, patientID, id int, req *domain.RevokeConsentRequest) (*domain.Consent, error) {
+	ctx := context.Background()
+	c, err := s.consent(ctx, actor, int32(patientID), int32(id))
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", domain.ErrInvalid)
+	}
+	if err := s.consentRepo.Revoke(ctx, c.ID, actor.UserID, reason); err != nil {
+		return nil, err
+	}
+
+	before := c.Status
+	c, err = s.consentRepo.Get(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	c.Status = c.StatusAt(time.Now())
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceConsent, &c.ID, &c.PatientID, map[string]domain.FieldChange{
+		"status":            {Before: before, After: c.Status},
+		"revocation_reason": {After: reason},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// CheckConsent reports whether the patient has consent in force for
+// purpose, and if not, why.
+func (s *ConsentService) CheckConsent(actor domain.Actor, patientID int, purpose string) (*domain.ConsentCheck, error) {
+	ctx := context.Background()
+	pid := int32(patientID)
+	if err := s.careTeamService.Authorize(ctx, actor, pid); err != nil {
+		return nil, err
+	}
+	if _, err := s.patientRepo.GetByID(ctx, pid); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, pid)
+	}
+
+	check, err := s.check(ctx, pid, purpose)
+	if err != nil {
+		return nil, err
+	}
+	if check.Consent != nil {
+		entry := NewEntry(actor, domain.AuditActionRead, domain.ResourceConsent, &check.Consent.ID, &pid, nil)
+		if err := s.auditService.Record(ctx, entry); err != nil {
+			return nil, err
+		}
+	}
+	return check, nil
+}
+
+// Require returns an error wrapping domain.ErrNoConsent unless the patient
+// has consent in force for purpose. It is for other services to call
+// before acting on the patient's behalf, so it does no access checks.
+func (s *ConsentService) Require(ctx context.Context, patientID int32, purpose string) error {
+	check, err := s.check(ctx, patientID, purpose)
+	if err != nil {
+		return err
+	}
+	if !check.Granted {
+		return fmt.Errorf("%w: %s", domain.ErrNoConsent, check.Reason)
+	}
+	return nil
+}
+
+func (s *ConsentService) check(ctx context.Context, patientID int32, purpose string) (*domain.ConsentCheck, error) {
+	switch purpose {
+	case domain.ConsentTreatment, domain.ConsentDataSharing, domain.ConsentSMSReminders:
+	default:
+		return nil, fmt.Errorf("%w: unknown consent purpose %q", domain.ErrInvalid, purpose)
+	}
+
+	c, err := s.consentRepo.Latest(ctx, patientID, purpose)
+	if err != nil {
+		return nil, err
+	}
+	check := &domain.ConsentCheck{PatientID: patientID, Purpose: purpose, Consent: c}
+	if c == nil {
+		check.Reason = fmt.Sprintf("patient %d has not consented to %s", patientID, purpose)
+		return check, nil
+	}
+
+	c.Status = c.StatusAt(time.Now())
+	switch c.Status {
+	case domain.ConsentActive:
+		check.Granted = true
+	case domain.ConsentExpired:
+		check.Reason = fmt.Sprintf("patient %d's consent to %s expired on %s", patientID, purpose, c.ExpiresAt.In(s.loc).Format("2006-01-02"))
+	case domain.ConsentRevoked:
+		check.Reason = fmt.Sprintf("patient %d revoked consent to %s on %s", patientID, purpose, c.RevokedAt.In(s.loc).Format("2006-01-02"))
+	}
+	return check, nil
+}
+
+// consent loads consent id of the patient if actor may see the chart.
+func (s *ConsentService) consent(ctx context.Context, actor domain.Actor, patientID, id int32) (*domain.Consent, error) {
+	if err := s.careTeamService.Authorize(ctx, actor, patientID); err != nil {
+		return nil, err
+	}
+	c, err := s.consentRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.PatientID != patientID {
+		return nil, fmt.Errorf("%w: consent %d", domain.ErrNotFound, id)
+	}
+	c.Status = c.StatusAt(time.Now())
+	return c, nil
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+type DepartmentService struct {
+	departmentRepo   *repository.DepartmentRepository
+	resourceRepo     *repository.ResourceRepository
+	availabilityRepo *repository.AvailabilityRepository
+	userRepo         *repository.UserRepository
+}
+
+func NewDepartmentService(departmentRepo *repository.DepartmentRepository, resourceRepo *repository.ResourceRepository, availabilityRepo *repository.AvailabilityRepository, userRepo *repository.UserRepository) *DepartmentService {
+	return &DepartmentService{
+		departmentRepo:   departmentRepo,
+		resourceRepo:     resourceRepo,
+		availabilityRepo: availabilityRepo,
+		userRepo:         userRepo,
+	}
+}
+
+func (s *DepartmentService) ListDepartments() ([]domain.Department, error) {
+	return s.departmentRepo.List(context.Background())
+}
+
+// GetDepartment returns the department with its doctors, specialties,
+// rooms and equipment.
+func (s *DepartmentService) GetDepartment(id int) (*domain.DepartmentDetail, error) {
+	ctx := context.Background()
+	d, err := s.departmentRepo.Get(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &domain.DepartmentDetail{Department: *d}
+	if detail.Doctors, err = s.departmentRepo.ListDoctors(ctx, d.ID); err != nil {
+		return nil, err
+	}
+	if detail.Specialties, err = s.departmentRepo.ListSpecialties(ctx, &d.ID); err != nil {
+		return nil, err
+	}
+	if detail.Rooms, err = s.resourceRepo.ListRooms(ctx, &d.ID); err != nil {
+		return nil, err
+	}
+	if detail.Equipment, err = s.resourceRepo.ListEquipment(ctx, &d.ID); err != nil {
+		return nil, err
+	}
+	return detail, nil
+}
+
+func (s *DepartmentService) CreateDepartment(req *domain.SaveDepartmentRequest) (*domain.Department, error) {
+	if err := trimName(&req.Name); err != nil {
+		return nil, err
+	}
+	return s.departmentRepo.Create(context.Background(), req)
+}
+
+func (s *DepartmentService) UpdateDepartment(id int, req *domain.SaveDepartmentRequest) (*domain.Department, error) {
+	if err := trimName(&req.Name); err != nil {
+		return nil, err
+	}
+	return s.departmentRepo.Update(context.Background(), int32(id), req)
+}
+
+func (s *DepartmentService) DeleteDepartment(id int) error {
+	return s.departmentRepo.Delete(context.Background(), int32(id))
+}
+
+// AddDoctor makes the doctor a member of the department. Doctors may
+// belong to several departments.
+func (s *DepartmentService) AddDoctor(departmentID, doctorID int) error {
+	ctx := context.Background()
+	if _, err := s.departmentRepo.Get(ctx, int32(departmentID)); err != nil {
+		return err
+	}
+	if err := s.requireDoctor(ctx, int32(doctorID)); err != nil {
+		return err
+	}
+	return s.departmentRepo.AddDoctor(ctx, int32(departmentID), int32(doctorID))
+}
+
+func (s *DepartmentService) RemoveDoctor(departmentID, doctorID int) error {
+	return s.departmentRepo.RemoveDoctor(context.Background(), int32(departmentID), int32(doctorID))
+}
+
+// ListDoctors returns the doctors with the given specialty and in the
+// given department; either filter may be nil.
+func (s *DepartmentService) ListDoctors(specialty *string, departmentID *int32) ([]domain.DoctorProfile, error) {
+	return s.availabilityRepo.ListDoctors(context.Background(), specialty, departmentID)
+}
+
+func (s *DepartmentService) ListSpecialties(departmentID *int32) ([]domain.Specialty, error) {
+	return s.departmentRepo.ListSpecialties(context.Background(), departmentID)
+}
+
+func (s *DepartmentService) CreateSpecialty(req *domain.CreateSpecialtyRequest) (*domain.Specialty, error) {
+	if err := trimName(&req.Name); err != nil {
+		return nil, err
+	}
+	return s.departmentRepo.CreateSpecialty(context.Background(), req)
+}
+
+func (s *DepartmentService) DeleteSpecialty(id int) error {
+	return s.departmentRepo.DeleteSpecialty(context.Background(), int32(id))
+}
+
+func (s *DepartmentService) ListDoctorSpecialties(doctorID int) ([]domain.Specialty, error) {
+	ctx := context.Background()
+	if err := s.requireDoctor(ctx, int32(doctorID)); err != nil {
+		return nil, err
+	}
+	return s.departmentRepo.ListDoctorSpecialties(ctx, int32(doctorID))
+}
+
+func (s *DepartmentService) AddDoctorSpecialty(doctorID, specialtyID int) error {
+	ctx := context.Background()
+	if err := s.requireDoctor(ctx, int32(doctorID)); err != nil {
+		return err
+	}
+	return s.departmentRepo.AddDoctorSpecialty(ctx, int32(doctorID), int32(specialtyID))
+}
+
+func (s *DepartmentService) RemoveDoctorSpecialty(doctorID, specialtyID int) error {
+	return s.departmentRepo.RemoveDoctorSpecialty(context.Background(), int32(doctorID), int32(specialtyID))
+}
+
+func (s *DepartmentService) requireDoctor(ctx context.Context, doctorID int32) error {
+	user, err := s.userRepo.GetByID(ctx, doctorID)
+	if err != nil || user.Role != domain.RoleDoctor {
+		return fmt.Errorf("%w: doctor %d", domain.ErrNotFound, doctorID)
+	}
+	return nil
+}
+
+// trimName trims surrounding space from a department, specialty or
+// resource name and rejects one that is left empty.
+func trimName(name *string) error {
+	*name = strings.TrimSpace(*name)
+	if *name == "" {
+		return fmt.Errorf("%w: name must not be blank", domain.ErrInvalid)
+	}
+	return nil
+}
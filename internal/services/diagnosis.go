@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/prem0x01/hospital/internal/audit"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/icd10"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+const (
+	defaultCodeSearchLimit = 20
+	maxCodeSearchLimit     = 100
+)
+
+// DiagnosisService searches the ICD-10 catalog and keeps the coded
+// diagnoses of encounters, from which each patient's problem list is
+// built. Diagnoses are recorded and read by doctors with access to the
+// patient's chart.
+type DiagnosisService struct {
+	icd10Repo       *repository.Icd10Repository
+	diagnosisRepo   *repository.DiagnosisRepository
+	appointmentRepo *repository.AppointmentRepository
+	careTeamService *CareTeamService
+	auditService    *AuditService
+}
+
+func NewDiagnosisService(icd10Repo *repository.Icd10Repository, diagnosisRepo *repository.DiagnosisRepository, appointmentRepo *repository.AppointmentRepository, careTeamService *CareTeamService, auditService *AuditService) *DiagnosisService {
+	return &DiagnosisService{
+		icd10Repo:       icd10Repo,
+		diagnosisRepo:   diagnosisRepo,
+		appointmentRepo: appointmentRepo,
+		careTeamService: careTeamService,
+		auditService:    auditService,
+	}
+}
+
+// SearchCodes looks codes up for autocomplete: a query shaped like the
+// start of a code matches codes by prefix, anything else matches words of
+// the descriptions.
+func (s *DiagnosisService) SearchCodes(search domain.Icd10Search) ([]domain.Icd10Code, error) {
+	ctx := context.Background()
+	q := strings.TrimSpace(search.Query)
+	if q == "" {
+		return nil, fmt.Errorf("%w: q is required", domain.ErrInvalid)
+	}
+	limit := search.Limit
+	if limit <= 0 {
+		limit = defaultCodeSearchLimit
+	}
+	limit = min(limit, maxCodeSearchLimit)
+
+	if icd10.IsCodePrefix(q) {
+		return s.icd10Repo.SearchByPrefix(ctx, icd10.Normalize(q), search.Billable, int32(limit))
+	}
+	tsquery := icd10.PrefixQuery(q)
+	if tsquery == "" {
+		return []domain.Icd10Code{}, nil
+	}
+	return s.icd10Repo.SearchByText(ctx, tsquery, search.Billable, int32(limit))
+}
+
+func (s *DiagnosisService) GetCode(code string) (*domain.Icd10Code, error) {
+	return s.icd10Repo.Get(context.Background(), icd10.Normalize(code))
+}
+
+// ListDiagnoses returns the appointment's diagnoses, the primary one first.
+func (s *DiagnosisService) ListDiagnoses(actor domain.Actor, appointmentID int) ([]domain.EncounterDiagnosis, error) {
+	ctx := context.Background()
+	a, err := s.encounter(ctx, actor, int32(appointmentID))
+	if err != nil {
+		return nil, err
+	}
+
+	diagnoses, err := s.diagnosisRepo.ListByAppointment(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(diagnoses))
+	for i := range diagnoses {
+		d := diagnoses[i]
+		entries = append(entries, NewEntry(actor, domain.AuditActionList, domain.ResourceDiagnosis, &d.ID, &d.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return diagnoses, nil
+}
+
+// AddDiagnosis records a billable, active code as a diagnosis of the
+// appointment. Diagnoses are secondary and active unless asked otherwise.
+func (s *DiagnosisService) AddDiagnosis(actor domain.Actor, appointmentID int, req *domain.CreateDiagnosisRequest) (*domain.EncounterDiagnosis, error) {
+	ctx := context.Background()
+	a, err := s.encounter(ctx, actor, int32(appointmentID))
+	if err != nil {
+		return nil, err
+	}
+	if status := statusOf(a); status == domain.AppointmentCancelled || status == domain.AppointmentNoShow {
+		return nil, fmt.Errorf("%w: appointment %d is %s", domain.ErrConflict, a.ID, status)
+	}
+	code, err := s.recordableCode(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &domain.EncounterDiagnosis{
+		AppointmentID: a.ID,
+		PatientID:     *a.PatientID,
+		Code:          code.Code,
+		Description:   code.Description,
+		Rank:          req.Rank,
+		Status:        req.Status,
+		Notes:         trimNotes(req.Notes),
+		RecordedBy:    &actor.UserID,
+	}
+	if d.Rank == "" {
+		d.Rank = domain.DiagnosisSecondary
+	}
+	if d.Status == "" {
+		d.Status = domain.DiagnosisActive
+	}
+	if err := s.diagnosisRepo.Create(ctx, d); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceDiagnosis, &d.ID, &d.PatientID, map[string]domain.FieldChange{
+		"appointment_id": {After: d.AppointmentID},
+		"code":           {After: d.Code},
+		"rank":           {After: d.Rank},
+		"status":         {After: d.Status},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// UpdateDiagnosis changes the rank, status or notes of a diagnosis. The
+// code itself is fixed; a wrong code is deleted and recorded again.
+func (s *DiagnosisService) UpdateDiagnosis(actor domain.Actor, appointmentID, id int, req *domain.UpdateDiagnosisRequest) (*domain.EncounterDiagnosis, error) {
+	ctx := context.Background()
+	d, err := s.diagnosis(ctx, actor, int32(appointmentID), int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	before := *d
+	if req.Rank != nil {
+		d.Rank = *req.Rank
+	}
+	if req.Status != nil {
+		d.Status = *req.Status
+	}
+	if req.Notes != nil {
+		d.Notes = trimNotes(req.Notes)
+	}
+	changes := audit.Redact(audit.Diff(&before, d), "notes")
+	if len(changes) == 0 {
+		return d, nil
+	}
+	if err := s.diagnosisRepo.Update(ctx, d); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceDiagnosis, &d.ID, &d.PatientID, changes)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *DiagnosisService) DeleteDiagnosis(actor domain.Actor, appointmentID, id int) error {
+	ctx := context.Background()
+	d, err := s.diagnosis(ctx, actor, int32(appointmentID), int32(id))
+	if err != nil {
+		return err
+	}
+	if err := s.diagnosisRepo.Delete(ctx, d.ID); err != nil {
+		return err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionDelete, domain.ResourceDiagnosis, &d.ID, &d.PatientID, map[string]domain.FieldChange{
+		"code": {Before: d.Code},
+	})
+	return s.auditService.Record(ctx, entry)
+}
+
+// ListProblems returns the patient's problem list. status picks problems
+// in that state; without it, problems last ruled out are left out.
+func (s *DiagnosisService) ListProblems(actor domain.Actor, patientID int, status *string) ([]domain.Problem, error) {
+	ctx := context.Background()
+	if err := s.authorize(ctx, actor, int32(patientID)); err != nil {
+		return nil, err
+	}
+	if status != nil {
+		switch *status {
+		case domain.DiagnosisActive, domain.DiagnosisResolved, domain.DiagnosisRuledOut:
+		default:
+			return nil, fmt.Errorf("%w: unknown problem status %q", domain.ErrInvalid, *status)
+		}
+	}
+
+	problems, err := s.diagnosisRepo.ListProblems(ctx, int32(patientID), status)
+	if err != nil {
+		return nil, err
+	}
+
+	pid := int32(patientID)
+	entry := NewEntry(actor, domain.AuditActionList, domain.ResourceDiagnosis, nil, &pid, map[string]domain.FieldChange{
+		"problems": {After: len(problems)},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+// recordableCode looks code up and checks that it may be recorded: it
+// must be in the catalog, active and billable.
+func (s *DiagnosisService) recordableCode(ctx context.Context, code string) (*domain.Icd10Code, error) {
+	if !icd10.Valid(code) {
+		return nil, fmt.Errorf("%w: %q is not an ICD-10 code", domain.ErrInvalid, code)
+	}
+	c, err := s.icd10Repo.Get(ctx, icd10.Normalize(code))
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown ICD-10 code %s", domain.ErrInvalid, icd10.Normalize(code))
+	}
+	if !c.Active {
+		return nil, fmt.Errorf("%w: ICD-10 code %s is no longer in use", domain.ErrInvalid, c.Code)
+	}
+	if !c.Billable {
+		return nil, fmt.Errorf("%w: ICD-10 code %s is a category; choose one of the codes under it", domain.ErrInvalid, c.Code)
+	}
+	return c, nil
+}
+
+// encounter loads the appointment if actor may record its diagnoses.
+func (s *DiagnosisService) encounter(ctx context.Context, actor domain.Actor, appointmentID int32) (*domain.Appointment, error) {
+	a, err := s.appointmentRepo.GetByID(ctx, appointmentID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: appointment %d", domain.ErrNotFound, appointmentID)
+	}
+	if a.PatientID == nil {
+		return nil, fmt.Errorf("%w: appointment %d has no patient", domain.ErrInvalid, appointmentID)
+	}
+	if err := s.authorize(ctx, actor, *a.PatientID); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// diagnosis loads diagnosis id of the appointment if actor may change it.
+func (s *DiagnosisService) diagnosis(ctx context.Context, actor domain.Actor, appointmentID, id int32) (*domain.EncounterDiagnosis, error) {
+	d, err := s.diagnosisRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.AppointmentID != appointmentID {
+		return nil, fmt.Errorf("%w: diagnosis %d of appointment %d", domain.ErrNotFound, id, appointmentID)
+	}
+	if err := s.authorize(ctx, actor, d.PatientID); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *DiagnosisService) authorize(ctx context.Context, actor domain.Actor, patientID int32) error {
+	if actor.Role != domain.RoleDoctor {
+		return fmt.Errorf("%w: diagnoses are recorded by doctors only", domain.ErrForbidden)
+	}
+	return s.careTeamService.Authorize(ctx, actor, patientID)
+}
+
+// trimNotes trims notes, dropping them if nothing is left.
+func trimNotes(notes *string) *string {
+	if notes == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*notes)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
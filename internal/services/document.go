@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/documents"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+// DocumentService renders the PDFs given to patients. Visit summaries and
+// prescriptions are issued by doctors with access to the patient's chart;
+// patient cards are also printed at the front desk. Every document issued
+// is recorded in the audit log as a read of what it shows.
+type DocumentService struct {
+	renderer        *documents.Renderer
+	appointmentRepo *repository.AppointmentRepository
+	patientRepo     *repository.PatientRepository
+	userRepo        *repository.UserRepository
+	departmentRepo  *repository.DepartmentRepository
+	diagnosisRepo   *repository.DiagnosisRepository
+	careTeamService *CareTeamService
+	auditService    *AuditService
+	loc             *time.Location
+}
+
+func NewDocumentService(renderer *documents.Renderer, appointmentRepo *repository.AppointmentRepository, patientRepo *repository.PatientRepository, userRepo *repository.UserRepository, departmentRepo *repository.DepartmentRepository, diagnosisRepo *repository.DiagnosisRepository, careTeamService *CareTeamService, auditService *AuditService, loc *time.Location) *DocumentService {
+	return &DocumentService{
+		renderer:        renderer,
+		appointmentRepo: appointmentRepo,
+		patientRepo:     patientRepo,
+		userRepo:        userRepo,
+		departmentRepo:  departmentRepo,
+		diagnosisRepo:   diagnosisRepo,
+		careTeamService: careTeamService,
+		auditService:    auditService,
+		loc:             loc,
+	}
+}
+
+// VisitSummary renders the summary of an appointment that has taken
+// place: its diagnosis, coded diagnoses and treatment plan. Ruled-out
+// diagnoses are left off.
+func (s *DocumentService) VisitSummary(actor domain.Actor, appointmentID int) ([]byte, error) {
+	ctx := context.Background()
+	a, patient, err := s.visit(ctx, actor, int32(appointmentID))
+	if err != nil {
+		return nil, err
+	}
+	if status := statusOf(a); status != domain.AppointmentInProgress && status != domain.AppointmentCompleted {
+		return nil, fmt.Errorf("%w: appointment %d is %s; summaries are issued once the visit has started", domain.ErrConflict, a.ID, status)
+	}
+
+	diagnoses, err := s.diagnosisRepo.ListByAppointment(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	summary := documents.VisitSummary{
+		IssuedAt:      time.Now().In(s.loc),
+		Patient:       documentPatient(patient),
+		Doctor:        a.DoctorName,
+		VisitAt:       a.AppointmentDate.Time.In(s.loc),
+		Diagnosis:     stringValue(a.Diagnosis),
+		TreatmentPlan: stringValue(a.TreatmentPlan),
+	}
+	for _, d := range diagnoses {
+		if d.Status == domain.DiagnosisRuledOut {
+			continue
+		}
+		summary.Diagnoses = append(summary.Diagnoses, documents.Diagnosis{
+			Code:        d.Code,
+			Description: d.Description,
+			Primary:     d.Rank == domain.DiagnosisPrimary,
+			Status:      d.Status,
+		})
+	}
+	if a.DepartmentID != nil {
+		if dept, err := s.departmentRepo.Get(ctx, *a.DepartmentID); err == nil {
+			summary.Department = dept.Name
+		}
+	}
+
+	body := s.renderer.VisitSummary(summary)
+	if err := s.record(ctx, actor, domain.ResourceAppointment, a.ID, patient.ID, "visit_summary"); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// Prescription renders a prescription from actor for the patient of an
+// appointment, with the patient's recorded allergies.
+func (s *DocumentService) Prescription(actor domain.Actor, appointmentID int, req *domain.CreatePrescriptionRequest) ([]byte, error) {
+	ctx := context.Background()
+	if actor.Role != domain.RoleDoctor {
+		return nil, fmt.Errorf("%w: only doctors can issue prescriptions", domain.ErrForbidden)
+	}
+	a, patient, err := s.visit(ctx, actor, int32(appointmentID))
+	if err != nil {
+		return nil, err
+	}
+	if status := statusOf(a); status == domain.AppointmentCancelled || status == domain.AppointmentNoShow {
+		return nil, fmt.Errorf("%w: appointment %d is %s", domain.ErrConflict, a.ID, status)
+	}
+	doctor, err := s.userRepo.GetByID(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	p := documents.Prescription{
+		IssuedAt:  time.Now().In(s.loc),
+		Patient:   documentPatient(patient),
+		Doctor:    doctor.FirstName + " " + doctor.LastName,
+		Allergies: stringValue(patient.Allergies),
+		Notes:     stringValue(req.Notes),
+	}
+	for _, item := range req.Items {
+		name := strings.TrimSpace(item.Medication)
+		if name == "" {
+			return nil, fmt.Errorf("%w: medication must not be blank", domain.ErrInvalid)
+		}
+		p.Medications = append(p.Medications, documents.Medication{
+			Name:         name,
+			Dosage:       strings.TrimSpace(item.Dosage),
+			Frequency:    strings.TrimSpace(item.Frequency),
+			Duration:     strings.TrimSpace(item.Duration),
+			Quantity:     strings.TrimSpace(item.Quantity),
+			Instructions: strings.TrimSpace(item.Instructions),
+		})
+	}
+
+	body := s.renderer.Prescription(p)
+	if err := s.record(ctx, actor, domain.ResourceAppointment, a.ID, patient.ID, "prescription"); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// PatientCard renders the patient's ID card with their MRN as a barcode.
+func (s *DocumentService) PatientCard(actor domain.Actor, patientID int) ([]byte, error) {
+	ctx := context.Background()
+	pid := int32(patientID)
+	if err := s.careTeamService.Authorize(ctx, actor, pid); err != nil {
+		return nil, err
+	}
+	patient, err := s.patientRepo.GetByID(ctx, pid)
+	if err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, pid)
+	}
+
+	body, err := s.renderer.PatientCard(documents.PatientCard{
+		IssuedAt: time.Now().In(s.loc),
+		Patient:  documentPatient(patient),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, actor, domain.ResourcePatient, patient.ID, patient.ID, "patient_card"); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// visit loads an appointment and its patient if actor may see the chart.
+func (s *DocumentService) visit(ctx context.Context, actor domain.Actor, appointmentID int32) (*domain.Appointment, *domain.Patient, error) {
+	a, err := s.appointmentRepo.GetByID(ctx, appointmentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: appointment %d", domain.ErrNotFound, appointmentID)
+	}
+	if a.PatientID == nil {
+		return nil, nil, fmt.Errorf("%w: appointment %d has no patient", domain.ErrInvalid, appointmentID)
+	}
+	if err := s.careTeamService.Authorize(ctx, actor, *a.PatientID); err != nil {
+		return nil, nil, err
+	}
+	patient, err := s.patientRepo.GetByID(ctx, *a.PatientID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, *a.PatientID)
+	}
+	return a, patient, nil
+}
+
+func (s *DocumentService) record(ctx context.Context, actor domain.Actor, resource string, id, patientID int32, document string) error {
+	entry := NewEntry(actor, domain.AuditActionRead, resource, &id, &patientID, map[string]domain.FieldChange{
+		"document": {After: document},
+	})
+	return s.auditService.Record(ctx, entry)
+}
+
+func documentPatient(p *domain.Patient) documents.Patient {
+	dp := documents.Patient{
+		Name: p.FirstName + " " + p.LastName,
+		MRN:  p.MRN,
+	}
+	if p.DateOfBirth.Valid {
+		dp.DateOfBirth = p.DateOfBirth.Time
+	}
+	if p.Gender != nil {
+		dp.Gender = *p.Gender
+	}
+	return dp
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimSpace(*s)
+}
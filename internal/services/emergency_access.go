@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+const defaultEmergencyAccessDuration = 60 * time.Minute
+
+type EmergencyAccessService struct {
+	emergencyAccessRepo *repository.EmergencyAccessRepository
+	patientRepo         *repository.PatientRepository
+	auditService        *AuditService
+}
+
+func NewEmergencyAccessService(emergencyAccessRepo *repository.EmergencyAccessRepository, patientRepo *repository.PatientRepository, auditService *AuditService) *EmergencyAccessService {
+	return &EmergencyAccessService{
+		emergencyAccessRepo: emergencyAccessRepo,
+		patientRepo:         patientRepo,
+		auditService:        auditService,
+	}
+}
+
+// RequestAccess grants a doctor time-limited access to one patient. The
+// grant is effective immediately; compliance reviews it afterwards.
+func (s *EmergencyAccessService) RequestAccess(actor domain.Actor, patientID int, req *domain.EmergencyAccessRequest) (*domain.EmergencyAccessGrant, error) {
+	if actor.Role != domain.RoleDoctor {
+		return nil, fmt.Errorf("%w: only doctors can request emergency access", domain.ErrForbidden)
+	}
+
+	ctx := context.Background()
+	if _, err := s.patientRepo.GetByID(ctx, int32(patientID)); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, patientID)
+	}
+
+	duration := defaultEmergencyAccessDuration
+	if req.DurationMinutes > 0 {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+	}
+
+	grant := &domain.EmergencyAccessGrant{
+		PatientID:     int32(patientID),
+		DoctorID:      actor.UserID,
+		Justification: strings.TrimSpace(req.Justification),
+		ExpiresAt:     time.Now().Add(duration),
+	}
+	// The grant opens the chart, so it is only saved along with its audit
+	// entry.
+	var entry *domain.AuditEntry
+	err := s.emergencyAccessRepo.Create(ctx, grant, func(g *domain.EmergencyAccessGrant) *domain.AuditEntry {
+		entry = NewEntry(actor, domain.AuditActionEmergencyAccess, domain.ResourceEmergencyAccess, &g.ID, &g.PatientID, map[string]domain.FieldChange{
+			"justification": {After: g.Justification},
+			"expires_at":    {After: g.ExpiresAt.UTC().Format(time.RFC3339)},
+		})
+		return entry
+	})
+	if err != nil {
+		if entry != nil {
+			// The grant was saved but could not be committed with its
+			// entry.
+			s.auditService.refuse(err, entry)
+		}
+		return nil, err
+	}
+
+	log.Printf("ALERT break-the-glass: doctor %d opened patient %d until %s (grant %d, request %s): %q",
+		grant.DoctorID, grant.PatientID, grant.ExpiresAt.Format(time.RFC3339), grant.ID, actor.RequestID, grant.Justification)
+
+	return grant, nil
+}
+
+// HasActiveGrant reports whether doctorID currently holds an unexpired
+// emergency grant for patientID.
+func (s *EmergencyAccessService) HasActiveGrant(ctx context.Context, doctorID, patientID int32) (bool, error) {
+	grant, err := s.emergencyAccessRepo.GetActive(ctx, doctorID, patientID)
+	if err != nil {
+		return false, err
+	}
+	return grant != nil, nil
+}
+
+// GetReviewQueue lists grants oldest first; status filters by review
+// status and may be empty for all grants.
+func (s *EmergencyAccessService) GetReviewQueue(status string, limit, offset int) ([]domain.EmergencyAccessGrant, error) {
+	ctx := context.Background()
+	var filter *string
+	if status != "" {
+		filter = &status
+	}
+	return s.emergencyAccessRepo.List(ctx, filter, int32(limit), int32(offset))
+}
+
+func (s *EmergencyAccessService) Review(actor domain.Actor, id int, req *domain.EmergencyAccessReviewRequest) (*domain.EmergencyAccessGrant, error) {
+	if actor.Role != domain.RoleCompliance {
+		return nil, fmt.Errorf("%w: only compliance staff can review emergency access", domain.ErrForbidden)
+	}
+
+	ctx := context.Background()
+	grant, err := s.emergencyAccessRepo.Review(ctx, int32(id), req.Decision, actor.UserID, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceEmergencyAccess, &grant.ID, &grant.PatientID, map[string]domain.FieldChange{
+		"review_status": {Before: domain.ReviewPending, After: grant.ReviewStatus},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	if grant.ReviewStatus == domain.ReviewFlagged {
+		log.Printf("ALERT break-the-glass grant %d flagged by compliance user %d", grant.ID, actor.UserID)
+	}
+	return grant, nil
+}
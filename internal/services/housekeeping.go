@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/audit"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+const (
+	noShowBatch = 100
+	// revokedTokenRetention is how long revoked calendar tokens stay
+	// listed before they are purged.
+	revokedTokenRetention = 30 * 24 * time.Hour
+)
+
+// HousekeepingService holds the jobs run by the JobScheduler.
+type HousekeepingService struct {
+	appointmentRepo *repository.AppointmentRepository
+	calendarRepo    *repository.CalendarRepository
+	auditService    *AuditService
+	// noShowGrace is how long after its start an appointment without a
+	// check-in is marked no_show.
+	noShowGrace time.Duration
+}
+
+func NewHousekeepingService(appointmentRepo *repository.AppointmentRepository, calendarRepo *repository.CalendarRepository, auditService *AuditService, noShowGrace time.Duration) *HousekeepingService {
+	return &HousekeepingService{
+		appointmentRepo: appointmentRepo,
+		calendarRepo:    calendarRepo,
+		auditService:    auditService,
+		noShowGrace:     noShowGrace,
+	}
+}
+
+// MarkNoShows moves appointments still scheduled noShowGrace after they
+// started to no_show.
+func (s *HousekeepingService) MarkNoShows(ctx context.Context) (string, error) {
+	reason := fmt.Sprintf("not checked in within %s of the appointment time", s.noShowGrace)
+	marked := 0
+	for {
+		ids, err := s.appointmentRepo.ListOverdue(ctx, time.Now().Add(-s.noShowGrace), noShowBatch)
+		if err != nil {
+			return fmt.Sprintf("marked %d appointments", marked), err
+		}
+		for _, id := range ids {
+			err := s.markNoShow(ctx, id, reason)
+			if errors.Is(err, domain.ErrConflict) {
+				// Checked in or cancelled meanwhile.
+				continue
+			}
+			if err != nil {
+				return fmt.Sprintf("marked %d appointments", marked), fmt.Errorf("appointment %d: %w", id, err)
+			}
+			marked++
+		}
+		if len(ids) < noShowBatch {
+			return fmt.Sprintf("marked %d appointments", marked), nil
+		}
+	}
+}
+
+func (s *HousekeepingService) markNoShow(ctx context.Context, id int32, reason string) error {
+	before, err := s.appointmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.appointmentRepo.MarkNoShow(ctx, id, reason); err != nil {
+		return err
+	}
+	after, err := s.appointmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	entry := NewEntry(systemActor, domain.AuditActionUpdate, domain.ResourceAppointment, &after.ID, after.PatientID, audit.Diff(before, after))
+	return s.auditService.Record(ctx, entry)
+}
+
+// PurgeRevokedCalendarTokens deletes calendar tokens revoked more than
+// revokedTokenRetention ago. Feed tokens do not expire, and access tokens
+// are signed rather than stored, so these are the only dead tokens kept.
+func (s *HousekeepingService) PurgeRevokedCalendarTokens(ctx context.Context) (string, error) {
+	n, err := s.calendarRepo.PurgeRevoked(ctx, time.Now().Add(-revokedTokenRetention))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("purged %d calendar tokens", n), nil
+}
+
+// RefreshStatistics recomputes the statistics views behind the dashboard.
+func (s *HousekeepingService) RefreshStatistics(ctx context.Context) (string, error) {
+	return "", s.appointmentRepo.RefreshStatistics(ctx)
+}
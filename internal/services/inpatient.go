@@ -0,0 +1,408 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/localtime"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+// InpatientService runs the wards: their beds, and admitting, moving and
+// discharging patients. The front desk and doctors admit; the attending
+// doctor joins the patient's care team, and a doctor can only admit or
+// move patients they care for.
+type InpatientService struct {
+	inpatientRepo   *repository.InpatientRepository
+	patientRepo     *repository.PatientRepository
+	userRepo        *repository.UserRepository
+	appointmentRepo *repository.AppointmentRepository
+	careTeamService *CareTeamService
+	auditService    *AuditService
+	loc             *time.Location
+}
+
+func NewInpatientService(inpatientRepo *repository.InpatientRepository, patientRepo *repository.PatientRepository, userRepo *repository.UserRepository, appointmentRepo *repository.AppointmentRepository, careTeamService *CareTeamService, auditService *AuditService, loc *time.Location) *InpatientService {
+	return &InpatientService{
+		inpatientRepo:   inpatientRepo,
+		patientRepo:     patientRepo,
+		userRepo:        userRepo,
+		appointmentRepo: appointmentRepo,
+		careTeamService: careTeamService,
+		auditService:    auditService,
+		loc:             loc,
+	}
+}
+
+func (s *InpatientService) ListWards(active *bool) ([]domain.Ward, error) {
+	return s.inpatientRepo.ListWards(context.Background(), active)
+}
+
+func (s *InpatientService) CreateWard(req *domain.SaveWardRequest) (*domain.Ward, error) {
+	w, err := ward(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.inpatientRepo.CreateWard(context.Background(), w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// UpdateWard replaces a ward. Deactivating a ward with patients in it
+// leaves them where they are; it only stops new admissions.
+func (s *InpatientService) UpdateWard(id int, req *domain.SaveWardRequest) (*domain.Ward, error) {
+	w, err := ward(req)
+	if err != nil {
+		return nil, err
+	}
+	w.ID = int32(id)
+	if err := s.inpatientRepo.UpdateWard(context.Background(), w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func ward(req *domain.SaveWardRequest) (*domain.Ward, error) {
+	w := &domain.Ward{
+		Name:         strings.TrimSpace(req.Name),
+		DepartmentID: req.DepartmentID,
+		Active:       true,
+	}
+	if w.Name == "" {
+		return nil, fmt.Errorf("%w: name must not be blank", domain.ErrInvalid)
+	}
+	if req.Active != nil {
+		w.Active = *req.Active
+	}
+	return w, nil
+}
+
+func (s *InpatientService) ListBeds(wardID int) ([]domain.Bed, error) {
+	ctx := context.Background()
+	if _, err := s.inpatientRepo.GetWard(ctx, int32(wardID)); err != nil {
+		return nil, err
+	}
+	return s.inpatientRepo.ListWardBeds(ctx, int32(wardID))
+}
+
+func (s *InpatientService) CreateBed(wardID int, req *domain.SaveBedRequest) (*domain.Bed, error) {
+	ctx := context.Background()
+	if _, err := s.inpatientRepo.GetWard(ctx, int32(wardID)); err != nil {
+		return nil, err
+	}
+	label, err := bedLabel(req.Label)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &domain.Bed{WardID: int32(wardID), Label: label}
+	if err := s.inpatientRepo.CreateBed(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *InpatientService) RenameBed(id int, req *domain.SaveBedRequest) (*domain.Bed, error) {
+	label, err := bedLabel(req.Label)
+	if err != nil {
+		return nil, err
+	}
+	return s.inpatientRepo.RenameBed(context.Background(), int32(id), label)
+}
+
+func bedLabel(label string) (string, error) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return "", fmt.Errorf("%w: label must not be blank", domain.ErrInvalid)
+	}
+	return label, nil
+}
+
+// SetBedStatus marks a bed free once cleaned, or takes it out of or puts
+// it back into service. Occupied beds change only through admissions.
+func (s *InpatientService) SetBedStatus(id int, req *domain.SetBedStatusRequest) (*domain.Bed, error) {
+	ctx := context.Background()
+	bed, err := s.inpatientRepo.GetBed(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.CheckBedStatusChange(bed.Status, req.Status); err != nil {
+		return nil, err
+	}
+	return s.inpatientRepo.SetBedStatus(ctx, bed.ID, req.Status, trimNotes(req.Note))
+}
+
+// Admit admits a patient to a free bed under an attending doctor, who is
+// put on the patient's care team. A doctor admitting defaults to being the
+// attending doctor.
+func (s *InpatientService) Admit(actor domain.Actor, req *domain.AdmitRequest) (*domain.Admission, error) {
+	ctx := context.Background()
+	if err := s.checkAdmitter(ctx, actor, req.PatientID); err != nil {
+		return nil, err
+	}
+	if _, err := s.patientRepo.GetByID(ctx, req.PatientID); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, req.PatientID)
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason must not be blank", domain.ErrInvalid)
+	}
+	doctorID := req.AttendingDoctorID
+	if doctorID == nil {
+		if actor.Role != domain.RoleDoctor {
+			return nil, fmt.Errorf("%w: attending_doctor_id is required", domain.ErrInvalid)
+		}
+		doctorID = &actor.UserID
+	}
+	if err := s.checkDoctor(ctx, *doctorID); err != nil {
+		return nil, err
+	}
+	if req.AppointmentID != nil {
+		appt, err := s.appointmentRepo.GetByID(ctx, *req.AppointmentID)
+		if err != nil || appt.PatientID == nil || *appt.PatientID != req.PatientID {
+			return nil, fmt.Errorf("%w: appointment %d is not one of patient %d's", domain.ErrInvalid, *req.AppointmentID, req.PatientID)
+		}
+	}
+
+	a := &domain.Admission{
+		PatientID:         req.PatientID,
+		AttendingDoctorID: *doctorID,
+		AppointmentID:     req.AppointmentID,
+		Reason:            reason,
+		AdmittedBy:        &actor.UserID,
+	}
+	member, err := s.inpatientRepo.Admit(ctx, a, req.BedID)
+	if err != nil {
+		return nil, err
+	}
+	admission, err := s.inpatientRepo.GetAdmission(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*domain.AuditEntry{
+		NewEntry(actor, domain.AuditActionCreate, domain.ResourceAdmission, &admission.ID, &admission.PatientID, map[string]domain.FieldChange{
+			"attending_doctor_id": {After: admission.AttendingDoctorID},
+			"appointment_id":      {After: admission.AppointmentID},
+			"bed_id":              {After: admission.BedID},
+			"ward_id":             {After: admission.WardID},
+			"status":              {After: admission.Status},
+		}),
+		careTeamEntry(actor, member),
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return admission, nil
+}
+
+// Transfer moves an admitted patient to another free bed, in their ward
+// or another. The bed they leave is left to be cleaned.
+func (s *InpatientService) Transfer(actor domain.Actor, id int, req *domain.TransferRequest) (*domain.Admission, error) {
+	ctx := context.Background()
+	before, err := s.inpatientRepo.GetAdmission(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAdmitter(ctx, actor, before.PatientID); err != nil {
+		return nil, err
+	}
+	if req.AttendingDoctorID != nil {
+		if err := s.checkDoctor(ctx, *req.AttendingDoctorID); err != nil {
+			return nil, err
+		}
+	}
+
+	member, err := s.inpatientRepo.Transfer(ctx, before.ID, req.BedID, req.AttendingDoctorID, trimNotes(req.Reason), &actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.inpatientRepo.GetAdmission(ctx, before.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]domain.FieldChange{
+		"bed_id":  {Before: before.BedID, After: after.BedID},
+		"ward_id": {Before: before.WardID, After: after.WardID},
+	}
+	if after.AttendingDoctorID != before.AttendingDoctorID {
+		changes["attending_doctor_id"] = domain.FieldChange{Before: before.AttendingDoctorID, After: after.AttendingDoctorID}
+	}
+	entries := []*domain.AuditEntry{
+		NewEntry(actor, domain.AuditActionUpdate, domain.ResourceAdmission, &after.ID, &after.PatientID, changes),
+	}
+	if member != nil {
+		entries = append(entries, careTeamEntry(actor, member))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// Discharge ends an admission. The patient's bed is left to be cleaned;
+// they stay on the care team.
+func (s *InpatientService) Discharge(actor domain.Actor, id int, req *domain.DischargeRequest) (*domain.Admission, error) {
+	ctx := context.Background()
+	before, err := s.inpatientRepo.GetAdmission(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAdmitter(ctx, actor, before.PatientID); err != nil {
+		return nil, err
+	}
+
+	if err := s.inpatientRepo.Discharge(ctx, before.ID, req.Disposition, trimNotes(req.Notes), &actor.UserID); err != nil {
+		return nil, err
+	}
+	after, err := s.inpatientRepo.GetAdmission(ctx, before.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceAdmission, &after.ID, &after.PatientID, map[string]domain.FieldChange{
+		"status":                {Before: before.Status, After: after.Status},
+		"bed_id":                {Before: before.BedID},
+		"ward_id":               {Before: before.WardID},
+		"discharge_disposition": {After: after.DischargeDisposition},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// ListAdmissions lists admissions for the front desk, or for a doctor
+// those of patients under their care.
+func (s *InpatientService) ListAdmissions(actor domain.Actor, filter domain.AdmissionFilter) ([]domain.Admission, error) {
+	ctx := context.Background()
+	switch actor.Role {
+	case domain.RoleReceptionist:
+	case domain.RoleDoctor:
+		filter.CareDoctorID = &actor.UserID
+	default:
+		return nil, fmt.Errorf("%w: cannot view admissions", domain.ErrForbidden)
+	}
+
+	admissions, err := s.inpatientRepo.ListAdmissions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(admissions))
+	for i := range admissions {
+		a := admissions[i]
+		entries = append(entries, NewEntry(actor, domain.AuditActionList, domain.ResourceAdmission, &a.ID, &a.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return admissions, nil
+}
+
+// GetAdmission returns an admission with the beds the patient has been
+// in.
+func (s *InpatientService) GetAdmission(actor domain.Actor, id int) (*domain.Admission, error) {
+	ctx := context.Background()
+	a, err := s.inpatientRepo.GetAdmissionDetail(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.careTeamService.Authorize(ctx, actor, a.PatientID); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourceAdmission, &a.ID, &a.PatientID, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Board returns the live bed board of every active ward, or of one.
+// Occupants are shown by initials only.
+func (s *InpatientService) Board(actor domain.Actor, wardID *int32) ([]domain.WardBoard, error) {
+	ctx := context.Background()
+	boards, err := s.inpatientRepo.Board(ctx, wardID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range boards {
+		for _, bed := range boards[i].Beds {
+			if bed.Occupant != nil {
+				bed.Occupant.PatientInitials = initials(bed.Occupant.PatientName)
+			}
+		}
+	}
+
+	entry := NewEntry(actor, domain.AuditActionList, domain.ResourceBedBoard, nil, nil, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return boards, nil
+}
+
+// Census returns each active ward's census for date (YYYY-MM-DD, default
+// today): the patients in it at the end of the day, or now for today, and
+// the day's admissions, transfers and discharges.
+func (s *InpatientService) Census(date string) ([]domain.WardCensus, error) {
+	now := time.Now().In(s.loc)
+	day := localtime.StartOfDay(now, s.loc)
+	if date != "" {
+		d, err := localtime.ParseDate(date, s.loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: date: %v", domain.ErrInvalid, err)
+		}
+		day = d
+	}
+	if day.After(now) {
+		return nil, fmt.Errorf("%w: date is in the future", domain.ErrInvalid)
+	}
+
+	until := day.AddDate(0, 0, 1)
+	if until.After(now) {
+		until = now
+	}
+	census, err := s.inpatientRepo.Census(context.Background(), day, until)
+	if err != nil {
+		return nil, err
+	}
+	for i := range census {
+		census[i].Date = day.Format("2006-01-02")
+	}
+	return census, nil
+}
+
+// checkAdmitter checks that actor may admit or move patientID: the front
+// desk may, and doctors for patients under their care.
+func (s *InpatientService) checkAdmitter(ctx context.Context, actor domain.Actor, patientID int32) error {
+	switch actor.Role {
+	case domain.RoleReceptionist:
+		return nil
+	case domain.RoleDoctor:
+		return s.careTeamService.Authorize(ctx, actor, patientID)
+	default:
+		return fmt.Errorf("%w: cannot admit patients", domain.ErrForbidden)
+	}
+}
+
+func (s *InpatientService) checkDoctor(ctx context.Context, id int32) error {
+	doctor, err := s.userRepo.GetByID(ctx, id)
+	if err != nil || doctor.Role != domain.RoleDoctor {
+		return fmt.Errorf("%w: doctor %d", domain.ErrNotFound, id)
+	}
+	return nil
+}
+
+func careTeamEntry(actor domain.Actor, member *domain.CareTeamMember) *domain.AuditEntry {
+	return NewEntry(actor, domain.AuditActionCreate, domain.ResourceCareTeamMember, &member.DoctorID, &member.PatientID, map[string]domain.FieldChange{
+		"doctor_id": {After: member.DoctorID},
+		"source":    {After: member.Source},
+	})
+}
@@ -0,0 +1,679 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/prem0x01/hospital/internal/claimfile"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/localtime"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+// maxInvoiceClaims bounds the claims looked at for one invoice; there is
+// at most one open claim per policy, and a patient holds three.
+const maxInvoiceClaims = 100
+
+// InsuranceService keeps patients' insurance policies and claims issued
+// invoices against them. Claims are drafted one invoice at a time, sent
+// to the payer in batches and then settled as the payer answers.
+type InsuranceService struct {
+	insuranceRepo   *repository.InsuranceRepository
+	billingRepo     *repository.BillingRepository
+	appointmentRepo *repository.AppointmentRepository
+	patientRepo     *repository.PatientRepository
+	diagnosisRepo   *repository.DiagnosisRepository
+	consentService  *ConsentService
+	auditService    *AuditService
+	loc             *time.Location
+}
+
+func NewInsuranceService(insuranceRepo *repository.InsuranceRepository, billingRepo *repository.BillingRepository, appointmentRepo *repository.AppointmentRepository, patientRepo *repository.PatientRepository, diagnosisRepo *repository.DiagnosisRepository, consentService *ConsentService, auditService *AuditService, loc *time.Location) *InsuranceService {
+	return &InsuranceService{
+		insuranceRepo:   insuranceRepo,
+		billingRepo:     billingRepo,
+		appointmentRepo: appointmentRepo,
+		patientRepo:     patientRepo,
+		diagnosisRepo:   diagnosisRepo,
+		consentService:  consentService,
+		auditService:    auditService,
+		loc:             loc,
+	}
+}
+
+func (s *InsuranceService) ListPayers(active *bool) ([]domain.InsurancePayer, error) {
+	return s.insuranceRepo.ListPayers(context.Background(), active)
+}
+
+func (s *InsuranceService) GetPayer(id int) (*domain.InsurancePayer, error) {
+	return s.insuranceRepo.GetPayer(context.Background(), int32(id))
+}
+
+func (s *InsuranceService) CreatePayer(req *domain.SaveInsurancePayerRequest) (*domain.InsurancePayer, error) {
+	p, err := insurancePayer(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.insuranceRepo.CreatePayer(context.Background(), p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// UpdatePayer replaces a payer. An inactive payer keeps its policies but
+// no new claims are made to it.
+func (s *InsuranceService) UpdatePayer(id int, req *domain.SaveInsurancePayerRequest) (*domain.InsurancePayer, error) {
+	p, err := insurancePayer(req)
+	if err != nil {
+		return nil, err
+	}
+	p.ID = int32(id)
+	if err := s.insuranceRepo.UpdatePayer(context.Background(), p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func insurancePayer(req *domain.SaveInsurancePayerRequest) (*domain.InsurancePayer, error) {
+	p := &domain.InsurancePayer{
+		Name:      strings.TrimSpace(req.Name),
+		PayerCode: strings.ToUpper(strings.TrimSpace(req.PayerCode)),
+		Phone:     trimNotes(req.Phone),
+		Email:     trimNotes(req.Email),
+		Active:    true,
+	}
+	if p.Name == "" || p.PayerCode == "" {
+		return nil, fmt.Errorf("%w: name and payer_code are required", domain.ErrInvalid)
+	}
+	if req.Active != nil {
+		p.Active = *req.Active
+	}
+	return p, nil
+}
+
+// ListCoverages returns the patient's insurance policies, those still in
+// force first.
+func (s *InsuranceService) ListCoverages(actor domain.Actor, patientID int) ([]domain.Coverage, error) {
+	ctx := context.Background()
+	pid := int32(patientID)
+	if _, err := s.patientRepo.GetByID(ctx, pid); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, pid)
+	}
+	coverages, err := s.insuranceRepo.ListCoverages(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(coverages))
+	for i := range coverages {
+		c := coverages[i]
+		entries = append(entries, NewEntry(actor, domain.AuditActionList, domain.ResourceCoverage, &c.ID, &c.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return coverages, nil
+}
+
+func (s *InsuranceService) CreateCoverage(actor domain.Actor, patientID int, req *domain.SaveCoverageRequest) (*domain.Coverage, error) {
+	ctx := context.Background()
+	pid := int32(patientID)
+	if _, err := s.patientRepo.GetByID(ctx, pid); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, pid)
+	}
+	c, err := s.coverage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	c.PatientID = pid
+	c.CreatedBy = &actor.UserID
+	if err := s.insuranceRepo.CreateCoverage(ctx, c); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceCoverage, &c.ID, &c.PatientID, map[string]domain.FieldChange{
+		"payer_id": {After: c.PayerID},
+		"priority": {After: c.Priority},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// UpdateCoverage replaces one of the patient's policies. Claims already
+// made keep the copay and coinsurance they were priced with.
+func (s *InsuranceService) UpdateCoverage(actor domain.Actor, patientID, id int, req *domain.SaveCoverageRequest) (*domain.Coverage, error) {
+	ctx := context.Background()
+	before, err := s.patientCoverage(ctx, patientID, id)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.coverage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	c.ID = before.ID
+	c.PatientID = before.PatientID
+	if err := s.insuranceRepo.UpdateCoverage(ctx, c); err != nil {
+		return nil, err
+	}
+
+	changes := map[string]domain.FieldChange{}
+	if before.PayerID != c.PayerID {
+		changes["payer_id"] = domain.FieldChange{Before: before.PayerID, After: c.PayerID}
+	}
+	if before.Priority != c.Priority {
+		changes["priority"] = domain.FieldChange{Before: before.Priority, After: c.Priority}
+	}
+	if before.ValidTo != c.ValidTo {
+		changes["valid_to"] = domain.FieldChange{Before: before.ValidTo, After: c.ValidTo}
+	}
+	if before.CopayMinor != c.CopayMinor {
+		changes["copay_minor"] = domain.FieldChange{Before: before.CopayMinor, After: c.CopayMinor}
+	}
+	if before.CoinsuranceBP != c.CoinsuranceBP {
+		changes["coinsurance_bp"] = domain.FieldChange{Before: before.CoinsuranceBP, After: c.CoinsuranceBP}
+	}
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceCoverage, &c.ID, &c.PatientID, changes)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// DeleteCoverage removes a policy entered by mistake. One that has been
+// claimed against is ended with valid_to instead.
+func (s *InsuranceService) DeleteCoverage(actor domain.Actor, patientID, id int) error {
+	ctx := context.Background()
+	c, err := s.patientCoverage(ctx, patientID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.insuranceRepo.DeleteCoverage(ctx, c.ID); err != nil {
+		return err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionDelete, domain.ResourceCoverage, &c.ID, &c.PatientID, nil)
+	return s.auditService.Record(ctx, entry)
+}
+
+// patientCoverage returns coverage id if it belongs to the patient.
+func (s *InsuranceService) patientCoverage(ctx context.Context, patientID, id int) (*domain.Coverage, error) {
+	c, err := s.insuranceRepo.GetCoverage(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if c.PatientID != int32(patientID) {
+		return nil, fmt.Errorf("%w: coverage %d", domain.ErrNotFound, id)
+	}
+	return c, nil
+}
+
+func (s *InsuranceService) coverage(ctx context.Context, req *domain.SaveCoverageRequest) (*domain.Coverage, error) {
+	c := &domain.Coverage{
+		PayerID:        req.PayerID,
+		Priority:       req.Priority,
+		PolicyNumber:   strings.TrimSpace(req.PolicyNumber),
+		MemberNumber:   strings.TrimSpace(req.MemberNumber),
+		GroupNumber:    trimNotes(req.GroupNumber),
+		SubscriberName: trimNotes(req.SubscriberName),
+		Relationship:   req.Relationship,
+		CopayMinor:     req.CopayMinor,
+		CoinsuranceBP:  req.CoinsuranceBP,
+	}
+	if c.PolicyNumber == "" || c.MemberNumber == "" {
+		return nil, fmt.Errorf("%w: policy_number and member_number are required", domain.ErrInvalid)
+	}
+	if c.Relationship == "" {
+		c.Relationship = domain.RelationshipSelf
+	}
+	if c.Relationship != domain.RelationshipSelf && c.SubscriberName == nil {
+		return nil, fmt.Errorf("%w: subscriber_name is required when the patient is not the subscriber", domain.ErrInvalid)
+	}
+
+	from, err := localtime.ParseDate(req.ValidFrom, s.loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: valid_from: %v", domain.ErrInvalid, err)
+	}
+	c.ValidFrom = pgtype.Date{Time: from, Valid: true}
+	if req.ValidTo != nil {
+		to, err := localtime.ParseDate(*req.ValidTo, s.loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: valid_to: %v", domain.ErrInvalid, err)
+		}
+		if to.Before(from) {
+			return nil, fmt.Errorf("%w: valid_to is before valid_from", domain.ErrInvalid)
+		}
+		c.ValidTo = pgtype.Date{Time: to, Valid: true}
+	}
+
+	payer, err := s.insuranceRepo.GetPayer(ctx, c.PayerID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: insurance payer %d", domain.ErrInvalid, c.PayerID)
+	}
+	if !payer.Active {
+		return nil, fmt.Errorf("%w: insurance payer %s is inactive", domain.ErrInvalid, payer.PayerCode)
+	}
+	return c, nil
+}
+
+// CreateClaim drafts a claim of an issued invoice. Without a coverage in
+// req it is made against the patient's first policy in force on the day
+// of service that the invoice has not been claimed against yet, so a
+// second call after the primary claim goes to the secondary payer. The
+// payer and patient shares follow the policy's copay and coinsurance.
+func (s *InsuranceService) CreateClaim(actor domain.Actor, invoiceID int, req *domain.CreateClaimRequest) (*domain.Claim, error) {
+	ctx := context.Background()
+	inv, err := s.billingRepo.GetInvoice(ctx, int32(invoiceID))
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status != domain.InvoiceIssued && inv.Status != domain.InvoicePartiallyPaid {
+		return nil, fmt.Errorf("%w: invoice %d is %s; only issued invoices with a balance can be claimed", domain.ErrConflict, inv.ID, inv.Status)
+	}
+	if err := s.consentService.Require(ctx, inv.PatientID, domain.ConsentDataSharing); err != nil {
+		return nil, err
+	}
+	serviceDate, err := s.serviceDate(ctx, inv)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage, err := s.claimCoverage(ctx, inv, serviceDate, req.CoverageID)
+	if err != nil {
+		return nil, err
+	}
+	claim, err := s.insuranceRepo.CreateClaim(ctx, &domain.Claim{
+		InvoiceID:     inv.ID,
+		CoverageID:    coverage.ID,
+		PayerID:       coverage.PayerID,
+		ServiceDate:   pgtype.Date{Time: serviceDate, Valid: true},
+		CopayMinor:    coverage.CopayMinor,
+		CoinsuranceBP: coverage.CoinsuranceBP,
+		CreatedBy:     &actor.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceClaim, &claim.ID, &claim.PatientID, map[string]domain.FieldChange{
+		"invoice_id":          {After: claim.InvoiceID},
+		"coverage_id":         {After: claim.CoverageID},
+		"billed_minor":        {After: claim.BilledMinor},
+		"payer_share_minor":   {After: claim.PayerShareMinor},
+		"patient_share_minor": {After: claim.PatientShareMinor},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// serviceDate is the day of the invoice's appointment in the hospital's
+// time zone or, for an invoice without one, the day it was issued.
+func (s *InsuranceService) serviceDate(ctx context.Context, inv *domain.Invoice) (time.Time, error) {
+	if inv.AppointmentID != nil {
+		a, err := s.appointmentRepo.GetByID(ctx, *inv.AppointmentID)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: appointment %d", domain.ErrNotFound, *inv.AppointmentID)
+		}
+		return localtime.StartOfDay(a.AppointmentDate.Time, s.loc), nil
+	}
+	return localtime.StartOfDay(*inv.IssuedAt, s.loc), nil
+}
+
+// claimCoverage picks the policy to claim inv against: coverageID if
+// given, else the first in force on day without an open claim of inv.
+func (s *InsuranceService) claimCoverage(ctx context.Context, inv *domain.Invoice, day time.Time, coverageID *int32) (*domain.Coverage, error) {
+	inForce, err := s.insuranceRepo.CoveragesInForce(ctx, inv.PatientID, day)
+	if err != nil {
+		return nil, err
+	}
+	if coverageID != nil {
+		for i := range inForce {
+			if inForce[i].ID == *coverageID {
+				return &inForce[i], nil
+			}
+		}
+		return nil, fmt.Errorf("%w: coverage %d is not a policy of the patient in force on %s", domain.ErrInvalid, *coverageID, day.Format("2006-01-02"))
+	}
+
+	claims, err := s.insuranceRepo.ListClaims(ctx, domain.ClaimFilter{InvoiceID: &inv.ID, Limit: maxInvoiceClaims})
+	if err != nil {
+		return nil, err
+	}
+	claimed := make(map[int32]bool, len(claims))
+	for _, c := range claims {
+		if c.Status != domain.ClaimDenied && c.Status != domain.ClaimCancelled {
+			claimed[c.CoverageID] = true
+		}
+	}
+	for i := range inForce {
+		if !claimed[inForce[i].ID] {
+			return &inForce[i], nil
+		}
+	}
+	if len(inForce) == 0 {
+		return nil, fmt.Errorf("%w: the patient has no policy in force on %s", domain.ErrUnavailable, day.Format("2006-01-02"))
+	}
+	return nil, fmt.Errorf("%w: invoice %d has been claimed against every policy in force", domain.ErrConflict, inv.ID)
+}
+
+func (s *InsuranceService) ListClaims(actor domain.Actor, filter domain.ClaimFilter) ([]domain.Claim, error) {
+	ctx := context.Background()
+	claims, err := s.insuranceRepo.ListClaims(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(claims))
+	for i := range claims {
+		c := claims[i]
+		entries = append(entries, NewEntry(actor, domain.AuditActionList, domain.ResourceClaim, &c.ID, &c.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (s *InsuranceService) GetClaim(actor domain.Actor, id int) (*domain.Claim, error) {
+	ctx := context.Background()
+	claim, err := s.insuranceRepo.GetClaim(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourceClaim, &claim.ID, &claim.PatientID, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// AdjudicateClaim records the payer's answer to a submitted claim. A
+// payer share of zero is a denial and needs a reason; anything else
+// becomes the payer's share, with the patient owing the rest.
+func (s *InsuranceService) AdjudicateClaim(actor domain.Actor, id int, req *domain.AdjudicateClaimRequest) (*domain.Claim, error) {
+	ctx := context.Background()
+	before, err := s.insuranceRepo.GetClaim(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	payerShare := *req.PayerShareMinor
+	status := domain.ClaimAdjudicated
+	reason := trimNotes(req.DenialReason)
+	if payerShare == 0 {
+		status = domain.ClaimDenied
+		if reason == nil {
+			return nil, fmt.Errorf("%w: a denied claim needs a denial_reason", domain.ErrInvalid)
+		}
+	} else {
+		reason = nil
+	}
+	if err := domain.CheckClaimTransition(before.Status, status); err != nil {
+		return nil, err
+	}
+	if payerShare > before.BilledMinor {
+		return nil, fmt.Errorf("%w: payer_share_minor is more than the %d billed", domain.ErrInvalid, before.BilledMinor)
+	}
+
+	if err := s.insuranceRepo.Adjudicate(ctx, before.ID, status, payerShare, trimNotes(req.PayerReference), reason); err != nil {
+		return nil, err
+	}
+	claim, err := s.insuranceRepo.GetClaim(ctx, before.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceClaim, &claim.ID, &claim.PatientID, map[string]domain.FieldChange{
+		"status":              {Before: before.Status, After: claim.Status},
+		"payer_share_minor":   {Before: before.PayerShareMinor, After: claim.PayerShareMinor},
+		"patient_share_minor": {Before: before.PatientShareMinor, After: claim.PatientShareMinor},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// CancelClaim withdraws a claim the payer has not decided on yet.
+func (s *InsuranceService) CancelClaim(actor domain.Actor, id int) (*domain.Claim, error) {
+	ctx := context.Background()
+	before, err := s.insuranceRepo.GetClaim(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.CheckClaimTransition(before.Status, domain.ClaimCancelled); err != nil {
+		return nil, err
+	}
+	if err := s.insuranceRepo.Cancel(ctx, before.ID); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceClaim, &before.ID, &before.PatientID, map[string]domain.FieldChange{
+		"status": {Before: before.Status, After: domain.ClaimCancelled},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return s.insuranceRepo.GetClaim(ctx, before.ID)
+}
+
+// RecordClaimPayment credits the payer's payment of an adjudicated claim
+// to its invoice and closes the claim.
+func (s *InsuranceService) RecordClaimPayment(actor domain.Actor, id int, req *domain.ClaimPaymentRequest) (*domain.Claim, error) {
+	ctx := context.Background()
+	p := &domain.Payment{
+		AmountMinor: req.AmountMinor,
+		Reference:   trimNotes(req.Reference),
+		ReceivedBy:  &actor.UserID,
+	}
+	if err := s.insuranceRepo.Pay(ctx, int32(id), p); err != nil {
+		return nil, err
+	}
+	claim, err := s.insuranceRepo.GetClaim(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	inv, err := s.billingRepo.GetInvoice(ctx, claim.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.auditService.Record(ctx,
+		NewEntry(actor, domain.AuditActionCreate, domain.ResourcePayment, &p.ID, &claim.PatientID, map[string]domain.FieldChange{
+			"kind":         {After: p.Kind},
+			"method":       {After: p.Method},
+			"amount_minor": {After: p.AmountMinor},
+			"claim_id":     {After: claim.ID},
+		}),
+		NewEntry(actor, domain.AuditActionUpdate, domain.ResourceInvoice, &inv.ID, &inv.PatientID, map[string]domain.FieldChange{
+			"paid_minor": {After: inv.PaidMinor},
+			"status":     {After: inv.Status},
+		}),
+		NewEntry(actor, domain.AuditActionUpdate, domain.ResourceClaim, &claim.ID, &claim.PatientID, map[string]domain.FieldChange{
+			"status":     {Before: domain.ClaimAdjudicated, After: claim.Status},
+			"paid_minor": {After: claim.PaidMinor},
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+// SubmitBatch submits every draft claim to the payer as one batch, whose
+// file is then sent to the clearinghouse. The file discloses the patients'
+// details and diagnoses, so every patient in the batch must have consented
+// to data sharing; otherwise nothing is submitted.
+func (s *InsuranceService) SubmitBatch(actor domain.Actor, req *domain.CreateClaimBatchRequest) (*domain.ClaimBatch, error) {
+	ctx := context.Background()
+	batch, err := s.insuranceRepo.SubmitBatch(ctx, req.PayerID, &actor.UserID, func(claims []domain.Claim) error {
+		return s.requireDataSharing(ctx, claims)
+	})
+	if err != nil {
+		return nil, err
+	}
+	claims, err := s.insuranceRepo.BatchClaims(ctx, batch.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(claims))
+	for i := range claims {
+		c := claims[i]
+		entries = append(entries, NewEntry(actor, domain.AuditActionUpdate, domain.ResourceClaim, &c.ID, &c.PatientID, map[string]domain.FieldChange{
+			"status":   {Before: domain.ClaimDraft, After: c.Status},
+			"batch_id": {After: batch.ID},
+		}))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+func (s *InsuranceService) ListBatches(payerID *int32, limit, offset int32) ([]domain.ClaimBatch, error) {
+	return s.insuranceRepo.ListBatches(context.Background(), payerID, limit, offset)
+}
+
+func (s *InsuranceService) GetBatch(id int) (*domain.ClaimBatch, error) {
+	return s.insuranceRepo.GetBatch(context.Background(), int32(id))
+}
+
+// BatchFile builds the clearinghouse file of a batch. It can be fetched
+// again at any time and always describes the claims as submitted, but not
+// once a patient in it has revoked their consent to data sharing.
+func (s *InsuranceService) BatchFile(actor domain.Actor, id int) (*claimfile.Batch, error) {
+	ctx := context.Background()
+	batch, err := s.insuranceRepo.GetBatch(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	claims, err := s.insuranceRepo.BatchClaims(ctx, batch.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireDataSharing(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	file := &claimfile.Batch{
+		ID:        batch.ID,
+		PayerCode: batch.PayerCode,
+		PayerName: batch.PayerName,
+		Claims:    make([]claimfile.Claim, 0, len(claims)),
+	}
+	entries := make([]*domain.AuditEntry, 0, len(claims))
+	for i := range claims {
+		c := claims[i]
+		fc, err := s.fileClaim(ctx, &c)
+		if err != nil {
+			return nil, fmt.Errorf("claim %d: %w", c.ID, err)
+		}
+		file.Claims = append(file.Claims, *fc)
+		entries = append(entries, NewEntry(actor, domain.AuditActionRead, domain.ResourceClaim, &c.ID, &c.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// requireDataSharing checks that the patient of every claim has consent to
+// data sharing in force, as the payer is sent their details.
+func (s *InsuranceService) requireDataSharing(ctx context.Context, claims []domain.Claim) error {
+	for i := range claims {
+		c := &claims[i]
+		if err := s.consentService.Require(ctx, c.PatientID, domain.ConsentDataSharing); err != nil {
+			return fmt.Errorf("claim %d: %w", c.ID, err)
+		}
+	}
+	return nil
+}
+
+// fileClaim gathers what the clearinghouse needs to know about c.
+func (s *InsuranceService) fileClaim(ctx context.Context, c *domain.Claim) (*claimfile.Claim, error) {
+	inv, err := s.billingRepo.GetInvoice(ctx, c.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	coverage, err := s.insuranceRepo.GetCoverage(ctx, c.CoverageID)
+	if err != nil {
+		return nil, err
+	}
+	patient, err := s.patientRepo.GetByID(ctx, c.PatientID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, c.PatientID)
+	}
+	lines, err := s.insuranceRepo.ClaimLines(ctx, inv.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	fc := &claimfile.Claim{
+		ID:          c.ID,
+		InvoiceID:   inv.ID,
+		ServiceDate: c.ServiceDate.Time,
+		Currency:    inv.Currency,
+		Policy: claimfile.Policy{
+			PolicyNumber: coverage.PolicyNumber,
+			MemberNumber: coverage.MemberNumber,
+			Relationship: coverage.Relationship,
+		},
+		Patient: claimfile.Patient{
+			MRN:       domain.MRN(patient.ID),
+			LastName:  patient.LastName,
+			FirstName: patient.FirstName,
+		},
+		BilledMinor:       c.BilledMinor,
+		PayerShareMinor:   c.PayerShareMinor,
+		PatientShareMinor: c.PatientShareMinor,
+		Lines:             make([]claimfile.Line, 0, len(lines)),
+	}
+	if coverage.GroupNumber != nil {
+		fc.Policy.GroupNumber = *coverage.GroupNumber
+	}
+	if coverage.SubscriberName != nil {
+		fc.Policy.SubscriberName = *coverage.SubscriberName
+	} else if coverage.Relationship == domain.RelationshipSelf {
+		fc.Policy.SubscriberName = patient.FirstName + " " + patient.LastName
+	}
+	if patient.DateOfBirth.Valid {
+		fc.Patient.BirthDate = patient.DateOfBirth.Time
+	}
+	if patient.Gender != nil {
+		fc.Patient.Gender = *patient.Gender
+	}
+
+	if inv.AppointmentID != nil {
+		diagnoses, err := s.diagnosisRepo.ListByAppointment(ctx, *inv.AppointmentID)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range diagnoses {
+			if d.Status != domain.DiagnosisRuledOut {
+				fc.Diagnoses = append(fc.Diagnoses, d.Code)
+			}
+		}
+	}
+	for _, l := range lines {
+		line := claimfile.Line{
+			Description:    l.Description,
+			Quantity:       l.Quantity,
+			UnitPriceMinor: l.UnitPriceMinor,
+			TotalMinor:     l.TotalMinor,
+		}
+		if l.ServiceCode != nil {
+			line.ServiceCode = *l.ServiceCode
+		}
+		fc.Lines = append(fc.Lines, line)
+	}
+	return fc, nil
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/audit"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/notify"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+const (
+	notificationBatchSize = 50
+	// notificationLease must comfortably exceed the time to send a batch,
+	// or another worker may claim and resend the same messages.
+	notificationLease       = 5 * time.Minute
+	maxNotificationAttempts = 5
+)
+
+// reminderData is what appointment reminder templates can refer to. It
+// carries only what a patient needs to recognise the appointment.
+type reminderData struct {
+	FirstName  string
+	DoctorName string
+	Date       string
+	Time       string
+}
+
+// sampleReminder is used to check templates before they are saved.
+var sampleReminder = reminderData{FirstName: "Alex", DoctorName: "Dr Smith", Date: "2025-01-31", Time: "09:30"}
+
+type NotificationService struct {
+	notificationRepo *repository.NotificationRepository
+	appointmentRepo  *repository.AppointmentRepository
+	patientRepo      *repository.PatientRepository
+	careTeamService  *CareTeamService
+	consentService   *ConsentService
+	email            notify.EmailSender
+	sms              notify.SMSSender
+	auditService     *AuditService
+}
+
+func NewNotificationService(notificationRepo *repository.NotificationRepository, appointmentRepo *repository.AppointmentRepository, patientRepo *repository.PatientRepository, careTeamService *CareTeamService, consentService *ConsentService, email notify.EmailSender, sms notify.SMSSender, auditService *AuditService) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		appointmentRepo:  appointmentRepo,
+		patientRepo:      patientRepo,
+		careTeamService:  careTeamService,
+		consentService:   consentService,
+		email:            email,
+		sms:              sms,
+		auditService:     auditService,
+	}
+}
+
+// DeliverDue sends the due messages from the outbox, a batch at a time.
+// It is run by the JobScheduler. Failed sends are retried with backoff up
+// to maxNotificationAttempts times.
+func (s *NotificationService) DeliverDue(ctx context.Context) (string, error) {
+	sent := 0
+	for {
+		n, claimed, err := s.deliverBatch(ctx)
+		sent += n
+		if err != nil {
+			return fmt.Sprintf("sent %d messages", sent), err
+		}
+		if claimed < notificationBatchSize {
+			return fmt.Sprintf("sent %d messages", sent), nil
+		}
+	}
+}
+
+// deliverBatch claims and sends one batch. It returns how many messages
+// were sent and how many were claimed.
+func (s *NotificationService) deliverBatch(ctx context.Context) (int, int, error) {
+	messages, err := s.notificationRepo.Claim(ctx, notificationBatchSize, notificationLease)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	sent := 0
+	for _, m := range messages {
+		skip, err := s.deliver(ctx, m)
+		switch {
+		case skip != "":
+			err = s.notificationRepo.Finish(ctx, m.ID, domain.NotificationSkipped, &skip)
+		case err == nil:
+			sent++
+			err = s.notificationRepo.Finish(ctx, m.ID, domain.NotificationSent, nil)
+		case m.Attempts >= maxNotificationAttempts:
+			reason := err.Error()
+			log.Printf("notify: giving up on message %d: %v", m.ID, err)
+			err = s.notificationRepo.Finish(ctx, m.ID, domain.NotificationFailed, &reason)
+		default:
+			backoff := time.Duration(m.Attempts*m.Attempts) * time.Minute
+			err = s.notificationRepo.Defer(ctx, m.ID, err.Error(), backoff)
+		}
+		if err != nil {
+			return sent, len(messages), err
+		}
+	}
+	return sent, len(messages), nil
+}
+
+// deliver sends m. It returns a reason instead if the message should not
+// be sent at all, or an error if sending failed and may be retried.
+func (s *NotificationService) deliver(ctx context.Context, m domain.OutboxMessage) (string, error) {
+	appointment, err := s.appointmentRepo.GetByID(ctx, m.AppointmentID)
+	if err != nil {
+		return "", err
+	}
+	if statusOf(appointment) != domain.AppointmentScheduled {
+		return "appointment is " + statusOf(appointment), nil
+	}
+	if !appointment.AppointmentDate.Time.After(m.SendAt) {
+		return "appointment has already started", nil
+	}
+
+	patient, err := s.patientRepo.GetByID(ctx, *appointment.PatientID)
+	if err != nil {
+		return "", err
+	}
+	prefs, err := s.notificationRepo.GetPreferences(ctx, patient.ID)
+	if err != nil {
+		return "", err
+	}
+
+	var to *string
+	switch m.Channel {
+	case notify.ChannelEmail:
+		if !prefs.EmailEnabled {
+			return "patient opted out of email", nil
+		}
+		to = patient.Email
+	case notify.ChannelSMS:
+		if !prefs.SMSEnabled {
+			return "patient opted out of sms", nil
+		}
+		err = s.consentService.Require(ctx, patient.ID, domain.ConsentSMSReminders)
+		if errors.Is(err, domain.ErrNoConsent) {
+			return err.Error(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		to = patient.Phone
+	}
+	if to == nil || *to == "" {
+		return "no " + m.Channel + " contact on file", nil
+	}
+
+	tmpl, err := s.notificationRepo.GetTemplate(ctx, m.Template, m.Channel, prefs.Language)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "no " + m.Template + " template for " + m.Channel, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	subject, body, err := notify.Template{Subject: tmpl.Subject, Body: tmpl.Body}.Render(reminderData{
+		FirstName:  patient.FirstName,
+		DoctorName: appointment.DoctorName,
+		Date:       appointment.AppointmentDate.Time.Format("2006-01-02"),
+		Time:       appointment.AppointmentDate.Time.Format("15:04"),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Record the disclosure before making it, as for any PHI access.
+	changes := map[string]domain.FieldChange{
+		"channel":  {After: m.Channel},
+		"template": {After: m.Template},
+	}
+	entry := NewEntry(systemActor, domain.AuditActionNotify, domain.ResourceAppointment, &appointment.ID, appointment.PatientID, changes)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return "", err
+	}
+
+	msg := notify.Message{ID: m.ID, Channel: m.Channel, To: *to, Subject: subject, Body: body}
+	if m.Channel == notify.ChannelSMS {
+		return "", s.sms.SendSMS(ctx, msg)
+	}
+	return "", s.email.SendEmail(ctx, msg)
+}
+
+func (s *NotificationService) ListTemplates() ([]domain.NotificationTemplate, error) {
+	return s.notificationRepo.ListTemplates(context.Background())
+}
+
+// SaveTemplate adds or replaces a template. It is rendered with sample
+// data first so that a broken template is rejected here rather than at
+// send time.
+func (s *NotificationService) SaveTemplate(template, channel, language string, req *domain.SaveNotificationTemplateRequest) (*domain.NotificationTemplate, error) {
+	if channel != notify.ChannelEmail && channel != notify.ChannelSMS {
+		return nil, fmt.Errorf("%w: unknown channel %q", domain.ErrInvalid, channel)
+	}
+	if template != domain.TemplateAppointmentReminder {
+		return nil, fmt.Errorf("%w: unknown template %q", domain.ErrInvalid, template)
+	}
+	if _, _, err := (notify.Template{Subject: req.Subject, Body: req.Body}).Render(sampleReminder); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalid, err)
+	}
+
+	t := &domain.NotificationTemplate{
+		Template: template,
+		Channel:  channel,
+		Language: language,
+		Subject:  req.Subject,
+		Body:     req.Body,
+	}
+	if err := s.notificationRepo.SaveTemplate(context.Background(), t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (s *NotificationService) GetPreferences(actor domain.Actor, patientID int) (*domain.NotificationPreferences, error) {
+	ctx := context.Background()
+	if err := s.careTeamService.Authorize(ctx, actor, int32(patientID)); err != nil {
+		return nil, err
+	}
+	if _, err := s.patientRepo.GetByID(ctx, int32(patientID)); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, patientID)
+	}
+	return s.notificationRepo.GetPreferences(ctx, int32(patientID))
+}
+
+func (s *NotificationService) SavePreferences(actor domain.Actor, patientID int, prefs *domain.NotificationPreferences) (*domain.NotificationPreferences, error) {
+	ctx := context.Background()
+	before, err := s.GetPreferences(actor, patientID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs.PatientID = int32(patientID)
+	if err := s.notificationRepo.SavePreferences(ctx, prefs); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceNotification, nil, &prefs.PatientID, audit.Diff(before, prefs))
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
@@ -6,31 +6,82 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/prem0x01/hospital/internal/audit"
 	"github.com/prem0x01/hospital/internal/domain"
 	"github.com/prem0x01/hospital/internal/repository"
 	//"github.com/prem0x01/hospital/internal/utils"
 )
 
+// PatientService restricts doctors to the patients on their care team;
+// see CareTeamService.Authorize.
 type PatientService struct {
-	patientRepo *repository.PatientRepository
+	patientRepo     *repository.PatientRepository
+	careTeamService *CareTeamService
+	auditService    *AuditService
 }
 
-func NewPatientService(patientRepo *repository.PatientRepository) *PatientService {
-	return &PatientService{patientRepo: patientRepo}
+func NewPatientService(patientRepo *repository.PatientRepository, careTeamService *CareTeamService, auditService *AuditService) *PatientService {
+	return &PatientService{patientRepo: patientRepo, careTeamService: careTeamService, auditService: auditService}
 }
 
-func (s *PatientService) GetPatients(limit, offset int) ([]domain.Patient, error) {
+func (s *PatientService) GetPatients(actor domain.Actor, limit, offset int) ([]domain.Patient, error) {
 	ctx := context.Background()
-	return s.patientRepo.GetAll(ctx, int32(limit), int32(offset))
+	var patients []domain.Patient
+	var err error
+	if actor.Role == domain.RoleDoctor {
+		patients, err = s.patientRepo.GetAllForDoctor(ctx, actor.UserID, int32(limit), int32(offset))
+	} else {
+		patients, err = s.patientRepo.GetAll(ctx, int32(limit), int32(offset))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.auditPatientList(ctx, actor, domain.AuditActionList, patients); err != nil {
+		return nil, err
+	}
+	return patients, nil
 }
 
-func (s *PatientService) GetPatient(id int) (*domain.Patient, error) {
+func (s *PatientService) GetPatient(actor domain.Actor, id int) (*domain.Patient, error) {
 	ctx := context.Background()
-	return s.patientRepo.GetByID(ctx, int32(id))
+	if err := s.careTeamService.Authorize(ctx, actor, int32(id)); err != nil {
+		return nil, err
+	}
+
+	patient, err := s.patientRepo.GetByID(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourcePatient, &patient.ID, &patient.ID, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return patient, nil
+}
+
+func (s *PatientService) SearchPatients(actor domain.Actor, keyword string, limit, offset int) ([]domain.Patient, error) {
+	ctx := context.Background()
+	var patients []domain.Patient
+	var err error
+	if actor.Role == domain.RoleDoctor {
+		patients, err = s.patientRepo.SearchForDoctor(ctx, actor.UserID, keyword, int32(limit), int32(offset))
+	} else {
+		patients, err = s.patientRepo.Search(ctx, keyword, int32(limit), int32(offset))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.auditPatientList(ctx, actor, domain.AuditActionSearch, patients); err != nil {
+		return nil, err
+	}
+	return patients, nil
 }
 
-func (s *PatientService) CreatePatient(req *domain.CreatePatientRequest, createdBy int) (*domain.Patient, error) {
-	createdByInt32 := int32(createdBy)
+func (s *PatientService) CreatePatient(actor domain.Actor, req *domain.CreatePatientRequest) (*domain.Patient, error) {
+	createdByInt32 := actor.UserID
 	patient := &domain.Patient{
 		FirstName:             req.FirstName,
 		LastName:              req.LastName,
@@ -53,21 +104,37 @@ func (s *PatientService) CreatePatient(req *domain.CreatePatientRequest, created
 		patient.DateOfBirth = pgtype.Date{Time: dob, Valid: true}
 	}
 	ctx := context.Background()
-	if _, err := s.patientRepo.Create(ctx, *patient); err != nil {
+	created, err := s.patientRepo.Create(ctx, *patient)
+	if err != nil {
 		return nil, err
 	}
 
-	return patient, nil
-}
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourcePatient, &created.ID, &created.ID, patientChanges(nil, created))
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	// A doctor registering a patient keeps access to the chart.
+	if actor.Role == domain.RoleDoctor {
+		if err := s.careTeamService.assignCreator(ctx, actor, created.ID); err != nil {
+			return nil, err
+		}
+	}
 
+	return created, nil
+}
 
-func (s *PatientService) UpdatePatient(id int, req *domain.UpdatePatientRequest) (*domain.Patient, error) {
+func (s *PatientService) UpdatePatient(actor domain.Actor, id int, req *domain.UpdatePatientRequest) (*domain.Patient, error) {
 	ctx := context.Background()
+	if err := s.careTeamService.Authorize(ctx, actor, int32(id)); err != nil {
+		return nil, err
+	}
 
 	existing, err := s.patientRepo.GetByID(ctx, int32(id))
 	if err != nil {
 		return nil, fmt.Errorf("patient not found: %w", err)
 	}
+	before := *existing
 
 	if req.FirstName != nil {
 		existing.FirstName = *req.FirstName
@@ -112,10 +179,49 @@ func (s *PatientService) UpdatePatient(id int, req *domain.UpdatePatientRequest)
 		existing.EmergencyContactPhone = req.EmergencyContactPhone
 	}
 
-	return s.patientRepo.Update(ctx, *existing)
+	updated, err := s.patientRepo.Update(ctx, *existing)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourcePatient, &updated.ID, &updated.ID, patientChanges(&before, updated))
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return updated, nil
 }
 
-func (s *PatientService) DeletePatient(id int) error {
+func (s *PatientService) DeletePatient(actor domain.Actor, id int) error {
 	ctx := context.Background()
-	return s.patientRepo.Delete(ctx, int32(id))
+	if err := s.careTeamService.Authorize(ctx, actor, int32(id)); err != nil {
+		return err
+	}
+	existing, err := s.patientRepo.GetByID(ctx, int32(id))
+	if err != nil {
+		return fmt.Errorf("patient not found: %w", err)
+	}
+
+	if err := s.patientRepo.Delete(ctx, existing.ID); err != nil {
+		return err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionDelete, domain.ResourcePatient, &existing.ID, &existing.ID, patientChanges(existing, nil))
+	return s.auditService.Record(ctx, entry)
+}
+
+// auditPatientList records one entry per returned patient so that the log
+// can answer "who has seen this patient" for list and search results too.
+func (s *PatientService) auditPatientList(ctx context.Context, actor domain.Actor, action string, patients []domain.Patient) error {
+	entries := make([]*domain.AuditEntry, 0, len(patients))
+	for i := range patients {
+		id := patients[i].ID
+		entries = append(entries, NewEntry(actor, action, domain.ResourcePatient, &id, &id, nil))
+	}
+	return s.auditService.Record(ctx, entries...)
+}
+
+// patientChanges diffs two versions of a patient for the audit log without
+// copying encrypted fields into it.
+func patientChanges(before, after *domain.Patient) map[string]domain.FieldChange {
+	return audit.Redact(audit.Diff(before, after), domain.ProtectedPatientFields...)
 }
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/repository"
+	"github.com/prem0x01/hospital/internal/storage"
+)
+
+// documentTypes are the file types that may be attached to a record, as
+// sniffed from their first bytes.
+var documentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/tiff":      true,
+	"image/webp":      true,
+	"text/plain":      true,
+}
+
+const maxDocumentFilename = 255
+
+// PatientDocumentService attaches files to patients' records. Contents go
+// to the blob store under their SHA-256, so a file uploaded for several
+// patients is stored once, and uploading the same file twice for one
+// patient is refused. Doctors need access to the chart; every upload,
+// download and deletion is audited.
+type PatientDocumentService struct {
+	documentRepo    *repository.PatientDocumentRepository
+	patientRepo     *repository.PatientRepository
+	store           storage.Store
+	careTeamService *CareTeamService
+	auditService    *AuditService
+	maxBytes        int64
+}
+
+func NewPatientDocumentService(documentRepo *repository.PatientDocumentRepository, patientRepo *repository.PatientRepository, store storage.Store, careTeamService *CareTeamService, auditService *AuditService, maxBytes int64) *PatientDocumentService {
+	return &PatientDocumentService{
+		documentRepo:    documentRepo,
+		patientRepo:     patientRepo,
+		store:           store,
+		careTeamService: careTeamService,
+		auditService:    auditService,
+		maxBytes:        maxBytes,
+	}
+}
+
+// MaxBytes is the largest file that can be uploaded.
+func (s *PatientDocumentService) MaxBytes() int64 {
+	return s.maxBytes
+}
+
+// Upload stores the file read from r as a document of the patient.
+func (s *PatientDocumentService) Upload(actor domain.Actor, patientID int, req *domain.UploadDocumentRequest, filename string, r io.Reader) (*domain.PatientDocument, error) {
+	ctx := context.Background()
+	pid := int32(patientID)
+	if err := s.careTeamService.Authorize(ctx, actor, pid); err != nil {
+		return nil, err
+	}
+	if _, err := s.patientRepo.GetByID(ctx, pid); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, pid)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, s.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", domain.ErrInvalid)
+	}
+	if int64(len(data)) > s.maxBytes {
+		return nil, fmt.Errorf("%w: file is larger than %d bytes", domain.ErrInvalid, s.maxBytes)
+	}
+	contentType := sniffContentType(data)
+	if !documentTypes[contentType] {
+		return nil, fmt.Errorf("%w: files of type %s cannot be attached", domain.ErrInvalid, contentType)
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	existing, err := s.documentRepo.FindByHash(ctx, pid, hash)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: this file is already attached as document %d", domain.ErrConflict, existing.ID)
+	}
+
+	d := &domain.PatientDocument{
+		PatientID:   pid,
+		Category:    req.Category,
+		Description: trimNotes(req.Description),
+		Filename:    cleanFilename(filename),
+		ContentType: contentType,
+		SizeBytes:   int64(len(data)),
+		SHA256:      hash,
+		StorageKey:  "sha256/" + hash[:2] + "/" + hash,
+		UploadedBy:  &actor.UserID,
+	}
+	stored := false
+	err = s.documentRepo.Create(ctx, d, func() error {
+		if err := s.store.Put(ctx, d.StorageKey, data, contentType); err != nil {
+			return fmt.Errorf("failed to store document: %w", err)
+		}
+		stored = true
+		return nil
+	})
+	if err != nil {
+		if stored {
+			s.releaseBlob(ctx, d.StorageKey)
+		}
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourcePatientDocument, &d.ID, &d.PatientID, map[string]domain.FieldChange{
+		"category":     {After: d.Category},
+		"content_type": {After: d.ContentType},
+		"size_bytes":   {After: d.SizeBytes},
+		"sha256":       {After: d.SHA256},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// ListDocuments returns the patient's documents, newest first. category
+// picks one kind of document.
+func (s *PatientDocumentService) ListDocuments(actor domain.Actor, patientID int, category *string) ([]domain.PatientDocument, error) {
+	ctx := context.Background()
+	pid := int32(patientID)
+	if err := s.careTeamService.Authorize(ctx, actor, pid); err != nil {
+		return nil, err
+	}
+
+	docs, err := s.documentRepo.List(ctx, pid, category)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(docs))
+	for i := range docs {
+		d := docs[i]
+		entries = append(entries, NewEntry(actor, domain.AuditActionList, domain.ResourcePatientDocument, &d.ID, &d.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (s *PatientDocumentService) GetDocument(actor domain.Actor, patientID, id int) (*domain.PatientDocument, error) {
+	ctx := context.Background()
+	d, err := s.document(ctx, actor, int32(patientID), int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourcePatientDocument, &d.ID, &d.PatientID, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// OpenDocument returns the document with a reader of its contents, which
+// the caller closes.
+func (s *PatientDocumentService) OpenDocument(actor domain.Actor, patientID, id int) (*domain.PatientDocument, io.ReadCloser, error) {
+	ctx := context.Background()
+	d, err := s.document(ctx, actor, int32(patientID), int32(id))
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := s.store.Open(ctx, d.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, fmt.Errorf("%w: the contents of document %d are missing from storage", domain.ErrUnavailable, d.ID)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourcePatientDocument, &d.ID, &d.PatientID, map[string]domain.FieldChange{
+		"content": {After: d.SHA256},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		content.Close()
+		return nil, nil, err
+	}
+	return d, content, nil
+}
+
+// DeleteDocument removes a document. Receptionists can remove any; doctors
+// only those they uploaded. The contents are deleted once no document
+// refers to them.
+func (s *PatientDocumentService) DeleteDocument(actor domain.Actor, patientID, id int) error {
+	ctx := context.Background()
+	d, err := s.document(ctx, actor, int32(patientID), int32(id))
+	if err != nil {
+		return err
+	}
+	if actor.Role != domain.RoleReceptionist && (d.UploadedBy == nil || *d.UploadedBy != actor.UserID) {
+		return fmt.Errorf("%w: only the uploader or the front desk can remove document %d", domain.ErrForbidden, d.ID)
+	}
+	if err := s.documentRepo.Delete(ctx, d, s.removeBlob(ctx, d.StorageKey)); err != nil {
+		return err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionDelete, domain.ResourcePatientDocument, &d.ID, &d.PatientID, map[string]domain.FieldChange{
+		"category": {Before: d.Category},
+		"sha256":   {Before: d.SHA256},
+	})
+	return s.auditService.Record(ctx, entry)
+}
+
+// document loads document id of the patient if actor may see the chart.
+func (s *PatientDocumentService) document(ctx context.Context, actor domain.Actor, patientID, id int32) (*domain.PatientDocument, error) {
+	if err := s.careTeamService.Authorize(ctx, actor, patientID); err != nil {
+		return nil, err
+	}
+	d, err := s.documentRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.PatientID != patientID {
+		return nil, fmt.Errorf("%w: document %d", domain.ErrNotFound, id)
+	}
+	return d, nil
+}
+
+// releaseBlob deletes stored contents no document refers to any more.
+// Failures only leave an orphaned blob behind, so they are logged.
+func (s *PatientDocumentService) releaseBlob(ctx context.Context, key string) {
+	if err := s.documentRepo.ReleaseBlob(ctx, key, s.removeBlob(ctx, key)); err != nil {
+		log.Printf("documents: releasing %s: %v", key, err)
+	}
+}
+
+// removeBlob returns a function deleting the stored contents at key. The
+// repository calls it while holding the key's lock, once no document
+// refers to the contents. It logs failures rather than returning them,
+// as they only leave an orphaned blob behind.
+func (s *PatientDocumentService) removeBlob(ctx context.Context, key string) func() error {
+	return func() error {
+		if err := s.store.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("documents: deleting %s: %v", key, err)
+		}
+		return nil
+	}
+}
+
+// sniffContentType identifies data from its first bytes, adding TIFF,
+// which scanners produce but net/http does not recognise.
+func sniffContentType(data []byte) string {
+	if bytes.HasPrefix(data, []byte("II*\x00")) || bytes.HasPrefix(data, []byte("MM\x00*")) {
+		return "image/tiff"
+	}
+	contentType, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	return contentType
+}
+
+// cleanFilename keeps the base name of an uploaded file without control
+// characters or quotes, so it is safe to echo in a Content-Disposition
+// header.
+func cleanFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "document"
+	}
+	runes := []rune(name)
+	if len(runes) > maxDocumentFilename {
+		name = string(runes[:maxDocumentFilename])
+	}
+	return name
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"log"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/queue"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+// queueRetry is how long to wait before listening for queue changes again
+// after the connection fails.
+const queueRetry = 5 * time.Second
+
+type QueueService struct {
+	queueRepo        *repository.QueueRepository
+	appointmentRepo  *repository.AppointmentRepository
+	availabilityRepo *repository.AvailabilityRepository
+	auditService     *AuditService
+	broker           *queue.Broker
+}
+
+func NewQueueService(queueRepo *repository.QueueRepository, appointmentRepo *repository.AppointmentRepository, availabilityRepo *repository.AvailabilityRepository, auditService *AuditService) *QueueService {
+	return &QueueService{
+		queueRepo:        queueRepo,
+		appointmentRepo:  appointmentRepo,
+		availabilityRepo: availabilityRepo,
+		auditService:     auditService,
+		broker:           queue.NewBroker(),
+	}
+}
+
+// Run passes queue changes made by any replica on to watchers until ctx is
+// done. Watchers are also nudged every interval so that estimated waits
+// stay current while nothing changes. Watchers are connected to one
+// replica, so every replica runs this.
+func (s *QueueService) Run(ctx context.Context, interval time.Duration) {
+	go func() {
+		for {
+			err := s.queueRepo.Listen(ctx, s.broker.Publish)
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("queue: listening for changes: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(queueRetry):
+			}
+			// Changes made while reconnecting were missed.
+			s.broker.Publish()
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.broker.Publish()
+		}
+	}
+}
+
+// GetQueue returns today's queue for each doctor matching filter.
+func (s *QueueService) GetQueue(actor domain.Actor, filter domain.QueueFilter) ([]domain.DoctorQueue, error) {
+	ctx := context.Background()
+	queues, err := s.build(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionList, domain.ResourceQueue, nil, nil, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return queues, nil
+}
+
+// Watch sends the queue on the returned channel straight away and again
+// after each change, until ctx is done. Opening the watch is audited once,
+// like a single GetQueue.
+func (s *QueueService) Watch(ctx context.Context, actor domain.Actor, filter domain.QueueFilter) (<-chan []domain.DoctorQueue, error) {
+	changes, unsubscribe := s.broker.Subscribe()
+	first, err := s.GetQueue(actor, filter)
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	out := make(chan []domain.DoctorQueue, 1)
+	out <- first
+	go func() {
+		defer close(out)
+		defer unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changes:
+			}
+			queues, err := s.build(ctx, filter)
+			if err != nil {
+				log.Printf("queue: building queue: %v", err)
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- queues:
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (s *QueueService) build(ctx context.Context, filter domain.QueueFilter) ([]domain.DoctorQueue, error) {
+	doctors, err := s.availabilityRepo.ListDoctors(ctx, filter.Specialty, filter.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	appointments, err := s.appointmentRepo.GetToday(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checkIns, err := s.queueRepo.ListTodaysCheckIns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int32]*domain.Appointment)
+	visits := make(map[int32][]queue.Visit)
+	for i := range appointments {
+		a := &appointments[i]
+		status := statusOf(a)
+		if a.DoctorID == nil || (status != domain.AppointmentCheckedIn && status != domain.AppointmentInProgress) {
+			continue
+		}
+		v := queue.Visit{
+			ID:        a.ID,
+			Scheduled: a.AppointmentDate.Time,
+			Started:   status == domain.AppointmentInProgress,
+		}
+		if a.EndTime.Valid {
+			v.Duration = a.EndTime.Time.Sub(a.AppointmentDate.Time)
+		}
+		if c, ok := checkIns[a.ID]; ok {
+			v.Arrived = c.ArrivedAt
+			v.Rank = domain.PriorityRank(c.Priority)
+		}
+		byID[a.ID] = a
+		visits[*a.DoctorID] = append(visits[*a.DoctorID], v)
+	}
+
+	now := time.Now()
+	result := make([]domain.DoctorQueue, 0, len(doctors))
+	for _, d := range doctors {
+		if filter.DoctorID != nil && d.DoctorID != *filter.DoctorID {
+			continue
+		}
+		doctorVisits := visits[d.DoctorID]
+		queue.Order(doctorVisits)
+		waits := queue.EstimateWaits(doctorVisits, now)
+
+		entries := make([]domain.QueueEntry, 0, len(doctorVisits))
+		position := 0
+		for i, v := range doctorVisits {
+			a := byID[v.ID]
+			e := domain.QueueEntry{
+				AppointmentID:        a.ID,
+				PatientInitials:      initials(a.PatientName),
+				AppointmentDate:      a.AppointmentDate.Time,
+				Status:               statusOf(a),
+				Priority:             domain.PriorityRoutine,
+				EstimatedWaitMinutes: int(math.Ceil(waits[i].Minutes())),
+			}
+			if !v.Started {
+				position++
+				e.Position = position
+			}
+			if c, ok := checkIns[a.ID]; ok {
+				e.ArrivedAt = &c.ArrivedAt
+				e.Priority = c.Priority
+			}
+			entries = append(entries, e)
+		}
+
+		result = append(result, domain.DoctorQueue{
+			DoctorID:   d.DoctorID,
+			DoctorName: d.Name,
+			Specialty:  d.Specialty,
+			Entries:    entries,
+		})
+	}
+	return result, nil
+}
+
+// initials turns "Jane Doe" into "JD".
+func initials(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Fields(name) {
+		for _, r := range word {
+			b.WriteRune(unicode.ToUpper(r))
+			break
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+// ReferralService sends patients between doctors and departments. A
+// doctor refers a patient they care for; the receiving doctor, or any
+// doctor of the receiving department, accepts or declines. Accepting puts
+// the receiver on the patient's care team, and the receiver or the front
+// desk then books the appointment from the referral.
+type ReferralService struct {
+	referralRepo       *repository.ReferralRepository
+	appointmentRepo    *repository.AppointmentRepository
+	patientRepo        *repository.PatientRepository
+	userRepo           *repository.UserRepository
+	departmentRepo     *repository.DepartmentRepository
+	noteRepo           *repository.ClinicalNoteRepository
+	appointmentService *AppointmentService
+	careTeamService    *CareTeamService
+	auditService       *AuditService
+}
+
+func NewReferralService(referralRepo *repository.ReferralRepository, appointmentRepo *repository.AppointmentRepository, patientRepo *repository.PatientRepository, userRepo *repository.UserRepository, departmentRepo *repository.DepartmentRepository, noteRepo *repository.ClinicalNoteRepository, appointmentService *AppointmentService, careTeamService *CareTeamService, auditService *AuditService) *ReferralService {
+	return &ReferralService{
+		referralRepo:       referralRepo,
+		appointmentRepo:    appointmentRepo,
+		patientRepo:        patientRepo,
+		userRepo:           userRepo,
+		departmentRepo:     departmentRepo,
+		noteRepo:           noteRepo,
+		appointmentService: appointmentService,
+		careTeamService:    careTeamService,
+		auditService:       auditService,
+	}
+}
+
+// CreateReferral sends the patient from actor to a doctor, a department,
+// or a doctor of a department.
+func (s *ReferralService) CreateReferral(actor domain.Actor, req *domain.CreateReferralRequest) (*domain.Referral, error) {
+	ctx := context.Background()
+	if actor.Role != domain.RoleDoctor {
+		return nil, fmt.Errorf("%w: only doctors can refer patients", domain.ErrForbidden)
+	}
+	if err := s.careTeamService.Authorize(ctx, actor, req.PatientID); err != nil {
+		return nil, err
+	}
+	if _, err := s.patientRepo.GetByID(ctx, req.PatientID); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, req.PatientID)
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason must not be blank", domain.ErrInvalid)
+	}
+	if err := s.checkTarget(ctx, actor, req.TargetDoctorID, req.TargetDepartmentID); err != nil {
+		return nil, err
+	}
+	if req.NoteID != nil {
+		note, err := s.noteRepo.Get(ctx, *req.NoteID)
+		if err != nil || note.PatientID != req.PatientID {
+			return nil, fmt.Errorf("%w: clinical note %d is not one of patient %d's", domain.ErrInvalid, *req.NoteID, req.PatientID)
+		}
+	}
+
+	ref := &domain.Referral{
+		PatientID:          req.PatientID,
+		SourceDoctorID:     actor.UserID,
+		TargetDoctorID:     req.TargetDoctorID,
+		TargetDepartmentID: req.TargetDepartmentID,
+		Reason:             reason,
+		Urgency:            req.Urgency,
+		ClinicalContext:    trimNotes(req.ClinicalContext),
+		NoteID:             req.NoteID,
+	}
+	if ref.Urgency == "" {
+		ref.Urgency = domain.UrgencyRoutine
+	}
+	if err := s.referralRepo.Create(ctx, ref); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionCreate, domain.ResourceReferral, &ref.ID, &ref.PatientID, map[string]domain.FieldChange{
+		"target_doctor_id":     {After: ref.TargetDoctorID},
+		"target_department_id": {After: ref.TargetDepartmentID},
+		"urgency":              {After: ref.Urgency},
+		"note_id":              {After: ref.NoteID},
+		"status":               {After: ref.Status},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return s.referralRepo.Get(ctx, ref.ID)
+}
+
+// ListReferrals lists referrals for the front desk, or for a doctor
+// either those they can act on (box "received", the default) or those
+// they sent (box "sent").
+func (s *ReferralService) ListReferrals(actor domain.Actor, box string, filter domain.ReferralFilter) ([]domain.Referral, error) {
+	ctx := context.Background()
+	switch actor.Role {
+	case domain.RoleReceptionist:
+	case domain.RoleDoctor:
+		switch box {
+		case "", "received":
+			filter.ReceiverID = &actor.UserID
+		case "sent":
+			filter.SourceDoctorID = &actor.UserID
+		default:
+			return nil, fmt.Errorf("%w: box must be received or sent", domain.ErrInvalid)
+		}
+	default:
+		return nil, fmt.Errorf("%w: cannot view referrals", domain.ErrForbidden)
+	}
+
+	referrals, err := s.referralRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(referrals))
+	for i := range referrals {
+		ref := referrals[i]
+		entries = append(entries, NewEntry(actor, domain.AuditActionList, domain.ResourceReferral, &ref.ID, &ref.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return referrals, nil
+}
+
+func (s *ReferralService) GetReferral(actor domain.Actor, id int) (*domain.Referral, error) {
+	ctx := context.Background()
+	ref, err := s.referralRepo.Get(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkVisible(ctx, actor, ref); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionRead, domain.ResourceReferral, &ref.ID, &ref.PatientID, nil)
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+// AcceptReferral makes actor the receiving doctor and gives them access
+// to the patient's chart.
+func (s *ReferralService) AcceptReferral(actor domain.Actor, id int) (*domain.Referral, error) {
+	ctx := context.Background()
+	ref, err := s.receivable(ctx, actor, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.referralRepo.Accept(ctx, ref, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*domain.AuditEntry{
+		NewEntry(actor, domain.AuditActionUpdate, domain.ResourceReferral, &ref.ID, &ref.PatientID, map[string]domain.FieldChange{
+			"status":           {Before: ref.Status, After: domain.ReferralAccepted},
+			"target_doctor_id": {Before: ref.TargetDoctorID, After: actor.UserID},
+		}),
+		NewEntry(actor, domain.AuditActionCreate, domain.ResourceCareTeamMember, &member.DoctorID, &member.PatientID, map[string]domain.FieldChange{
+			"doctor_id": {After: member.DoctorID},
+			"source":    {After: member.Source},
+		}),
+	}
+	if err := s.auditService.Record(ctx, entries...); err != nil {
+		return nil, err
+	}
+	return s.referralRepo.Get(ctx, ref.ID)
+}
+
+func (s *ReferralService) DeclineReferral(actor domain.Actor, id int, req *domain.DeclineReferralRequest) (*domain.Referral, error) {
+	ctx := context.Background()
+	ref, err := s.receivable(ctx, actor, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason must not be blank", domain.ErrInvalid)
+	}
+
+	if err := s.referralRepo.Decline(ctx, ref.ID, actor.UserID, reason); err != nil {
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceReferral, &ref.ID, &ref.PatientID, map[string]domain.FieldChange{
+		"status": {Before: ref.Status, After: domain.ReferralDeclined},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return s.referralRepo.Get(ctx, ref.ID)
+}
+
+// BookAppointment books the appointment an accepted referral asks for,
+// with the receiving doctor and in the target department, and marks the
+// referral scheduled. The receiving doctor or a receptionist can book.
+func (s *ReferralService) BookAppointment(actor domain.Actor, id int, req *domain.BookReferralRequest) (*domain.Referral, error) {
+	ctx := context.Background()
+	ref, err := s.referralRepo.Get(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case actor.Role == domain.RoleReceptionist:
+	case actor.Role == domain.RoleDoctor && ref.TargetDoctorID != nil && *ref.TargetDoctorID == actor.UserID:
+	default:
+		return nil, fmt.Errorf("%w: only the receiving doctor or the front desk can book referral %d", domain.ErrForbidden, ref.ID)
+	}
+	if ref.Status != domain.ReferralAccepted {
+		return nil, fmt.Errorf("%w: referral %d is %s, not %s", domain.ErrConflict, ref.ID, ref.Status, domain.ReferralAccepted)
+	}
+
+	appointment, err := s.appointmentService.CreateAppointment(actor, &domain.CreateAppointmentRequest{
+		PatientID:            ref.PatientID,
+		DoctorID:             ref.TargetDoctorID,
+		AppointmentDate:      req.AppointmentDate,
+		DurationMinutes:      req.DurationMinutes,
+		DepartmentID:         ref.TargetDepartmentID,
+		RoomID:               req.RoomID,
+		Notes:                req.Notes,
+		OverrideAvailability: req.OverrideAvailability,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.referralRepo.Schedule(ctx, ref.ID, appointment.ID); err != nil {
+		// Someone else booked the referral meanwhile; this appointment is
+		// not needed.
+		if errors.Is(err, domain.ErrConflict) {
+			if delErr := s.appointmentRepo.Delete(ctx, appointment.ID); delErr != nil {
+				return nil, fmt.Errorf("%w; appointment %d could not be removed: %v", err, appointment.ID, delErr)
+			}
+			entry := NewEntry(actor, domain.AuditActionDelete, domain.ResourceAppointment, &appointment.ID, appointment.PatientID, nil)
+			if auditErr := s.auditService.Record(ctx, entry); auditErr != nil {
+				return nil, auditErr
+			}
+		}
+		return nil, err
+	}
+
+	entry := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceReferral, &ref.ID, &ref.PatientID, map[string]domain.FieldChange{
+		"status":         {Before: ref.Status, After: domain.ReferralScheduled},
+		"appointment_id": {After: appointment.ID},
+	})
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return s.referralRepo.Get(ctx, ref.ID)
+}
+
+// checkTarget validates where a referral from actor is sent.
+func (s *ReferralService) checkTarget(ctx context.Context, actor domain.Actor, doctorID, departmentID *int32) error {
+	if doctorID == nil && departmentID == nil {
+		return fmt.Errorf("%w: target_doctor_id or target_department_id is required", domain.ErrInvalid)
+	}
+	if doctorID != nil {
+		if *doctorID == actor.UserID {
+			return fmt.Errorf("%w: cannot refer a patient to yourself", domain.ErrInvalid)
+		}
+		doctor, err := s.userRepo.GetByID(ctx, *doctorID)
+		if err != nil || doctor.Role != domain.RoleDoctor {
+			return fmt.Errorf("%w: doctor %d", domain.ErrInvalid, *doctorID)
+		}
+	}
+	if departmentID != nil {
+		if _, err := s.departmentRepo.Get(ctx, *departmentID); err != nil {
+			return fmt.Errorf("%w: department %d", domain.ErrInvalid, *departmentID)
+		}
+	}
+	if doctorID != nil && departmentID != nil {
+		ok, err := s.departmentRepo.HasDoctor(ctx, *departmentID, *doctorID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: doctor %d is not in department %d", domain.ErrInvalid, *doctorID, *departmentID)
+		}
+	}
+	return nil
+}
+
+// checkVisible lets the front desk and the doctors on either end of the
+// referral see it.
+func (s *ReferralService) checkVisible(ctx context.Context, actor domain.Actor, ref *domain.Referral) error {
+	switch actor.Role {
+	case domain.RoleReceptionist:
+		return nil
+	case domain.RoleDoctor:
+		if ref.SourceDoctorID == actor.UserID {
+			return nil
+		}
+		ok, err := s.isReceiver(ctx, actor, ref)
+		if err != nil || ok {
+			return err
+		}
+	}
+	return fmt.Errorf("%w: referral %d is not addressed to you", domain.ErrForbidden, ref.ID)
+}
+
+// receivable loads the referral if actor may accept or decline it.
+func (s *ReferralService) receivable(ctx context.Context, actor domain.Actor, id int32) (*domain.Referral, error) {
+	ref, err := s.referralRepo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role != domain.RoleDoctor || ref.SourceDoctorID == actor.UserID {
+		return nil, fmt.Errorf("%w: referral %d is not addressed to you", domain.ErrForbidden, ref.ID)
+	}
+	ok, err := s.isReceiver(ctx, actor, ref)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: referral %d is not addressed to you", domain.ErrForbidden, ref.ID)
+	}
+	if ref.Status != domain.ReferralSent {
+		return nil, fmt.Errorf("%w: referral %d is already %s", domain.ErrConflict, ref.ID, ref.Status)
+	}
+	return ref, nil
+}
+
+// isReceiver reports whether the referral is addressed to actor, directly
+// or through a department no doctor has taken it for yet.
+func (s *ReferralService) isReceiver(ctx context.Context, actor domain.Actor, ref *domain.Referral) (bool, error) {
+	if ref.TargetDoctorID != nil {
+		return *ref.TargetDoctorID == actor.UserID, nil
+	}
+	return s.departmentRepo.HasDoctor(ctx, *ref.TargetDepartmentID, actor.UserID)
+}
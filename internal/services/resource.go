@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/localtime"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+// maxBookingRange caps how long a room or equipment schedule may span.
+const maxBookingRange = 31 * 24 * time.Hour
+
+// ResourceService manages rooms and equipment. Rooms are booked by giving
+// an appointment a room; equipment is booked here.
+type ResourceService struct {
+	resourceRepo    *repository.ResourceRepository
+	departmentRepo  *repository.DepartmentRepository
+	appointmentRepo *repository.AppointmentRepository
+	// loc is the hospital's time zone, in which times without a UTC
+	// offset are read.
+	loc *time.Location
+}
+
+func NewResourceService(resourceRepo *repository.ResourceRepository, departmentRepo *repository.DepartmentRepository, appointmentRepo *repository.AppointmentRepository, loc *time.Location) *ResourceService {
+	return &ResourceService{
+		resourceRepo:    resourceRepo,
+		departmentRepo:  departmentRepo,
+		appointmentRepo: appointmentRepo,
+		loc:             loc,
+	}
+}
+
+func (s *ResourceService) ListRooms(departmentID *int32) ([]domain.Room, error) {
+	return s.resourceRepo.ListRooms(context.Background(), departmentID)
+}
+
+func (s *ResourceService) GetRoom(id int) (*domain.Room, error) {
+	return s.resourceRepo.GetRoom(context.Background(), int32(id))
+}
+
+func (s *ResourceService) CreateRoom(req *domain.SaveResourceRequest) (*domain.Room, error) {
+	if err := trimName(&req.Name); err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	room, err := s.resourceRepo.CreateRoom(ctx, req.Name, req.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	// Rooms are created active; an inactive one is just an update.
+	if req.Active != nil && !*req.Active {
+		room.Active = false
+		if err := s.resourceRepo.UpdateRoom(ctx, room); err != nil {
+			return nil, err
+		}
+	}
+	return room, nil
+}
+
+// UpdateRoom renames or moves the room, or takes it out of use. An
+// inactive room keeps its existing appointments but takes no new ones.
+func (s *ResourceService) UpdateRoom(id int, req *domain.SaveResourceRequest) (*domain.Room, error) {
+	if err := trimName(&req.Name); err != nil {
+		return nil, err
+	}
+	room := &domain.Room{ID: int32(id), Name: req.Name, DepartmentID: req.DepartmentID, Active: req.Active == nil || *req.Active}
+	if err := s.resourceRepo.UpdateRoom(context.Background(), room); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// GetRoomSchedule lists the appointments in the room between from and to.
+func (s *ResourceService) GetRoomSchedule(id int, from, to time.Time) ([]domain.ResourceBooking, error) {
+	if err := checkBookingRange(from, to); err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	if _, err := s.resourceRepo.GetRoom(ctx, int32(id)); err != nil {
+		return nil, err
+	}
+	return s.resourceRepo.ListRoomBookings(ctx, int32(id), from, to)
+}
+
+func (s *ResourceService) ListEquipment(departmentID *int32) ([]domain.Equipment, error) {
+	return s.resourceRepo.ListEquipment(context.Background(), departmentID)
+}
+
+func (s *ResourceService) GetEquipment(id int) (*domain.Equipment, error) {
+	return s.resourceRepo.GetEquipment(context.Background(), int32(id))
+}
+
+func (s *ResourceService) CreateEquipment(req *domain.SaveResourceRequest) (*domain.Equipment, error) {
+	if err := trimName(&req.Name); err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	e, err := s.resourceRepo.CreateEquipment(ctx, req.Name, req.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Active != nil && !*req.Active {
+		e.Active = false
+		if err := s.resourceRepo.UpdateEquipment(ctx, e); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// UpdateEquipment renames or moves the equipment, or takes it out of use.
+// Inactive equipment keeps its existing bookings but takes no new ones.
+func (s *ResourceService) UpdateEquipment(id int, req *domain.SaveResourceRequest) (*domain.Equipment, error) {
+	if err := trimName(&req.Name); err != nil {
+		return nil, err
+	}
+	e := &domain.Equipment{ID: int32(id), Name: req.Name, DepartmentID: req.DepartmentID, Active: req.Active == nil || *req.Active}
+	if err := s.resourceRepo.UpdateEquipment(context.Background(), e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *ResourceService) ListEquipmentBookings(id int, from, to time.Time) ([]domain.ResourceBooking, error) {
+	if err := checkBookingRange(from, to); err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	if _, err := s.resourceRepo.GetEquipment(ctx, int32(id)); err != nil {
+		return nil, err
+	}
+	return s.resourceRepo.ListEquipmentBookings(ctx, int32(id), from, to)
+}
+
+// BookEquipment books the equipment. A booking for an appointment takes
+// the appointment's time unless one is given, and then follows the
+// appointment when it moves; other bookings need starts_at and ends_at.
+func (s *ResourceService) BookEquipment(actor domain.Actor, id int, req *domain.CreateEquipmentBookingRequest) (*domain.ResourceBooking, error) {
+	ctx := context.Background()
+	e, err := s.resourceRepo.GetEquipment(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	if !e.Active {
+		return nil, fmt.Errorf("%w: equipment %d is not in use", domain.ErrInvalid, e.ID)
+	}
+
+	var start, end time.Time
+	if req.AppointmentID != nil {
+		a, err := s.appointmentRepo.GetByID(ctx, *req.AppointmentID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: appointment %d", domain.ErrNotFound, *req.AppointmentID)
+		}
+		switch statusOf(a) {
+		case domain.AppointmentCancelled, domain.AppointmentNoShow, domain.AppointmentCompleted:
+			return nil, fmt.Errorf("%w: appointment %d is %s", domain.ErrInvalid, a.ID, statusOf(a))
+		}
+		start, end = a.AppointmentDate.Time, a.EndTime.Time
+	}
+	if req.StartsAt != nil || req.EndsAt != nil || req.AppointmentID == nil {
+		if req.StartsAt == nil || req.EndsAt == nil {
+			return nil, fmt.Errorf("%w: starts_at and ends_at are required together", domain.ErrInvalid)
+		}
+		if start, err = localtime.Parse(*req.StartsAt, s.loc); err != nil {
+			return nil, fmt.Errorf("%w: starts_at: %v", domain.ErrInvalid, err)
+		}
+		if end, err = localtime.Parse(*req.EndsAt, s.loc); err != nil {
+			return nil, fmt.Errorf("%w: ends_at: %v", domain.ErrInvalid, err)
+		}
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at", domain.ErrInvalid)
+	}
+
+	return s.resourceRepo.CreateEquipmentBooking(ctx, e.ID, req.AppointmentID, start, end, actor.UserID)
+}
+
+func (s *ResourceService) CancelEquipmentBooking(id int) error {
+	return s.resourceRepo.DeleteEquipmentBooking(context.Background(), int32(id))
+}
+
+// checkPlacement returns domain.ErrInvalid unless departmentID is nil or
+// an existing department, and roomID is nil or a room in use.
+func (s *ResourceService) checkPlacement(ctx context.Context, departmentID, roomID *int32) error {
+	if departmentID != nil {
+		if _, err := s.departmentRepo.Get(ctx, *departmentID); err != nil {
+			return fmt.Errorf("%w: department %d does not exist", domain.ErrInvalid, *departmentID)
+		}
+	}
+	if roomID != nil {
+		room, err := s.resourceRepo.GetRoom(ctx, *roomID)
+		if err != nil {
+			return fmt.Errorf("%w: room %d does not exist", domain.ErrInvalid, *roomID)
+		}
+		if !room.Active {
+			return fmt.Errorf("%w: room %d is not in use", domain.ErrInvalid, *roomID)
+		}
+	}
+	return nil
+}
+
+func checkBookingRange(from, to time.Time) error {
+	if !to.After(from) {
+		return fmt.Errorf("%w: to must be after from", domain.ErrInvalid)
+	}
+	if to.Sub(from) > maxBookingRange {
+		return fmt.Errorf("%w: schedules are limited to %d days", domain.ErrInvalid, int(maxBookingRange.Hours()/24))
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/cron"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/repository"
+)
+
+// JobFunc does one run of a job and returns a short summary of what it did.
+type JobFunc func(ctx context.Context) (string, error)
+
+type scheduledJob struct {
+	name     string
+	schedule *cron.Schedule
+	run      JobFunc
+	next     time.Time
+}
+
+// JobScheduler runs jobs on cron schedules in the hospital's time zone.
+// Every replica runs the scheduler; an advisory lock and the run history
+// make sure each scheduled run happens once, and that a job never overlaps
+// with itself.
+type JobScheduler struct {
+	jobRepo *repository.JobRepository
+	loc     *time.Location
+
+	mu   sync.Mutex
+	jobs []*scheduledJob
+}
+
+func NewJobScheduler(jobRepo *repository.JobRepository, loc *time.Location) *JobScheduler {
+	return &JobScheduler{jobRepo: jobRepo, loc: loc}
+}
+
+// Register adds a job. It must be called before Run.
+func (s *JobScheduler) Register(name, spec string, run JobFunc) error {
+	schedule, err := cron.Parse(spec)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, &scheduledJob{
+		name:     name,
+		schedule: schedule,
+		run:      run,
+		next:     schedule.Next(time.Now().In(s.loc)),
+	})
+	return nil
+}
+
+// Run starts jobs as they fall due until ctx is done.
+func (s *JobScheduler) Run(ctx context.Context) {
+	for {
+		timer := time.NewTimer(time.Until(s.wake()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now().In(s.loc)
+		s.mu.Lock()
+		for _, j := range s.jobs {
+			if j.next.IsZero() || j.next.After(now) {
+				continue
+			}
+			go s.start(ctx, j.name, j.run, j.next)
+			j.next = j.schedule.Next(now)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// wake returns when the next job is due, or a day from now if none is.
+func (s *JobScheduler) wake() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wake := time.Now().Add(24 * time.Hour)
+	for _, j := range s.jobs {
+		if !j.next.IsZero() && j.next.Before(wake) {
+			wake = j.next
+		}
+	}
+	return wake
+}
+
+func (s *JobScheduler) start(ctx context.Context, name string, run JobFunc, scheduledAt time.Time) {
+	unlock, ok, err := s.jobRepo.Lock(ctx, name)
+	if err != nil {
+		log.Printf("jobs: %s: locking: %v", name, err)
+		return
+	}
+	if !ok {
+		log.Printf("jobs: %s: already running, skipping the run due at %s", name, scheduledAt.Format(time.RFC3339))
+		return
+	}
+	defer unlock()
+
+	id, ok, err := s.jobRepo.StartRun(ctx, name, scheduledAt)
+	if err != nil {
+		log.Printf("jobs: %s: recording start: %v", name, err)
+		return
+	}
+	if !ok {
+		// Another replica has already run it.
+		return
+	}
+
+	detail, err := runJob(ctx, run)
+	if err != nil {
+		log.Printf("jobs: %s: %v", name, err)
+	}
+	if err := s.jobRepo.FinishRun(context.Background(), id, detail, err); err != nil {
+		log.Printf("jobs: %s: recording finish: %v", name, err)
+	}
+}
+
+// runJob calls run, turning a panic into an error.
+func runJob(ctx context.Context, run JobFunc) (detail string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return run(ctx)
+}
+
+// ListJobs returns the registered jobs with their next and latest runs.
+func (s *JobScheduler) ListJobs() ([]domain.Job, error) {
+	latest, err := s.jobRepo.LatestRuns(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]domain.Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		job := domain.Job{Name: j.name, Schedule: j.schedule.String(), NextRun: j.next}
+		if run, ok := latest[j.name]; ok {
+			job.LastRun = &run
+		}
+		result = append(result, job)
+	}
+	return result, nil
+}
+
+func (s *JobScheduler) ListRuns(filter domain.JobRunFilter) ([]domain.JobRun, error) {
+	return s.jobRepo.ListRuns(context.Background(), filter)
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/prem0x01/hospital/internal/audit"
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/localtime"
+	"github.com/prem0x01/hospital/internal/repository"
+	"github.com/prem0x01/hospital/internal/utils"
+)
+
+// waitlistHoldDuration is how long a waitlisted patient has to confirm a
+// freed slot before it is offered to the next one.
+const waitlistHoldDuration = 2 * time.Hour
+
+// systemActor attributes work done by background jobs, such as expiring
+// holds, in the audit log.
+var systemActor = domain.Actor{Role: "system"}
+
+type WaitlistService struct {
+	waitlistRepo    *repository.WaitlistRepository
+	appointmentRepo *repository.AppointmentRepository
+	patientRepo     *repository.PatientRepository
+	careTeamService *CareTeamService
+	auditService    *AuditService
+	loc             *time.Location
+}
+
+func NewWaitlistService(waitlistRepo *repository.WaitlistRepository, appointmentRepo *repository.AppointmentRepository, patientRepo *repository.PatientRepository, careTeamService *CareTeamService, auditService *AuditService, loc *time.Location) *WaitlistService {
+	return &WaitlistService{
+		waitlistRepo:    waitlistRepo,
+		appointmentRepo: appointmentRepo,
+		patientRepo:     patientRepo,
+		careTeamService: careTeamService,
+		auditService:    auditService,
+		loc:             loc,
+	}
+}
+
+func (s *WaitlistService) CreateEntry(actor domain.Actor, req *domain.CreateWaitlistEntryRequest) (*domain.WaitlistEntry, error) {
+	ctx := context.Background()
+	if err := s.careTeamService.Authorize(ctx, actor, req.PatientID); err != nil {
+		return nil, err
+	}
+	if _, err := s.patientRepo.GetByID(ctx, req.PatientID); err != nil {
+		return nil, fmt.Errorf("%w: patient %d", domain.ErrNotFound, req.PatientID)
+	}
+	if req.DoctorID == nil && (req.Specialty == nil || *req.Specialty == "") {
+		return nil, fmt.Errorf("%w: a doctor or a specialty is required", domain.ErrInvalid)
+	}
+
+	earliest, err := localtime.Parse(req.Earliest, s.loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: earliest: %v", domain.ErrInvalid, err)
+	}
+	latest, err := localtime.Parse(req.Latest, s.loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: latest: %v", domain.ErrInvalid, err)
+	}
+	duration := defaultAppointmentDuration
+	if req.DurationMinutes > 0 {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+	}
+	if latest.Sub(earliest) < duration {
+		return nil, fmt.Errorf("%w: the window is shorter than the appointment", domain.ErrInvalid)
+	}
+
+	entry := &domain.WaitlistEntry{
+		PatientID:       req.PatientID,
+		DoctorID:        req.DoctorID,
+		Specialty:       req.Specialty,
+		Earliest:        earliest,
+		Latest:          latest,
+		DurationMinutes: int32(duration / time.Minute),
+		Notes:           req.Notes,
+		CreatedBy:       utils.Int32Ptr(actor.UserID),
+	}
+	if err := s.waitlistRepo.CreateEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	record := NewEntry(actor, domain.AuditActionCreate, domain.ResourceWaitlistEntry, &entry.ID, &entry.PatientID, audit.Diff(nil, entry))
+	if err := s.auditService.Record(ctx, record); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *WaitlistService) ListEntries(actor domain.Actor, status *string) ([]domain.WaitlistEntry, error) {
+	ctx := context.Background()
+	entries, err := s.waitlistRepo.ListEntries(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*domain.AuditEntry, 0, len(entries))
+	for i := range entries {
+		e := entries[i]
+		audits = append(audits, NewEntry(actor, domain.AuditActionList, domain.ResourceWaitlistEntry, &e.ID, &e.PatientID, nil))
+	}
+	if err := s.auditService.Record(ctx, audits...); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (s *WaitlistService) GetEntry(actor domain.Actor, id int) (*domain.WaitlistEntry, error) {
+	ctx := context.Background()
+	entry, err := s.waitlistRepo.GetEntry(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+
+	record := NewEntry(actor, domain.AuditActionRead, domain.ResourceWaitlistEntry, &entry.ID, &entry.PatientID, nil)
+	if err := s.auditService.Record(ctx, record); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// CancelEntry takes a patient off the waitlist. A slot on hold for them is
+// offered to the next candidate.
+func (s *WaitlistService) CancelEntry(actor domain.Actor, id int) error {
+	ctx := context.Background()
+	entry, err := s.waitlistRepo.GetEntry(ctx, int32(id))
+	if err != nil {
+		return err
+	}
+
+	released, err := s.waitlistRepo.CancelEntry(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	changes := map[string]domain.FieldChange{"status": {Before: entry.Status, After: domain.WaitlistCancelled}}
+	record := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceWaitlistEntry, &entry.ID, &entry.PatientID, changes)
+	if err := s.auditService.Record(ctx, record); err != nil {
+		return err
+	}
+
+	if released != nil {
+		s.offer(ctx, actor, released.DoctorID, released.StartsAt, released.SlotEndsAt)
+	}
+	return nil
+}
+
+// ConfirmHold books the held slot for the waitlisted patient.
+func (s *WaitlistService) ConfirmHold(actor domain.Actor, id int) (*domain.Appointment, error) {
+	ctx := context.Background()
+	hold, err := s.pendingHold(ctx, int32(id))
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.waitlistRepo.GetEntry(ctx, hold.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.careTeamService.Authorize(ctx, actor, entry.PatientID); err != nil {
+		return nil, err
+	}
+
+	appointment := &domain.Appointment{
+		PatientID:       utils.Int32Ptr(entry.PatientID),
+		DoctorID:        utils.Int32Ptr(hold.DoctorID),
+		AppointmentDate: utils.TimeToTimestamp(hold.StartsAt),
+		EndTime:         utils.TimeToTimestamp(hold.EndsAt),
+		Notes:           entry.Notes,
+		CreatedBy:       utils.Int32Ptr(actor.UserID),
+	}
+	if err := s.waitlistRepo.Confirm(ctx, hold, appointment); err != nil {
+		return nil, err
+	}
+
+	holdChanges := map[string]domain.FieldChange{
+		"status":         {Before: domain.HoldPending, After: domain.HoldConfirmed},
+		"appointment_id": {After: appointment.ID},
+	}
+	err = s.auditService.Record(ctx,
+		NewEntry(actor, domain.AuditActionCreate, domain.ResourceAppointment, &appointment.ID, appointment.PatientID, audit.Diff(nil, appointment)),
+		NewEntry(actor, domain.AuditActionUpdate, domain.ResourceWaitlistHold, &hold.ID, &entry.PatientID, holdChanges),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
+
+// DeclineHold gives up a held slot, which is then offered to the next
+// candidate. The patient stays on the waitlist.
+func (s *WaitlistService) DeclineHold(actor domain.Actor, id int) error {
+	ctx := context.Background()
+	hold, err := s.pendingHold(ctx, int32(id))
+	if err != nil {
+		return err
+	}
+	return s.release(ctx, actor, hold, domain.HoldDeclined)
+}
+
+// ExpireHolds releases every hold that was not confirmed in time and
+// offers each slot to the next candidate. It is run by the JobScheduler.
+func (s *WaitlistService) ExpireHolds(ctx context.Context) (string, error) {
+	holds, err := s.waitlistRepo.ListExpiredHolds(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	expired := 0
+	for i := range holds {
+		err := s.release(ctx, systemActor, &holds[i], domain.HoldExpired)
+		if errors.Is(err, domain.ErrConflict) {
+			continue // confirmed or declined meanwhile
+		}
+		if err != nil {
+			return fmt.Sprintf("expired %d holds", expired), err
+		}
+		expired++
+	}
+	return fmt.Sprintf("expired %d holds", expired), nil
+}
+
+// SlotReleased offers the time of a cancelled or moved appointment to the
+// waitlist. The appointment change has already been saved, so failures
+// are logged rather than returned.
+func (s *WaitlistService) SlotReleased(ctx context.Context, actor domain.Actor, a *domain.Appointment) {
+	if a.DoctorID == nil || !a.AppointmentDate.Time.After(time.Now()) {
+		return
+	}
+	s.offer(ctx, actor, *a.DoctorID, a.AppointmentDate.Time, a.EndTime.Time)
+}
+
+// CheckHeld returns ErrConflict if the doctor's time [start, end) is on
+// hold for a patient other than patientID.
+func (s *WaitlistService) CheckHeld(ctx context.Context, doctorID, patientID int32, start, end time.Time) error {
+	holds, err := s.waitlistRepo.ListActiveHolds(ctx, doctorID, patientID, start, end)
+	if err != nil {
+		return err
+	}
+	if len(holds) > 0 {
+		return fmt.Errorf("%w: doctor %d is on hold for a waitlisted patient until %s",
+			domain.ErrConflict, doctorID, holds[0].ExpiresAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// pendingHold returns the hold if it can still be confirmed or declined.
+// A hold found past its expiry is expired on the spot.
+func (s *WaitlistService) pendingHold(ctx context.Context, id int32) (*domain.WaitlistHold, error) {
+	hold, err := s.waitlistRepo.GetHold(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if hold.Status != domain.HoldPending {
+		return nil, fmt.Errorf("%w: hold %d is %s", domain.ErrConflict, id, hold.Status)
+	}
+	if !hold.ExpiresAt.After(time.Now()) {
+		if err := s.release(ctx, systemActor, hold, domain.HoldExpired); err != nil && !errors.Is(err, domain.ErrConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: hold %d has expired", domain.ErrConflict, id)
+	}
+	return hold, nil
+}
+
+// release closes the hold as declined or expired and moves the slot on to
+// the next candidate.
+func (s *WaitlistService) release(ctx context.Context, actor domain.Actor, hold *domain.WaitlistHold, status string) error {
+	if err := s.waitlistRepo.Release(ctx, hold, status); err != nil {
+		return err
+	}
+
+	entry, err := s.waitlistRepo.GetEntry(ctx, hold.EntryID)
+	if err != nil {
+		return err
+	}
+	changes := map[string]domain.FieldChange{"status": {Before: domain.HoldPending, After: status}}
+	record := NewEntry(actor, domain.AuditActionUpdate, domain.ResourceWaitlistHold, &hold.ID, &entry.PatientID, changes)
+	if err := s.auditService.Record(ctx, record); err != nil {
+		return err
+	}
+
+	s.offer(ctx, actor, hold.DoctorID, hold.StartsAt, hold.SlotEndsAt)
+	return nil
+}
+
+// offer puts the doctor's free time [start, end) on hold for the best
+// waitlist candidate who is free then, if there is one. Errors are logged:
+// a slot that is not offered is no worse than no waitlist at all.
+func (s *WaitlistService) offer(ctx context.Context, actor domain.Actor, doctorID int32, start, end time.Time) {
+	if _, err := s.tryOffer(ctx, actor, doctorID, start, end); err != nil {
+		log.Printf("waitlist: offering doctor %d at %s: %v", doctorID, start.Format(time.RFC3339), err)
+	}
+}
+
+func (s *WaitlistService) tryOffer(ctx context.Context, actor domain.Actor, doctorID int32, start, end time.Time) (*domain.WaitlistHold, error) {
+	candidates, err := s.waitlistRepo.FindCandidates(ctx, doctorID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range candidates {
+		apptEnd := start.Add(time.Duration(c.DurationMinutes) * time.Minute)
+
+		// The doctor or the patient may have been booked since.
+		overlaps, err := s.appointmentRepo.ListOverlapping(ctx, 0, &doctorID, &c.PatientID, nil,
+			utils.TimeToTimestamp(start), utils.TimeToTimestamp(apptEnd))
+		if err != nil {
+			return nil, err
+		}
+		if len(overlaps) > 0 {
+			continue
+		}
+		if err := s.CheckHeld(ctx, doctorID, 0, start, apptEnd); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				continue
+			}
+			return nil, err
+		}
+
+		hold := &domain.WaitlistHold{
+			EntryID:    c.ID,
+			DoctorID:   doctorID,
+			StartsAt:   start,
+			EndsAt:     apptEnd,
+			SlotEndsAt: end,
+			ExpiresAt:  time.Now().Add(waitlistHoldDuration),
+		}
+		err = s.waitlistRepo.Offer(ctx, hold)
+		if errors.Is(err, domain.ErrConflict) {
+			continue // taken off the waitlist meanwhile
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		log.Printf("waitlist: holding doctor %d at %s for entry %d until %s",
+			doctorID, start.Format(time.RFC3339), c.ID, hold.ExpiresAt.Format(time.RFC3339))
+		record := NewEntry(actor, domain.AuditActionCreate, domain.ResourceWaitlistHold, &hold.ID, &c.PatientID, audit.Diff(nil, hold))
+		return hold, s.auditService.Record(ctx, record)
+	}
+	return nil, nil
+}
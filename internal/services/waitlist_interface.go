@@ -0,0 +1,14 @@
+package services
+
+import (
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+type IWaitlistService interface {
+	CreateEntry(actor domain.Actor, req *domain.CreateWaitlistEntryRequest) (*domain.WaitlistEntry, error)
+	ListEntries(actor domain.Actor, status *string) ([]domain.WaitlistEntry, error)
+	GetEntry(actor domain.Actor, id int) (*domain.WaitlistEntry, error)
+	CancelEntry(actor domain.Actor, id int) error
+	ConfirmHold(actor domain.Actor, id int) (*domain.Appointment, error)
+	DeclineHold(actor domain.Actor, id int) error
+}
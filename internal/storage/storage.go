@@ -0,0 +1,63 @@
+// Package storage keeps the contents of uploaded files. Metadata lives in
+// the database; a Store only maps keys to bytes, on the local disk or in
+// an S3-compatible bucket.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNotFound is returned when no object is stored under a key.
+var ErrNotFound = errors.New("storage: object not found")
+
+// Store is a blob store. Keys are slash-separated paths of letters,
+// digits, '-', '_' and '.'; writing a key that exists replaces it.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object; deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Config selects and configures a driver.
+type Config struct {
+	// Driver is "local" (the default) or "s3".
+	Driver string
+	// Dir is where the local driver keeps files.
+	Dir string
+	S3  S3Config
+}
+
+// New returns the store described by cfg.
+func New(cfg Config) (Store, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocal(cfg.Dir)
+	case "s3":
+		return NewS3(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("storage: invalid key %q", key)
+		}
+		for _, c := range part {
+			ok := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.'
+			if !ok {
+				return fmt.Errorf("storage: invalid key %q", key)
+			}
+		}
+	}
+	return nil
+}
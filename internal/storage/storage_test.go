@@ -0,0 +1,147 @@
+package storage_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prem0x01/hospital/internal/storage"
+)
+
+// exercise runs the behaviour every driver shares.
+func exercise(t *testing.T, s storage.Store) {
+	t.Helper()
+	ctx := context.Background()
+	key := "sha256/ab/abcdef.bin"
+
+	_, err := s.Open(ctx, key)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	require.NoError(t, s.Put(ctx, key, []byte("first"), "text/plain"))
+	require.NoError(t, s.Put(ctx, key, []byte("second"), "text/plain"))
+	r, err := s.Open(ctx, key)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "second", string(got))
+
+	require.NoError(t, s.Delete(ctx, key))
+	require.NoError(t, s.Delete(ctx, key))
+	_, err = s.Open(ctx, key)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	for _, bad := range []string{"", "/abs", "a/../b", "a//b", "dir/", "sp ace"} {
+		assert.Error(t, s.Put(ctx, bad, []byte("x"), ""), bad)
+	}
+}
+
+func TestLocal(t *testing.T) {
+	dir := t.TempDir()
+	s, err := storage.New(storage.Config{Dir: dir})
+	require.NoError(t, err)
+	exercise(t, s)
+
+	require.NoError(t, s.Put(context.Background(), "a/b.txt", []byte("kept"), ""))
+	info, err := os.Stat(filepath.Join(dir, "a", "b.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+// s3Stub is a minimal S3-compatible server in the style of MinIO: path
+// style addressing, one bucket, and signed requests only.
+type s3Stub struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func (s *s3Stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=minio/") || !strings.Contains(auth, "/eu-west-2/s3/aws4_request") {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	sum := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `<Error><Code>XAmzContentSHA256Mismatch</Code><Message>bad hash</Message></Error>`)
+		return
+	}
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != "documents" {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		s.objects[key] = body
+		s.types[key] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		data, ok := s.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3(t *testing.T) {
+	stub := &s3Stub{objects: map[string][]byte{}, types: map[string]string{}}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	cfg := storage.S3Config{
+		Endpoint:  srv.URL,
+		Region:    "eu-west-2",
+		Bucket:    "documents",
+		AccessKey: "minio",
+		SecretKey: "minio-secret",
+		PathStyle: true,
+	}
+	s, err := storage.New(storage.Config{Driver: "s3", S3: cfg})
+	require.NoError(t, err)
+	exercise(t, s)
+
+	require.NoError(t, s.Put(context.Background(), "scan.pdf", []byte("%PDF"), "application/pdf"))
+	assert.Equal(t, "application/pdf", stub.types["scan.pdf"])
+
+	cfg.Bucket = "missing"
+	s, err = storage.NewS3(cfg)
+	require.NoError(t, err)
+	err = s.Put(context.Background(), "scan.pdf", []byte("%PDF"), "application/pdf")
+	assert.ErrorContains(t, err, "NoSuchBucket")
+}
+
+func TestNewRejectsBadConfig(t *testing.T) {
+	_, err := storage.New(storage.Config{Driver: "ftp"})
+	assert.ErrorContains(t, err, "unknown storage driver")
+	_, err = storage.New(storage.Config{Driver: "local"})
+	assert.Error(t, err)
+	_, err = storage.New(storage.Config{Driver: "s3", S3: storage.S3Config{Endpoint: "minio:9000", Bucket: "b", AccessKey: "k", SecretKey: "s"}})
+	assert.ErrorContains(t, err, "invalid s3 endpoint")
+	_, err = storage.New(storage.Config{Driver: "s3", S3: storage.S3Config{Endpoint: "http://minio:9000"}})
+	assert.Error(t, err)
+}
@@ -15,14 +15,14 @@ func Int32Ptr(i int32) *int32 {
 }
 
 
-func TimeToTimestamp(t time.Time) pgtype.Timestamp {
-	return pgtype.Timestamp{
+func TimeToTimestamp(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{
 		Time:  t,
 		Valid: true,
 	}
 }
 
-func TimestampToTime(ts pgtype.Timestamp) time.Time {
+func TimestampToTime(ts pgtype.Timestamptz) time.Time {
 	if !ts.Valid {
 		return time.Time{}
 	}
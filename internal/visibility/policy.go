@@ -0,0 +1,151 @@
+// Package visibility shapes API responses according to what the caller's
+// role is allowed to see. Rules are keyed by the JSON name of a field, so
+// every output built from the domain types (list, detail, export, FHIR)
+// can be masked by the same policy.
+package visibility
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/prem0x01/hospital/internal/domain"
+)
+
+type Rule string
+
+const (
+	// Show leaves the field untouched; it is the default for unlisted fields.
+	Show Rule = "show"
+	// Redact keeps the fact that a value exists but replaces its content.
+	Redact Rule = "redact"
+	// Hide clears the field as if it had no value.
+	Hide Rule = "hide"
+)
+
+const RedactedValue = "[redacted]"
+
+// Policy maps role -> resource type -> JSON field name -> rule.
+type Policy map[string]map[string]map[string]Rule
+
+// DefaultPolicy gives receptionists demographics and scheduling data only.
+func DefaultPolicy() Policy {
+	return Policy{
+		"receptionist": {
+			domain.ResourcePatient: {
+				"medical_history": Hide,
+				"allergies":       Redact,
+			},
+			domain.ResourceAppointment: {
+				"diagnosis":      Hide,
+				"treatment_plan": Hide,
+			},
+			domain.ResourceReferral: {
+				"clinical_context": Hide,
+				"note_id":          Hide,
+			},
+		},
+	}
+}
+
+// Load reads a policy from a JSON file in the same shape as Policy. An
+// empty path returns DefaultPolicy.
+func Load(path string) (Policy, error) {
+	if path == "" {
+		return DefaultPolicy(), nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read field policy: %w", err)
+	}
+
+	var p Policy
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, fmt.Errorf("invalid field policy: %w", err)
+	}
+	for role, resources := range p {
+		for resource, fields := range resources {
+			for field, rule := range fields {
+				if rule != Show && rule != Redact && rule != Hide {
+					return nil, fmt.Errorf("invalid rule %q for %s.%s.%s", rule, role, resource, field)
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+// Rule returns the rule for one field as seen by role.
+func (p Policy) Rule(role, resource, field string) Rule {
+	if rule, ok := p[role][resource][field]; ok {
+		return rule
+	}
+	return Show
+}
+
+// Apply masks v in place for role. v must be a pointer to a struct or a
+// slice of structs (or of pointers to structs) of the given resource type.
+func (p Policy) Apply(role, resource string, v interface{}) {
+	rules := p[role][resource]
+	if len(rules) == 0 || v == nil {
+		return
+	}
+	apply(rules, reflect.ValueOf(v))
+}
+
+func apply(rules map[string]Rule, rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !rv.IsNil() {
+			apply(rules, rv.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			apply(rules, rv.Index(i))
+		}
+	case reflect.Struct:
+		applyStruct(rules, rv)
+	}
+}
+
+func applyStruct(rules map[string]Rule, rv reflect.Value) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		name := strings.Split(rt.Field(i).Tag.Get("json"), ",")[0]
+		rule, ok := rules[name]
+		if !ok || rule == Show {
+			continue
+		}
+
+		field := rv.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		if rule == Redact && redact(field) {
+			continue
+		}
+		field.Set(reflect.Zero(field.Type()))
+	}
+}
+
+// redact replaces a present string value with RedactedValue and reports
+// whether it could; other kinds of field fall back to being hidden.
+func redact(field reflect.Value) bool {
+	switch {
+	case field.Kind() == reflect.String:
+		if field.Len() > 0 {
+			field.SetString(RedactedValue)
+		}
+		return true
+	case field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.String:
+		if !field.IsNil() {
+			s := RedactedValue
+			field.Set(reflect.ValueOf(&s))
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package visibility_test
+
+import (
+	"testing"
+
+	"github.com/prem0x01/hospital/internal/domain"
+	"github.com/prem0x01/hospital/internal/visibility"
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestReceptionistSeesDemographicsOnly(t *testing.T) {
+	patients := []domain.Patient{{
+		FirstName:      "Asha",
+		Phone:          strPtr("9800000000"),
+		MedicalHistory: strPtr("diabetes"),
+		Allergies:      strPtr("penicillin"),
+	}}
+
+	visibility.DefaultPolicy().Apply("receptionist", domain.ResourcePatient, patients)
+
+	assert.Equal(t, "Asha", patients[0].FirstName)
+	assert.Equal(t, "9800000000", *patients[0].Phone)
+	assert.Nil(t, patients[0].MedicalHistory)
+	assert.Equal(t, visibility.RedactedValue, *patients[0].Allergies)
+}
+
+func TestRedactKeepsAbsentValuesAbsent(t *testing.T) {
+	patient := &domain.Patient{FirstName: "Ravi"}
+
+	visibility.DefaultPolicy().Apply("receptionist", domain.ResourcePatient, patient)
+
+	assert.Nil(t, patient.Allergies)
+}
+
+func TestDoctorSeesClinicalFields(t *testing.T) {
+	appointment := &domain.Appointment{Diagnosis: strPtr("J45"), TreatmentPlan: strPtr("inhaler")}
+
+	visibility.DefaultPolicy().Apply("doctor", domain.ResourceAppointment, appointment)
+
+	assert.Equal(t, "J45", *appointment.Diagnosis)
+	assert.Equal(t, "inhaler", *appointment.TreatmentPlan)
+}
+
+func TestReceptionistAppointmentHidesClinicalFields(t *testing.T) {
+	appointment := &domain.Appointment{Notes: strPtr("bring reports"), Diagnosis: strPtr("J45"), TreatmentPlan: strPtr("inhaler")}
+
+	visibility.DefaultPolicy().Apply("receptionist", domain.ResourceAppointment, appointment)
+
+	assert.Equal(t, "bring reports", *appointment.Notes)
+	assert.Nil(t, appointment.Diagnosis)
+	assert.Nil(t, appointment.TreatmentPlan)
+}
+
+func TestReceptionistReferralHidesClinicalContext(t *testing.T) {
+	noteID := int32(7)
+	referral := &domain.Referral{Reason: "chest pain on exertion", ClinicalContext: strPtr("ECG: ST depression"), NoteID: &noteID}
+
+	visibility.DefaultPolicy().Apply("receptionist", domain.ResourceReferral, referral)
+
+	assert.Equal(t, "chest pain on exertion", referral.Reason)
+	assert.Nil(t, referral.ClinicalContext)
+	assert.Nil(t, referral.NoteID)
+}